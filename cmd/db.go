@@ -3,12 +3,15 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/dgraph-io/badger/v3"
 	"github.com/rumsystem/quorum/internal/pkg/storage"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
 	quorumpb "github.com/rumsystem/quorum/pkg/pb"
 	"github.com/spf13/cobra"
 	"go.etcd.io/bbolt"
@@ -23,6 +26,8 @@ const (
 var (
 	_migrateParam dbParam
 	_compactParam dbParam
+	_verifyParam  dbVerifyParam
+	_replayParam  dbReplayParam
 
 	kinds = []string{"db", "appdb", "groups", "pubqueue"} // FIXME: hardcode
 )
@@ -30,15 +35,21 @@ var (
 var (
 	dbCmd = &cobra.Command{
 		Use:              "db",
-		Short:            "database tool, migrate or compact",
+		Short:            "database tool, migrate, compact, verify or replay",
 		TraverseChildren: true,
 	}
 
 	migrateCmd = &cobra.Command{
 		Use:   "migrate",
-		Short: "migrate data from badger to boltdb",
+		Short: "migrate data between storage backends (bolt, badger)",
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := migrateAll(); err != nil {
+			if _migrateParam.Legacy {
+				if err := migrateAll(); err != nil {
+					logger.Fatal(err)
+				}
+				return
+			}
+			if err := migrateBackendAll(); err != nil {
 				logger.Fatal(err)
 			}
 		},
@@ -53,6 +64,30 @@ var (
 			}
 		},
 	}
+
+	verifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "verify block hashes, prev hash links, producer signs and trx signs of the stored chain",
+		Run: func(cmd *cobra.Command, args []string) {
+			ok, err := verifyAll()
+			if err != nil {
+				logger.Fatal(err)
+			}
+			if !ok {
+				os.Exit(1)
+			}
+		},
+	}
+
+	replayCmd = &cobra.Command{
+		Use:   "replay",
+		Short: "re-derive the state of a group from the stored blocks into a fresh db, and print the state digest",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := replayGroup(); err != nil {
+				logger.Fatal(err)
+			}
+		},
+	}
 )
 
 type (
@@ -60,12 +95,42 @@ type (
 		PeerName   string
 		DataDir    string
 		NewDataDir string
+		From       string
+		To         string
+		Legacy     bool
+	}
+
+	dbVerifyParam struct {
+		PeerName string
+		DataDir  string
+		Backend  string
+		NodeName string
+		GroupId  string
+	}
+
+	dbReplayParam struct {
+		PeerName     string
+		DataDir      string
+		Backend      string
+		NodeName     string
+		GroupId      string
+		ToBlock      uint64
+		ProducerNode bool
+		NewDataDir   string
+		Apply        bool
+	}
+
+	dbVerifySummary struct {
+		Ok     bool                              `json:"ok"`
+		Groups []*chainstorage.ChainVerifyResult `json:"groups"`
 	}
 )
 
 func init() {
 	dbCmd.AddCommand(migrateCmd)
 	dbCmd.AddCommand(compactCmd)
+	dbCmd.AddCommand(verifyCmd)
+	dbCmd.AddCommand(replayCmd)
 	rootCmd.AddCommand(dbCmd)
 
 	// migrate
@@ -75,6 +140,9 @@ func init() {
 	migrateFlags.StringVar(&_migrateParam.PeerName, "peername", "peer", "peer name")
 	migrateFlags.StringVar(&_migrateParam.DataDir, "datadir", "data", "data dir")
 	migrateFlags.StringVar(&_migrateParam.NewDataDir, "newdatadir", "", "new data dir")
+	migrateFlags.StringVar(&_migrateParam.From, "from", storage.BoltBackend, "source storage backend: bolt or badger")
+	migrateFlags.StringVar(&_migrateParam.To, "to", storage.BadgerBackend, "target storage backend: bolt or badger")
+	migrateFlags.BoolVar(&_migrateParam.Legacy, "legacy", false, "migrate data of the legacy badger layout (<datadir>/<peername>_<kind>) to bolt")
 	migrateCmd.MarkFlagRequired("newdatadir")
 
 	// compact
@@ -85,6 +153,31 @@ func init() {
 	compactFlags.StringVar(&_compactParam.DataDir, "datadir", "data", "data dir")
 	compactFlags.StringVar(&_compactParam.NewDataDir, "newdatadir", "", "new data dir")
 	migrateCmd.MarkFlagRequired("newdatadir")
+
+	// verify
+	verifyFlags := verifyCmd.Flags()
+	verifyFlags.SortFlags = false
+
+	verifyFlags.StringVar(&_verifyParam.PeerName, "peername", "peer", "peer name")
+	verifyFlags.StringVar(&_verifyParam.DataDir, "datadir", "data", "data dir")
+	verifyFlags.StringVar(&_verifyParam.Backend, "dbbackend", "", "storage backend: bolt or badger, detected from the data dir if empty")
+	verifyFlags.StringVar(&_verifyParam.NodeName, "nodename", "fullnode_default", "node name the chain data saved with, producernode_default for a producer node")
+	verifyFlags.StringVar(&_verifyParam.GroupId, "groupid", "", "group id, verify all groups if empty")
+
+	// replay
+	replayFlags := replayCmd.Flags()
+	replayFlags.SortFlags = false
+
+	replayFlags.StringVar(&_replayParam.PeerName, "peername", "peer", "peer name")
+	replayFlags.StringVar(&_replayParam.DataDir, "datadir", "data", "data dir")
+	replayFlags.StringVar(&_replayParam.Backend, "dbbackend", "", "storage backend: bolt or badger, detected from the data dir if empty")
+	replayFlags.StringVar(&_replayParam.NodeName, "nodename", "fullnode_default", "node name the chain data saved with, producernode_default for a producer node")
+	replayFlags.StringVar(&_replayParam.GroupId, "groupid", "", "group id")
+	replayFlags.Uint64Var(&_replayParam.ToBlock, "toblock", 0, "stop after this block applied, 0 means the head of the chain")
+	replayFlags.BoolVar(&_replayParam.ProducerNode, "producer", false, "apply trxs as a producer node, POST and APP_CONFIG are skipped")
+	replayFlags.StringVar(&_replayParam.NewDataDir, "newdatadir", "", "keep the replayed db in this dir, a temporary dir is used and removed if empty")
+	replayFlags.BoolVar(&_replayParam.Apply, "apply", false, "replace the state of the group in the data dir with the replayed state, only when replayed to the head")
+	replayCmd.MarkFlagRequired("groupid")
 }
 
 func openBadgerDB(dbDir string) (*badger.DB, error) {
@@ -202,6 +295,88 @@ func migrateAll() error {
 	return nil
 }
 
+// migrateBackend copies all key/values of one kind from the src backend to the dst backend
+func migrateBackend(peerName, dataDir, kind, newDataDir, from, to string) error {
+	srcPath := filepath.Join(dataDir, peerName)
+	if storage.DetectBackend(srcPath, kind) != from {
+		fmt.Printf("skip %s, no %s data in %s\n", kind, from, srcPath)
+		return nil
+	}
+
+	ctx := context.Background()
+	srcDB, err := storage.OpenStore(ctx, from, srcPath, kind)
+	if err != nil {
+		return err
+	}
+	defer srcDB.Close()
+
+	dstPath := filepath.Join(newDataDir, peerName)
+	if existing := storage.DetectBackend(dstPath, kind); existing != "" {
+		return fmt.Errorf("%s data already exists in %s", kind, dstPath)
+	}
+	dstDB, err := storage.OpenStore(ctx, to, dstPath, kind)
+	if err != nil {
+		return err
+	}
+	defer dstDB.Close()
+
+	keys := [][]byte{}
+	vals := [][]byte{}
+	count := 0
+	err = srcDB.Foreach(func(k []byte, v []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		keys = append(keys, append([]byte{}, k...))
+		vals = append(vals, append([]byte{}, v...))
+		if len(keys) >= maxBatchSize {
+			if err := dstDB.BatchWrite(keys, vals); err != nil {
+				return err
+			}
+			count += len(keys)
+			keys = [][]byte{}
+			vals = [][]byte{}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		if err := dstDB.BatchWrite(keys, vals); err != nil {
+			return err
+		}
+		count += len(keys)
+	}
+
+	fmt.Printf("migrated %d keys of %s\n", count, kind)
+	return nil
+}
+
+func migrateBackendAll() error {
+	_dbParam := _migrateParam
+
+	if _dbParam.From == _dbParam.To {
+		return fmt.Errorf("source and target backend are the same: %s", _dbParam.From)
+	}
+	for _, backend := range []string{_dbParam.From, _dbParam.To} {
+		if backend != storage.BoltBackend && backend != storage.BadgerBackend {
+			return fmt.Errorf("unsupported storage backend: %s", backend)
+		}
+	}
+
+	for _, kind := range kinds {
+		fmt.Printf("migrate %s from %s to %s\n", kind, _dbParam.From, _dbParam.To)
+		if err := migrateBackend(_dbParam.PeerName, _dbParam.DataDir, kind, _dbParam.NewDataDir, _dbParam.From, _dbParam.To); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("migrate data to %s, please start the node with: --datadir %s --dbbackend %s\n", _dbParam.NewDataDir, _dbParam.NewDataDir, _dbParam.To)
+	return nil
+}
+
 func compactAll() error {
 	_dbParam := _compactParam
 	srcBasePath := filepath.Join(_dbParam.DataDir, peerName)
@@ -228,3 +403,140 @@ func compactAll() error {
 
 	return nil
 }
+
+// verifyAll verifies the chain of the groups and prints the summary as json, returns false if any issue found
+func verifyAll() (bool, error) {
+	_dbParam := _verifyParam
+	datapath := filepath.Join(_dbParam.DataDir, _dbParam.PeerName)
+	if storage.DetectBackend(datapath, "db") == "" {
+		return false, fmt.Errorf("no chain data in %s", datapath)
+	}
+
+	dbMgr, err := storage.CreateDbWithBackend(datapath, _dbParam.Backend)
+	if err != nil {
+		return false, err
+	}
+	defer dbMgr.CloseDb()
+	chainStorage := chainstorage.NewChainStorage(dbMgr)
+
+	groupIds := []string{}
+	if _dbParam.GroupId != "" {
+		groupIds = append(groupIds, _dbParam.GroupId)
+	} else {
+		items, err := dbMgr.GetGroupsBytes()
+		if err != nil {
+			return false, err
+		}
+		for _, b := range items {
+			item := &quorumpb.GroupItem{}
+			if err := proto.Unmarshal(b, item); err != nil {
+				return false, err
+			}
+			groupIds = append(groupIds, item.GroupId)
+		}
+	}
+
+	summary := dbVerifySummary{Ok: true, Groups: []*chainstorage.ChainVerifyResult{}}
+	for _, groupId := range groupIds {
+		logger.Infof("verify chain of group %s", groupId)
+		result, err := chainStorage.VerifyChain(groupId, _dbParam.NodeName)
+		if err != nil {
+			return false, fmt.Errorf("verify group %s failed: %s", groupId, err)
+		}
+		if !result.Ok {
+			summary.Ok = false
+		}
+		summary.Groups = append(summary.Groups, result)
+	}
+
+	output, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return false, err
+	}
+	fmt.Println(string(output))
+	return summary.Ok, nil
+}
+
+// replayGroup re-derives the state of the group in a fresh db, prints the result as json
+func replayGroup() error {
+	_dbParam := _replayParam
+	datapath := filepath.Join(_dbParam.DataDir, _dbParam.PeerName)
+	if storage.DetectBackend(datapath, "db") == "" {
+		return fmt.Errorf("no chain data in %s", datapath)
+	}
+
+	srcMgr, err := storage.CreateDbWithBackend(datapath, _dbParam.Backend)
+	if err != nil {
+		return err
+	}
+	defer srcMgr.CloseDb()
+	src := chainstorage.NewChainStorage(srcMgr)
+
+	newDataDir := _dbParam.NewDataDir
+	if newDataDir == "" {
+		newDataDir, err = os.MkdirTemp("", "quorum-replay-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(newDataDir)
+	}
+	dstPath := filepath.Join(newDataDir, _dbParam.PeerName)
+	if storage.DetectBackend(dstPath, "db") != "" {
+		return fmt.Errorf("chain data already exists in %s", dstPath)
+	}
+	dstMgr, err := storage.CreateDbWithBackend(dstPath, "")
+	if err != nil {
+		return err
+	}
+	defer dstMgr.CloseDb()
+	dst := chainstorage.NewChainStorage(dstMgr)
+
+	opts := &chainstorage.ReplayOptions{ToBlock: _dbParam.ToBlock, ProducerNode: _dbParam.ProducerNode}
+	result, err := dst.ReplayState(src, _dbParam.GroupId, opts, _dbParam.NodeName)
+	if err != nil {
+		return err
+	}
+
+	head, err := src.GetHeadBlockId(_dbParam.GroupId, _dbParam.NodeName)
+	if err != nil {
+		return err
+	}
+	if head == result.ToBlock {
+		result.CurrentDigest, _, err = src.GetStateDigest(_dbParam.GroupId, _dbParam.NodeName)
+		if err != nil {
+			return err
+		}
+		result.Match = result.CurrentDigest == result.StateDigest
+	}
+
+	if _dbParam.Apply {
+		if result.CurrentDigest == "" {
+			return fmt.Errorf("the state is only applied when replayed to the head of the chain")
+		}
+		groupItem, err := src.GetGroupInfo(_dbParam.GroupId)
+		if err != nil {
+			return err
+		}
+		//posts of private groups can't be decrypted without the keystore
+		if groupItem.EncryptType == quorumpb.GroupEncryptType_PRIVATE && !_dbParam.ProducerNode {
+			return fmt.Errorf("can't apply the state of a private group offline, replay it with the node api")
+		}
+		if !result.Match {
+			items, err := dst.GetStateItems(_dbParam.GroupId, _dbParam.NodeName)
+			if err != nil {
+				return err
+			}
+			if err := src.ApplyStateItems(_dbParam.GroupId, items, _dbParam.NodeName); err != nil {
+				return err
+			}
+			result.Applied = true
+		}
+	}
+
+	output, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
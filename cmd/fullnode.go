@@ -25,7 +25,10 @@ import (
 	"github.com/rumsystem/quorum/internal/pkg/utils"
 	"github.com/rumsystem/quorum/pkg/chainapi/api"
 	appapi "github.com/rumsystem/quorum/pkg/chainapi/appapi"
+	"github.com/rumsystem/quorum/pkg/consensus"
 	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	_ "google.golang.org/protobuf/types/known/timestamppb" //import for swaggo
@@ -94,6 +97,14 @@ func init() {
 	flags.String("skippeers", "", "peer id lists, will be skipped in the pubsub connection")
 	flags.String("jsontracer", "", "output tracer data to a json file")
 	flags.Bool("autorelay", true, "enable relay")
+	flags.String("dbbackend", "", "storage backend: bolt or badger, default to the backend of the existing data, bolt for a new data dir")
+	flags.Uint64("keepblocks", 0, "prune mode, keep the latest n blocks and drop older block bodies, new groups bootstrap from a state snapshot, 0 to keep all blocks")
+	flags.Uint64("snapshotinterval", 1000, "write a signed state snapshot every n blocks, only when EnableSnapshot is set in node options and the node is a producer of the group")
+	flags.Bool("searchindex", false, "index decrypted post content for the app search api")
+	flags.Int("buffersendermax", 100, "max trxs of a sender in the trx buffer of a producer, 0 for no limit")
+	flags.Int("buffergroupmax", 10000, "max trxs in the trx buffer of a group, 0 for no limit")
+	flags.Duration("trxexpire", 12*time.Hour, "trxs created by the node expire after the duration if not packaged")
+	flags.String("signer", "", "forward signing and decryption with the keys held by a signer to it, unix:///path/to/signer.sock")
 
 	fullNodeViper = options.NewViper()
 	if err := fullNodeViper.BindPFlags(flags); err != nil {
@@ -127,6 +138,19 @@ func runFullnode(config cli.FullNodeFlag) {
 	// overwrite by cli flags
 	nodeoptions.EnableRelay = config.EnableRelay
 
+	// block pruning and state snapshot
+	chain.PRUNE_KEEP_BLOCKS = config.KeepBlocks
+	if nodeoptions.EnableSnapshot {
+		chain.SNAPSHOT_INTERVAL = config.SnapshotInterval
+	}
+
+	// trx buffer limits of producer
+	consensus.TRX_BUFFER_SENDER_LIMIT = config.BufferSenderMax
+	consensus.TRX_BUFFER_GROUP_LIMIT = config.BufferGroupMax
+	if config.TrxExpire > 0 {
+		rumchaindata.TRX_EXPIRE_DURATION = config.TrxExpire
+	}
+
 	keystoreParam := InitKeystoreParam{
 		KeystoreName:   config.KeyStoreName,
 		KeystoreDir:    config.KeyStoreDir,
@@ -142,6 +166,21 @@ func runFullnode(config cli.FullNodeFlag) {
 		logger.Fatalf(err.Error())
 	}
 
+	if config.Signer != "" {
+		signer, err := localcrypto.DialSigner(config.Signer)
+		if err != nil {
+			cancel()
+			logger.Fatalf("connect to signer failed: %s", err)
+		}
+		remoteks, err := localcrypto.NewRemoteKeyStore(ks, signer)
+		if err != nil {
+			cancel()
+			logger.Fatalf("load keys of signer failed: %s", err)
+		}
+		localcrypto.SetKeystore(remoteks)
+		ks = remoteks
+	}
+
 	keys, err := localcrypto.SignKeytoPeerKeys(defaultkey)
 	if err != nil {
 		logger.Fatalf(err.Error())
@@ -164,7 +203,7 @@ func runFullnode(config cli.FullNodeFlag) {
 	nodename := "fullnode_default"
 
 	datapath := config.DataDir + "/" + config.PeerName
-	dbManager, err := storage.CreateDb(datapath)
+	dbManager, err := storage.CreateDbWithBackend(datapath, config.DbBackend)
 	if err != nil {
 		logger.Fatalf(err.Error())
 	}
@@ -218,7 +257,7 @@ func runFullnode(config cli.FullNodeFlag) {
 	peerok := make(chan struct{})
 	go fullNode.ConnectPeers(ctx, peerok, nodeoptions.MaxPeers, config.RendezvousString)
 
-	appdb, err := appdata.CreateAppDb(datapath)
+	appdb, err := appdata.CreateAppDbWithBackend(datapath, config.DbBackend)
 	if err != nil {
 		logger.Fatalf(err.Error())
 	}
@@ -226,7 +265,7 @@ func runFullnode(config cli.FullNodeFlag) {
 	CheckLockError(err)
 
 	// init the websocket manager
-	websocketManager := api.NewWebsocketManager()
+	websocketManager := api.NewWebsocketManager(appdb)
 	go websocketManager.Start()
 
 	//start sync all groups
@@ -246,9 +285,13 @@ func runFullnode(config cli.FullNodeFlag) {
 		WebsocketManager: websocketManager,
 	}
 
+	appdb.SearchIndex = config.SearchIndex
+
 	apiaddress := fmt.Sprintf("http://localhost:%d/api/v1", config.APIPort)
 	appsync := appdata.NewAppSyncAgent(apiaddress, nodectx.GetNodeCtx().Name, appdb, dbManager)
-	appsync.Start(10)
+	if err := initAppIndex(appdb, dbManager.Db, nodectx.GetNodeCtx().Name, appsync); err != nil {
+		logger.Fatalf("init app indexes failed: %s", err)
+	}
 	apph := &appapi.Handler{
 		Appdb:     appdb,
 		Trxdb:     newchainstorage,
@@ -282,3 +325,30 @@ func runFullnode(config cli.FullNodeFlag) {
 	logger.Infof("On Signal <%s>", signalType)
 	logger.Infof("Exit command received. Exiting...")
 }
+
+// initAppIndex reindexes posts from chain data if the indexes are missing, outdated or built with other options
+func initAppIndex(appdb *appdata.AppDb, chainDb storage.QuorumStorage, nodename string, appsync *appdata.AppSync) error {
+	vertag, err := appdb.GetIndexVersion()
+	if err != nil {
+		return err
+	}
+	if vertag == appdb.IndexVersionTag() {
+		appsync.Start(10)
+		return nil
+	}
+
+	var groupItems []*quorumpb.GroupItem
+	for _, group := range chain.GetGroupMgr().Groups {
+		groupItems = append(groupItems, group.Item)
+	}
+	//appsync starts after the rebuild, so the indexes are not updated concurrently
+	go func() {
+		if err := appdb.Rebuild(appdb.IndexVersionTag(), chainDb, groupItems, nodename); err != nil {
+			logger.Errorf("rebuild app indexes failed: %s", err)
+		} else {
+			logger.Infof("app indexes rebuilt for %d group(s)", len(groupItems))
+		}
+		appsync.Start(10)
+	}()
+	return nil
+}
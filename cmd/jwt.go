@@ -15,6 +15,11 @@ var (
 	jwtGroupId  string
 	jwtDuration time.Duration
 
+	// create scoped
+	jwtAllowGroups []string
+	jwtScopes      []string
+	jwtRateLimit   int
+
 	// parse
 	jwtToken string
 )
@@ -46,6 +51,18 @@ var jwtCreateChainCmd = &cobra.Command{
 	},
 }
 
+var jwtCreateScopedCmd = &cobra.Command{
+	Use:   "scoped",
+	Short: "Create jwt with the scopes of the allow groups and save to config file",
+	Long: `Create jwt with the scopes of the allow groups and save to config file.
+Scopes: read (GET the apis of the groups), post (post content and trx to the groups), admin (all the apis of the groups).
+Use "*" as the allow group for all groups, e.g. a read only token of all groups for a dashboard,
+the apis with the group id in request body (e.g. /api/v1/group/chainconfig) need admin of "*".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		createScopedToken(configDir, peerName, jwtName, jwtDuration, jwtAllowGroups, jwtScopes, jwtRateLimit)
+	},
+}
+
 var jwtParseCmd = &cobra.Command{
 	Use:   "parse",
 	Short: "Parse jwt",
@@ -57,6 +74,7 @@ var jwtParseCmd = &cobra.Command{
 func init() {
 	jwtCreateCmd.AddCommand(jwtCreateNodeCmd)
 	jwtCreateCmd.AddCommand(jwtCreateChainCmd)
+	jwtCreateCmd.AddCommand(jwtCreateScopedCmd)
 
 	jwtCmd.AddCommand(jwtCreateCmd)
 	jwtCmd.AddCommand(jwtParseCmd)
@@ -85,6 +103,21 @@ func init() {
 
 	jwtCreateChainCmd.MarkFlagRequired("name")
 
+	// create scoped jwt
+	createScopedFlags := jwtCreateScopedCmd.Flags()
+	createScopedFlags.SortFlags = false
+	createScopedFlags.StringVarP(&configDir, "configdir", "c", "config", "config directory")
+	createScopedFlags.StringVarP(&peerName, "peername", "p", "peer", "peer name")
+	createScopedFlags.StringVarP(&jwtName, "name", "n", "", "name of the scoped jwt")
+	createScopedFlags.DurationVarP(&jwtDuration, "duration", "d", time.Hour*24*365, "duration of scoped jwt")
+	createScopedFlags.StringSliceVarP(&jwtAllowGroups, "groupid", "g", nil, "allow groups of scoped jwt, \"*\" for all groups")
+	createScopedFlags.StringSliceVarP(&jwtScopes, "scope", "s", nil, "scopes of scoped jwt: read, post or admin")
+	createScopedFlags.IntVar(&jwtRateLimit, "ratelimit", 0, "max requests per minute of scoped jwt, 0 for no limit")
+
+	jwtCreateScopedCmd.MarkFlagRequired("name")
+	jwtCreateScopedCmd.MarkFlagRequired("groupid")
+	jwtCreateScopedCmd.MarkFlagRequired("scope")
+
 	// parse jwt
 	parseFlags := jwtParseCmd.Flags()
 	parseFlags.SortFlags = false
@@ -135,6 +168,29 @@ func createChainToken(configDir string, peerName string, name string, duration t
 	fmt.Printf("new chain token: %s\n", token)
 }
 
+func createScopedToken(configDir string, peerName string, name string, duration time.Duration, allowGroups []string, scopes []string, rateLimit int) {
+	for _, scope := range scopes {
+		switch scope {
+		case utils.JWTScopeRead, utils.JWTScopePost, utils.JWTScopeAdmin:
+		default:
+			logger.Fatalf("invalid scope: %s, should be read, post or admin", scope)
+		}
+	}
+	if rateLimit < 0 {
+		logger.Fatalf("invalid rate limit: %d", rateLimit)
+	}
+
+	nodeoptions, err := options.InitNodeOptions(configDir, peerName)
+	if err != nil {
+		logger.Fatalf("init node option failed: %s", err)
+	}
+	token, err := nodeoptions.NewScopedJWT(name, allowGroups, scopes, rateLimit, time.Now().Add(duration))
+	if err != nil {
+		logger.Fatalf("create scoped token failed: %s", err)
+	}
+	fmt.Printf("new scoped token: %s\n", token)
+}
+
 func parseToken(configDir string, peerName string, token string) {
 	key := getJWTKey(configDir, peerName)
 	claims, err := utils.ParseJWTToken(token, key)
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/rumsystem/quorum/internal/pkg/options"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	"github.com/spf13/cobra"
+)
+
+var ( // flags
+	// export and import
+	keyExportNames    []string
+	keyExportFile     string
+	keyExportPassword string
+
+	// mnemonic
+	mnemonicRestore    string
+	mnemonicPassphrase string
+)
+
+var keystoreCmd = &cobra.Command{
+	Use:   "keystore",
+	Short: "A keystore tool, export or import keys, and derive keys from a mnemonic",
+}
+
+var keystoreExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export keys or aliases of the keystore to a password protected file",
+	Long: `Export the sign and encrypt keys of the keynames or aliases, e.g. the keys of a group (--keyname <group_id>),
+to a file encrypted by the export password, then import them on another node with "quorum keystore import".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ks, nodeoptions := openKeystore()
+		if err := ks.Unlock(nodeoptions.SignKeyMap, keystorePassword); err != nil {
+			logger.Fatalf(err.Error())
+		}
+		defer ks.Lock()
+
+		password := keyExportPassword
+		if password == "" {
+			var err error
+			if password, err = localcrypto.PassphrasePromptForEncryption(); err != nil {
+				logger.Fatalf(err.Error())
+			}
+		}
+		data, err := ks.ExportKeys(keyExportNames, password)
+		if err != nil {
+			logger.Fatalf(err.Error())
+		}
+		if err := ioutil.WriteFile(keyExportFile, data, 0600); err != nil {
+			logger.Fatalf(err.Error())
+		}
+		logger.Infof("keys %v exported to %s", keyExportNames, keyExportFile)
+	},
+}
+
+var keystoreImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import the keys exported by \"quorum keystore export\"",
+	Run: func(cmd *cobra.Command, args []string) {
+		ks, nodeoptions := openKeystore()
+
+		data, err := ioutil.ReadFile(keyExportFile)
+		if err != nil {
+			logger.Fatalf(err.Error())
+		}
+		password := keyExportPassword
+		if password == "" {
+			if password, err = localcrypto.PassphrasePromptForUnlock(); err != nil {
+				logger.Fatalf(err.Error())
+			}
+		}
+		keys, signkeymap, err := ks.ImportKeys(data, password, keystorePassword)
+		//save the addresses of the imported sign keys, even some of the keys failed
+		for keyname, addr := range signkeymap {
+			if err := nodeoptions.SetSignKeyMap(keyname, addr); err != nil {
+				logger.Errorf("save sign key %s address failed: %s", keyname, err)
+			}
+		}
+		if err != nil {
+			logger.Fatalf(err.Error())
+		}
+		for _, key := range keys {
+			logger.Infof("key %s imported, alias: %v", key.Type.NameString(key.Keyname), key.Alias)
+		}
+	},
+}
+
+var keystoreMnemonicCmd = &cobra.Command{
+	Use:   "mnemonic",
+	Short: "Create or restore the mnemonic the new keys of the keystore are derived from",
+	Long: `Create a mnemonic, or restore it with --restore, and save its seed to the keystore.
+The keys created after that (e.g. the keys of the groups created or joined later) are derived from the mnemonic,
+so they can be re-derived by creating the keys with the same names in a keystore restored from the same mnemonic.
+The keys created before are not changed, export them with "quorum keystore export".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ks, _ := openKeystore()
+
+		mnemonic := mnemonicRestore
+		if mnemonic == "" {
+			var err error
+			if mnemonic, err = localcrypto.NewMnemonic(256); err != nil {
+				logger.Fatalf(err.Error())
+			}
+		}
+		if err := ks.InitMnemonic(mnemonic, mnemonicPassphrase, keystorePassword); err != nil {
+			logger.Fatalf(err.Error())
+		}
+		if mnemonicRestore == "" {
+			fmt.Println("Write down the mnemonic and keep it safe, it is the only way to re-derive the keys:")
+			fmt.Println(mnemonic)
+		}
+		logger.Infof("mnemonic of keystore %s saved", keystoreName)
+	},
+}
+
+func init() {
+	keystoreCmd.AddCommand(keystoreExportCmd)
+	keystoreCmd.AddCommand(keystoreImportCmd)
+	keystoreCmd.AddCommand(keystoreMnemonicCmd)
+	rootCmd.AddCommand(keystoreCmd)
+
+	for _, c := range []*cobra.Command{keystoreExportCmd, keystoreImportCmd, keystoreMnemonicCmd} {
+		flags := c.Flags()
+		flags.SortFlags = false
+		flags.StringVar(&peerName, "peername", "peer", "peer name")
+		flags.StringVar(&configDir, "configdir", "config", "config dir")
+		flags.StringVar(&keystoreDir, "keystoredir", "keystore", "keystore dir")
+		flags.StringVar(&keystoreName, "keystorename", "default", "keystore name")
+		flags.StringVar(&keystorePassword, "keystorepass", "", "keystore password")
+	}
+
+	exportFlags := keystoreExportCmd.Flags()
+	exportFlags.StringSliceVar(&keyExportNames, "keyname", nil, "keyname or alias to export, e.g. the group id for the keys of a group")
+	exportFlags.StringVar(&keyExportFile, "file", "", "export filename")
+	exportFlags.StringVar(&keyExportPassword, "exportpass", "", "password of the export file")
+	keystoreExportCmd.MarkFlagRequired("keyname")
+	keystoreExportCmd.MarkFlagRequired("file")
+
+	importFlags := keystoreImportCmd.Flags()
+	importFlags.StringVar(&keyExportFile, "file", "", "filename exported by keystore export")
+	importFlags.StringVar(&keyExportPassword, "exportpass", "", "password of the export file")
+	keystoreImportCmd.MarkFlagRequired("file")
+
+	mnemonicFlags := keystoreMnemonicCmd.Flags()
+	mnemonicFlags.StringVar(&mnemonicRestore, "restore", "", "restore the mnemonic instead of creating a new one")
+	mnemonicFlags.StringVar(&mnemonicPassphrase, "passphrase", "", "optional passphrase of the mnemonic")
+}
+
+// openKeystore opens the keystore and the node options of the flags, and reads the keystore password
+func openKeystore() (*localcrypto.DirKeyStore, *options.NodeOptions) {
+	nodeoptions, err := options.InitNodeOptions(configDir, peerName)
+	if err != nil {
+		logger.Fatalf(err.Error())
+	}
+	ks, _, err := localcrypto.InitDirKeyStore(keystoreName, keystoreDir)
+	if err != nil {
+		logger.Fatalf(err.Error())
+	}
+	if keystorePassword == "" {
+		keystorePassword = os.Getenv("RUM_KSPASSWD")
+	}
+	if keystorePassword == "" {
+		if keystorePassword, err = localcrypto.PassphrasePromptForUnlock(); err != nil {
+			logger.Fatalf(err.Error())
+		}
+	}
+	return ks, nodeoptions
+}
@@ -23,7 +23,9 @@ import (
 	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
 	"github.com/rumsystem/quorum/internal/pkg/utils"
 	"github.com/rumsystem/quorum/pkg/chainapi/api"
+	"github.com/rumsystem/quorum/pkg/consensus"
 	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -90,6 +92,10 @@ func init() {
 	flags.StringSlice("peer", nil, "bootstrap peer address")
 	flags.String("jsontracer", "", "output tracer data to a json file")
 	flags.Bool("debug", false, "show debug log")
+	flags.String("dbbackend", "", "storage backend: bolt or badger, default to the backend of the existing data, bolt for a new data dir")
+	flags.Int("buffersendermax", 100, "max trxs of a sender in the trx buffer of a producer, 0 for no limit")
+	flags.Int("buffergroupmax", 10000, "max trxs in the trx buffer of a group, 0 for no limit")
+	flags.Duration("trxexpire", 12*time.Hour, "trxs created by the node expire after the duration if not packaged")
 
 	if err := producerViper.BindPFlags(flags); err != nil {
 		logger.Fatalf("viper bind flags failed: %s", err)
@@ -116,6 +122,13 @@ func runProducerNode(config cli.ProducerNodeFlag) {
 
 	nodeoptions.EnableRelay = false
 
+	// trx buffer limits of producer
+	consensus.TRX_BUFFER_SENDER_LIMIT = config.BufferSenderMax
+	consensus.TRX_BUFFER_GROUP_LIMIT = config.BufferGroupMax
+	if config.TrxExpire > 0 {
+		rumchaindata.TRX_EXPIRE_DURATION = config.TrxExpire
+	}
+
 	keystoreParam := InitKeystoreParam{
 		KeystoreName:   config.KeyStoreName,
 		KeystoreDir:    config.KeyStoreDir,
@@ -153,7 +166,7 @@ func runProducerNode(config cli.ProducerNodeFlag) {
 	nodename := "producernode_default"
 
 	datapath := config.DataDir + "/" + config.PeerName
-	dbManager, err := storage.CreateDb(datapath)
+	dbManager, err := storage.CreateDbWithBackend(datapath, config.DbBackend)
 
 	if err != nil {
 		logger.Fatalf(err.Error())
@@ -188,7 +201,7 @@ func runProducerNode(config cli.ProducerNodeFlag) {
 		logger.Fatalf(err.Error())
 	}
 
-	appdb, err := appdata.CreateAppDb(datapath)
+	appdb, err := appdata.CreateAppDbWithBackend(datapath, config.DbBackend)
 	if err != nil {
 		logger.Fatalf(err.Error())
 	}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rumsystem/quorum/internal/pkg/options"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	"github.com/spf13/cobra"
+)
+
+var ( // flags
+	signerListen string
+)
+
+var signerCmd = &cobra.Command{
+	Use:   "signer",
+	Short: "Run a signer serving the keys in the keystore for a fullnode started with --signer",
+	Long: `Run a signer serving the keys in the keystore for a fullnode started with --signer.
+The fullnode forwards signing and decryption with the keys held by the signer to it, e.g. keep the group owner keys
+(sign_<group_id> and encrypt_<group_id> in the keystore, with their SignKeyMap in the node options) on an isolated machine.
+The signer only listens on a unix socket accessible by its owner, forward the socket by ssh to serve a fullnode on another machine,
+e.g. ssh -L /path/to/node/signer.sock:/path/to/signer.sock user@signer-host`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if keystorePassword == "" {
+			keystorePassword = os.Getenv("RUM_KSPASSWD")
+		}
+		runSigner()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(signerCmd)
+
+	flags := signerCmd.Flags()
+	flags.SortFlags = false
+	flags.StringVar(&peerName, "peername", "peer", "peer name")
+	flags.StringVar(&configDir, "configdir", "config", "config dir")
+	flags.StringVar(&keystoreDir, "keystoredir", "keystore", "keystore dir")
+	flags.StringVar(&keystoreName, "keystorename", "default", "keystore name")
+	flags.StringVar(&keystorePassword, "keystorepass", "", "keystore password")
+	flags.StringVar(&signerListen, "listen", "unix://signer.sock", "signer listen address, unix:///path/to/signer.sock")
+}
+
+func runSigner() {
+	nodeoptions, err := options.InitNodeOptions(configDir, peerName)
+	if err != nil {
+		logger.Fatalf(err.Error())
+	}
+
+	ks, count, err := localcrypto.InitDirKeyStore(keystoreName, keystoreDir)
+	if err != nil {
+		logger.Fatalf(err.Error())
+	}
+	if count == 0 {
+		logger.Fatalf("no key in keystore %s", keystoreDir)
+	}
+	password := keystorePassword
+	if password == "" {
+		if password, err = localcrypto.PassphrasePromptForUnlock(); err != nil {
+			logger.Fatalf(err.Error())
+		}
+	}
+	if err := ks.Unlock(nodeoptions.SignKeyMap, password); err != nil {
+		logger.Fatalf(err.Error())
+	}
+
+	ln, err := localcrypto.ListenSigner(signerListen)
+	if err != nil {
+		logger.Fatalf(err.Error())
+	}
+	logger.Infof("signer listen on %s", signerListen)
+	go localcrypto.ServeSigner(ln, ks)
+
+	signalch := make(chan os.Signal, 1)
+	signal.Notify(signalch, os.Interrupt, syscall.SIGTERM)
+	<-signalch
+	ln.Close()
+	ks.Lock()
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
+	"github.com/spf13/cobra"
+)
+
+var ( // flags
+	sudoApiPrefix string
+	sudoGroupId   string
+	sudoTrxIds    []string
+	sudoJwt       string
+)
+
+var sudoCmd = &cobra.Command{
+	Use:   "sudo",
+	Short: "Package pending trxs into a sudo block signed by the group owner, to recover a group whose producers are gone",
+	Run: func(cmd *cobra.Command, args []string) {
+		result, err := sudoBlock(sudoApiPrefix, sudoJwt, sudoGroupId, sudoTrxIds)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		fmt.Printf("sudo block %d <%s> with trxs %v is broadcasted\n", result.BlockId, result.BlockHash, result.TrxIds)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sudoCmd)
+
+	flags := sudoCmd.Flags()
+	flags.SortFlags = false
+	flags.StringVar(&sudoApiPrefix, "api", "http://localhost:8000", "api prefix of the owner node")
+	flags.StringVar(&sudoGroupId, "groupid", "", "group id")
+	flags.StringSliceVar(&sudoTrxIds, "trxid", nil, "id of the pending trx to package, repeat or separate by comma for more trxs")
+	flags.StringVar(&sudoJwt, "jwt", "", "jwt of the owner node, not needed for localhost")
+	sudoCmd.MarkFlagRequired("groupid")
+	sudoCmd.MarkFlagRequired("trxid")
+}
+
+func sudoBlock(apiPrefix, jwt, groupId string, trxIds []string) (*handlers.SudoBlockResult, error) {
+	jsondata, err := json.Marshal(&handlers.SudoBlockParam{TrxIds: trxIds})
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/api/v1/group/%s/sudo", apiPrefix, groupId)
+	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsondata))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if jwt != "" {
+		req.Header.Set("Authorization", "Bearer "+jwt)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http err code %d: %s", resp.StatusCode, body)
+	}
+
+	result := &handlers.SudoBlockResult{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,576 @@
+package appdata
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+const ACT_OBJECT_PREFIX string = "aob_"   //object by sender and id
+const ACT_ID_PREFIX string = "aid_"       //the first object with an id, references by id are resolved to it
+const ACT_LIST_PREFIX string = "aol_"     //objects in descending time order
+const ACT_REPLY_PREFIX string = "arp_"    //replies of an object in time order
+const ACT_REACTION_PREFIX string = "arc_" //like or dislike of a sender on an object
+const ACT_COUNT_PREFIX string = "acn_"    //counters of an object
+
+const (
+	ActivityCreate  = "Create"
+	ActivityAdd     = "Add"
+	ActivityUpdate  = "Update"
+	ActivityDelete  = "Delete"
+	ActivityRemove  = "Remove"
+	ActivityLike    = "Like"
+	ActivityDislike = "Dislike"
+	ActivityUndo    = "Undo"
+)
+
+var errStopIter = errors.New("stop iteration")
+
+// ActivityObject is an object posted to the group, updated by the Update and Delete activities of its sender.
+// Objects are keyed by the sender and the id, so the ids taken by other senders don't block the activities of the sender
+type ActivityObject struct {
+	Id           string          `json:"id"`
+	TrxId        string          `json:"trx_id"`
+	GroupId      string          `json:"group_id"`
+	Sender       string          `json:"sender"`
+	Type         string          `json:"type" example:"Note"`
+	InReplyTo    string          `json:"in_reply_to"`
+	Object       json.RawMessage `json:"object" swaggertype:"object"`
+	TimeStamp    int64           `json:"timestamp"`
+	UpdatedAt    int64           `json:"updated_at"`
+	UpdateTrxId  string          `json:"update_trx_id"`
+	Deleted      bool            `json:"deleted"`
+	DeletedAt    int64           `json:"deleted_at"`
+	DeleteTrxId  string          `json:"delete_trx_id"`
+	LikeCount    int64           `json:"like_count"`
+	DislikeCount int64           `json:"dislike_count"`
+	ReplyCount   int64           `json:"reply_count"`
+}
+
+// ActivityThread is an object with its replies
+type ActivityThread struct {
+	*ActivityObject
+	Children []*ActivityThread `json:"children"`
+}
+
+type ActivityObjectsQuery struct {
+	Senders        []string
+	StartObject    string // object id of the last page, excluded
+	Num            int
+	TopLevel       bool // skip replies
+	IncludeDeleted bool
+}
+
+type activityCounts struct {
+	Likes    int64 `json:"likes"`
+	Dislikes int64 `json:"dislikes"`
+	Replies  int64 `json:"replies"`
+}
+
+type activityReaction struct {
+	Type      string `json:"type"` //Like, Dislike or empty for undo
+	TimeStamp int64  `json:"timestamp"`
+	TrxId     string `json:"trx_id"`
+}
+
+type activityFields struct {
+	Id        string          `json:"id"`
+	Type      string          `json:"type"`
+	InReplyTo json.RawMessage `json:"inReplyTo"`
+	Object    json.RawMessage `json:"object"`
+}
+
+// RemoveActivities removes all activity indexes of the group
+func (appdb *AppDb) RemoveActivities(groupid string) error {
+	for _, prefix := range []string{ACT_OBJECT_PREFIX, ACT_ID_PREFIX, ACT_LIST_PREFIX, ACT_REPLY_PREFIX, ACT_REACTION_PREFIX, ACT_COUNT_PREFIX} {
+		if _, err := appdb.Db.PrefixDelete([]byte(prefix + groupid + "_")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addActivities applies the activities of POSTs to the objects, replies and counters of the group, posts should be in block order
+func (appdb *AppDb) addActivities(groupItem *quorumpb.GroupItem, posts []*decryptedPost) error {
+	for _, post := range posts {
+		activity, err := parseActivityFields(post.data)
+		if err != nil {
+			appdatalog.Debugf("skip activity of trx %s, parse failed: %s", post.trx.TrxId, err)
+			continue
+		}
+
+		switch activity.Type {
+		case ActivityCreate, ActivityAdd:
+			if len(activity.Object) == 0 {
+				continue
+			}
+			err = appdb.createObject(post.trx, activity.Object, getReplyTo(activity.InReplyTo))
+		case ActivityUpdate:
+			err = appdb.updateObject(post.trx, activity.Object)
+		case ActivityDelete, ActivityRemove:
+			err = appdb.deleteObject(groupItem, post.trx, getObjectId(activity.Object))
+		case ActivityLike, ActivityDislike:
+			err = appdb.setReaction(post.trx, getObjectId(activity.Object), activity.Type)
+		case ActivityUndo:
+			undo, perr := parseActivityFields(activity.Object)
+			if perr != nil || (undo.Type != ActivityLike && undo.Type != ActivityDislike) {
+				continue
+			}
+			target := getObjectId(undo.Object)
+			if target == "" {
+				target = undo.Id
+			}
+			err = appdb.setReaction(post.trx, target, "")
+		default:
+			//a flat object like {"type": "Note", "content": "..."}
+			if len(activity.Object) == 0 && activity.Type != "" {
+				err = appdb.createObject(post.trx, post.data, "")
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (appdb *AppDb) createObject(trx *quorumpb.Trx, data []byte, inReplyTo string) error {
+	fields, err := parseActivityFields(data)
+	if err != nil || !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return nil
+	}
+
+	id := fields.Id
+	if !isValidObjectId(id) {
+		id = trx.TrxId
+	}
+	ref := objectRef(trx.SenderPubkey, id)
+	exist, err := appdb.Db.IsExist([]byte(actObjectKey(trx.GroupId, ref)))
+	if err != nil || exist {
+		//the first object of the sender with the id wins, it also skips trxs applied already
+		return err
+	}
+
+	if replyTo := getReplyTo(fields.InReplyTo); replyTo != "" {
+		inReplyTo = replyTo
+	}
+	obj := &ActivityObject{
+		Id:        id,
+		TrxId:     trx.TrxId,
+		GroupId:   trx.GroupId,
+		Sender:    trx.SenderPubkey,
+		Type:      fields.Type,
+		InReplyTo: inReplyTo,
+		Object:    data,
+		TimeStamp: trx.TimeStamp,
+	}
+	if err := appdb.saveObject(obj); err != nil {
+		return err
+	}
+
+	keys := [][]byte{[]byte(actListKey(obj.GroupId, obj.TimeStamp, ref))}
+	values := [][]byte{nil}
+	idExist, err := appdb.Db.IsExist([]byte(actIdKey(obj.GroupId, id)))
+	if err != nil {
+		return err
+	}
+	if !idExist {
+		keys = append(keys, []byte(actIdKey(obj.GroupId, id)))
+		values = append(values, []byte(ref))
+	}
+	parent, err := appdb.resolveObjectRef(obj.GroupId, obj.InReplyTo)
+	if err != nil {
+		return err
+	}
+	if parent != "" {
+		keys = append(keys, []byte(actReplyKey(obj.GroupId, parent, obj.TimeStamp, ref)))
+		values = append(values, nil)
+		if err := appdb.updateCounts(obj.GroupId, parent, func(c *activityCounts) { c.Replies++ }); err != nil {
+			return err
+		}
+	}
+	return appdb.Db.BatchWrite(keys, values)
+}
+
+// updateObject updates the object of the sender with the id, and the search doc of the object
+func (appdb *AppDb) updateObject(trx *quorumpb.Trx, data []byte) error {
+	obj, err := appdb.getObjectByRef(trx.GroupId, objectRef(trx.SenderPubkey, getObjectId(data)))
+	if err != nil || obj == nil || obj.Deleted {
+		return err
+	}
+	if trx.TimeStamp < obj.UpdatedAt || (trx.TimeStamp == obj.UpdatedAt && trx.TrxId <= obj.UpdateTrxId) {
+		//applied already, the search doc is reindexed with the trxs applied again
+		return appdb.refreshSearchDoc(obj)
+	}
+
+	obj.Object = data
+	obj.UpdatedAt = trx.TimeStamp
+	obj.UpdateTrxId = trx.TrxId
+	if err := appdb.saveObject(obj); err != nil {
+		return err
+	}
+	return appdb.refreshSearchDoc(obj)
+}
+
+// deleteObject deletes the object of the sender with the id, the group owner could delete any object referred by the id
+func (appdb *AppDb) deleteObject(groupItem *quorumpb.GroupItem, trx *quorumpb.Trx, id string) error {
+	obj, err := appdb.getObjectByRef(trx.GroupId, objectRef(trx.SenderPubkey, id))
+	if err != nil {
+		return err
+	}
+	if obj == nil && groupItem.OwnerPubKey == trx.SenderPubkey {
+		obj, err = appdb.getObject(trx.GroupId, id)
+		if err != nil {
+			return err
+		}
+	}
+	if obj == nil {
+		return nil
+	}
+	if obj.Deleted {
+		//applied already, drop the search doc reindexed with the trxs applied again
+		if appdb.SearchIndex {
+			return appdb.removeSearchDoc(obj.GroupId, obj.TimeStamp, obj.TrxId)
+		}
+		return nil
+	}
+
+	//keep a tombstone, so the thread is still complete
+	obj.Deleted = true
+	obj.DeletedAt = trx.TimeStamp
+	obj.DeleteTrxId = trx.TrxId
+	obj.Object = nil
+	if err := appdb.saveObject(obj); err != nil {
+		return err
+	}
+	parent, err := appdb.resolveObjectRef(obj.GroupId, obj.InReplyTo)
+	if err != nil {
+		return err
+	}
+	if parent != "" {
+		if err := appdb.updateCounts(obj.GroupId, parent, func(c *activityCounts) { c.Replies-- }); err != nil {
+			return err
+		}
+	}
+	if appdb.SearchIndex {
+		return appdb.removeSearchDoc(obj.GroupId, obj.TimeStamp, obj.TrxId)
+	}
+	return nil
+}
+
+// setReaction keeps the latest reaction of the sender on the object
+func (appdb *AppDb) setReaction(trx *quorumpb.Trx, id string, reactionType string) error {
+	ref, err := appdb.resolveObjectRef(trx.GroupId, id)
+	if err != nil || ref == "" {
+		return err
+	}
+	key := actReactionKey(trx.GroupId, ref, trx.SenderPubkey)
+	prev := &activityReaction{}
+	value, err := appdb.Db.Get([]byte(key))
+	if err != nil {
+		return err
+	}
+	if value != nil {
+		if err := json.Unmarshal(value, prev); err != nil {
+			return err
+		}
+		if trx.TimeStamp < prev.TimeStamp || (trx.TimeStamp == prev.TimeStamp && trx.TrxId <= prev.TrxId) {
+			return nil
+		}
+	}
+
+	if prev.Type != reactionType {
+		err := appdb.updateCounts(trx.GroupId, ref, func(c *activityCounts) {
+			c.add(prev.Type, -1)
+			c.add(reactionType, 1)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	value, err = json.Marshal(&activityReaction{Type: reactionType, TimeStamp: trx.TimeStamp, TrxId: trx.TrxId})
+	if err != nil {
+		return err
+	}
+	return appdb.Db.Set([]byte(key), value)
+}
+
+func (c *activityCounts) add(reactionType string, n int64) {
+	switch reactionType {
+	case ActivityLike:
+		c.Likes += n
+	case ActivityDislike:
+		c.Dislikes += n
+	}
+}
+
+func (appdb *AppDb) getCounts(groupid string, ref string) (*activityCounts, error) {
+	counts := &activityCounts{}
+	value, err := appdb.Db.Get([]byte(actCountKey(groupid, ref)))
+	if err != nil || value == nil {
+		return counts, err
+	}
+	err = json.Unmarshal(value, counts)
+	return counts, err
+}
+
+func (appdb *AppDb) updateCounts(groupid string, ref string, update func(*activityCounts)) error {
+	counts, err := appdb.getCounts(groupid, ref)
+	if err != nil {
+		return err
+	}
+	update(counts)
+	value, err := json.Marshal(counts)
+	if err != nil {
+		return err
+	}
+	return appdb.Db.Set([]byte(actCountKey(groupid, ref)), value)
+}
+
+func (appdb *AppDb) saveObject(obj *ActivityObject) error {
+	value, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return appdb.Db.Set([]byte(actObjectKey(obj.GroupId, objectRef(obj.Sender, obj.Id))), value)
+}
+
+// resolveObjectRef returns the ref of the first object with the id, empty if the object is not found
+func (appdb *AppDb) resolveObjectRef(groupid string, id string) (string, error) {
+	if !isValidObjectId(id) {
+		return "", nil
+	}
+	value, err := appdb.Db.Get([]byte(actIdKey(groupid, id)))
+	return string(value), err
+}
+
+// getObject returns the first object with the id, nil if the object is not found
+func (appdb *AppDb) getObject(groupid string, id string) (*ActivityObject, error) {
+	ref, err := appdb.resolveObjectRef(groupid, id)
+	if err != nil || ref == "" {
+		return nil, err
+	}
+	return appdb.getObjectByRef(groupid, ref)
+}
+
+// getObjectByRef returns nil if the object is not found
+func (appdb *AppDb) getObjectByRef(groupid string, ref string) (*ActivityObject, error) {
+	value, err := appdb.Db.Get([]byte(actObjectKey(groupid, ref)))
+	if err != nil || value == nil {
+		return nil, err
+	}
+	obj := &ActivityObject{}
+	if err := json.Unmarshal(value, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// GetActivityObject returns the first object with the id with its counters, nil if the object is not found
+func (appdb *AppDb) GetActivityObject(groupid string, id string) (*ActivityObject, error) {
+	obj, err := appdb.getObject(groupid, id)
+	if err != nil || obj == nil {
+		return nil, err
+	}
+	return appdb.withCounts(obj)
+}
+
+func (appdb *AppDb) getActivityObjectByRef(groupid string, ref string) (*ActivityObject, error) {
+	obj, err := appdb.getObjectByRef(groupid, ref)
+	if err != nil || obj == nil {
+		return nil, err
+	}
+	return appdb.withCounts(obj)
+}
+
+func (appdb *AppDb) withCounts(obj *ActivityObject) (*ActivityObject, error) {
+	counts, err := appdb.getCounts(obj.GroupId, objectRef(obj.Sender, obj.Id))
+	if err != nil {
+		return nil, err
+	}
+	obj.LikeCount = counts.Likes
+	obj.DislikeCount = counts.Dislikes
+	obj.ReplyCount = counts.Replies
+	return obj, nil
+}
+
+// GetActivityObjects returns a page of objects with counters, newest first
+func (appdb *AppDb) GetActivityObjects(groupid string, query *ActivityObjectsQuery) ([]*ActivityObject, error) {
+	if query.Num <= 0 {
+		query.Num = 20
+	}
+	senders := make(map[string]bool)
+	for _, s := range query.Senders {
+		senders[s] = true
+	}
+
+	valid := fmt.Sprintf("%s%s_", ACT_LIST_PREFIX, groupid)
+	seek := valid
+	if query.StartObject != "" {
+		start, err := appdb.getObject(groupid, query.StartObject)
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			return nil, fmt.Errorf("object %s not found", query.StartObject)
+		}
+		seek = actListKey(groupid, start.TimeStamp, objectRef(start.Sender, start.Id))
+	}
+
+	objs := []*ActivityObject{}
+	_, err := appdb.Db.PrefixForeachKey([]byte(seek), []byte(valid), false, func(k []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		if string(k) == seek && query.StartObject != "" {
+			return nil
+		}
+		//key is the prefix, time and object ref
+		ref := string(k[len(valid)+len(descTime(0))+1:])
+		obj, err := appdb.getActivityObjectByRef(groupid, ref)
+		if err != nil {
+			return err
+		}
+		if obj == nil {
+			return nil
+		}
+		if len(senders) > 0 && !senders[obj.Sender] {
+			return nil
+		}
+		if query.TopLevel && obj.InReplyTo != "" {
+			return nil
+		}
+		if obj.Deleted && !query.IncludeDeleted {
+			return nil
+		}
+		objs = append(objs, obj)
+		if len(objs) >= query.Num {
+			return errStopIter
+		}
+		return nil
+	})
+	if err != nil && err != errStopIter {
+		return nil, err
+	}
+	return objs, nil
+}
+
+// GetActivityThread returns the object and its replies down to depth levels, oldest reply first, at most maxObjs objects
+func (appdb *AppDb) GetActivityThread(groupid string, id string, depth int, maxObjs int) (*ActivityThread, error) {
+	root, err := appdb.GetActivityObject(groupid, id)
+	if err != nil || root == nil {
+		return nil, err
+	}
+
+	thread := &ActivityThread{ActivityObject: root, Children: []*ActivityThread{}}
+	count := 1
+	level := []*ActivityThread{thread}
+	for d := 0; d < depth && len(level) > 0 && count < maxObjs; d++ {
+		next := []*ActivityThread{}
+		for _, parent := range level {
+			if count >= maxObjs {
+				break
+			}
+			prefix := actReplyPrefix(groupid, objectRef(parent.Sender, parent.Id))
+			_, err := appdb.Db.PrefixForeachKey([]byte(prefix), []byte(prefix), false, func(k []byte, err error) error {
+				if err != nil {
+					return err
+				}
+				childRef := string(k[len(prefix)+len(descTime(0))+1:])
+				child, err := appdb.getActivityObjectByRef(groupid, childRef)
+				if err != nil {
+					return err
+				}
+				if child == nil {
+					return nil
+				}
+				node := &ActivityThread{ActivityObject: child, Children: []*ActivityThread{}}
+				parent.Children = append(parent.Children, node)
+				next = append(next, node)
+				count++
+				if count >= maxObjs {
+					return errStopIter
+				}
+				return nil
+			})
+			if err != nil && err != errStopIter {
+				return nil, err
+			}
+		}
+		level = next
+	}
+	return thread, nil
+}
+
+func parseActivityFields(data []byte) (*activityFields, error) {
+	fields := &activityFields{}
+	if err := json.Unmarshal(data, fields); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+// object could be referred by id string or an object with id
+func getObjectId(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var id string
+	if err := json.Unmarshal(raw, &id); err == nil {
+		return id
+	}
+	fields, err := parseActivityFields(raw)
+	if err != nil {
+		return ""
+	}
+	return fields.Id
+}
+
+// ids are part of the keys, the separator is not allowed
+func isValidObjectId(id string) bool {
+	return id != "" && !strings.Contains(id, "_")
+}
+
+// objectRef is the sender and the id of an object, the id has no separator, so a ref is never a prefix of another one
+func objectRef(sender string, id string) string {
+	return sender + ":" + id
+}
+
+func actObjectKey(groupid string, ref string) string {
+	return fmt.Sprintf("%s%s_%s", ACT_OBJECT_PREFIX, groupid, ref)
+}
+
+func actIdKey(groupid string, id string) string {
+	return fmt.Sprintf("%s%s_%s", ACT_ID_PREFIX, groupid, id)
+}
+
+func actListKey(groupid string, timestamp int64, ref string) string {
+	return fmt.Sprintf("%s%s_%s_%s", ACT_LIST_PREFIX, groupid, descTime(timestamp), ref)
+}
+
+func actReplyPrefix(groupid string, parentref string) string {
+	return fmt.Sprintf("%s%s_%s_", ACT_REPLY_PREFIX, groupid, parentref)
+}
+
+func actReplyKey(groupid string, parentref string, timestamp int64, ref string) string {
+	if timestamp < 0 {
+		timestamp = 0
+	}
+	return fmt.Sprintf("%s%019d_%s", actReplyPrefix(groupid, parentref), timestamp, ref)
+}
+
+func actReactionKey(groupid string, ref string, sender string) string {
+	return fmt.Sprintf("%s%s_%s_%s", ACT_REACTION_PREFIX, groupid, ref, sender)
+}
+
+func actCountKey(groupid string, ref string) string {
+	return fmt.Sprintf("%s%s_%s", ACT_COUNT_PREFIX, groupid, ref)
+}
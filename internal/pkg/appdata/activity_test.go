@@ -0,0 +1,219 @@
+package appdata
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/rumsystem/quorum/internal/pkg/storage"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func makeActivityTrxs(t *testing.T, groupItem *quorumpb.GroupItem, key []byte, posts [][2]string) []*quorumpb.Trx {
+	trxs := []*quorumpb.Trx{}
+	for i, post := range posts {
+		data, err := localcrypto.AesEncrypt([]byte(post[1]), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		trxs = append(trxs, &quorumpb.Trx{
+			TrxId:        fmt.Sprintf("trx%d", i),
+			GroupId:      groupItem.GroupId,
+			SenderPubkey: post[0],
+			TimeStamp:    int64(i + 1),
+			Type:         quorumpb.TrxType_POST,
+			Data:         data,
+		})
+	}
+	return trxs
+}
+
+// newTestChainDb saves the trxs in the block as the chain db
+func newTestChainDb(t *testing.T, blockId uint64, trxs []*quorumpb.Trx) storage.QuorumStorage {
+	chainDb, err := storage.OpenStore(context.Background(), storage.BoltBackend, t.TempDir(), "chain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { chainDb.Close() })
+
+	block := &quorumpb.Block{GroupId: trxs[0].GroupId, BlockId: blockId, Trxs: trxs}
+	value, err := proto.Marshal(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := [][]byte{[]byte(storage.GetBlockKey(block.GroupId, blockId, "node"))}
+	values := [][]byte{value}
+	for _, trx := range trxs {
+		value, err := proto.Marshal(trx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, []byte(storage.GetTrxKey(trx.GroupId, trx.TrxId, "node")), []byte(storage.GetTrxBlockKey(trx.GroupId, trx.TrxId, "node")))
+		values = append(values, value, []byte(fmt.Sprint(blockId)))
+	}
+	if err := chainDb.BatchWrite(keys, values); err != nil {
+		t.Fatal(err)
+	}
+	return chainDb
+}
+
+func checkActivities(t *testing.T, appdb *AppDb, groupid string) {
+	root, err := appdb.GetActivityObject(groupid, "trx0")
+	if err != nil || root == nil {
+		t.Fatalf("get object failed: %v %v", root, err)
+	}
+	if root.LikeCount != 1 || root.DislikeCount != 1 || root.ReplyCount != 1 {
+		t.Errorf("unexpected counts: %+v", root)
+	}
+	if string(root.Object) != `{"type":"Note","id":"trx0","content":"edited"}` {
+		t.Errorf("unexpected updated object: %s", root.Object)
+	}
+
+	thread, err := appdb.GetActivityThread(groupid, "trx0", 10, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(thread.Children) != 2 || thread.Children[0].Id != "trx1" || !thread.Children[1].Deleted {
+		t.Fatalf("unexpected thread children: %+v", thread.Children)
+	}
+	if len(thread.Children[0].Children) != 1 || thread.Children[0].Children[0].Id != "reply2" {
+		t.Errorf("unexpected nested replies: %+v", thread.Children[0].Children)
+	}
+
+	objs, err := appdb.GetActivityObjects(groupid, &ActivityObjectsQuery{TopLevel: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 1 || objs[0].Id != "trx0" {
+		t.Errorf("unexpected top level objects: %+v", objs)
+	}
+
+	objs, err = appdb.GetActivityObjects(groupid, &ActivityObjectsQuery{Num: 1, StartObject: "reply2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 1 || objs[0].Id != "trx1" {
+		t.Errorf("unexpected page: %+v", objs)
+	}
+}
+
+func TestActivities(t *testing.T) {
+	appdb, err := CreateAppDb(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer appdb.Db.Close()
+
+	key, _ := localcrypto.CreateAesKey()
+	groupItem := &quorumpb.GroupItem{
+		GroupId:     "group1",
+		CipherKey:   hex.EncodeToString(key),
+		EncryptType: quorumpb.GroupEncryptType_PUBLIC,
+		OwnerPubKey: "owner",
+	}
+	trxs := makeActivityTrxs(t, groupItem, key, [][2]string{
+		{"alice", `{"type":"Create","object":{"type":"Note","content":"root"}}`},
+		{"bob", `{"type":"Create","object":{"type":"Note","content":"reply","inreplyto":{"trxid":"trx0"}}}`},
+		{"carol", `{"type":"Create","object":{"type":"Note","id":"reply2","content":"nested","inReplyTo":"trx1"}}`},
+		{"bob", `{"type":"Like","object":{"id":"trx0"}}`},
+		{"carol", `{"type":"Like","object":{"id":"trx0"}}`},
+		{"carol", `{"type":"Dislike","object":{"id":"trx0"}}`},
+		{"bob", `{"type":"Undo","object":{"type":"Like","object":{"id":"trx0"}}}`},
+		{"dave", `{"type":"Like","object":{"id":"trx0"}}`},
+		{"bob", `{"type":"Update","object":{"type":"Note","id":"trx0","content":"hijack"}}`},
+		{"alice", `{"type":"Update","object":{"type":"Note","id":"trx0","content":"edited"}}`},
+		{"dave", `{"type":"Note","content":"spam","inReplyTo":"trx0"}`},
+		{"owner", `{"type":"Delete","object":{"id":"trx10"}}`},
+	})
+
+	//applied twice, as appsync after a rebuild
+	for i := 0; i < 2; i++ {
+		if err := appdb.AddPostIndex(groupItem, trxs); err != nil {
+			t.Fatal(err)
+		}
+		checkActivities(t, appdb, groupItem.GroupId)
+	}
+
+	//rebuild from the trxs saved in chain db
+	chainDb := newTestChainDb(t, 1, trxs)
+	if err := appdb.Rebuild(appdb.IndexVersionTag(), chainDb, []*quorumpb.GroupItem{groupItem}, "node"); err != nil {
+		t.Fatal(err)
+	}
+	checkActivities(t, appdb, groupItem.GroupId)
+	if tag, _ := appdb.GetIndexVersion(); tag != appdb.IndexVersionTag() {
+		t.Errorf("unexpected index version: %s", tag)
+	}
+}
+
+func TestActivityObjectsBySender(t *testing.T) {
+	appdb, err := CreateAppDb(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer appdb.Db.Close()
+	appdb.SearchIndex = true
+
+	key, _ := localcrypto.CreateAesKey()
+	groupItem := &quorumpb.GroupItem{
+		GroupId:     "group1",
+		CipherKey:   hex.EncodeToString(key),
+		EncryptType: quorumpb.GroupEncryptType_PUBLIC,
+		OwnerPubKey: "owner",
+	}
+	trxs := makeActivityTrxs(t, groupItem, key, [][2]string{
+		{"alice", `{"type":"Create","object":{"type":"Note","id":"note1","content":"first draft"}}`},
+		{"mallory", `{"type":"Create","object":{"type":"Note","id":"note1","content":"squat"}}`},
+		{"alice", `{"type":"Update","object":{"type":"Note","id":"note1","content":"final version"}}`},
+		{"bob", `{"type":"Like","object":{"id":"note1"}}`},
+		{"mallory", `{"type":"Delete","object":{"id":"note1"}}`},
+	})
+	//the timestamps are set by the senders, the trxs are applied in block order
+	trxs[0].TimeStamp = 10
+
+	check := func() {
+		objs, err := appdb.GetActivityObjects(groupItem.GroupId, &ActivityObjectsQuery{Senders: []string{"alice"}, IncludeDeleted: true})
+		if err != nil || len(objs) != 1 {
+			t.Fatalf("get objects of alice failed: %+v %v", objs, err)
+		}
+		if objs[0].Deleted || string(objs[0].Object) != `{"type":"Note","id":"note1","content":"final version"}` || objs[0].LikeCount != 1 {
+			t.Errorf("unexpected object of alice: %+v, %s", objs[0], objs[0].Object)
+		}
+
+		objs, err = appdb.GetActivityObjects(groupItem.GroupId, &ActivityObjectsQuery{Senders: []string{"mallory"}, IncludeDeleted: true})
+		if err != nil || len(objs) != 1 || !objs[0].Deleted || objs[0].LikeCount != 0 {
+			t.Errorf("unexpected objects of mallory: %+v %v", objs, err)
+		}
+
+		//references by id are resolved to the first object in block order
+		obj, err := appdb.GetActivityObject(groupItem.GroupId, "note1")
+		if err != nil || obj == nil || obj.Sender != "alice" {
+			t.Errorf("note1 should be resolved to the object of alice, got %+v %v", obj, err)
+		}
+
+		docs, err := appdb.Search(groupItem.GroupId, &SearchQuery{Query: "final", ObjectType: "Note"})
+		//the doc of the Create refreshed, and the doc of the Update
+		if err != nil || len(docs) != 2 || docs[0].TrxId != "trx0" || docs[0].Type != "Create" || docs[1].Type != "Update" {
+			t.Errorf("search doc of the updated object should be refreshed, got %v %v", docs, err)
+		}
+		if docs, _ := appdb.Search(groupItem.GroupId, &SearchQuery{Query: "draft"}); len(docs) != 0 {
+			t.Errorf("old content of the updated object should not be matched, got %+v", docs)
+		}
+	}
+
+	//applied twice, as appsync after a rebuild
+	for i := 0; i < 2; i++ {
+		if err := appdb.AddPostIndex(groupItem, trxs); err != nil {
+			t.Fatal(err)
+		}
+		check()
+	}
+
+	chainDb := newTestChainDb(t, 1, trxs)
+	if err := appdb.Rebuild(appdb.IndexVersionTag(), chainDb, []*quorumpb.GroupItem{groupItem}, "node"); err != nil {
+		t.Fatal(err)
+	}
+	check()
+}
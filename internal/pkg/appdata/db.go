@@ -11,6 +11,7 @@ import (
 	"github.com/google/orderedcode"
 	"github.com/rumsystem/quorum/internal/pkg/logging"
 	"github.com/rumsystem/quorum/internal/pkg/storage"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
 	quorumpb "github.com/rumsystem/quorum/pkg/pb"
 )
 
@@ -25,9 +26,10 @@ const SED_PREFIX string = "sed_"
 const STATUS_PREFIX string = "stu_"
 
 type AppDb struct {
-	Db       storage.QuorumStorage
-	seq      map[string]storage.Sequence
-	DataPath string
+	Db          storage.QuorumStorage
+	seq         map[string]storage.Sequence
+	DataPath    string
+	SearchIndex bool
 }
 
 func NewAppDb() *AppDb {
@@ -50,6 +52,83 @@ func (appdb *AppDb) GetGroupStatus(groupid string, name string) (string, error)
 	return string(value), err
 }
 
+// GetSyncedBlockId returns the highest block of the group parsed by appsync, trxs after it are not pushed yet
+func (appdb *AppDb) GetSyncedBlockId(groupid string) (uint64, error) {
+	blockIdStr, err := appdb.GetGroupStatus(groupid, "Block")
+	if err != nil {
+		return 0, err
+	}
+	if blockIdStr == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(blockIdStr, 10, 64)
+}
+
+// RewindSyncedBlock moves the synced block of the group back to the block before the forks switched since last called,
+// so the blocks of the new branch are parsed again. It returns the synced block after rewind.
+func (appdb *AppDb) RewindSyncedBlock(groupid string, forks []*chainstorage.ForkRecord) (uint64, error) {
+	synced, err := appdb.GetSyncedBlockId(groupid)
+	if err != nil {
+		return 0, err
+	}
+	handledStr, err := appdb.GetGroupStatus(groupid, "Fork")
+	if err != nil {
+		return 0, err
+	}
+	var handled int64
+	if handledStr != "" {
+		if handled, err = strconv.ParseInt(handledStr, 10, 64); err != nil {
+			return 0, err
+		}
+	}
+
+	latest := handled
+	rewind := synced
+	for _, fork := range forks {
+		if fork.Resolution != chainstorage.ForkSwitched || fork.TimeStamp <= handled {
+			continue
+		}
+		if fork.TimeStamp > latest {
+			latest = fork.TimeStamp
+		}
+		if fork.BlockId <= rewind {
+			rewind = fork.BlockId - 1
+		}
+	}
+	if latest == handled {
+		return synced, nil
+	}
+
+	keys := [][]byte{
+		[]byte(fmt.Sprintf("%s%s_%s", STATUS_PREFIX, groupid, "Block")),
+		[]byte(fmt.Sprintf("%s%s_%s", STATUS_PREFIX, groupid, "Fork")),
+	}
+	values := [][]byte{
+		[]byte(strconv.FormatUint(rewind, 10)),
+		[]byte(strconv.FormatInt(latest, 10)),
+	}
+	if err := appdb.Db.BatchWrite(keys, values); err != nil {
+		return 0, err
+	}
+	return rewind, nil
+}
+
+// GetTrxBlockId returns the block the trx is packaged in, false if the trx is not parsed by appsync yet
+func (appdb *AppDb) GetTrxBlockId(groupid string, trxid string) (uint64, bool, error) {
+	value, err := appdb.Db.Get(trxBlockKey(groupid, trxid))
+	if err != nil {
+		return 0, false, err
+	}
+	if value == nil {
+		return 0, false, nil
+	}
+	blockId, err := strconv.ParseUint(string(value), 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return blockId, true, nil
+}
+
 func (appdb *AppDb) GetSeqId(seqkey string) (uint64, error) {
 	if appdb.seq[seqkey] == nil {
 		seq, err := appdb.Db.GetSequence([]byte(seqkey), 100)
@@ -62,11 +141,6 @@ func (appdb *AppDb) GetSeqId(seqkey string) (uint64, error) {
 	return appdb.seq[seqkey].Next()
 }
 
-func (appdb *AppDb) Rebuild(vertag string, chainDb storage.QuorumStorage) error {
-
-	return nil
-}
-
 func (appdb *AppDb) GetGroupContentBySenders(groupid string, senders []string, starttrx string, num int, reverse bool, starttrxinclude bool) (trxidList []string, err error) {
 	prefix := fmt.Sprintf("%s%s-%s", CNT_PREFIX, GRP_PREFIX, groupid)
 	sendermap := make(map[string]bool)
@@ -214,6 +288,12 @@ func (appdb *AppDb) AddMetaByTrx(blockId uint64, groupid string, trxs []*quorump
 	keylist := [][]byte{}
 	for _, trx := range trxs {
 		if trx.Type == quorumpb.TrxType_POST {
+			//parsed before the fork switched, the block is parsed again
+			if _, parsed, err := appdb.GetTrxBlockId(groupid, trx.TrxId); err != nil {
+				return err
+			} else if parsed {
+				continue
+			}
 			seqid, err := appdb.GetSeqId(seqkey)
 			if err != nil {
 				return err
@@ -240,6 +320,12 @@ func (appdb *AppDb) AddMetaByTrx(blockId uint64, groupid string, trxs []*quorump
 		values = append(values, nil)
 	}
 
+	//trx to block index, to resume trx subscriptions from a trx
+	for _, trx := range trxs {
+		keys = append(keys, trxBlockKey(groupid, trx.TrxId))
+		values = append(values, []byte(strconv.FormatUint(blockId, 10)))
+	}
+
 	valuename := "Block"
 	groupLastestBlockidkey := fmt.Sprintf("%s%s_%s", STATUS_PREFIX, groupid, valuename)
 	keys = append(keys, []byte(groupLastestBlockidkey))
@@ -264,6 +350,10 @@ func (appdb *AppDb) Close() {
 	appdb.Db.Close()
 }
 
+func trxBlockKey(groupID string, trxID string) []byte {
+	return []byte(fmt.Sprintf("%s%s_%s", TRX_PREFIX, groupID, trxID))
+}
+
 func groupSeedKey(groupID string) []byte {
 	return []byte(fmt.Sprintf("%s%s", SED_PREFIX, groupID))
 }
@@ -0,0 +1,209 @@
+package appdata
+
+import (
+	"encoding/hex"
+	"sort"
+	"strconv"
+
+	"github.com/rumsystem/quorum/internal/pkg/storage"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// version of the post indexes format, indexes are rebuilt if the tag saved in db mismatch
+const APP_INDEX_VERSION = "2"
+
+const indexVersionKey = STATUS_PREFIX + "index_version"
+
+type decryptedPost struct {
+	trx  *quorumpb.Trx
+	data []byte
+}
+
+// IsSearchIndexEnabled returns true if POST content is indexed for search by appsync
+func (appdb *AppDb) IsSearchIndexEnabled() bool {
+	return appdb.SearchIndex
+}
+
+// IndexVersionTag returns the tag of the indexes with the current version and options
+func (appdb *AppDb) IndexVersionTag() string {
+	if appdb.SearchIndex {
+		return APP_INDEX_VERSION + "-search"
+	}
+	return APP_INDEX_VERSION
+}
+
+// GetIndexVersion returns the tag of the indexes saved in db, empty if never built
+func (appdb *AppDb) GetIndexVersion() (string, error) {
+	value, err := appdb.Db.Get([]byte(indexVersionKey))
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// AddPostIndex decrypts POST trxs and indexes them, trxs can't be decrypted are skipped
+func (appdb *AppDb) AddPostIndex(groupItem *quorumpb.GroupItem, trxs []*quorumpb.Trx) error {
+	posts := decryptPosts(groupItem, trxs)
+	if len(posts) == 0 {
+		return nil
+	}
+
+	//index first, a Delete in the same batch drops the doc of the deleted post
+	if appdb.SearchIndex {
+		if err := appdb.addSearchIndex(posts); err != nil {
+			return err
+		}
+	}
+	return appdb.addActivities(groupItem, posts)
+}
+
+// Rebuild reindexes the POST trxs of the groups saved in the chain db in block order, as appsync applies them,
+// then tags the indexes with vertag
+func (appdb *AppDb) Rebuild(vertag string, chainDb storage.QuorumStorage, groupItems []*quorumpb.GroupItem, prefix ...string) error {
+	for _, groupItem := range groupItems {
+		appdatalog.Infof("rebuild post indexes of group %s", groupItem.GroupId)
+		if err := appdb.RemoveSearchIndex(groupItem.GroupId); err != nil {
+			return err
+		}
+		if err := appdb.RemoveActivities(groupItem.GroupId); err != nil {
+			return err
+		}
+
+		refs, err := getPostRefs(chainDb, groupItem.GroupId, prefix...)
+		if err != nil {
+			return err
+		}
+
+		trxs := []*quorumpb.Trx{}
+		for i, ref := range refs {
+			value, err := chainDb.Get(ref.key)
+			if err != nil {
+				return err
+			}
+			trx := &quorumpb.Trx{}
+			if err := proto.Unmarshal(value, trx); err != nil {
+				return err
+			}
+			trxs = append(trxs, trx)
+			if len(trxs) >= 1000 || i == len(refs)-1 {
+				if err := appdb.AddPostIndex(groupItem, trxs); err != nil {
+					return err
+				}
+				trxs = []*quorumpb.Trx{}
+			}
+		}
+	}
+
+	return appdb.Db.Set([]byte(indexVersionKey), []byte(vertag))
+}
+
+type postRef struct {
+	key       []byte
+	blockId   uint64
+	index     int // index in the block, the blocks pruned are ordered by time
+	timestamp int64
+	trxid     string
+}
+
+// getPostRefs returns the keys of the POST trxs of the group in the order of the blocks packaged them
+func getPostRefs(chainDb storage.QuorumStorage, groupId string, prefix ...string) ([]*postRef, error) {
+	//trxs are saved by trx id, collect the keys and sort them
+	refs := []*postRef{}
+	trxPrefix := storage.GetTrxPrefix(groupId, prefix...)
+	err := chainDb.PrefixForeach([]byte(trxPrefix), func(k []byte, v []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		trx := &quorumpb.Trx{}
+		if err := proto.Unmarshal(v, trx); err != nil {
+			return err
+		}
+		if trx.Type == quorumpb.TrxType_POST {
+			refs = append(refs, &postRef{key: append([]byte{}, k...), timestamp: trx.TimeStamp, trxid: trx.TrxId})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	blockTrxs := make(map[uint64]map[string]int)
+	for _, ref := range refs {
+		value, err := chainDb.Get([]byte(storage.GetTrxBlockKey(groupId, ref.trxid, prefix...)))
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		if ref.blockId, err = strconv.ParseUint(string(value), 10, 64); err != nil {
+			return nil, err
+		}
+
+		indexes, ok := blockTrxs[ref.blockId]
+		if !ok {
+			indexes = make(map[string]int)
+			value, err := chainDb.Get([]byte(storage.GetBlockKey(groupId, ref.blockId, prefix...)))
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				block := &quorumpb.Block{}
+				if err := proto.Unmarshal(value, block); err != nil {
+					return nil, err
+				}
+				for i, trx := range block.Trxs {
+					indexes[trx.TrxId] = i
+				}
+			}
+			blockTrxs[ref.blockId] = indexes
+		}
+		ref.index = indexes[ref.trxid]
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].blockId != refs[j].blockId {
+			return refs[i].blockId < refs[j].blockId
+		}
+		if refs[i].index != refs[j].index {
+			return refs[i].index < refs[j].index
+		}
+		if refs[i].timestamp != refs[j].timestamp {
+			return refs[i].timestamp < refs[j].timestamp
+		}
+		return refs[i].trxid < refs[j].trxid
+	})
+	return refs, nil
+}
+
+func decryptPosts(groupItem *quorumpb.GroupItem, trxs []*quorumpb.Trx) []*decryptedPost {
+	posts := []*decryptedPost{}
+	for _, trx := range trxs {
+		if trx.Type != quorumpb.TrxType_POST {
+			continue
+		}
+		data, err := decryptPostData(groupItem, trx)
+		if err != nil {
+			appdatalog.Debugf("skip index of trx %s, decrypt failed: %s", trx.TrxId, err)
+			continue
+		}
+		posts = append(posts, &decryptedPost{trx: trx, data: data})
+	}
+	return posts
+}
+
+func decryptPostData(groupItem *quorumpb.GroupItem, trx *quorumpb.Trx) ([]byte, error) {
+	//for post, private group, encrypted by age for all announced group user
+	if groupItem.EncryptType == quorumpb.GroupEncryptType_PRIVATE {
+		ks := localcrypto.GetKeystore()
+		return ks.Decrypt(groupItem.GroupId, trx.Data)
+	}
+
+	ciperKey, err := hex.DecodeString(groupItem.CipherKey)
+	if err != nil {
+		return nil, err
+	}
+	return localcrypto.AesDecode(trx.Data, ciperKey)
+}
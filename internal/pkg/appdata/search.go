@@ -0,0 +1,366 @@
+package appdata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+const SEARCH_DOC_PREFIX string = "sdc_"
+const SEARCH_TERM_PREFIX string = "sti_"
+
+const maxSearchTermLen = 64
+
+// SearchDoc is the indexed fields of a decrypted POST, in ActivityStreams
+type SearchDoc struct {
+	TrxId       string   `json:"trx_id"`
+	GroupId     string   `json:"group_id"`
+	Sender      string   `json:"sender"`
+	TimeStamp   int64    `json:"timestamp"`
+	Type        string   `json:"type" example:"Create"`
+	ObjectType  string   `json:"object_type" example:"Note"`
+	Name        string   `json:"name"`
+	Content     string   `json:"content"`
+	InReplyTo   string   `json:"in_reply_to"`
+	Attachments []string `json:"attachments"`
+}
+
+type SearchQuery struct {
+	Query      string   // all terms should be matched
+	Senders    []string // any of the senders
+	StartTime  int64    // included, in nanoseconds
+	EndTime    int64    // excluded, in nanoseconds, 0 means no limit
+	Type       string
+	ObjectType string
+	InReplyTo  string
+	Num        int
+}
+
+// activity stream fields to index, all other fields are ignored
+type searchObject struct {
+	Id          string          `json:"id"`
+	Type        string          `json:"type"`
+	Name        string          `json:"name"`
+	Content     string          `json:"content"`
+	InReplyTo   json.RawMessage `json:"inReplyTo"`
+	Attachment  json.RawMessage `json:"attachment"`
+	Attachments json.RawMessage `json:"attachments"`
+	Object      *searchObject   `json:"object"`
+}
+
+// RemoveSearchIndex removes all indexed content of the group
+func (appdb *AppDb) RemoveSearchIndex(groupid string) error {
+	if _, err := appdb.Db.PrefixDelete([]byte(searchDocPrefix(groupid))); err != nil {
+		return err
+	}
+	_, err := appdb.Db.PrefixDelete([]byte(SEARCH_TERM_PREFIX + groupid + "_"))
+	return err
+}
+
+// addSearchIndex indexes the decrypted content of POSTs, posts can't be parsed are skipped
+func (appdb *AppDb) addSearchIndex(posts []*decryptedPost) error {
+	docs := []*SearchDoc{}
+	for _, post := range posts {
+		doc, err := newSearchDoc(post.trx, post.data)
+		if err != nil {
+			appdatalog.Debugf("skip search index of trx %s, parse failed: %s", post.trx.TrxId, err)
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	return appdb.indexSearchDocs(docs)
+}
+
+func (appdb *AppDb) indexSearchDocs(docs []*SearchDoc) error {
+	keys := [][]byte{}
+	values := [][]byte{}
+
+	for _, doc := range docs {
+		docValue, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, []byte(searchDocKey(doc.GroupId, doc.TimeStamp, doc.TrxId)))
+		values = append(values, docValue)
+
+		for _, term := range doc.terms() {
+			keys = append(keys, []byte(searchTermKey(doc.GroupId, term, doc.TimeStamp, doc.TrxId)))
+			values = append(values, nil)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+	return appdb.Db.BatchWrite(keys, values)
+}
+
+// removeSearchDoc drops the doc of a deleted post, its term keys are skipped by Search
+func (appdb *AppDb) removeSearchDoc(groupid string, timestamp int64, trxid string) error {
+	return appdb.Db.Delete([]byte(searchDocKey(groupid, timestamp, trxid)))
+}
+
+// refreshSearchDoc reindexes the doc of the post created the object with the updated object,
+// the term keys of the old content are left and skipped by Search
+func (appdb *AppDb) refreshSearchDoc(obj *ActivityObject) error {
+	if !appdb.SearchIndex {
+		return nil
+	}
+	value, err := appdb.Db.Get([]byte(searchDocKey(obj.GroupId, obj.TimeStamp, obj.TrxId)))
+	if err != nil || value == nil {
+		return err
+	}
+	prev := &SearchDoc{}
+	if err := json.Unmarshal(value, prev); err != nil {
+		return err
+	}
+
+	trx := &quorumpb.Trx{TrxId: obj.TrxId, GroupId: obj.GroupId, SenderPubkey: obj.Sender, TimeStamp: obj.TimeStamp}
+	doc, err := newSearchDoc(trx, obj.Object)
+	if err != nil {
+		appdatalog.Debugf("skip search index of object %s, parse failed: %s", obj.Id, err)
+		return nil
+	}
+	doc.Type = prev.Type
+	if err := appdb.removeSearchDoc(obj.GroupId, obj.TimeStamp, obj.TrxId); err != nil {
+		return err
+	}
+	return appdb.indexSearchDocs([]*SearchDoc{doc})
+}
+
+// Search returns the indexed POSTs of the group matched with the query, newest first
+func (appdb *AppDb) Search(groupid string, query *SearchQuery) ([]*SearchDoc, error) {
+	if query.Num <= 0 {
+		query.Num = 20
+	}
+	terms := tokenize(query.Query)
+	senders := make(map[string]bool)
+	for _, s := range query.Senders {
+		senders[s] = true
+	}
+
+	match := func(doc *SearchDoc) bool {
+		if len(senders) > 0 && !senders[doc.Sender] {
+			return false
+		}
+		if query.Type != "" && !strings.EqualFold(doc.Type, query.Type) {
+			return false
+		}
+		if query.ObjectType != "" && !strings.EqualFold(doc.ObjectType, query.ObjectType) {
+			return false
+		}
+		if query.InReplyTo != "" && doc.InReplyTo != query.InReplyTo {
+			return false
+		}
+		//the term keys of the updated docs are not removed
+		if len(terms) > 0 {
+			docTerms := make(map[string]bool)
+			for _, term := range doc.terms() {
+				docTerms[term] = true
+			}
+			for _, term := range terms {
+				if !docTerms[term] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	//keys are in descending time order, seek to the end of the time range
+	valid := searchDocPrefix(groupid)
+	if len(terms) > 0 {
+		valid = searchTermPrefix(groupid, terms[0])
+	}
+	seek := valid
+	if query.EndTime > 0 {
+		seek = valid + descTime(query.EndTime-1)
+	}
+	stop := ""
+	if query.StartTime > 0 {
+		stop = valid + descTime(query.StartTime) + "~"
+	}
+
+	docs := []*SearchDoc{}
+	_, err := appdb.Db.PrefixForeachKey([]byte(seek), []byte(valid), false, func(k []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		if stop != "" && string(k) > stop {
+			return errStopIter
+		}
+
+		//doc key is the tailing time and trx id
+		docKey := searchDocPrefix(groupid) + string(k[len(valid):])
+		value, err := appdb.Db.Get([]byte(docKey))
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			return nil
+		}
+		doc := &SearchDoc{}
+		if err := json.Unmarshal(value, doc); err != nil {
+			return err
+		}
+
+		if match(doc) {
+			docs = append(docs, doc)
+			if len(docs) >= query.Num {
+				return errStopIter
+			}
+		}
+		return nil
+	})
+	if err != nil && err != errStopIter {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func newSearchDoc(trx *quorumpb.Trx, data []byte) (*SearchDoc, error) {
+	activity := &searchObject{}
+	if err := json.Unmarshal(data, activity); err != nil {
+		//fields with unexpected types are skipped, others are still filled
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			return nil, err
+		}
+	}
+
+	doc := &SearchDoc{
+		TrxId:     trx.TrxId,
+		GroupId:   trx.GroupId,
+		Sender:    trx.SenderPubkey,
+		TimeStamp: trx.TimeStamp,
+		Type:      activity.Type,
+	}
+
+	//a flat post like {"type": "Note", "content": "..."} is the object itself
+	object := activity.Object
+	if object == nil {
+		object = activity
+	}
+	doc.ObjectType = object.Type
+	doc.Name = object.Name
+	doc.Content = object.Content
+	doc.InReplyTo = getReplyTo(object.InReplyTo)
+	if doc.InReplyTo == "" {
+		doc.InReplyTo = getReplyTo(activity.InReplyTo)
+	}
+	for _, raw := range []json.RawMessage{object.Attachment, object.Attachments} {
+		for _, attachment := range getObjects(raw) {
+			if attachment.Name != "" {
+				doc.Attachments = append(doc.Attachments, attachment.Name)
+			}
+		}
+	}
+
+	return doc, nil
+}
+
+// inReplyTo could be a trx id string, a Reply {"trxid": ""} or an Object {"id": ""}
+func getReplyTo(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var id string
+	if err := json.Unmarshal(raw, &id); err == nil {
+		return id
+	}
+	reply := struct {
+		TrxId string `json:"trxid"`
+		Id    string `json:"id"`
+	}{}
+	if err := json.Unmarshal(raw, &reply); err != nil {
+		return ""
+	}
+	if reply.TrxId != "" {
+		return reply.TrxId
+	}
+	return reply.Id
+}
+
+// attachment could be an object or an array of objects
+func getObjects(raw json.RawMessage) []*searchObject {
+	if len(raw) == 0 {
+		return nil
+	}
+	objects := []*searchObject{}
+	if err := json.Unmarshal(raw, &objects); err == nil {
+		return objects
+	}
+	object := &searchObject{}
+	if err := json.Unmarshal(raw, object); err == nil {
+		return []*searchObject{object}
+	}
+	return nil
+}
+
+func (doc *SearchDoc) terms() []string {
+	text := []string{doc.Name, doc.Content}
+	text = append(text, doc.Attachments...)
+	return tokenize(strings.Join(text, " "))
+}
+
+// tokenize splits text into lower case words of letters and digits, han characters are terms one by one
+func tokenize(text string) []string {
+	terms := []string{}
+	seen := make(map[string]bool)
+	add := func(term []rune) {
+		if len(term) == 0 || len(term) > maxSearchTermLen {
+			return
+		}
+		t := string(term)
+		if !seen[t] {
+			seen[t] = true
+			terms = append(terms, t)
+		}
+	}
+
+	word := []rune{}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			add(word)
+			word = word[:0]
+			add([]rune{r})
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word = append(word, r)
+		default:
+			add(word)
+			word = word[:0]
+		}
+	}
+	add(word)
+	return terms
+}
+
+// newer trxs go first in key order
+func descTime(timestamp int64) string {
+	if timestamp < 0 {
+		timestamp = 0
+	}
+	return fmt.Sprintf("%019d", math.MaxInt64-timestamp)
+}
+
+func searchDocPrefix(groupid string) string {
+	return fmt.Sprintf("%s%s_", SEARCH_DOC_PREFIX, groupid)
+}
+
+func searchDocKey(groupid string, timestamp int64, trxid string) string {
+	return fmt.Sprintf("%s%s_%s", searchDocPrefix(groupid), descTime(timestamp), trxid)
+}
+
+func searchTermPrefix(groupid string, term string) string {
+	return fmt.Sprintf("%s%s_%s_", SEARCH_TERM_PREFIX, groupid, term)
+}
+
+func searchTermKey(groupid string, term string, timestamp int64, trxid string) string {
+	return fmt.Sprintf("%s%s_%s", searchTermPrefix(groupid, term), descTime(timestamp), trxid)
+}
@@ -0,0 +1,102 @@
+package appdata
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+func TestTokenize(t *testing.T) {
+	terms := tokenize("Hello, hello World_2 你好")
+	expected := []string{"hello", "world", "2", "你", "好"}
+	if fmt.Sprint(terms) != fmt.Sprint(expected) {
+		t.Errorf("tokenize got %v, expected %v", terms, expected)
+	}
+}
+
+func TestNewSearchDoc(t *testing.T) {
+	trx := &quorumpb.Trx{TrxId: "trx1", GroupId: "group1", SenderPubkey: "sender1", TimeStamp: 100}
+	data := []byte(`{"type":"Create","object":{"type":"Note","name":"title","content":"body","inreplyto":{"trxid":"trx0"},"attachment":[{"name":"a.png"}],"image":1}}`)
+	doc, err := newSearchDoc(trx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Type != "Create" || doc.ObjectType != "Note" || doc.Name != "title" || doc.Content != "body" {
+		t.Errorf("unexpected doc: %+v", doc)
+	}
+	if doc.InReplyTo != "trx0" || len(doc.Attachments) != 1 || doc.Attachments[0] != "a.png" {
+		t.Errorf("unexpected reply or attachments: %+v", doc)
+	}
+
+	doc, err = newSearchDoc(trx, []byte(`{"type":"Note","content":"flat"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.ObjectType != "Note" || doc.Content != "flat" {
+		t.Errorf("unexpected flat doc: %+v", doc)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	appdb, err := CreateAppDb(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer appdb.Db.Close()
+	appdb.SearchIndex = true
+
+	key, _ := localcrypto.CreateAesKey()
+	groupItem := &quorumpb.GroupItem{
+		GroupId:     "group1",
+		CipherKey:   hex.EncodeToString(key),
+		EncryptType: quorumpb.GroupEncryptType_PUBLIC,
+	}
+
+	contents := []string{"quorum search", "hello quorum", "hello world"}
+	trxs := []*quorumpb.Trx{}
+	for i, content := range contents {
+		data, err := localcrypto.AesEncrypt([]byte(fmt.Sprintf(`{"type":"Create","object":{"type":"Note","content":"%s"}}`, content)), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		trxs = append(trxs, &quorumpb.Trx{
+			TrxId:        fmt.Sprintf("trx%d", i),
+			GroupId:      groupItem.GroupId,
+			SenderPubkey: fmt.Sprintf("sender%d", i%2),
+			TimeStamp:    int64(i + 1),
+			Type:         quorumpb.TrxType_POST,
+			Data:         data,
+		})
+	}
+	if err := appdb.AddPostIndex(groupItem, trxs); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		query    SearchQuery
+		expected []string
+	}{
+		{SearchQuery{}, []string{"trx2", "trx1", "trx0"}},
+		{SearchQuery{Query: "Quorum"}, []string{"trx1", "trx0"}},
+		{SearchQuery{Query: "hello quorum"}, []string{"trx1"}},
+		{SearchQuery{Query: "hello", Senders: []string{"sender0"}}, []string{"trx2"}},
+		{SearchQuery{StartTime: 2, EndTime: 3}, []string{"trx1"}},
+		{SearchQuery{Num: 1}, []string{"trx2"}},
+	}
+	for _, c := range cases {
+		docs, err := appdb.Search(groupItem.GroupId, &c.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []string{}
+		for _, doc := range docs {
+			ids = append(ids, doc.TrxId)
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(c.expected) {
+			t.Errorf("search %+v got %v, expected %v", c.query, ids, c.expected)
+		}
+	}
+}
@@ -24,6 +24,7 @@ var (
 type OnChainTrxEvent struct {
 	GroupId string `json:"group_id"`
 	TrxId   string `json:"trx_id"`
+	BlockId uint64 `json:"block_id"`
 }
 
 type AppSync struct {
@@ -42,12 +43,13 @@ func GetOnChainTrxQueue() *deque.Deque[*OnChainTrxEvent] {
 	return onChainTrxQueue
 }
 
-func pushOnChainTrxQueue(trxs []*quorumpb.Trx) {
+func pushOnChainTrxQueue(blockId uint64, trxs []*quorumpb.Trx) {
 	q := GetOnChainTrxQueue()
 	for _, trx := range trxs {
 		item := OnChainTrxEvent{
 			GroupId: trx.GroupId,
 			TrxId:   trx.TrxId,
+			BlockId: blockId,
 		}
 		appsynclog.Debugf("put on chain trx event: %+v to queue", item)
 		if q.Len() >= maxOnChainTrxQueueLength {
@@ -73,18 +75,36 @@ func (appsync *AppSync) GetGroups() []*quorumpb.GroupItem {
 
 func (appsync *AppSync) ParseBlockTrxs(groupid string, block *quorumpb.Block) error {
 	appsynclog.Infof("ParseBlockTrxs %d trx(s) on group %s blockId <%d>", len(block.Trxs), groupid, block.BlockId)
+	//index first, AddMetaByTrx advances the synced block, so the block is parsed again if indexing failed
+	if group, ok := appsync.groupmgr.Groups[groupid]; ok {
+		if err := appsync.appdb.AddPostIndex(group.Item, block.Trxs); err != nil {
+			appsynclog.Errorf("<%s> add post index err: %s", groupid, err)
+			return err
+		}
+	}
+
 	err := appsync.appdb.AddMetaByTrx(block.BlockId, groupid, block.Trxs)
 	if err != nil {
-		appsynclog.Errorf("ParseBlockTrxs on group %s err:  ", groupid, err)
+		appsynclog.Errorf("ParseBlockTrxs on group %s err: %s", groupid, err)
 		return err
 	}
 
-	pushOnChainTrxQueue(block.Trxs)
+	pushOnChainTrxQueue(block.BlockId, block.Trxs)
 
 	return nil
 }
 
 func (appsync *AppSync) RunSync(groupid string, lastSyncBlock uint64, highestBlock uint64) {
+	//skip pruned blocks, or blocks before the snapshot the chain bootstrapped from
+	lowestBlock, err := nodectx.GetNodeCtx().GetChainStorage().GetLowestBlockId(groupid, appsync.nodename)
+	if err != nil {
+		appsynclog.Errorf("db read err: %s, groupid: %s", err, groupid)
+		return
+	}
+	if lowestBlock > lastSyncBlock+1 {
+		appsynclog.Warnf("<%s> blocks before <%d> are pruned, skip to it", groupid, lowestBlock)
+		lastSyncBlock = lowestBlock - 1
+	}
 
 	for {
 		if lastSyncBlock >= highestBlock {
@@ -118,6 +138,13 @@ func (appsync *AppSync) Start(interval int) {
 					continue
 				}
 
+				//blocks parsed after a switched fork are replaced by the new branch
+				if forks, err := nodectx.GetNodeCtx().GetChainStorage().GetForkRecords(groupId, appsync.nodename); err != nil {
+					appsynclog.Errorf("sync group : %s GetForkRecords err %s", groupId, err)
+				} else if _, err := appsync.appdb.RewindSyncedBlock(groupId, forks); err != nil {
+					appsynclog.Errorf("sync group : %s RewindSyncedBlock err %s", groupId, err)
+				}
+
 				blockIdStr, err := appsync.appdb.GetGroupStatus(groupId, "Block")
 				if err == nil {
 					if blockIdStr == "" { //init, set to 0
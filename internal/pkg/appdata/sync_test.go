@@ -0,0 +1,129 @@
+package appdata
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	"github.com/rumsystem/quorum/internal/pkg/storage"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+// failIndexStore fails the batch writes of search docs when fail is set
+type failIndexStore struct {
+	storage.QuorumStorage
+	fail bool
+}
+
+func (s *failIndexStore) BatchWrite(keys [][]byte, values [][]byte) error {
+	for _, k := range keys {
+		if s.fail && bytes.HasPrefix(k, []byte(SEARCH_DOC_PREFIX)) {
+			return errors.New("index write failed")
+		}
+	}
+	return s.QuorumStorage.BatchWrite(keys, values)
+}
+
+func TestParseBlockTrxsRetryIndex(t *testing.T) {
+	appdb, err := CreateAppDb(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer appdb.Db.Close()
+	store := &failIndexStore{QuorumStorage: appdb.Db, fail: true}
+	appdb.Db = store
+	appdb.SearchIndex = true
+
+	key, _ := localcrypto.CreateAesKey()
+	groupItem := &quorumpb.GroupItem{
+		GroupId:     "group1",
+		CipherKey:   hex.EncodeToString(key),
+		EncryptType: quorumpb.GroupEncryptType_PUBLIC,
+	}
+	appsync := &AppSync{
+		appdb:    appdb,
+		groupmgr: &chain.GroupMgr{Groups: map[string]*chain.Group{groupItem.GroupId: {Item: groupItem}}},
+	}
+
+	data, err := localcrypto.AesEncrypt([]byte(`{"type":"Create","object":{"type":"Note","content":"hello quorum"}}`), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &quorumpb.Block{
+		GroupId: groupItem.GroupId,
+		BlockId: 1,
+		Trxs: []*quorumpb.Trx{{
+			TrxId:        "trx0",
+			GroupId:      groupItem.GroupId,
+			SenderPubkey: "sender0",
+			TimeStamp:    1,
+			Type:         quorumpb.TrxType_POST,
+			Data:         data,
+		}},
+	}
+
+	if err := appsync.ParseBlockTrxs(groupItem.GroupId, block); err == nil {
+		t.Fatal("parse block should fail if the index write failed")
+	}
+	if synced, err := appdb.GetSyncedBlockId(groupItem.GroupId); err != nil || synced != 0 {
+		t.Fatalf("synced block should not be advanced if the index write failed, got %d, %v", synced, err)
+	}
+
+	//the block is parsed again from the synced block
+	store.fail = false
+	if err := appsync.ParseBlockTrxs(groupItem.GroupId, block); err != nil {
+		t.Fatal(err)
+	}
+	if synced, err := appdb.GetSyncedBlockId(groupItem.GroupId); err != nil || synced != block.BlockId {
+		t.Fatalf("synced block should be %d, got %d, %v", block.BlockId, synced, err)
+	}
+	docs, err := appdb.Search(groupItem.GroupId, &SearchQuery{Query: "quorum"})
+	if err != nil || len(docs) != 1 || docs[0].TrxId != "trx0" {
+		t.Fatalf("post of the retried block should be indexed, got %+v, %v", docs, err)
+	}
+}
+
+func TestRewindSyncedBlock(t *testing.T) {
+	appdb, err := CreateAppDb(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer appdb.Db.Close()
+	groupId := "group1"
+
+	post := func(trxId string) *quorumpb.Trx {
+		//listed by the uuid of trx and the pubkey of sender
+		return &quorumpb.Trx{TrxId: "5f1c2a7e-3b4d-4e6f-8a9b-0c1d2e3f4a5" + trxId[len(trxId)-1:], GroupId: groupId, SenderPubkey: "CAISIQKDY1R5hZ09yG1+i/Kdk8E/KDT8Wm/PrKmgtsdtXFHXEg==", Type: quorumpb.TrxType_POST}
+	}
+	for blockId, trxId := range []string{"trx0", "trx1", "trx2"} {
+		if err := appdb.AddMetaByTrx(uint64(blockId+1), groupId, []*quorumpb.Trx{post(trxId)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	forks := []*chainstorage.ForkRecord{
+		{GroupId: groupId, BlockId: 1, Resolution: chainstorage.ForkKept, TimeStamp: 5},
+		{GroupId: groupId, BlockId: 2, Resolution: chainstorage.ForkSwitched, TimeStamp: 10},
+	}
+	if synced, err := appdb.RewindSyncedBlock(groupId, forks); err != nil || synced != 1 {
+		t.Fatalf("expect rewind to block 1, got %d, %v", synced, err)
+	}
+
+	//block 2 of the new branch packages trx1 again, and trx3
+	if err := appdb.AddMetaByTrx(2, groupId, []*quorumpb.Trx{post("trx1"), post("trx3")}); err != nil {
+		t.Fatal(err)
+	}
+	trxIds, err := appdb.GetGroupContentBySenders(groupId, nil, "", 10, false, false)
+	if err != nil || len(trxIds) != 4 {
+		t.Errorf("posts parsed again should not be listed twice, got %v, %v", trxIds, err)
+	}
+
+	//the fork is handled once
+	if synced, err := appdb.RewindSyncedBlock(groupId, forks); err != nil || synced != 2 {
+		t.Errorf("expect synced block 2 kept, got %d, %v", synced, err)
+	}
+}
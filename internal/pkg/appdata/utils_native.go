@@ -9,9 +9,19 @@ import (
 	"github.com/rumsystem/quorum/internal/pkg/storage"
 )
 
+// CreateAppDb opens the appdb under path with the backend already on disk, new appdb uses bolt
 func CreateAppDb(path string) (*AppDb, error) {
+	return CreateAppDbWithBackend(path, "")
+}
+
+func CreateAppDbWithBackend(path string, backend string) (*AppDb, error) {
+	backend, err := storage.ResolveBackend(path, "appdb", backend)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx := context.Background()
-	db, err := storage.NewStore(ctx, path, "appdb")
+	db, err := storage.OpenStore(ctx, backend, path, "appdb")
 	if err != nil {
 		return nil, err
 	}
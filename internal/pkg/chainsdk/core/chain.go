@@ -13,6 +13,7 @@ import (
 	"github.com/rumsystem/quorum/internal/pkg/conn"
 	"github.com/rumsystem/quorum/internal/pkg/logging"
 	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
 	"github.com/rumsystem/quorum/internal/pkg/utils"
 	"github.com/rumsystem/quorum/pkg/consensus"
 	"github.com/rumsystem/quorum/pkg/consensus/def"
@@ -28,17 +29,19 @@ import (
 var chain_log = logging.Logger("chain")
 
 type Chain struct {
-	groupItem    *quorumpb.GroupItem
-	nodename     string
-	producerPool map[string]*quorumpb.ProducerItem
-	userPool     map[string]*quorumpb.UserItem
-	trxFactory   *rumchaindata.TrxFactory
-	rexSyncer    *RexSyncer
-	chaindata    *ChainData
-	Consensus    def.Consensus
-	CurrBlock    uint64
-	CurrEpoch    uint64
-	LatestUpdate int64
+	groupItem      *quorumpb.GroupItem
+	nodename       string
+	producerPool   map[string]*quorumpb.ProducerItem
+	userPool       map[string]*quorumpb.UserItem
+	trxFactory     *rumchaindata.TrxFactory
+	rexSyncer      *RexSyncer
+	chaindata      *ChainData
+	snapshotHeader *quorumpb.Snapshot
+	trxTracker     *TrxTracker
+	Consensus      def.Consensus
+	CurrBlock      uint64
+	CurrEpoch      uint64
+	LatestUpdate   int64
 }
 
 func (chain *Chain) NewChain(item *quorumpb.GroupItem, nodename string, loadChainInfo bool) error {
@@ -50,10 +53,18 @@ func (chain *Chain) NewChain(item *quorumpb.GroupItem, nodename string, loadChai
 	//initial TrxFactory
 	chain.trxFactory = &rumchaindata.TrxFactory{}
 	chain.trxFactory.Init(nodectx.GetNodeCtx().Version, chain.groupItem, chain.nodename)
+	if groupKey, err := nodectx.GetNodeCtx().GetChainStorage().GetLatestGroupKey(chain.groupItem.GroupId, chain.nodename); err != nil {
+		return err
+	} else if groupKey != nil {
+		chain.trxFactory.SetCipherKey(groupKey.CipherKey)
+	}
 
 	//initial Syncer
 	chain.rexSyncer = NewRexSyncer(chain.groupItem.GroupId, chain.nodename, chain, chain)
 
+	//initial tracker of trxs sent by this node
+	chain.trxTracker = NewTrxTracker(chain.groupItem.GroupId, chain.nodename)
+
 	//initial chaindata manager
 	chain.chaindata = &ChainData{
 		nodename:       chain.nodename,
@@ -82,6 +93,8 @@ func (chain *Chain) NewChain(item *quorumpb.GroupItem, nodename string, loadChai
 		chain.SaveChainInfoToDb()
 	}
 
+	chain.trxTracker.Start()
+
 	chain_log.Debugf("<%s> NewChain done", chain.groupItem.GroupId)
 
 	return nil
@@ -90,10 +103,12 @@ func (chain *Chain) NewChain(item *quorumpb.GroupItem, nodename string, loadChai
 // atomic opt for currEpoch
 func (chain *Chain) SetCurrEpoch(currEpoch uint64) {
 	atomic.StoreUint64(&chain.CurrEpoch, currEpoch)
+	chain.activateScheduledProducers()
 }
 
 func (chain *Chain) IncCurrEpoch() {
 	atomic.AddUint64(&chain.CurrEpoch, 1)
+	chain.activateScheduledProducers()
 }
 
 func (chain *Chain) GetCurrEpoch() uint64 {
@@ -201,6 +216,11 @@ func (chain *Chain) HandleTrxPsConn(trx *quorumpb.Trx) error {
 		return fmt.Errorf("invalid trx, signature verify failed")
 	}
 
+	if rumchaindata.IsTrxExpired(trx, time.Now().UnixNano()) {
+		chain_log.Debugf("<%s> trx <%s> expired, resend count <%d>, drop it", chain.groupItem.GroupId, trx.TrxId, trx.ResendCount)
+		return fmt.Errorf("trx expired")
+	}
+
 	switch trx.Type {
 	case
 		quorumpb.TrxType_POST,
@@ -208,7 +228,8 @@ func (chain *Chain) HandleTrxPsConn(trx *quorumpb.Trx) error {
 		quorumpb.TrxType_PRODUCER,
 		quorumpb.TrxType_USER,
 		quorumpb.TrxType_APP_CONFIG,
-		quorumpb.TrxType_CHAIN_CONFIG:
+		quorumpb.TrxType_CHAIN_CONFIG,
+		quorumpb.TrxType_STAKE:
 		chain.producerAddTrx(trx)
 	default:
 		chain_log.Warningf("<%s> unsupported msg type", chain.groupItem.GroupId)
@@ -234,6 +255,20 @@ func (chain *Chain) producerAddTrx(trx *quorumpb.Trx) error {
 func (chain *Chain) HandleBlockPsConn(block *quorumpb.Block) error {
 	chain_log.Debugf("<%s> HandleBlockPsConn called", chain.groupItem.GroupId)
 
+	// sudo blocks are signed by the owner out of the consensus, every node (producers included) adds them
+	if block.Sudo {
+		if valid, err := rumchaindata.ValidSudoBlock(block, chain.groupItem.OwnerPubKey); !valid {
+			chain_log.Warningf("<%s> received invalid sudo block <%d>, reject it: <%v>", chain.groupItem.GroupId, block.BlockId, err)
+			return nil
+		}
+		return chain.ApplyBlocks([]*quorumpb.Block{block})
+	}
+
+	// blocks of POS groups are built by the producers selected by stake, every node adds blocks from others
+	if chain.isPos() {
+		return chain.ApplyBlocks([]*quorumpb.Block{block})
+	}
+
 	// all approved producers ignore block from psconn (they gonna build block by themselves)
 	if chain.isProducer() {
 		return nil
@@ -327,7 +362,11 @@ func (chain *Chain) HandleTrxRex(trx *quorumpb.Trx, s network.Stream) error {
 	case quorumpb.TrxType_REQ_BLOCK:
 		chain.handleReqBlocks(trx, s)
 	case quorumpb.TrxType_REQ_BLOCK_RESP:
-		chain.handleReqBlockResp(trx)
+		chain.handleReqBlockResp(trx, s)
+	case quorumpb.TrxType_REQ_SNAPSHOT:
+		chain.handleReqSnapshot(trx, s)
+	case quorumpb.TrxType_REQ_SNAPSHOT_RESP:
+		chain.handleReqSnapshotResp(trx)
 	default:
 		//do nothing
 	}
@@ -370,7 +409,7 @@ func (chain *Chain) handleReqBlocks(trx *quorumpb.Trx, s network.Stream) error {
 	}
 }
 
-func (chain *Chain) handleReqBlockResp(trx *quorumpb.Trx) {
+func (chain *Chain) handleReqBlockResp(trx *quorumpb.Trx, s network.Stream) {
 	chain_log.Debugf("<%s> handleReqBlockResp called", chain.groupItem.GroupId)
 
 	//decode resp
@@ -407,13 +446,26 @@ func (chain *Chain) handleReqBlockResp(trx *quorumpb.Trx) {
 
 	result := &SyncResult{
 		TaskId: reqBlockResp.FromBlock,
+		Type:   SyncBlock,
 		Data:   reqBlockResp,
 	}
+	if s != nil {
+		result.Provider = s.Conn().RemotePeer()
+	}
 
 	chain.rexSyncer.AddResult(result)
 }
 
 func (chain *Chain) ApplyBlocks(blocks []*quorumpb.Block) error {
+	//only the owner can sign a sudo block
+	for _, block := range blocks {
+		if block.Sudo {
+			if valid, err := rumchaindata.ValidSudoBlock(block, chain.groupItem.OwnerPubKey); !valid {
+				return fmt.Errorf("invalid sudo block <%d>: %v", block.BlockId, err)
+			}
+		}
+	}
+
 	//PRODUCER_NODE add SYNC
 	if nodectx.GetNodeCtx().NodeType == nodectx.PRODUCER_NODE {
 		for _, block := range blocks {
@@ -441,7 +493,11 @@ func (chain *Chain) ApplyBlocks(blocks []*quorumpb.Block) error {
 
 func (chain *Chain) UpdConnMgrProducer() {
 	chain_log.Debugf("<%s> UpdConnMgrProducer called", chain.groupItem.GroupId)
-	connMgr, _ := conn.GetConn().GetConnMgr(chain.groupItem.GroupId)
+	connMgr, err := conn.GetConn().GetConnMgr(chain.groupItem.GroupId)
+	if err != nil {
+		chain_log.Warningf("<%s> get connMgr failed with error <%s>", chain.groupItem.GroupId, err.Error())
+		return
+	}
 
 	var producerspubkey []string
 	for key := range chain.producerPool {
@@ -474,19 +530,40 @@ func (chain *Chain) updProducerList() {
 		}
 		chain_log.Infof("<%s> load producer <%s%s>", chain.groupItem.GroupId, item.ProducerPubkey, ownerPrefix)
 	}
+
+	//producers of POS groups are the stakers
+	if chain.isPos() {
+		stakes, err := nodectx.GetNodeCtx().GetChainStorage().GetStakes(chain.groupItem.GroupId, chain.nodename)
+		if err != nil {
+			chain_log.Infof("Get stakes failed with err <%s>", err.Error())
+		}
+		for _, item := range rumchaindata.GetActiveStakes(stakes) {
+			pk := item.StakerPubkey
+			if base64ethpkey, err := localcrypto.Libp2pPubkeyToEthBase64(pk); err == nil {
+				pk = base64ethpkey
+			}
+			if _, ok := chain.producerPool[pk]; ok {
+				continue
+			}
+			chain.producerPool[pk] = &quorumpb.ProducerItem{
+				GroupId:          item.GroupId,
+				ProducerPubkey:   item.StakerPubkey,
+				GroupOwnerPubkey: chain.groupItem.OwnerPubKey,
+				TimeStamp:        item.TimeStamp,
+				Memo:             "staker",
+			}
+			chain_log.Infof("<%s> load producer <%s(staker)>", chain.groupItem.GroupId, item.StakerPubkey)
+		}
+	}
 }
 
 func (chain *Chain) updAnnouncedProducerStatus() {
 	chain_log.Debugf("<%s> updAnnouncedProducerStatus called", chain.groupItem.GroupId)
 
 	//update announced producer result
-	announcedProducers, _ := nodectx.GetNodeCtx().GetChainStorage().GetAnnounceProducersByGroup(chain.groupItem.GroupId, chain.nodename)
-	for _, item := range announcedProducers {
-		_, ok := chain.producerPool[item.SignPubkey]
-		err := nodectx.GetNodeCtx().GetChainStorage().UpdateAnnounceResult(quorumpb.AnnounceType_AS_PRODUCER, chain.groupItem.GroupId, item.SignPubkey, ok, chain.nodename)
-		if err != nil {
-			chain_log.Warningf("<%s> UpdAnnounceResult failed with error <%s>", chain.groupItem.GroupId, err.Error())
-		}
+	err := nodectx.GetNodeCtx().GetChainStorage().UpdateAnnouncedProducerResults(chain.groupItem.GroupId, chain.nodename)
+	if err != nil {
+		chain_log.Warningf("<%s> UpdAnnounceResult failed with error <%s>", chain.groupItem.GroupId, err.Error())
 	}
 }
 
@@ -500,6 +577,26 @@ func (chain *Chain) updProducerConfig() {
 	chain.Consensus.Producer().RecreateBft()
 }
 
+// activateScheduledProducers applies the producer lists scheduled for the next epoch, the producer
+// list is only changed between epochs, so all producers switch at the same epoch
+func (chain *Chain) activateScheduledProducers() {
+	activated, err := nodectx.GetNodeCtx().GetChainStorage().ActivateScheduledProducers(chain.groupItem.GroupId, chain.GetCurrEpoch()+1, chain.nodename)
+	if err != nil {
+		chain_log.Warningf("<%s> activate scheduled producers failed with error <%s>", chain.groupItem.GroupId, err.Error())
+		return
+	}
+	if !activated {
+		return
+	}
+
+	chain.updProducerList()
+	chain.updAnnouncedProducerStatus()
+	chain.updProducerConfig()
+	if nodectx.GetNodeCtx().NodeType == nodectx.PRODUCER_NODE {
+		chain.UpdConnMgrProducer()
+	}
+}
+
 func (chain *Chain) updUserList() {
 	chain_log.Debugf("<%s> updUserList called", chain.groupItem.GroupId)
 
@@ -516,17 +613,9 @@ func (chain *Chain) updUserList() {
 	}
 
 	//update announced User result
-	announcedUsers, _ := nodectx.GetNodeCtx().GetChainStorage().GetAnnounceUsersByGroup(chain.groupItem.GroupId, chain.nodename)
-	for _, item := range announcedUsers {
-		_, ok := chain.userPool[item.SignPubkey]
-		err := nodectx.GetNodeCtx().GetChainStorage().UpdateAnnounceResult(quorumpb.AnnounceType_AS_USER,
-			chain.groupItem.GroupId,
-			item.SignPubkey,
-			ok,
-			chain.nodename)
-		if err != nil {
-			chain_log.Warningf("<%s> UpdAnnounceResult failed with error <%s>", chain.groupItem.GroupId, err.Error())
-		}
+	err := nodectx.GetNodeCtx().GetChainStorage().UpdateAnnouncedUserResults(chain.groupItem.GroupId, chain.nodename)
+	if err != nil {
+		chain_log.Warningf("<%s> UpdAnnounceResult failed with error <%s>", chain.groupItem.GroupId, err.Error())
 	}
 }
 
@@ -559,8 +648,8 @@ func (chain *Chain) CreateConsensus() error {
 		shouldCreateProducer = true
 		shouldCreateUser = false
 	} else if nodectx.GetNodeCtx().NodeType == nodectx.FULL_NODE {
-		//check if I am owner of the Group
-		if chain.groupItem.UserSignPubkey == chain.groupItem.OwnerPubKey {
+		//check if I am owner of the Group, any node may stake to be a producer of a POS group
+		if chain.groupItem.UserSignPubkey == chain.groupItem.OwnerPubKey || chain.isPos() {
 			shouldCreateProducer = true
 		} else {
 			shouldCreateProducer = false
@@ -570,6 +659,24 @@ func (chain *Chain) CreateConsensus() error {
 		return fmt.Errorf("unknow nodetype")
 	}
 
+	if chain.isPos() {
+		if shouldCreateProducer {
+			chain_log.Infof("<%s> Create and initial pos producer", chain.groupItem.GroupId)
+			producer = &consensus.PosProducer{}
+			producer.NewProducer(chain.groupItem, chain.nodename, chain)
+			producer.StartPropose()
+		}
+
+		if shouldCreateUser {
+			chain_log.Infof("<%s> Create and initial pos user", chain.groupItem.GroupId)
+			user = &consensus.PosUser{}
+			user.NewUser(chain.groupItem, chain.nodename, chain)
+		}
+
+		chain.Consensus = consensus.NewPos(producer, user)
+		return nil
+	}
+
 	if shouldCreateProducer {
 		chain_log.Infof("<%s> Create and initial molasses producer", chain.groupItem.GroupId)
 		producer = &consensus.MolassesProducer{}
@@ -587,6 +694,21 @@ func (chain *Chain) CreateConsensus() error {
 	return nil
 }
 
+// stop the POS producer from building blocks
+func (chain *Chain) StopConsensus() {
+	consensus.RemoveEpochTracer(chain.groupItem.GroupId)
+	if chain.Consensus == nil {
+		return
+	}
+	if producer, ok := chain.Consensus.Producer().(*consensus.PosProducer); ok {
+		producer.StopPropose()
+	}
+}
+
+func (chain *Chain) isPos() bool {
+	return chain.groupItem.ConsenseType == quorumpb.GroupConsenseType_POS
+}
+
 func (chain *Chain) isProducer() bool {
 	_, ok := chain.producerPool[chain.groupItem.UserSignPubkey]
 	return ok
@@ -627,8 +749,10 @@ func (chain *Chain) GetLastRexSyncResult() (*chaindef.RexSyncResult, error) {
 	return chain.rexSyncer.GetLastRexSyncResult()
 }
 
-func (chain *Chain) ApplyTrxsFullNode(trxs []*quorumpb.Trx, nodename string) error {
+// ApplyTrxsFullNode applies the trxs packaged in the block blockId
+func (chain *Chain) ApplyTrxsFullNode(trxs []*quorumpb.Trx, blockId uint64, nodename string) error {
 	chain_log.Debugf("<%s> ApplyTrxsFullNode called", chain.groupItem.GroupId)
+	chain.trxTracker.MarkOnChain(trxs)
 	for _, trx := range trxs {
 		//check if trx already applied
 		isExist, err := nodectx.GetNodeCtx().GetChainStorage().IsTrxExist(trx.GroupId, trx.TrxId, nodename)
@@ -657,12 +781,13 @@ func (chain *Chain) ApplyTrxsFullNode(trxs []*quorumpb.Trx, nodename string) err
 				trx.Data = decryptData
 			}
 		} else {
-			ciperKey, err := hex.DecodeString(chain.groupItem.CipherKey)
-			if err != nil {
-				return err
+			decryptData, err := nodectx.GetNodeCtx().GetChainStorage().DecryptTrxData(chain.groupItem, trx, blockId, nodename)
+			if chainstorage.IsGroupKeyErr(err) {
+				//save it but not apply, the same as the private POST can't be decrypted
+				chain_log.Warningf("<%s> skip apply trx <%s>: %s", chain.groupItem.GroupId, trx.TrxId, err.Error())
+				nodectx.GetNodeCtx().GetChainStorage().AddTrx(trx, nodename)
+				continue
 			}
-
-			decryptData, err := localcrypto.AesDecode(trx.Data, ciperKey)
 			if err != nil {
 				return err
 			}
@@ -694,6 +819,12 @@ func (chain *Chain) ApplyTrxsFullNode(trxs []*quorumpb.Trx, nodename string) err
 		case quorumpb.TrxType_CHAIN_CONFIG:
 			chain_log.Debugf("<%s> apply CHAIN_CONFIG trx", chain.groupItem.GroupId)
 			nodectx.GetNodeCtx().GetChainStorage().UpdateChainConfigTrx(trx, nodename)
+		case quorumpb.TrxType_STAKE:
+			chain_log.Debugf("<%s> apply STAKE trx", chain.groupItem.GroupId)
+			chain.applyStakeTrx(trx, nodename)
+		case quorumpb.TrxType_GROUP_KEY:
+			chain_log.Debugf("<%s> apply GROUP_KEY trx", chain.groupItem.GroupId)
+			chain.applyGroupKeyTrx(trx, nodename)
 		default:
 			chain_log.Warningf("<%s> unsupported msgType <%s>", chain.groupItem.GroupId, trx.Type.String())
 		}
@@ -707,8 +838,10 @@ func (chain *Chain) ApplyTrxsFullNode(trxs []*quorumpb.Trx, nodename string) err
 	return nil
 }
 
-func (chain *Chain) ApplyTrxsProducerNode(trxs []*quorumpb.Trx, nodename string) error {
+// ApplyTrxsProducerNode applies the trxs packaged in the block blockId
+func (chain *Chain) ApplyTrxsProducerNode(trxs []*quorumpb.Trx, blockId uint64, nodename string) error {
 	chain_log.Debugf("<%s> ApplyTrxsProducerNode called", chain.groupItem.GroupId)
+	chain.trxTracker.MarkOnChain(trxs)
 	for _, trx := range trxs {
 		//producer node does not handle APP_CONFIG and POST
 		if trx.Type == quorumpb.TrxType_APP_CONFIG || trx.Type == quorumpb.TrxType_POST {
@@ -730,12 +863,12 @@ func (chain *Chain) ApplyTrxsProducerNode(trxs []*quorumpb.Trx, nodename string)
 
 		originalData := trx.Data
 		//decode trx data
-		ciperKey, err := hex.DecodeString(chain.groupItem.CipherKey)
-		if err != nil {
-			return err
+		decryptData, err := nodectx.GetNodeCtx().GetChainStorage().DecryptTrxData(chain.groupItem, trx, blockId, nodename)
+		if chainstorage.IsGroupKeyErr(err) {
+			chain_log.Warningf("<%s> skip apply trx <%s>: %s", chain.groupItem.GroupId, trx.TrxId, err.Error())
+			nodectx.GetNodeCtx().GetChainStorage().AddTrx(trx, nodename)
+			continue
 		}
-
-		decryptData, err := localcrypto.AesDecode(trx.Data, ciperKey)
 		if err != nil {
 			return err
 		}
@@ -763,6 +896,12 @@ func (chain *Chain) ApplyTrxsProducerNode(trxs []*quorumpb.Trx, nodename string)
 		case quorumpb.TrxType_CHAIN_CONFIG:
 			chain_log.Debugf("<%s> apply CHAIN_CONFIG trx", chain.groupItem.GroupId)
 			nodectx.GetNodeCtx().GetChainStorage().UpdateChainConfigTrx(trx, nodename)
+		case quorumpb.TrxType_STAKE:
+			chain_log.Debugf("<%s> apply STAKE trx", chain.groupItem.GroupId)
+			chain.applyStakeTrx(trx, nodename)
+		case quorumpb.TrxType_GROUP_KEY:
+			chain_log.Debugf("<%s> apply GROUP_KEY trx", chain.groupItem.GroupId)
+			chain.applyGroupKeyTrx(trx, nodename)
 		default:
 			chain_log.Warningf("<%s> unsupported msgType <%s>", chain.groupItem.GroupId, trx.Type)
 		}
@@ -776,6 +915,44 @@ func (chain *Chain) ApplyTrxsProducerNode(trxs []*quorumpb.Trx, nodename string)
 	return nil
 }
 
+func (chain *Chain) applyStakeTrx(trx *quorumpb.Trx, nodename string) {
+	if !chain.isPos() {
+		chain_log.Warningf("<%s> STAKE trx <%s> is ignored by the group not POS", chain.groupItem.GroupId, trx.TrxId)
+		return
+	}
+	if err := nodectx.GetNodeCtx().GetChainStorage().UpdateStakeTrx(trx, nodename); err != nil {
+		chain_log.Warningf("<%s> apply STAKE trx <%s> failed with error <%s>", chain.groupItem.GroupId, trx.TrxId, err.Error())
+		return
+	}
+	chain.updProducerList()
+	chain.UpdConnMgrProducer()
+}
+
+// applyGroupKeyTrx saves the group cipher key rotated by owner, trxs are encrypted by it since then
+func (chain *Chain) applyGroupKeyTrx(trx *quorumpb.Trx, nodename string) {
+	cs := nodectx.GetNodeCtx().GetChainStorage()
+	block, err := cs.GetTrxBlock(chain.groupItem.GroupId, trx.TrxId, chain.GetCurrBlockId()+1, nodename)
+	if err != nil || block == nil {
+		chain_log.Warningf("<%s> GROUP_KEY trx <%s> is ignored, can't find the block packaged it", chain.groupItem.GroupId, trx.TrxId)
+		return
+	}
+	ks := localcrypto.GetKeystore()
+	decrypt := func(data []byte) ([]byte, error) {
+		return ks.Decrypt(chain.groupItem.GroupId, data)
+	}
+	item, err := cs.UpdateGroupKeyTrx(chain.groupItem, trx, block.BlockId, block.TimeStamp, decrypt, nodename)
+	if err == chainstorage.ErrNotKeyRecipient {
+		chain_log.Warningf("<%s> group key is rotated by trx <%s> in block <%d>, but not encrypted to me", chain.groupItem.GroupId, trx.TrxId, block.BlockId)
+		return
+	}
+	if err != nil {
+		chain_log.Warningf("<%s> apply GROUP_KEY trx <%s> failed with error <%s>", chain.groupItem.GroupId, trx.TrxId, err.Error())
+		return
+	}
+	chain_log.Infof("<%s> group key is rotated by trx <%s> in block <%d>", chain.groupItem.GroupId, trx.TrxId, block.BlockId)
+	chain.trxFactory.SetCipherKey(item.CipherKey)
+}
+
 func (chain *Chain) VerifySign(hash, signature []byte, pubkey string) (bool, error) {
 	//check signature
 	bytespubkey, err := base64.RawURLEncoding.DecodeString(pubkey)
@@ -799,7 +976,7 @@ func (chain *Chain) VerifySign(hash, signature []byte, pubkey string) (bool, err
 func (chain *Chain) StartSync() error {
 	chain_log.Debugf("<%s> StartSync called", chain.groupItem.GroupId)
 
-	if chain.isOwner() {
+	if chain.isOwner() && !chain.isPos() {
 		chain_log.Debugf("<%s> owner no need to sync", chain.groupItem.GroupId)
 		return nil
 	}
@@ -808,6 +985,15 @@ func (chain *Chain) StartSync() error {
 	return nil
 }
 
+// GetTrxStatus returns the status of a trx sent by this node, nil if the trx is not tracked
+func (chain *Chain) GetTrxStatus(trxId string) (*chainstorage.TrxStatusItem, error) {
+	return chain.trxTracker.GetStatus(trxId)
+}
+
+func (chain *Chain) StopTrxTracker() {
+	chain.trxTracker.Stop()
+}
+
 func (chain *Chain) StopSync() {
 	chain_log.Debugf("<%s> StopSync called", chain.groupItem.GroupId)
 	if chain.rexSyncer != nil {
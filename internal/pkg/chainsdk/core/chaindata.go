@@ -1,6 +1,7 @@
 package chain
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 
@@ -81,7 +82,7 @@ func (d *ChainData) GetReqBlocks(trx *quorumpb.Trx) (requester string, fromBlock
 		totalBlockBytes = totalBlockBytes + len(pdate)
 		//check if reach maximum length, may have more
 		if totalBlockBytes > MAX_BLOCK_IN_RESP_BYTES {
-			return reqBlockItem.ReqPubkey, reqBlockItem.FromBlock, reqBlockItem.BlksRequested, blocks, quorumpb.ReqBlkResult_BLOCK_IN_RESP, nil
+			return reqBlockItem.ReqPubkey, reqBlockItem.FromBlock, reqBlockItem.BlksRequested, bs, quorumpb.ReqBlkResult_BLOCK_IN_RESP, nil
 		}
 
 		//put block into blocks list
@@ -103,3 +104,101 @@ func (d *ChainData) GetReqBlocks(trx *quorumpb.Trx) (requester string, fromBlock
 
 	return reqBlockItem.ReqPubkey, reqBlockItem.FromBlock, reqBlockItem.BlksRequested, bs, quorumpb.ReqBlkResult_BLOCK_IN_RESP, nil
 }
+
+// TBD, move this to chain config
+const MAX_SNAPSHOT_ITEMS_IN_RESP_BYTES = 10485760 //10MB
+
+func (d *ChainData) GetReqSnapshot(trx *quorumpb.Trx) (requester string, fromItem int32, snapshot *quorumpb.Snapshot, block *quorumpb.Block, result quorumpb.ReqSnapshotResult, err error) {
+	chain_log.Debugf("<%s> GetReqSnapshot called", d.groupId)
+
+	var reqSnapshotItem quorumpb.ReqSnapshot
+	ciperKey, err := hex.DecodeString(d.groupCipherKey)
+	if err != nil {
+		return "", 0, nil, nil, -1, err
+	}
+
+	decryptData, err := localcrypto.AesDecode(trx.Data, ciperKey)
+	if err != nil {
+		return "", 0, nil, nil, -1, err
+	}
+
+	if err := proto.Unmarshal(decryptData, &reqSnapshotItem); err != nil {
+		return "", 0, nil, nil, -1, err
+	}
+
+	//check trx sender should be same as requester in reqSnapshot Item
+	if trx.SenderPubkey != reqSnapshotItem.ReqPubkey {
+		return "", 0, nil, nil, -1, errors.New("trx sender/snapshot requester mismatch")
+	}
+
+	//snapshot shares the auth of REQ_BLOCK
+	isAllow, err := nodectx.GetNodeCtx().GetChainStorage().CheckTrxTypeAuth(trx.GroupId, trx.SenderPubkey, quorumpb.TrxType_REQ_BLOCK, d.nodename)
+	if err != nil {
+		return "", 0, nil, nil, -1, err
+	}
+
+	if !isAllow {
+		chain_log.Debugf("<%s> user <%s>: trxType <%s> is denied", d.groupId, trx.SenderPubkey, quorumpb.TrxType_REQ_BLOCK.String())
+		return "", 0, nil, nil, -1, errors.New("requester don't have sufficient privileges")
+	}
+
+	requester = reqSnapshotItem.ReqPubkey
+	fromItem = reqSnapshotItem.FromItem
+
+	latest, err := nodectx.GetNodeCtx().GetChainStorage().GetSnapshot(reqSnapshotItem.GroupId, d.nodename)
+	if err != nil {
+		return "", 0, nil, nil, -1, err
+	}
+
+	//no snapshot, or the snapshot requester is syncing has been replaced
+	if latest == nil || fromItem < 0 || fromItem > latest.ItemsCount ||
+		(len(reqSnapshotItem.StateHash) != 0 && !bytes.Equal(reqSnapshotItem.StateHash, latest.StateHash)) {
+		return requester, fromItem, nil, nil, quorumpb.ReqSnapshotResult_SNAPSHOT_NOT_FOUND, nil
+	}
+
+	//blocks after the snapshot height should be kept for requester to sync
+	lowestBlock, err := nodectx.GetNodeCtx().GetChainStorage().GetLowestBlockId(reqSnapshotItem.GroupId, d.nodename)
+	if err != nil {
+		return "", 0, nil, nil, -1, err
+	}
+	if lowestBlock > latest.BlockId {
+		return requester, fromItem, nil, nil, quorumpb.ReqSnapshotResult_SNAPSHOT_NOT_FOUND, nil
+	}
+
+	block, err = nodectx.GetNodeCtx().GetChainStorage().GetBlock(reqSnapshotItem.GroupId, latest.BlockId, false, d.nodename)
+	if err != nil {
+		return "", 0, nil, nil, -1, err
+	}
+
+	//put items into resp until reach maximum length
+	var items []*quorumpb.SnapshotItem
+	totalItemBytes := 0
+	for i := int(fromItem); i < len(latest.Items); i++ {
+		totalItemBytes += proto.Size(latest.Items[i])
+		if totalItemBytes > MAX_SNAPSHOT_ITEMS_IN_RESP_BYTES && len(items) > 0 {
+			break
+		}
+		items = append(items, latest.Items[i])
+	}
+
+	snapshot = &quorumpb.Snapshot{
+		GroupId:      latest.GroupId,
+		BlockId:      latest.BlockId,
+		Epoch:        latest.Epoch,
+		BlockHash:    latest.BlockHash,
+		ItemsCount:   latest.ItemsCount,
+		StateHash:    latest.StateHash,
+		Items:        items,
+		SenderPubkey: latest.SenderPubkey,
+		TimeStamp:    latest.TimeStamp,
+		SnapshotHash: latest.SnapshotHash,
+		SenderSign:   latest.SenderSign,
+	}
+
+	result = quorumpb.ReqSnapshotResult_SNAPSHOT_IN_RESP
+	if int(fromItem)+len(items) >= len(latest.Items) {
+		result = quorumpb.ReqSnapshotResult_SNAPSHOT_ON_TOP
+	}
+
+	return requester, fromItem, snapshot, block, result, nil
+}
@@ -0,0 +1,243 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rumsystem/quorum/internal/pkg/metric"
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	"github.com/rumsystem/quorum/internal/pkg/storage"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+// ResolveFork handles a block received with the BlockId of a block on chain but a different hash.
+// Each producer builds and keeps its own block of an epoch, so blocks with the same parent and trxs are taken as the same one.
+// Otherwise both blocks are verified with the producer list in effect at that height, and the block on chain is replaced
+// if it is invalid, or if the conflicting one is a sudo block of the owner while it is not. The state is rolled back and
+// reapplied from the fork point after the replacement. Each conflicting block is resolved once, and reported by log,
+// metric and the fork records of the group.
+func (chain *Chain) ResolveFork(block *quorumpb.Block) error {
+	groupId := chain.groupItem.GroupId
+	cs := nodectx.GetNodeCtx().GetChainStorage()
+
+	local, err := cs.GetBlock(groupId, block.BlockId, false, chain.nodename)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(local.BlockHash, block.BlockHash) || equivalentBlocks(local, block) {
+		return nil
+	}
+
+	remoteHash := hex.EncodeToString(block.BlockHash)
+	if recorded, err := cs.IsForkRecorded(groupId, block.BlockId, remoteHash, chain.nodename); err != nil || recorded {
+		return err
+	}
+
+	record := &chainstorage.ForkRecord{
+		GroupId:        groupId,
+		BlockId:        block.BlockId,
+		LocalHash:      hex.EncodeToString(local.BlockHash),
+		LocalProducer:  local.ProducerPubkey,
+		RemoteHash:     remoteHash,
+		RemoteProducer: block.ProducerPubkey,
+		TimeStamp:      time.Now().UnixNano(),
+	}
+	chain_log.Warningf("<%s> block <%d> <%s> from <%s> conflicts with block <%s> from <%s> on chain",
+		groupId, block.BlockId, record.RemoteHash, record.RemoteProducer, record.LocalHash, record.LocalProducer)
+
+	if block.BlockId == 0 {
+		record.Resolution = chainstorage.ForkRejected
+		record.Reason = "the genesis block can't be replaced"
+	} else if results, err := cs.VerifyBlocksAtHeight(groupId, block.BlockId, []*quorumpb.Block{local, block}, chain.nodename); err != nil {
+		record.Resolution = chainstorage.ForkKept
+		record.Reason = fmt.Sprintf("the blocks can't be verified: %s", err)
+	} else {
+		record.LocalIssue = forkIssue(results[0])
+		record.RemoteIssue = forkIssue(results[1])
+		switch {
+		case !results[1].Ok:
+			record.Resolution = chainstorage.ForkRejected
+			record.Reason = "the conflicting block is invalid"
+		case !results[0].Ok:
+			record.Resolution = chainstorage.ForkSwitched
+			record.Reason = "the block on chain is invalid"
+		case chain.isOwnerSudo(block) && !chain.isOwnerSudo(local):
+			record.Resolution = chainstorage.ForkSwitched
+			record.Reason = "the sudo block of the owner is preferred"
+		default:
+			record.Resolution = chainstorage.ForkKept
+			record.Reason = "both blocks are valid"
+		}
+	}
+
+	if record.Resolution == chainstorage.ForkSwitched {
+		record.RolledBack, err = chain.switchFork(block)
+		if err != nil {
+			record.Resolution = chainstorage.ForkFailed
+			record.Reason = fmt.Sprintf("%s, rollback failed: %s", record.Reason, err)
+		}
+	}
+
+	chain_log.Warningf("<%s> fork at block <%d> %s: %s", groupId, block.BlockId, record.Resolution, record.Reason)
+	metric.ChainForkCount.WithLabelValues(groupId, string(record.Resolution)).Inc()
+	return cs.AddForkRecord(record, chain.nodename)
+}
+
+// switchFork replaces the block on chain with the conflicting one, removes the blocks after it which are linked to
+// the replaced block, and re-derives the state to the fork point. The state is replayed into a temporary db and swapped in,
+// so the state on chain is kept if the replay fails. The blocks after it are synced again, and the trxs of the removed
+// blocks not packaged by the new block are buffered again by producers.
+func (chain *Chain) switchFork(block *quorumpb.Block) (int, error) {
+	groupId := chain.groupItem.GroupId
+	cs := nodectx.GetNodeCtx().GetChainStorage()
+
+	//the state before the fork point is needed to reapply from it
+	lowest, err := cs.GetLowestBlockId(groupId, chain.nodename)
+	if err != nil {
+		return 0, err
+	}
+	if lowest > 1 {
+		snapshot, err := cs.GetSnapshot(groupId, chain.nodename)
+		if err != nil {
+			return 0, err
+		}
+		if snapshot == nil || snapshot.BlockId >= block.BlockId {
+			return 0, fmt.Errorf("the state before block %d is pruned", block.BlockId)
+		}
+	}
+
+	removed := 0
+	var orphaned []*quorumpb.Trx
+	for blockId := chain.GetCurrBlockId(); blockId >= block.BlockId; blockId-- {
+		exist, err := cs.IsBlockExist(groupId, blockId, false, chain.nodename)
+		if err != nil {
+			return removed, err
+		}
+		if !exist {
+			continue
+		}
+		local, err := cs.GetBlock(groupId, blockId, false, chain.nodename)
+		if err != nil {
+			return removed, err
+		}
+		orphaned = append(orphaned, local.Trxs...)
+		if err := cs.RmBlock(groupId, blockId, false, chain.nodename); err != nil {
+			return removed, err
+		}
+		if blockId > block.BlockId {
+			removed++
+		}
+	}
+	if cached, _ := cs.IsBlockExist(groupId, block.BlockId, true, chain.nodename); cached {
+		if err := cs.RmBlock(groupId, block.BlockId, true, chain.nodename); err != nil {
+			return removed, err
+		}
+	}
+	if err := cs.AddBlock(block, false, chain.nodename); err != nil {
+		return removed, err
+	}
+
+	chain.SetCurrBlockId(block.BlockId)
+	chain.SetLastUpdate(block.TimeStamp)
+	//the epoch of producers moves with the consensus
+	if nodectx.GetNodeCtx().NodeType != nodectx.PRODUCER_NODE {
+		chain.SetCurrEpoch(block.Epoch)
+	}
+	if err := chain.SaveChainInfoToDb(); err != nil {
+		return removed, err
+	}
+
+	packaged := make(map[string]bool)
+	trxIds := []string{}
+	for _, trx := range block.Trxs {
+		packaged[trx.TrxId] = true
+		trxIds = append(trxIds, trx.TrxId)
+	}
+	for _, trx := range orphaned {
+		if !packaged[trx.TrxId] {
+			trxIds = append(trxIds, trx.TrxId)
+		}
+	}
+	result, err := chain.replaySwap(block.BlockId, trxIds)
+	if err != nil {
+		return removed, err
+	}
+	chain.reloadState()
+
+	rebuffered := 0
+	if chain.isProducer() {
+		for _, trx := range orphaned {
+			if packaged[trx.TrxId] {
+				continue
+			}
+			chain.producerAddTrx(trx)
+			rebuffered++
+		}
+	}
+	chain_log.Warningf("<%s> switched to block <%d> <%x>, <%d> blocks after it removed, <%d> trxs buffered again, state reapplied <%s>",
+		groupId, block.BlockId, block.BlockHash, removed, rebuffered, result.StateDigest)
+	return removed, nil
+}
+
+// replaySwap replays the state to toBlock into a temporary db and replaces the state on chain with it,
+// the trxs of trxIds applied by the replay are saved, the others are removed
+func (chain *Chain) replaySwap(toBlock uint64, trxIds []string) (*chainstorage.ReplayResult, error) {
+	groupId := chain.groupItem.GroupId
+	cs := nodectx.GetNodeCtx().GetChainStorage()
+
+	dir, err := os.MkdirTemp("", "quorum-fork-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	dbMgr, err := storage.CreateDbWithBackend(dir, storage.BoltBackend)
+	if err != nil {
+		return nil, err
+	}
+	defer dbMgr.CloseDb()
+	dst := chainstorage.NewChainStorage(dbMgr)
+
+	result, err := dst.ReplayState(cs, groupId, chain.replayOptions(toBlock), chain.nodename)
+	if err != nil {
+		return nil, err
+	}
+	items, err := dst.GetStateItems(groupId, chain.nodename)
+	if err != nil {
+		return nil, err
+	}
+	if err := cs.ApplyStateItems(groupId, items, chain.nodename); err != nil {
+		return nil, err
+	}
+	if err := cs.CopyTrxs(dst, groupId, trxIds, chain.nodename); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (chain *Chain) isOwnerSudo(block *quorumpb.Block) bool {
+	return block.Sudo && chain.isOwnerByPubkey(block.ProducerPubkey)
+}
+
+// equivalentBlocks checks the blocks have the same parent and package the same trxs in the same order
+func equivalentBlocks(a, b *quorumpb.Block) bool {
+	if a.BlockId != b.BlockId || !bytes.Equal(a.PrevHash, b.PrevHash) || len(a.Trxs) != len(b.Trxs) {
+		return false
+	}
+	for i := range a.Trxs {
+		if a.Trxs[i].TrxId != b.Trxs[i].TrxId {
+			return false
+		}
+	}
+	return true
+}
+
+func forkIssue(result *chainstorage.ChainVerifyResult) string {
+	if len(result.Issues) == 0 {
+		return ""
+	}
+	return result.Issues[0].Message
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/rumsystem/quorum/internal/pkg/conn"
 	"github.com/rumsystem/quorum/internal/pkg/logging"
 	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
 	"github.com/rumsystem/quorum/internal/pkg/storage/def"
 	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
 	quorumpb "github.com/rumsystem/quorum/pkg/pb"
@@ -145,6 +146,9 @@ func (grp *Group) Teardown() {
 	//unregisted chainctx with conn
 	conn.GetConn().UnregisterChainCtx(grp.Item.GroupId)
 
+	grp.ChainCtx.StopTrxTracker()
+	grp.ChainCtx.StopConsensus()
+
 	group_log.Infof("Group <%s> teardown peacefully", grp.Item.GroupId)
 }
 
@@ -156,6 +160,9 @@ func (grp *Group) LeaveGrp() error {
 		return err
 	}
 
+	grp.ChainCtx.StopTrxTracker()
+	grp.ChainCtx.StopConsensus()
+
 	//remove group from local db
 	return nodectx.GetNodeCtx().GetChainStorage().RmGroup(grp.Item.GroupId)
 }
@@ -197,6 +204,12 @@ func (grp *Group) GetTrx(trxId string) (*quorumpb.Trx, error) {
 	return nodectx.GetNodeCtx().GetChainStorage().GetTrx(grp.Item.GroupId, trxId, def.Chain, grp.Nodename)
 }
 
+// GetTrxBlock returns the block on chain packaged the trx, nil if it is not found
+func (grp *Group) GetTrxBlock(trxId string) (*quorumpb.Block, error) {
+	group_log.Debugf("<%s> GetTrxBlock called trxId: <%s>", grp.Item.GroupId, trxId)
+	return nodectx.GetNodeCtx().GetChainStorage().GetTrxBlock(grp.Item.GroupId, trxId, grp.GetCurrentBlockId(), grp.Nodename)
+}
+
 func (grp *Group) GetTrxFromCache(trxId string) (*quorumpb.Trx, error) {
 	group_log.Debugf("<%s> GetTrxFromCache called trxId: <%s>", grp.Item.GroupId, trxId)
 	return nodectx.GetNodeCtx().GetChainStorage().GetTrx(grp.Item.GroupId, trxId, def.Cache, grp.Nodename)
@@ -288,6 +301,64 @@ func (grp *Group) UpdChainConfig(item *quorumpb.ChainConfigItem) (string, error)
 	return grp.sendTrx(trx)
 }
 
+// send stake trx, only for POS groups
+func (grp *Group) UpdStake(item *quorumpb.StakeItem) (string, error) {
+	group_log.Debugf("<%s> UpdStake called", grp.Item.GroupId)
+	trx, err := grp.ChainCtx.GetTrxFactory().GetStakeTrx("", item)
+	if err != nil {
+		return "", err
+	}
+	return grp.sendTrx(trx)
+}
+
+// rotate the cipher key of a private group, only for owner
+func (grp *Group) RotateGroupKey(encryptPubkeys []string, memo string) (string, []string, error) {
+	group_log.Debugf("<%s> RotateGroupKey called", grp.Item.GroupId)
+	trx, recipients, err := grp.ChainCtx.GetGroupKeyTrx(encryptPubkeys, memo)
+	if err != nil {
+		return "", nil, err
+	}
+	trxId, err := grp.sendTrx(trx)
+	if err != nil {
+		return "", nil, err
+	}
+	return trxId, recipients, nil
+}
+
+func (grp *Group) GetGroupKeys() ([]*chainstorage.GroupKeyItem, error) {
+	group_log.Debugf("<%s> GetGroupKeys called", grp.Item.GroupId)
+	return nodectx.GetNodeCtx().GetChainStorage().GetGroupKeys(grp.Item.GroupId, grp.Nodename)
+}
+
+func (grp *Group) GetStakes() ([]*quorumpb.StakeItem, error) {
+	group_log.Debugf("<%s> GetStakes called", grp.Item.GroupId)
+	return nodectx.GetNodeCtx().GetChainStorage().GetStakes(grp.Item.GroupId, grp.Nodename)
+}
+
+// producer lists waiting for their activate epoch
+func (grp *Group) GetScheduledProducers() ([]*chainstorage.ScheduledProducers, error) {
+	group_log.Debugf("<%s> GetScheduledProducers called", grp.Item.GroupId)
+	return nodectx.GetNodeCtx().GetChainStorage().GetScheduledProducers(grp.Item.GroupId, grp.Nodename)
+}
+
+// evidence of producer misbehaviour found by this node
+func (grp *Group) GetEvidences() ([]*quorumpb.Evidence, error) {
+	group_log.Debugf("<%s> GetEvidences called", grp.Item.GroupId)
+	return nodectx.GetNodeCtx().GetChainStorage().GetEvidences(grp.Item.GroupId, grp.Nodename)
+}
+
+// conflicting blocks found by this node and how they are resolved
+func (grp *Group) GetForkRecords() ([]*chainstorage.ForkRecord, error) {
+	group_log.Debugf("<%s> GetForkRecords called", grp.Item.GroupId)
+	return nodectx.GetNodeCtx().GetChainStorage().GetForkRecords(grp.Item.GroupId, grp.Nodename)
+}
+
+// create a sudo block with the pending trxs, only the owner can do it
+func (grp *Group) SudoBlock(trxIds []string) (*quorumpb.Block, error) {
+	group_log.Debugf("<%s> SudoBlock called", grp.Item.GroupId)
+	return grp.ChainCtx.CreateSudoBlock(trxIds)
+}
+
 // send update appconfig trx
 func (grp *Group) UpdAppConfig(item *quorumpb.AppConfigItem) (string, error) {
 	group_log.Debugf("<%s> UpdAppConfig called", grp.Item.GroupId)
@@ -303,19 +374,25 @@ func (grp *Group) SendRawTrx(trx *quorumpb.Trx) (string, error) {
 	return grp.sendTrx(trx)
 }
 
+// send trx and track it, it is resent until packaged or expired
 func (grp *Group) sendTrx(trx *quorumpb.Trx) (string, error) {
-	connMgr, err := conn.GetConn().GetConnMgr(grp.Item.GroupId)
-	if err != nil {
-		return "", err
-	}
-	err = connMgr.SendUserTrxPubsub(trx)
-	if err != nil {
+	if err := grp.ChainCtx.trxTracker.Send(trx); err != nil {
 		return "", err
 	}
 
 	return trx.TrxId, nil
 }
 
+// ReplayState re-derives the group state from the stored blocks into dst, see Chain.ReplayState
+func (grp *Group) ReplayState(dst *chainstorage.Storage, toBlock uint64, apply bool) (*chainstorage.ReplayResult, error) {
+	return grp.ChainCtx.ReplayState(dst, toBlock, apply)
+}
+
+// GetTrxStatus returns the status of a trx sent by this node, nil if the trx is not tracked
+func (grp *Group) GetTrxStatus(trxId string) (*chainstorage.TrxStatusItem, error) {
+	return grp.ChainCtx.GetTrxStatus(trxId)
+}
+
 func (grp *Group) StartSync(restart bool) error {
 	group_log.Debugf("<%s> StartSync called", grp.Item.GroupId)
 	return grp.ChainCtx.StartSync()
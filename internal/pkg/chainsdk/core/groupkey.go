@@ -0,0 +1,87 @@
+package chain
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+// GetGroupKeyTrx creates a GROUP_KEY trx rotating the cipher key of a private group. The new key is encrypted to
+// the users in the group (the removed users are excluded), the producers, which decrypt the trxs to validate them,
+// and the extra encryptPubkeys. The key takes effect after the trx is packaged, the key before it is revoked since then.
+func (chain *Chain) GetGroupKeyTrx(encryptPubkeys []string, memo string) (*quorumpb.Trx, []string, error) {
+	if !chain.isOwner() {
+		return nil, nil, rumerrors.ErrOnlyGroupOwner
+	}
+	if chain.groupItem.EncryptType != quorumpb.GroupEncryptType_PRIVATE {
+		return nil, nil, errors.New("group key can only be rotated in private group")
+	}
+
+	keys, err := chain.GetUsesEncryptPubKeys()
+	if err != nil {
+		return nil, nil, err
+	}
+	producerKeys, err := chain.getProducersEncryptPubKeys()
+	if err != nil {
+		return nil, nil, err
+	}
+	keys = append(keys, producerKeys...)
+
+	recipients := []string{}
+	added := make(map[string]bool)
+	for _, key := range append(keys, encryptPubkeys...) {
+		if key == "" || added[key] {
+			continue
+		}
+		added[key] = true
+		recipients = append(recipients, key)
+	}
+
+	cipherKey := make([]byte, 32)
+	if _, err := rand.Read(cipherKey); err != nil {
+		return nil, nil, err
+	}
+	encryptedKey, err := localcrypto.GetKeystore().EncryptTo(recipients, cipherKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	item := &quorumpb.GroupKeyItem{
+		GroupId:      chain.groupItem.GroupId,
+		EncryptedKey: encryptedKey,
+		Recipients:   recipients,
+		TimeStamp:    time.Now().UnixNano(),
+		Memo:         memo,
+	}
+	trx, err := chain.trxFactory.GetGroupKeyTrx("", item)
+	if err != nil {
+		return nil, nil, err
+	}
+	return trx, recipients, nil
+}
+
+// getProducersEncryptPubKeys returns the encrypt pubkeys announced by the producers except the owner,
+// the stakers of POS groups may announce as users
+func (chain *Chain) getProducersEncryptPubKeys() ([]string, error) {
+	cs := nodectx.GetNodeCtx().GetChainStorage()
+	keys := []string{}
+	for _, item := range chain.producerPool {
+		if chain.isOwnerByPubkey(item.ProducerPubkey) {
+			continue
+		}
+		if ann, err := cs.GetAnnouncedProducer(chain.groupItem.GroupId, item.ProducerPubkey, chain.nodename); err == nil && ann.EncryptPubkey != "" {
+			keys = append(keys, ann.EncryptPubkey)
+		} else if usr, ok := chain.userPool[item.ProducerPubkey]; ok && usr.EncryptPubkey != "" {
+			keys = append(keys, usr.EncryptPubkey)
+		} else {
+			return nil, fmt.Errorf("encrypt pubkey of producer <%s> is not announced", item.ProducerPubkey)
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,73 @@
+package chain
+
+import (
+	"fmt"
+
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+)
+
+func (chain *Chain) replayOptions(toBlock uint64) *chainstorage.ReplayOptions {
+	groupId := chain.groupItem.GroupId
+	return &chainstorage.ReplayOptions{
+		ToBlock:      toBlock,
+		ProducerNode: nodectx.GetNodeCtx().NodeType == nodectx.PRODUCER_NODE,
+		DecryptPost: func(data []byte) ([]byte, error) {
+			return localcrypto.GetKeystore().Decrypt(groupId, data)
+		},
+		DecryptGroupKey: func(data []byte) ([]byte, error) {
+			return localcrypto.GetKeystore().Decrypt(groupId, data)
+		},
+	}
+}
+
+// ReplayState re-derives the state of the group from the stored blocks into dst, which should be a fresh db.
+// The replayed state is compared with the current state if replayed to the current block,
+// and replaces the current state if apply is set and they mismatch.
+func (chain *Chain) ReplayState(dst *chainstorage.Storage, toBlock uint64, apply bool) (*chainstorage.ReplayResult, error) {
+	groupId := chain.groupItem.GroupId
+	chain_log.Infof("<%s> replay state to block <%d>", groupId, toBlock)
+
+	cs := nodectx.GetNodeCtx().GetChainStorage()
+	result, err := dst.ReplayState(cs, groupId, chain.replayOptions(toBlock), chain.nodename)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.ToBlock == chain.GetCurrBlockId() {
+		result.CurrentDigest, _, err = cs.GetStateDigest(groupId, chain.nodename)
+		if err != nil {
+			return nil, err
+		}
+		result.Match = result.CurrentDigest == result.StateDigest
+	}
+	chain_log.Infof("<%s> state replayed to block <%d>, digest <%s>, current <%s>", groupId, result.ToBlock, result.StateDigest, result.CurrentDigest)
+
+	if !apply {
+		return result, nil
+	}
+	if result.CurrentDigest == "" {
+		return nil, fmt.Errorf("the state is only applied when replayed to the current block %d", chain.GetCurrBlockId())
+	}
+	if result.Match {
+		return result, nil
+	}
+
+	items, err := dst.GetStateItems(groupId, chain.nodename)
+	if err != nil {
+		return nil, err
+	}
+	//new blocks applied while replaying
+	if result.ToBlock != chain.GetCurrBlockId() {
+		return nil, fmt.Errorf("the chain moved to block %d while replaying, try again", chain.GetCurrBlockId())
+	}
+	if err := cs.ApplyStateItems(groupId, items, chain.nodename); err != nil {
+		return nil, err
+	}
+	chain.reloadState()
+	result.Applied = true
+	chain_log.Warningf("<%s> state replaced by the replayed state at block <%d>", groupId, result.ToBlock)
+
+	return result, nil
+}
@@ -1,16 +1,20 @@
 package chain
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/rand"
 	"sync"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rumsystem/quorum/internal/pkg/chainsdk/def"
 	"github.com/rumsystem/quorum/internal/pkg/conn"
 	"github.com/rumsystem/quorum/internal/pkg/logging"
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
 
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
 	quorumpb "github.com/rumsystem/quorum/pkg/pb"
 
 	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
@@ -18,15 +22,26 @@ import (
 
 var TASK_RETRY_NUM = 30                // task retry times
 var REQ_BLOCKS_PER_REQUEST = int32(10) // ask for n blocks per request
+var SYNC_WINDOW_SIZE = 4               // ask for n block ranges from different providers in parallel
 var SYNC_BLOCK_TASK_TIMEOUT = 4 * 1000 // in millseconds
 var SYNC_BLOCK_FREQ_ADJ = 5 * 1000     // in millseconds
 var MAXIMUM_DELAY_DURATION = 60 * 1000 // is millseconds
+var SNAPSHOT_RETRY_NUM = 5             // snapshot sync retry times before fall back to sync blocks from genesis
 
 var rex_syncer_log = logging.Logger("rsyncer")
 
+type SyncTaskType uint
+
+const (
+	SyncBlock SyncTaskType = iota
+	SyncSnapshot
+)
+
 type SyncResult struct {
-	TaskId uint64
-	Data   interface{}
+	TaskId   uint64
+	Type     SyncTaskType
+	Data     interface{}
+	Provider peer.ID //peer the resp comes from, empty if unknown
 }
 
 type SyncerStatus uint
@@ -38,10 +53,51 @@ const (
 )
 
 type SyncTask struct {
-	TaskId      uint64 //block
+	TaskId      uint64 //block, or snapshot item for snapshot task
+	Type        SyncTaskType
 	ReqBlockNum int32
 	DelayTime   int
 	TriggerTime int64
+
+	//block ranges of the sync window, each range is requested from a different provider
+	Ranges []*SyncRange
+	onTop  bool //owner says there is no more block after one of the ranges
+	inResp bool //more blocks may be available after one of the ranges
+}
+
+type SyncRange struct {
+	FromBlock   uint64
+	ReqBlockNum int32
+	Provider    peer.ID //empty if the req is published to a random peer
+	Done        bool
+	Failed      bool //provider resp invalid blocks
+}
+
+func (task *SyncTask) getRange(fromBlock uint64) *SyncRange {
+	for _, syncRange := range task.Ranges {
+		if syncRange.FromBlock == fromBlock {
+			return syncRange
+		}
+	}
+	return nil
+}
+
+func (task *SyncTask) isDone() bool {
+	for _, syncRange := range task.Ranges {
+		if !syncRange.Done {
+			return false
+		}
+	}
+	return true
+}
+
+func (task *SyncTask) hasFailed() bool {
+	for _, syncRange := range task.Ranges {
+		if syncRange.Failed {
+			return true
+		}
+	}
+	return false
 }
 
 type RexSyncer struct {
@@ -60,8 +116,16 @@ type RexSyncer struct {
 	CurrentDely       int
 	CurrentTask       *SyncTask
 	CurrentTaskCancel context.CancelFunc
+	mutask            sync.Mutex
 
 	LastSyncResult *def.RexSyncResult
+
+	//bootstrap from state snapshot
+	snapshotSync  bool
+	snapshotRetry int
+	snapshot      *quorumpb.Snapshot
+	snapshotBlock *quorumpb.Block
+	snapshotItems []*quorumpb.SnapshotItem
 }
 
 func NewRexSyncer(groupid string, nodename string, cdnIface def.ChainDataSyncIface, chainCtx *Chain) *RexSyncer {
@@ -126,7 +190,12 @@ func (rs *RexSyncer) Start() {
 		}
 	}()
 
-	task := rs.newSyncBlockTask()
+	rs.snapshotSync = rs.chainCtx.needSnapshotSync()
+	if rs.snapshotSync {
+		rex_syncer_log.Infof("<%s> bootstrap from state snapshot", rs.GroupId)
+	}
+
+	task := rs.newSyncTask()
 	rs.AddTask(task)
 }
 
@@ -156,9 +225,12 @@ func (rs *RexSyncer) GetLastRexSyncResult() (*def.RexSyncResult, error) {
 func (rs *RexSyncer) runTask(ctx context.Context, task *SyncTask, cancel context.CancelFunc) error {
 	//TODO: close this goroutine when the processTask func return. add some defer signal?
 	rex_syncer_log.Debugf("runTask called, taskId <%d>, retry <%d>", task.TaskId, rs.CurrRetryCount)
+	rs.mutask.Lock()
+	rs.CurrentTask = task //set current task
+	rs.CurrentTaskCancel = cancel
+	rs.mutask.Unlock()
+
 	go func() {
-		rs.CurrentTask = task //set current task
-		rs.CurrentTaskCancel = cancel
 		err := rs.syncBlockTaskSender(task)
 		if err != nil {
 			rex_syncer_log.Debugf("todo add retry task <%d>", task.TaskId)
@@ -171,10 +243,14 @@ func (rs *RexSyncer) runTask(ctx context.Context, task *SyncTask, cancel context
 		switch ctx.Err() {
 		case context.DeadlineExceeded:
 			if rs.Status != CLOSED {
+				rs.mutask.Lock()
 				//a workround, should cancel the ctx for current task
-				if rs.CurrentTask != nil {
+				if rs.CurrentTask == task {
 					rex_syncer_log.Debugf("task <%d> timeout", task.TaskId)
-					rs.CurrRetryCount += 1
+					//providers not resp in time are penalized, retry count only grows if nobody resp
+					if !rs.penalizeSlowProviders(task) {
+						rs.CurrRetryCount += 1
+					}
 					rex_syncer_log.Debugf("CurrRetryCount <%d>", rs.CurrRetryCount)
 
 					//remove current task
@@ -182,10 +258,15 @@ func (rs *RexSyncer) runTask(ctx context.Context, task *SyncTask, cancel context
 					rs.CurrentTaskCancel = nil
 					rs.Status = IDLE
 
+					if task.Type == SyncSnapshot {
+						rs.retrySnapshot()
+					}
+
 					//start next round with highest epoch
-					task := rs.newSyncBlockTask()
+					task := rs.newSyncTask()
 					rs.AddTask(task)
 				}
+				rs.mutask.Unlock()
 			}
 		case context.Canceled:
 			rex_syncer_log.Debugf("task <%d> done", task.TaskId)
@@ -216,24 +297,48 @@ func (rs *RexSyncer) AddResult(result *SyncResult) {
 }
 
 // task generators
+func (rs *RexSyncer) newSyncTask() *SyncTask {
+	if rs.snapshotSync {
+		return rs.newSyncSnapshotTask()
+	}
+	return rs.newSyncBlockTask()
+}
+
 func (rs *RexSyncer) newSyncBlockTask() *SyncTask {
 	rex_syncer_log.Debugf("<%s> newSyncBlockTask called", rs.GroupId)
 	nextBlock := rs.cdnIface.GetCurrBlockId() + uint64(1)
 	randDelay := rand.Intn(500)
-	return &SyncTask{TaskId: nextBlock, ReqBlockNum: REQ_BLOCKS_PER_REQUEST, DelayTime: randDelay}
+	task := &SyncTask{TaskId: nextBlock, Type: SyncBlock, ReqBlockNum: REQ_BLOCKS_PER_REQUEST, DelayTime: randDelay}
+
+	//the chain is on top, only ask for the next range
+	windowSize := SYNC_WINDOW_SIZE
+	if rs.CurrentDely == MAXIMUM_DELAY_DURATION || windowSize < 1 {
+		windowSize = 1
+	}
+
+	for i := 0; i < windowSize; i++ {
+		fromBlock := nextBlock + uint64(i)*uint64(REQ_BLOCKS_PER_REQUEST)
+		//blocks received out of order are waiting in the block cache, don't ask for them again
+		if i > 0 {
+			if cached, _ := nodectx.GetNodeCtx().GetChainStorage().IsBlockExist(rs.GroupId, fromBlock, true, rs.nodename); cached {
+				continue
+			}
+		}
+		task.Ranges = append(task.Ranges, &SyncRange{FromBlock: fromBlock, ReqBlockNum: REQ_BLOCKS_PER_REQUEST})
+	}
+	return task
+}
+
+func (rs *RexSyncer) newSyncSnapshotTask() *SyncTask {
+	rex_syncer_log.Debugf("<%s> newSyncSnapshotTask called", rs.GroupId)
+	nextItem := uint64(len(rs.snapshotItems))
+	randDelay := rand.Intn(500)
+	return &SyncTask{TaskId: nextItem, Type: SyncSnapshot, DelayTime: randDelay}
 }
 
 func (rs *RexSyncer) syncBlockTaskSender(task *SyncTask) error {
 	rex_syncer_log.Debugf("<%s> syncBlockTaskSender called", rs.GroupId)
 
-	var trx *quorumpb.Trx
-	var trxerr error
-
-	trx, trxerr = rs.chainCtx.GetTrxFactory().GetReqBlocksTrx("", rs.GroupId, task.TaskId, task.ReqBlockNum)
-	if trxerr != nil {
-		return trxerr
-	}
-
 	connMgr, err := conn.GetConn().GetConnMgr(rs.GroupId)
 	if err != nil {
 		return err
@@ -244,30 +349,121 @@ func (rs *RexSyncer) syncBlockTaskSender(task *SyncTask) error {
 
 	//set status to SYNCING since the syncing task is always running and the "real" sync work (after send out reqBlock) only start after sleep
 	rs.Status = SYNCING
-	return connMgr.SendReqTrxRex(trx)
+
+	if task.Type == SyncSnapshot {
+		var stateHash []byte
+		if rs.snapshot != nil {
+			stateHash = rs.snapshot.StateHash
+		}
+		trx, err := rs.chainCtx.GetTrxFactory().GetReqSnapshotTrx("", rs.GroupId, int32(task.TaskId), stateHash)
+		if err != nil {
+			return err
+		}
+		return connMgr.SendReqTrxRex(trx)
+	}
+
+	return rs.sendReqBlocks(task, connMgr)
+}
+
+// assign ranges of the sync window to the best ranked providers, one range per provider, and send the reqs in parallel
+func (rs *RexSyncer) sendReqBlocks(task *SyncTask, connMgr *conn.ConnMgr) error {
+	providers := connMgr.GetSyncPeers(len(task.Ranges))
+
+	rs.mutask.Lock()
+	if len(providers) == 0 {
+		//no known provider, publish the first range to a random peer
+		task.Ranges = task.Ranges[:1]
+	} else if len(providers) < len(task.Ranges) {
+		task.Ranges = task.Ranges[:len(providers)]
+	}
+	for i, provider := range providers {
+		if i < len(task.Ranges) {
+			task.Ranges[i].Provider = provider
+		}
+	}
+	ranges := append([]*SyncRange{}, task.Ranges...)
+	rs.mutask.Unlock()
+
+	var lastErr error
+	for _, syncRange := range ranges {
+		trx, err := rs.chainCtx.GetTrxFactory().GetReqBlocksTrx("", rs.GroupId, syncRange.FromBlock, syncRange.ReqBlockNum)
+		if err != nil {
+			return err
+		}
+
+		if syncRange.Provider == "" {
+			err = connMgr.SendReqTrxRex(trx)
+		} else {
+			rex_syncer_log.Debugf("<%s> req blocks from <%d> to provider <%s>", rs.GroupId, syncRange.FromBlock, syncRange.Provider)
+			err = connMgr.SendReqTrxRexToPeer(trx, syncRange.Provider)
+		}
+		if err != nil {
+			rex_syncer_log.Debugf("<%s> send req blocks from <%d> failed with error <%s>", rs.GroupId, syncRange.FromBlock, err.Error())
+			lastErr = err
+		}
+	}
+	return lastErr
 }
 
 func (rs *RexSyncer) handleResult(result *SyncResult) error {
 	rex_syncer_log.Debugf("<%s> handleResult called", rs.GroupId)
+	rs.mutask.Lock()
+	defer rs.mutask.Unlock()
+
 	//check if the resp is what we are waiting for
 	if rs.CurrentTask == nil {
 		rex_syncer_log.Debugf("<%s> CurrentTask is nil, ignore", rs.GroupId)
 		return rumerrors.ErrTaskIdMismatch
 	}
-	if result.TaskId != rs.CurrentTask.TaskId {
+	if result.Type != rs.CurrentTask.Type {
+		return rumerrors.ErrTaskIdMismatch
+	}
+
+	if result.Type == SyncSnapshot {
+		if result.TaskId != rs.CurrentTask.TaskId {
+			return rumerrors.ErrTaskIdMismatch
+		}
+		rs.handleSnapshotResult(result.Data.(*quorumpb.ReqSnapshotResp))
+		rs.finishCurrentTask()
+		return nil
+	}
+
+	return rs.handleBlocksResult(result)
+}
+
+func (rs *RexSyncer) handleBlocksResult(result *SyncResult) error {
+	task := rs.CurrentTask
+	syncRange := task.getRange(result.TaskId)
+	if syncRange == nil || syncRange.Done {
 		//chain_log.Warningf("<%s> HandleReqBlockResp error <%s>", sr.groupId, rumerrors.ErrEpochMismatch)
 		return rumerrors.ErrTaskIdMismatch
 	}
 
+	//only accept resp from the provider the range is assigned to
+	if syncRange.Provider != "" && syncRange.Provider != result.Provider {
+		return rumerrors.ErrTaskIdMismatch
+	}
+
 	reqBlockResp := result.Data.(*quorumpb.ReqBlockResp)
+	blocks := reqBlockResp.GetBlocks().GetBlocks()
 
 	rex_syncer_log.Debugf("- Receive valid reqBlockResp, provider <%s> result <%s> from block <%d> total <%d> blocks provided",
 		reqBlockResp.ProviderPubkey,
 		reqBlockResp.Result.String(),
 		reqBlockResp.FromBlock,
-		len(reqBlockResp.Blocks.Blocks))
+		len(blocks))
+
+	syncRange.Done = true
+	if err := rs.verifyReqBlockResp(reqBlockResp, syncRange); err != nil {
+		rex_syncer_log.Warningf("<%s> invalid blocks from provider <%s>, error <%s>", rs.GroupId, reqBlockResp.ProviderPubkey, err.Error())
+		syncRange.Failed = true
+		rs.penalizePeer(result.Provider)
+		if task.isDone() {
+			rs.finishCurrentTask()
+		}
+		return nil
+	}
 
-	//Since a valid response is retrieved, finish current task
 	/*
 		only 1 producer (owner) is supported in this version
 		node should only accept BLOCK_NOT_FOUND from group owner and ignore all other BLOCK_NOT_FOUND msg
@@ -277,28 +473,31 @@ func (rs *RexSyncer) handleResult(result *SyncResult) error {
 	//check if resp is from owner
 	isOwner := rs.chainCtx.isOwnerByPubkey(reqBlockResp.ProviderPubkey)
 
+	//blocks of a range after a missing range are kept in the block cache, and moved to chain once the missing range is applied
 	switch reqBlockResp.Result {
 	case quorumpb.ReqBlkResult_BLOCK_NOT_FOUND:
 		if isOwner {
-			rs.CurrentDely = MAXIMUM_DELAY_DURATION
-			chain_log.Debugf("<%s> receive BLOCK_NOT_FOUND from group owner, set delay to <%d>", rs.GroupId, rs.CurrentDely)
+			task.onTop = true
+			chain_log.Debugf("<%s> receive BLOCK_NOT_FOUND from group owner for block <%d>", rs.GroupId, syncRange.FromBlock)
 		}
 
 	case quorumpb.ReqBlkResult_BLOCK_IN_RESP_ON_TOP:
-		rs.chainCtx.ApplyBlocks(reqBlockResp.Blocks.Blocks)
+		rs.chainCtx.ApplyBlocks(blocks)
 		if isOwner {
-			rs.CurrentDely = MAXIMUM_DELAY_DURATION
-			chain_log.Debugf("<%s> receive BLOCK_IN_RESP_ON_TOP from group owner, apply blocks, set task delay to <%d>", rs.GroupId, rs.CurrentDely)
+			task.onTop = true
+			chain_log.Debugf("<%s> receive BLOCK_IN_RESP_ON_TOP from group owner, apply blocks", rs.GroupId)
 		}
 
 	case quorumpb.ReqBlkResult_BLOCK_IN_RESP:
-		rs.CurrentDely = 0
-		chain_log.Debugf("<%s> HandleReqBlockResp - receive BLOCK_IN_RESP from node <%s>, apply all blocks and reset syncer timer to <%d>", rs.GroupId, reqBlockResp.ProviderPubkey, rs.CurrentDely)
-		rs.chainCtx.ApplyBlocks(reqBlockResp.Blocks.Blocks)
+		task.inResp = true
+		chain_log.Debugf("<%s> HandleReqBlockResp - receive BLOCK_IN_RESP from node <%s>, apply all blocks", rs.GroupId, reqBlockResp.ProviderPubkey)
+		rs.chainCtx.ApplyBlocks(blocks)
 	default:
 
 	}
 
+	rs.rewardPeer(result.Provider, uint64(len(blocks)))
+
 	//received something, reset current retry count
 	rs.CurrRetryCount = 0
 
@@ -311,6 +510,85 @@ func (rs *RexSyncer) handleResult(result *SyncResult) error {
 		NextSyncTaskTimeStamp: -1,
 	}
 
+	if task.isDone() {
+		//all ranges of the window are answered, wait longer if owner says the chain is on top
+		if task.onTop && !task.hasFailed() {
+			rs.CurrentDely = MAXIMUM_DELAY_DURATION
+		} else if task.inResp {
+			rs.CurrentDely = 0
+		}
+		chain_log.Debugf("<%s> sync window from block <%d> done, set task delay to <%d>", rs.GroupId, task.TaskId, rs.CurrentDely)
+		rs.finishCurrentTask()
+	}
+	return nil
+}
+
+// check blocks in resp are in the requested range, with valid hash and producer sign
+func (rs *RexSyncer) verifyReqBlockResp(resp *quorumpb.ReqBlockResp, syncRange *SyncRange) error {
+	blocks := resp.GetBlocks().GetBlocks()
+	if resp.Result == quorumpb.ReqBlkResult_BLOCK_NOT_FOUND {
+		return nil
+	}
+
+	if len(blocks) > int(syncRange.ReqBlockNum) {
+		return fmt.Errorf("<%d> blocks provided, <%d> requested", len(blocks), syncRange.ReqBlockNum)
+	}
+
+	for i, block := range blocks {
+		if block.GroupId != rs.GroupId {
+			return fmt.Errorf("block <%d> from other group <%s>", block.BlockId, block.GroupId)
+		}
+		if block.BlockId != syncRange.FromBlock+uint64(i) {
+			return fmt.Errorf("block <%d> out of requested range from <%d>", block.BlockId, syncRange.FromBlock)
+		}
+		if valid, err := rumchaindata.ValidBlock(block); !valid {
+			if err == nil {
+				err = fmt.Errorf("producer sign for block <%d> is invalid", block.BlockId)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// penalize providers which not resp in time, returns true if any range of the task is answered
+func (rs *RexSyncer) penalizeSlowProviders(task *SyncTask) bool {
+	answered := false
+	for _, syncRange := range task.Ranges {
+		if syncRange.Done {
+			if !syncRange.Failed {
+				answered = true
+			}
+			continue
+		}
+		if syncRange.Provider != "" {
+			rex_syncer_log.Debugf("<%s> provider <%s> timeout for blocks from <%d>", rs.GroupId, syncRange.Provider, syncRange.FromBlock)
+			rs.penalizePeer(syncRange.Provider)
+		}
+	}
+	return answered
+}
+
+func (rs *RexSyncer) rewardPeer(pid peer.ID, blocks uint64) {
+	if pid == "" || blocks == 0 {
+		return
+	}
+	if connMgr, err := conn.GetConn().GetConnMgr(rs.GroupId); err == nil {
+		connMgr.RewardBlockProvider(pid, blocks)
+	}
+}
+
+func (rs *RexSyncer) penalizePeer(pid peer.ID) {
+	if pid == "" {
+		return
+	}
+	if connMgr, err := conn.GetConn().GetConnMgr(rs.GroupId); err == nil {
+		connMgr.PenalizePeer(pid)
+	}
+}
+
+// finish current task and start next round
+func (rs *RexSyncer) finishCurrentTask() {
 	if rs.CurrentTaskCancel != nil {
 		rs.CurrentTaskCancel()
 	}
@@ -319,8 +597,76 @@ func (rs *RexSyncer) handleResult(result *SyncResult) error {
 	rs.Status = IDLE
 
 	//start next round
-	task := rs.newSyncBlockTask()
+	task := rs.newSyncTask()
 	rs.AddTask(task)
+}
 
-	return nil
+func (rs *RexSyncer) handleSnapshotResult(resp *quorumpb.ReqSnapshotResp) {
+	rex_syncer_log.Debugf("- Receive valid reqSnapshotResp, provider <%s> result <%s> from item <%d>", resp.ProviderPubkey, resp.Result.String(), resp.FromItem)
+
+	if resp.Result == quorumpb.ReqSnapshotResult_SNAPSHOT_NOT_FOUND || resp.Snapshot == nil || resp.Block == nil {
+		rs.retrySnapshot()
+		return
+	}
+
+	snapshot := resp.Snapshot
+	if rs.snapshot == nil {
+		//only accept snapshot signed by producers of the group, fullnodes serve the one they bootstrapped from
+		if valid, err := rumchaindata.ValidSnapshotResp(resp, rs.chainCtx.isProducerByPubkey); !valid {
+			rex_syncer_log.Warningf("<%s> invalid snapshot from <%s>, error <%v>", rs.GroupId, resp.ProviderPubkey, err)
+			rs.retrySnapshot()
+			return
+		}
+
+		if snapshot.BlockId <= rs.cdnIface.GetCurrBlockId() {
+			rex_syncer_log.Infof("<%s> snapshot at block <%d> is not ahead of local chain, sync blocks", rs.GroupId, snapshot.BlockId)
+			rs.stopSnapshotSync()
+			return
+		}
+
+		rs.snapshot = snapshot
+		rs.snapshotBlock = resp.Block
+	} else if !bytes.Equal(snapshot.SnapshotHash, rs.snapshot.SnapshotHash) {
+		rex_syncer_log.Debugf("<%s> snapshot mismatch with the one being synced, ignore", rs.GroupId)
+		return
+	}
+
+	rs.snapshotItems = append(rs.snapshotItems, snapshot.Items...)
+	rs.snapshotRetry = 0
+	if len(rs.snapshotItems) < int(rs.snapshot.ItemsCount) && resp.Result != quorumpb.ReqSnapshotResult_SNAPSHOT_ON_TOP {
+		return
+	}
+
+	//all items received, verify and apply
+	if valid, err := rumchaindata.ValidSnapshotItems(rs.snapshot, rs.snapshotItems); !valid {
+		rex_syncer_log.Warningf("<%s> invalid snapshot items, error <%v>", rs.GroupId, err)
+		rs.resetSnapshot()
+		rs.retrySnapshot()
+		return
+	}
+
+	if err := rs.chainCtx.ApplySnapshot(rs.snapshot, rs.snapshotBlock, rs.snapshotItems); err != nil {
+		rex_syncer_log.Warningf("<%s> apply snapshot failed with error <%s>", rs.GroupId, err.Error())
+	}
+	rs.stopSnapshotSync()
+}
+
+func (rs *RexSyncer) retrySnapshot() {
+	rs.snapshotRetry += 1
+	if rs.snapshotRetry >= SNAPSHOT_RETRY_NUM {
+		rex_syncer_log.Infof("<%s> no valid snapshot after <%d> retries, sync blocks from genesis", rs.GroupId, rs.snapshotRetry)
+		rs.stopSnapshotSync()
+	}
+}
+
+func (rs *RexSyncer) resetSnapshot() {
+	rs.snapshot = nil
+	rs.snapshotBlock = nil
+	rs.snapshotItems = nil
+}
+
+func (rs *RexSyncer) stopSnapshotSync() {
+	rs.snapshotSync = false
+	rs.snapshotRetry = 0
+	rs.resetSnapshot()
 }
@@ -0,0 +1,235 @@
+package chain
+
+import (
+	"encoding/hex"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/rumsystem/quorum/internal/pkg/conn"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+var SNAPSHOT_INTERVAL = uint64(0) // write a signed state snapshot every n blocks, 0 means never
+var PRUNE_KEEP_BLOCKS = uint64(0) // keep the latest n blocks and drop older block bodies, 0 means keep all blocks
+
+// UpdSnapshot writes a state snapshot and prunes old blocks if needed, should be called after all trxs in the current block are applied
+func (chain *Chain) UpdSnapshot() {
+	if nodectx.GetNodeCtx().NodeType != nodectx.FULL_NODE {
+		return
+	}
+
+	if SNAPSHOT_INTERVAL == 0 && PRUNE_KEEP_BLOCKS == 0 {
+		return
+	}
+
+	groupId := chain.groupItem.GroupId
+	currBlockId := chain.GetCurrBlockId()
+
+	snapshot := chain.snapshotHeader
+	if snapshot == nil {
+		latest, err := nodectx.GetNodeCtx().GetChainStorage().GetSnapshot(groupId, chain.nodename)
+		if err != nil {
+			chain_log.Warningf("<%s> get snapshot failed with error <%s>", groupId, err.Error())
+			return
+		}
+		snapshot = chain.setSnapshotHeader(latest)
+	}
+
+	//only snapshots signed by producers are accepted, other fullnodes serve the snapshot they bootstrapped from
+	if SNAPSHOT_INTERVAL > 0 && chain.isProducer() {
+		lastSnapshotBlock := uint64(0)
+		if snapshot != nil {
+			lastSnapshotBlock = snapshot.BlockId
+		}
+
+		if currBlockId >= lastSnapshotBlock+SNAPSHOT_INTERVAL {
+			newSnapshot, err := chain.createSnapshot(currBlockId)
+			if err != nil {
+				chain_log.Warningf("<%s> create snapshot at block <%d> failed with error <%s>", groupId, currBlockId, err.Error())
+			} else {
+				chain_log.Infof("<%s> snapshot created at block <%d>, <%d> items", groupId, currBlockId, newSnapshot.ItemsCount)
+				snapshot = chain.setSnapshotHeader(newSnapshot)
+			}
+		}
+	}
+
+	if PRUNE_KEEP_BLOCKS > 0 && currBlockId+1 > PRUNE_KEEP_BLOCKS {
+		pruneTo := currBlockId + 1 - PRUNE_KEEP_BLOCKS
+		//keep blocks from the snapshot height, new nodes bootstrapped by the snapshot sync them
+		if SNAPSHOT_INTERVAL > 0 && snapshot != nil && pruneTo > snapshot.BlockId {
+			pruneTo = snapshot.BlockId
+		}
+
+		pruned, err := nodectx.GetNodeCtx().GetChainStorage().PruneBlocks(groupId, pruneTo, chain.nodename)
+		if err != nil {
+			chain_log.Warningf("<%s> prune blocks before <%d> failed with error <%s>", groupId, pruneTo, err.Error())
+		} else if pruned > 0 {
+			chain_log.Debugf("<%s> <%d> blocks before <%d> pruned", groupId, pruned, pruneTo)
+		}
+	}
+}
+
+// keep the header of the latest snapshot in memory, items are only loaded when serving the snapshot
+func (chain *Chain) setSnapshotHeader(snapshot *quorumpb.Snapshot) *quorumpb.Snapshot {
+	if snapshot == nil {
+		chain.snapshotHeader = nil
+		return nil
+	}
+
+	chain.snapshotHeader = &quorumpb.Snapshot{
+		GroupId:      snapshot.GroupId,
+		BlockId:      snapshot.BlockId,
+		Epoch:        snapshot.Epoch,
+		BlockHash:    snapshot.BlockHash,
+		ItemsCount:   snapshot.ItemsCount,
+		StateHash:    snapshot.StateHash,
+		SenderPubkey: snapshot.SenderPubkey,
+		TimeStamp:    snapshot.TimeStamp,
+		SnapshotHash: snapshot.SnapshotHash,
+		SenderSign:   snapshot.SenderSign,
+	}
+	return chain.snapshotHeader
+}
+
+func (chain *Chain) createSnapshot(blockId uint64) (*quorumpb.Snapshot, error) {
+	chain_log.Debugf("<%s> createSnapshot called, block <%d>", chain.groupItem.GroupId, blockId)
+
+	block, err := nodectx.GetNodeCtx().GetChainStorage().GetBlock(chain.groupItem.GroupId, blockId, false, chain.nodename)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := nodectx.GetNodeCtx().GetChainStorage().GetStateItems(chain.groupItem.GroupId, chain.nodename)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := localcrypto.GetKeystore()
+	snapshot, err := rumchaindata.CreateSnapshotByEthKey(block, items, chain.groupItem.UserSignPubkey, ks, "", chain.nodename)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := nodectx.GetNodeCtx().GetChainStorage().SaveSnapshot(snapshot, chain.nodename); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// ApplySnapshot replaces the group state with a verified snapshot and moves the chain to the snapshot height
+func (chain *Chain) ApplySnapshot(snapshot *quorumpb.Snapshot, block *quorumpb.Block, items []*quorumpb.SnapshotItem) error {
+	groupId := chain.groupItem.GroupId
+	chain_log.Infof("<%s> apply snapshot at block <%d>, <%d> items", groupId, snapshot.BlockId, len(items))
+
+	cs := nodectx.GetNodeCtx().GetChainStorage()
+	if err := cs.ApplyStateItems(groupId, items, chain.nodename); err != nil {
+		return err
+	}
+
+	if err := cs.AddBlock(block, false, chain.nodename); err != nil && err != rumerrors.ErrBlockExist {
+		return err
+	}
+
+	//blocks before the snapshot height are not synced
+	if err := cs.SetLowestBlockId(groupId, block.BlockId, chain.nodename); err != nil {
+		return err
+	}
+
+	//keep the snapshot, so this node can serve it as well
+	snapshot.Items = items
+	if err := cs.SaveSnapshot(snapshot, chain.nodename); err != nil {
+		return err
+	}
+	chain.setSnapshotHeader(snapshot)
+
+	chain.SetCurrBlockId(block.BlockId)
+	chain.SetCurrEpoch(block.Epoch)
+	chain.SetLastUpdate(block.TimeStamp)
+	if err := chain.SaveChainInfoToDb(); err != nil {
+		return err
+	}
+
+	chain.reloadState()
+	return nil
+}
+
+// reloadState reloads the producer and user list after the state in db replaced
+func (chain *Chain) reloadState() {
+	chain.updProducerList()
+	chain.updAnnouncedProducerStatus()
+	chain.updProducerConfig()
+	chain.updUserList()
+}
+
+// should the chain bootstrap from a state snapshot instead of syncing all blocks from genesis
+func (chain *Chain) needSnapshotSync() bool {
+	return nodectx.GetNodeCtx().NodeType == nodectx.FULL_NODE && PRUNE_KEEP_BLOCKS > 0 && chain.GetCurrBlockId() == 0
+}
+
+func (chain *Chain) handleReqSnapshot(trx *quorumpb.Trx, s network.Stream) error {
+	chain_log.Debugf("<%s> handleReqSnapshot called", chain.groupItem.GroupId)
+	requester, fromItem, snapshot, block, result, err := chain.chaindata.GetReqSnapshot(trx)
+	if err != nil {
+		return err
+	}
+
+	chain_log.Debugf("<%s> send REQ_SNAPSHOT_RESP", chain.groupItem.GroupId)
+	chain_log.Debugf("-- requester <%s>, from item <%d>, status <%s>", requester, fromItem, result.String())
+
+	trx, err = chain.trxFactory.GetReqSnapshotRespTrx("", chain.groupItem.GroupId, requester, fromItem, snapshot, block, result)
+	if err != nil {
+		return err
+	}
+
+	if cmgr, err := conn.GetConn().GetConnMgr(chain.groupItem.GroupId); err != nil {
+		return err
+	} else {
+		return cmgr.SendRespTrxRex(trx, s)
+	}
+}
+
+func (chain *Chain) handleReqSnapshotResp(trx *quorumpb.Trx) {
+	chain_log.Debugf("<%s> handleReqSnapshotResp called", chain.groupItem.GroupId)
+
+	//decode resp
+	ciperKey, err := hex.DecodeString(chain.groupItem.CipherKey)
+	if err != nil {
+		chain_log.Warningf("<%s> HandleReqSnapshotResp error <%s>", chain.groupItem.GroupId, err.Error())
+		return
+	}
+
+	decryptData, err := localcrypto.AesDecode(trx.Data, ciperKey)
+	if err != nil {
+		chain_log.Warningf("<%s> HandleReqSnapshotResp error <%s>", chain.groupItem.GroupId, err.Error())
+		return
+	}
+
+	reqSnapshotResp := &quorumpb.ReqSnapshotResp{}
+	if err := proto.Unmarshal(decryptData, reqSnapshotResp); err != nil {
+		chain_log.Warningf("<%s> HandleReqSnapshotResp error <%s>", chain.groupItem.GroupId, err.Error())
+		return
+	}
+
+	//if not asked by me, ignore it
+	if reqSnapshotResp.RequesterPubkey != chain.groupItem.UserSignPubkey {
+		return
+	}
+
+	//check trx sender
+	if trx.SenderPubkey != reqSnapshotResp.ProviderPubkey {
+		chain_log.Debugf("<%s> HandleReqSnapshotResp - Trx Sender/snapshot provider mismatch", chain.groupItem.GroupId)
+		return
+	}
+
+	result := &SyncResult{
+		TaskId: uint64(reqSnapshotResp.FromItem),
+		Type:   SyncSnapshot,
+		Data:   reqSnapshotResp,
+	}
+
+	chain.rexSyncer.AddResult(result)
+}
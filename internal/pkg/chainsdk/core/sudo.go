@@ -0,0 +1,116 @@
+package chain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rumsystem/quorum/internal/pkg/conn"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
+	"github.com/rumsystem/quorum/pkg/consensus"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// CreateSudoBlock packages the pending trxs into a sudo block signed by the owner after the current block,
+// applies it and broadcasts it to the group. Sudo blocks are produced out of the consensus, the owner uses them to
+// recover a group whose producers are gone or stuck, e.g. with a PRODUCER trx adding new producers.
+// A trx is pending if it is sent by this node and not packaged yet, or it is in the trx buffer of this node.
+func (chain *Chain) CreateSudoBlock(trxIds []string) (*quorumpb.Block, error) {
+	groupId := chain.groupItem.GroupId
+	if !chain.isOwner() {
+		return nil, rumerrors.ErrOnlyGroupOwner
+	}
+	if len(trxIds) == 0 {
+		return nil, errors.New("no trx to package")
+	}
+
+	cs := nodectx.GetNodeCtx().GetChainStorage()
+	buffer := consensus.NewTrxBuffer(groupId, chain.nodename)
+	packaged := make(map[string]bool)
+	var trxs []*quorumpb.Trx
+	for _, trxId := range trxIds {
+		if packaged[trxId] {
+			continue
+		}
+		packaged[trxId] = true
+
+		exist, err := cs.IsTrxExist(groupId, trxId, chain.nodename)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			return nil, fmt.Errorf("trx %s is already on chain", trxId)
+		}
+		trx, err := chain.getPendingTrx(buffer, trxId)
+		if err != nil {
+			return nil, err
+		}
+		//VerifyTrx may modify the recovery id of the sign
+		if valid, err := rumchaindata.VerifyTrx(proto.Clone(trx).(*quorumpb.Trx)); !valid {
+			return nil, fmt.Errorf("trx %s is invalid: %v", trxId, err)
+		}
+		trxs = append(trxs, trx)
+	}
+
+	parent, err := cs.GetBlock(groupId, chain.GetCurrBlockId(), false, chain.nodename)
+	if err != nil {
+		return nil, err
+	}
+	epoch := chain.GetCurrEpoch()
+	if parent.Epoch > epoch {
+		epoch = parent.Epoch
+	}
+	version, err := cs.GetBlockVersionByGroupId(groupId, epoch+1, chain.nodename)
+	if err != nil {
+		return nil, err
+	}
+	ks := localcrypto.GetKeystore()
+	block, err := rumchaindata.CreateBlockByEthKey(parent, epoch+1, version, trxs, true, chain.groupItem.UserSignPubkey, ks, "", chain.nodename)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := chain.ApplyBlocks([]*quorumpb.Block{block}); err != nil {
+		return nil, err
+	}
+	if exist, err := cs.IsBlockExist(groupId, block.BlockId, false, chain.nodename); err != nil || !exist {
+		return nil, fmt.Errorf("sudo block %d is not applied: %v", block.BlockId, err)
+	}
+	for _, trx := range trxs {
+		buffer.Delete(trx.TrxId)
+	}
+	chain_log.Warningf("<%s> sudo block <%d> with <%d> trxs created by owner", groupId, block.BlockId, len(trxs))
+
+	connMgr, err := conn.GetConn().GetConnMgr(groupId)
+	if err != nil {
+		return nil, err
+	}
+	if err := connMgr.BroadcastBlock(block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (chain *Chain) getPendingTrx(buffer *consensus.TrxBuffer, trxId string) (*quorumpb.Trx, error) {
+	item, err := chain.trxTracker.GetStatus(trxId)
+	if err != nil {
+		return nil, err
+	}
+	if item != nil && item.Status == chainstorage.TrxStatusPending && len(item.Trx) > 0 {
+		trx := &quorumpb.Trx{}
+		if err := proto.Unmarshal(item.Trx, trx); err != nil {
+			return nil, err
+		}
+		return trx, nil
+	}
+
+	trx, err := buffer.GetTrxById(trxId)
+	if err != nil || trx == nil || trx.GroupId != chain.groupItem.GroupId {
+		return nil, fmt.Errorf("trx %s is not pending on this node", trxId)
+	}
+	return trx, nil
+}
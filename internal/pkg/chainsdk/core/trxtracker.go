@@ -0,0 +1,228 @@
+package chain
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rumsystem/quorum/internal/pkg/conn"
+	"github.com/rumsystem/quorum/internal/pkg/logging"
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+var trxtracker_log = logging.Logger("trxtracker")
+
+var TRX_RESEND_INTERVAL = 10 * time.Second //resend a pending trx if it is not packaged in the interval
+var TRX_RESEND_MAX = int64(3)              //max resend count of a trx
+var TRX_STATUS_KEEP = 24 * time.Hour       //keep the final status of a trx for a while
+var trxTrackerCheckInterval = 2 * time.Second
+
+// TrxTracker tracks the trxs sent by this node, resends them until they are packaged or expired
+type TrxTracker struct {
+	groupId  string
+	nodename string
+	mu       sync.Mutex
+	pending  map[string]*chainstorage.TrxStatusItem
+	stop     chan struct{}
+	send     func(trx *quorumpb.Trx) error
+}
+
+func NewTrxTracker(groupId string, nodename string) *TrxTracker {
+	return &TrxTracker{
+		groupId:  groupId,
+		nodename: nodename,
+		pending:  make(map[string]*chainstorage.TrxStatusItem),
+		send:     sendUserTrx,
+	}
+}
+
+func sendUserTrx(trx *quorumpb.Trx) error {
+	connMgr, err := conn.GetConn().GetConnMgr(trx.GroupId)
+	if err != nil {
+		return err
+	}
+	return connMgr.SendUserTrxPubsub(trx)
+}
+
+// Start loads the pending trxs saved before and starts the resend loop
+func (t *TrxTracker) Start() {
+	items, err := nodectx.GetNodeCtx().GetChainStorage().GetAllTrxStatus(t.groupId, t.nodename)
+	if err != nil {
+		trxtracker_log.Warnf("<%s> load trx status failed <%s>", t.groupId, err.Error())
+	}
+
+	now := time.Now().UnixNano()
+	t.mu.Lock()
+	for _, item := range items {
+		if item.IsFinal() {
+			if now-item.UpdatedAt > TRX_STATUS_KEEP.Nanoseconds() {
+				nodectx.GetNodeCtx().GetChainStorage().RemoveTrxStatus(t.groupId, item.TrxId, t.nodename)
+			}
+			continue
+		}
+		t.pending[item.TrxId] = item
+	}
+	if t.stop == nil {
+		t.stop = make(chan struct{})
+		go t.run(t.stop)
+	}
+	t.mu.Unlock()
+}
+
+func (t *TrxTracker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stop != nil {
+		close(t.stop)
+		t.stop = nil
+	}
+}
+
+func (t *TrxTracker) run(stop chan struct{}) {
+	ticker := time.NewTicker(trxTrackerCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			t.check(time.Now().UnixNano())
+		}
+	}
+}
+
+// Send sends the trx and tracks it, trx.Data is compressed by the sender, so a copy is kept for resend
+func (t *TrxTracker) Send(trx *quorumpb.Trx) error {
+	trxBytes, err := proto.Marshal(trx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UnixNano()
+	item := &chainstorage.TrxStatusItem{
+		TrxId:     trx.TrxId,
+		GroupId:   trx.GroupId,
+		Type:      trx.Type.String(),
+		Status:    chainstorage.TrxStatusPending,
+		TimeStamp: trx.TimeStamp,
+		Expired:   trx.Expired,
+		LastSent:  now,
+		UpdatedAt: now,
+		Trx:       trxBytes,
+	}
+
+	sendErr := t.send(trx)
+	if sendErr != nil {
+		item.Status = chainstorage.TrxStatusFailed
+		item.Error = sendErr.Error()
+		item.Trx = nil
+	}
+
+	t.mu.Lock()
+	if !item.IsFinal() {
+		t.pending[item.TrxId] = item
+	}
+	err = t.save(item)
+	t.mu.Unlock()
+	if err != nil {
+		trxtracker_log.Warnf("<%s> save trx <%s> status failed <%s>", t.groupId, trx.TrxId, err.Error())
+	}
+	return sendErr
+}
+
+// MarkOnChain marks the tracked trxs in an applied block as on chain
+func (t *TrxTracker) MarkOnChain(trxs []*quorumpb.Trx) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.pending) == 0 {
+		return
+	}
+	for _, trx := range trxs {
+		item, ok := t.pending[trx.TrxId]
+		if !ok {
+			continue
+		}
+		trxtracker_log.Debugf("<%s> trx <%s> on chain, resend count <%d>", t.groupId, trx.TrxId, item.ResendCount)
+		t.finish(item, chainstorage.TrxStatusOnChain, "")
+	}
+}
+
+// GetStatus returns the status of a trx sent by this node, nil if the trx is not tracked
+func (t *TrxTracker) GetStatus(trxId string) (*chainstorage.TrxStatusItem, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if item, ok := t.pending[trxId]; ok {
+		copied := *item
+		return &copied, nil
+	}
+	return nodectx.GetNodeCtx().GetChainStorage().GetTrxStatus(t.groupId, trxId, t.nodename)
+}
+
+func (t *TrxTracker) check(now int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, item := range t.pending {
+		trx := &quorumpb.Trx{}
+		if err := proto.Unmarshal(item.Trx, trx); err != nil {
+			t.finish(item, chainstorage.TrxStatusFailed, err.Error())
+			continue
+		}
+
+		//packaged, but the block is applied before the trx is tracked
+		isExist, _ := nodectx.GetNodeCtx().GetChainStorage().IsTrxExist(t.groupId, trx.TrxId, t.nodename)
+		if isExist {
+			t.finish(item, chainstorage.TrxStatusOnChain, "")
+			continue
+		}
+
+		if rumchaindata.IsTrxExpired(trx, now) {
+			trxtracker_log.Debugf("<%s> trx <%s> expired, resend count <%d>", t.groupId, trx.TrxId, item.ResendCount)
+			t.finish(item, chainstorage.TrxStatusExpired, "")
+			continue
+		}
+
+		if now-item.LastSent < TRX_RESEND_INTERVAL.Nanoseconds() {
+			continue
+		}
+
+		if item.ResendCount >= TRX_RESEND_MAX {
+			//trx never expires, give up after the last resend interval
+			if trx.Expired == 0 {
+				t.finish(item, chainstorage.TrxStatusFailed, "not packaged after all resends")
+			}
+			continue
+		}
+
+		item.ResendCount++
+		item.LastSent = now
+		item.UpdatedAt = now
+		trx.ResendCount = item.ResendCount
+		trxtracker_log.Debugf("<%s> resend trx <%s>, resend count <%d>", t.groupId, trx.TrxId, item.ResendCount)
+		if err := t.send(trx); err != nil {
+			item.Error = err.Error()
+			trxtracker_log.Warnf("<%s> resend trx <%s> failed <%s>", t.groupId, trx.TrxId, err.Error())
+		}
+		if err := t.save(item); err != nil {
+			trxtracker_log.Warnf("<%s> save trx <%s> status failed <%s>", t.groupId, trx.TrxId, err.Error())
+		}
+	}
+}
+
+// finish should be called with t.mu held
+func (t *TrxTracker) finish(item *chainstorage.TrxStatusItem, status chainstorage.TrxStatus, errmsg string) {
+	delete(t.pending, item.TrxId)
+	item.Status = status
+	item.Error = errmsg
+	item.Trx = nil
+	item.UpdatedAt = time.Now().UnixNano()
+	if err := t.save(item); err != nil {
+		trxtracker_log.Warnf("<%s> save trx <%s> status failed <%s>", t.groupId, item.TrxId, err.Error())
+	}
+}
+
+func (t *TrxTracker) save(item *chainstorage.TrxStatusItem) error {
+	return nodectx.GetNodeCtx().GetChainStorage().SaveTrxStatus(item, t.nodename)
+}
@@ -10,7 +10,10 @@ type TrxFactoryIface interface {
 	GetRegProducerBundleTrx(keyalias string, item *quorumpb.BFTProducerBundleItem) (*quorumpb.Trx, error)
 	GetUpdAppConfigTrx(keyalias string, item *quorumpb.AppConfigItem) (*quorumpb.Trx, error)
 	GetRegUserTrx(keyalias string, item *quorumpb.UserItem) (*quorumpb.Trx, error)
+	GetStakeTrx(keyalias string, item *quorumpb.StakeItem) (*quorumpb.Trx, error)
 	GetPostAnyTrx(keyalias string, content []byte, encryptto ...[]string) (*quorumpb.Trx, error)
 	GetReqBlocksTrx(keyalias string, groupId string, fromBlock uint64, blkReq int32) (*quorumpb.Trx, error)
 	GetReqBlocksRespTrx(keyalias string, groupId string, requester string, fromBlock uint64, blkReq int32, blocks []*quorumpb.Block, result quorumpb.ReqBlkResult) (*quorumpb.Trx, error)
+	GetReqSnapshotTrx(keyalias string, groupId string, fromItem int32, stateHash []byte) (*quorumpb.Trx, error)
+	GetReqSnapshotRespTrx(keyalias string, groupId string, requester string, fromItem int32, snapshot *quorumpb.Snapshot, block *quorumpb.Block, result quorumpb.ReqSnapshotResult) (*quorumpb.Trx, error)
 }
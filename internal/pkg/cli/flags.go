@@ -2,6 +2,7 @@ package cli
 
 import (
 	"strings"
+	"time"
 
 	maddr "github.com/multiformats/go-multiaddr"
 )
@@ -27,6 +28,14 @@ type FullNodeFlag struct {
 	KeyStoreName     string
 	KeyStorePwd      string
 	EnableRelay      bool
+	DbBackend        string
+	KeepBlocks       uint64
+	SnapshotInterval uint64
+	SearchIndex      bool
+	BufferSenderMax  int
+	BufferGroupMax   int
+	TrxExpire        time.Duration
+	Signer           string
 }
 
 // TBD remove unused flags
@@ -94,6 +103,10 @@ type ProducerNodeFlag struct {
 	KeyStoreDir      string
 	KeyStoreName     string
 	KeyStorePwd      string
+	DbBackend        string
+	BufferSenderMax  int
+	BufferGroupMax   int
+	TrxExpire        time.Duration
 }
 
 func (al *AddrList) String() string {
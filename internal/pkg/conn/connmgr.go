@@ -11,6 +11,7 @@ import (
 	logging "github.com/ipfs/go-log/v2"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	chaindef "github.com/rumsystem/quorum/internal/pkg/chainsdk/def"
 	"github.com/rumsystem/quorum/internal/pkg/conn/pubsubconn"
 	"github.com/rumsystem/quorum/internal/pkg/nodectx"
@@ -213,30 +214,78 @@ func (connMgr *ConnMgr) SendReqTrxRex(trx *quorumpb.Trx) error {
 		return errors.New("RumExchange is nil, please set enablerumexchange as true")
 	}
 
-	// compress trx.Data
+	rummsg, err := getRexTrxMsg(trx)
+	if err != nil {
+		return err
+	}
+
+	psconn := connMgr.getUserConn()
+	if psconn == nil {
+		return fmt.Errorf("no user conn for %s. (can be ignored)", connMgr.GroupId)
+	}
+	channelpeers := psconn.Topic.ListPeers()
+	return nodectx.GetNodeCtx().Node.RumExchange.Publish(trx.GroupId, channelpeers, rummsg)
+}
+
+// SendReqTrxRexToPeer sends the req trx to the given peer only
+func (connMgr *ConnMgr) SendReqTrxRexToPeer(trx *quorumpb.Trx, pid peer.ID) error {
+	conn_log.Debugf("<%s> SendReqTrxRexToPeer called, peer <%s>", connMgr.GroupId, pid)
+	if nodectx.GetNodeCtx().Node.RumExchange == nil {
+		return errors.New("RumExchange is nil, please set enablerumexchange as true")
+	}
+
+	rummsg, err := getRexTrxMsg(trx)
+	if err != nil {
+		return err
+	}
+	return nodectx.GetNodeCtx().Node.RumExchange.PublishToPeerId(rummsg, pid.String())
+}
+
+// GetSyncPeers returns at most n peers of the group to sync blocks from, ranked by the block provider score
+func (connMgr *ConnMgr) GetSyncPeers(n int) []peer.ID {
+	if nodectx.GetNodeCtx().Node.RumExchange == nil {
+		return nil
+	}
+
+	psconn := connMgr.getUserConn()
+	if psconn == nil {
+		return nil
+	}
+	return nodectx.GetNodeCtx().Node.RumExchange.SyncPeers(psconn.Topic.ListPeers(), n)
+}
+
+// RewardBlockProvider raises the block provider score of the peer after its blocks are verified
+func (connMgr *ConnMgr) RewardBlockProvider(pid peer.ID, blocks uint64) {
+	if nodectx.GetNodeCtx().Node.RumExchange != nil {
+		nodectx.GetNodeCtx().Node.RumExchange.RewardBlockProvider(pid, blocks)
+	}
+}
+
+// PenalizePeer counts a bad response from the peer, peers with too many bad responses are not asked again
+func (connMgr *ConnMgr) PenalizePeer(pid peer.ID) {
+	if nodectx.GetNodeCtx().Node.RumExchange != nil {
+		nodectx.GetNodeCtx().Node.RumExchange.PenalizePeer(pid)
+	}
+}
+
+// compress trx data and wrap the trx into a rum exchange msg
+func getRexTrxMsg(trx *quorumpb.Trx) (*quorumpb.RumDataMsg, error) {
 	compressedContent := new(bytes.Buffer)
 	if err := utils.Compress(bytes.NewReader(trx.Data), compressedContent); err != nil {
-		return err
+		return nil, err
 	}
 	trx.Data = compressedContent.Bytes()
 
 	pbBytes, err := proto.Marshal(trx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	pkg := &quorumpb.Package{
 		Type: quorumpb.PackageType_TRX,
 		Data: pbBytes,
 	}
-	rummsg := &quorumpb.RumDataMsg{MsgType: quorumpb.RumDataMsgType_CHAIN_DATA, DataPackage: pkg}
-
-	psconn := connMgr.getUserConn()
-	if psconn == nil {
-		return fmt.Errorf("no user conn for %s. (can be ignored)", connMgr.GroupId)
-	}
-	channelpeers := psconn.Topic.ListPeers()
-	return nodectx.GetNodeCtx().Node.RumExchange.Publish(trx.GroupId, channelpeers, rummsg)
+	return &quorumpb.RumDataMsg{MsgType: quorumpb.RumDataMsgType_CHAIN_DATA, DataPackage: pkg}, nil
 }
 
 func (connMgr *ConnMgr) SendRespTrxRex(trx *quorumpb.Trx, s network.Stream) error {
@@ -6,6 +6,7 @@ package p2p
 import (
 	"context"
 	"fmt"
+	"time"
 
 	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
@@ -28,12 +29,17 @@ import (
 	"github.com/rumsystem/quorum/internal/pkg/cli"
 	"github.com/rumsystem/quorum/internal/pkg/options"
 	"github.com/rumsystem/quorum/internal/pkg/storage"
+	"github.com/rumsystem/quorum/pkg/autorelay/audit"
 )
 
+// interval to flush the relayed traffic to db and check the quotas
+const trafficAuditInterval = 10 * time.Second
+
 type RelayNode struct {
 	PeerID peer.ID
 	Host   host.Host
 	Info   *NodeInfo
+	Audit  *audit.QuorumTrafficAudit
 }
 
 func (node *RelayNode) GetRelay() *relayv2.Relay {
@@ -92,6 +98,8 @@ func NewRelayServiceNode(ctx context.Context, nodeOpt *options.RelayNodeOptions,
 		return nil, err
 	}
 
+	trafficAudit := audit.NewQuorumTrafficAudit(db, nodeOpt.Quota)
+
 	libp2poptions := []libp2p.Option{
 		routing,
 		libp2p.ListenAddrs(listenAddresses...),
@@ -104,9 +112,9 @@ func NewRelayServiceNode(ctx context.Context, nodeOpt *options.RelayNodeOptions,
 			libp2p.Transport(ws.New),
 		),
 		libp2p.DisableRelay(),
+		// the relay v2 has no audit option, the traffic is counted by the bandwidth reporter
+		libp2p.BandwidthReporter(trafficAudit),
 		libp2p.EnableRelayService(
-			//TODO: upgrade the WithAudit func
-			//relay.WithAudit(audit.NewQuorumTrafficAudit(db)),
 			relay.WithACL(NewQuorumRelayFilter(db)),
 			relay.WithResources(nodeOpt.RC),
 			relay.WithLimit(nil), /* double check, nodeOpt.RC.Limit should already be nil */
@@ -127,9 +135,10 @@ func NewRelayServiceNode(ctx context.Context, nodeOpt *options.RelayNodeOptions,
 
 	info := &NodeInfo{NATType: network.ReachabilityUnknown}
 
-	node := &RelayNode{Host: host, Info: info}
+	node := &RelayNode{Host: host, Info: info, Audit: trafficAudit}
 
 	go node.eventhandler(ctx)
+	go trafficAudit.Start(ctx, trafficAuditInterval)
 	return node, nil
 }
 
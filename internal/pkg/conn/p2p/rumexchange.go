@@ -200,6 +200,24 @@ func (r *RexService) Publish(groupid string, channelpeers []peer.ID, msg *quorum
 	return rumerrors.ErrNoPeersAvailable
 }
 
+// SyncPeers returns at most n peers to request blocks from, ranked by the block provider score
+func (r *RexService) SyncPeers(channelpeers []peer.ID, n int) []peer.ID {
+	if len(channelpeers) == 0 {
+		channelpeers = r.Host.Network().Peers()
+	}
+	return r.peerstore.rankBlockProviders(context.Background(), channelpeers, n)
+}
+
+// RewardBlockProvider adds the number of valid blocks provided by the peer to its block provider score
+func (r *RexService) RewardBlockProvider(pid peer.ID, blocks uint64) {
+	r.peerstore.Scorers().BlockProviderScorer().IncrementProcessedBlocks(pid, blocks)
+}
+
+// PenalizePeer records a bad response (no response in time or invalid data) from the peer
+func (r *RexService) PenalizePeer(pid peer.ID) {
+	r.peerstore.Scorers().BadResponsesScorer().Increment(pid)
+}
+
 func (r *RexService) HandleRumExchangeMsg(rummsg *quorumpb.RumDataMsg, s network.Stream) {
 	rumMsgSize := float64(metric.GetProtoSize(rummsg))
 	switch rummsg.MsgType {
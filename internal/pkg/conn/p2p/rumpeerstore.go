@@ -116,3 +116,12 @@ func trimPeers(peers []peer.ID, peersPercentage float64) []peer.ID {
 	limit = utils.Min(limit, uint64(len(peers)))
 	return peers[:limit]
 }
+
+// rankBlockProviders returns at most n good peers, peers with a higher block provider score are more likely to be ranked first
+func (rps *RumGroupPeerStore) rankBlockProviders(ctx context.Context, peers []peer.ID, n int) []peer.ID {
+	peers = rps.filterPeers(ctx, peers, 1.0)
+	if len(peers) > n {
+		peers = peers[:n]
+	}
+	return peers
+}
@@ -40,3 +40,27 @@ func TestTrimPeersKeepsMinimumAndCapsAtAvailablePeers(t *testing.T) {
 		t.Fatalf("expected cap at available peers, got %d: %v", len(trimmed), trimmed)
 	}
 }
+
+func TestRankBlockProvidersCapsAndDropsBadPeers(t *testing.T) {
+	rgp := NewRumGroupPeerStore()
+	pids := []peer.ID{"peer1", "peer2", "peer3", "peer4", "peer5"}
+
+	for i := 0; i < scorers.DefaultBadResponsesThreshold; i++ {
+		rgp.Scorers().BadResponsesScorer().Increment(pids[0])
+	}
+
+	ranked := rgp.rankBlockProviders(context.Background(), pids, 2)
+	if len(ranked) != 2 {
+		t.Fatalf("expected two providers, got %d: %v", len(ranked), ranked)
+	}
+	for _, pid := range ranked {
+		if pid == pids[0] {
+			t.Fatalf("bad peer %s was ranked: %v", pid, ranked)
+		}
+	}
+
+	ranked = rgp.rankBlockProviders(context.Background(), pids, 10)
+	if len(ranked) != 4 {
+		t.Fatalf("expected all four good peers, got %d: %v", len(ranked), ranked)
+	}
+}
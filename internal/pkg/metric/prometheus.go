@@ -87,4 +87,68 @@ var (
 		},
 		[]string{"action"},
 	)
+
+	ConsensusEpoch = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "consensus_epoch",
+			Help:      "Current consensus epoch of group",
+		},
+		[]string{"group_id"},
+	)
+
+	ConsensusEpochLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "consensus_epoch_latency_seconds",
+			Help:      "Time from epoch started to the decided set of epoch agreed",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 10),
+		},
+		[]string{"group_id"},
+	)
+
+	ConsensusRbcMsgCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "consensus_rbc_msg_total",
+			Help:      "The total number of RBC messages received",
+		},
+		[]string{"group_id", "type"},
+	)
+
+	ConsensusRbcDoneCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "consensus_rbc_done_total",
+			Help:      "The total number of RBC instances done of proposer",
+		},
+		[]string{"group_id", "proposer"},
+	)
+
+	ConsensusMissedProposalCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "consensus_missed_proposal_total",
+			Help:      "The total number of epochs decided without the proposal of proposer",
+		},
+		[]string{"group_id", "proposer"},
+	)
+
+	ConsensusEvidenceCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "consensus_evidence_total",
+			Help:      "The total number of producer misbehaviour found",
+		},
+		[]string{"group_id", "type"},
+	)
+
+	ChainForkCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "chain_fork_total",
+			Help:      "The total number of conflicting blocks found at an existing height",
+		},
+		[]string{"group_id", "resolution"},
+	)
 )
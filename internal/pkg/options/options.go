@@ -26,9 +26,10 @@ type NodeOptions struct {
 
 type (
 	JWT struct {
-		Key   string                  `json:"key" mapstructure:"key"`
-		Chain *JWTListItem            `json:"chain" mapstructure:"chain"`
-		Node  map[string]*JWTListItem `json:"node" mapstructure:"node"`
+		Key    string                  `json:"key" mapstructure:"key"`
+		Chain  *JWTListItem            `json:"chain" mapstructure:"chain"`
+		Node   map[string]*JWTListItem `json:"node" mapstructure:"node"`
+		Scoped *JWTListItem            `json:"scoped" mapstructure:"scoped"` // tokens with the scopes of the allow groups
 	}
 
 	JWTListItem struct {
@@ -218,3 +218,96 @@ func (opt *NodeOptions) IsValidNodeJWT(groupid, token string) bool {
 
 	return false
 }
+
+func (opt *NodeOptions) NewScopedJWT(name string, allowGroups []string, scopes []string, rateLimit int, exp time.Time) (string, error) {
+	opt.mu.Lock()
+	defer opt.mu.Unlock()
+
+	if len(allowGroups) == 0 || len(scopes) == 0 {
+		return "", errors.New("allow groups and scopes of scoped jwt can't be empty")
+	}
+	if opt.JWT.Scoped == nil {
+		opt.JWT.Scoped = &JWTListItem{}
+	}
+
+	token, err := utils.NewScopedJWTToken(name, allowGroups, scopes, rateLimit, opt.JWT.Key, exp)
+	if err != nil {
+		return "", err
+	}
+	opt.JWT.Scoped.Normal = append(opt.JWT.Scoped.Normal, &TokenItem{Remark: name, Token: token})
+
+	return token, opt.writeToconfig()
+}
+
+func (opt *NodeOptions) RevokeScopedJWT(token string) error {
+	opt.mu.Lock()
+	defer opt.mu.Unlock()
+
+	if opt.JWT.Scoped == nil {
+		return errors.New("not find jwt")
+	}
+
+	var tokens []*TokenItem
+	for _, v := range opt.JWT.Scoped.Normal {
+		if v.Token == token {
+			opt.JWT.Scoped.Revoke = append(opt.JWT.Scoped.Revoke, v)
+			continue
+		}
+		tokens = append(tokens, v)
+	}
+	opt.JWT.Scoped.Normal = tokens
+
+	return opt.writeToconfig()
+}
+
+func (opt *NodeOptions) RemoveScopedJWT(token string) error {
+	opt.mu.Lock()
+	defer opt.mu.Unlock()
+
+	if opt.JWT.Scoped == nil {
+		return errors.New("not find jwt")
+	}
+
+	tokens := []*TokenItem{}
+	for _, v := range opt.JWT.Scoped.Normal {
+		if v.Token == token {
+			continue
+		}
+		tokens = append(tokens, v)
+	}
+	opt.JWT.Scoped.Normal = tokens
+
+	tokens = []*TokenItem{}
+	for _, v := range opt.JWT.Scoped.Revoke {
+		if v.Token == token {
+			continue
+		}
+		tokens = append(tokens, v)
+	}
+	opt.JWT.Scoped.Revoke = tokens
+
+	return opt.writeToconfig()
+}
+
+func (opt *NodeOptions) IsValidScopedJWT(token string) bool {
+	if opt.JWT.Scoped == nil {
+		return false
+	}
+
+	ok, err := utils.IsJWTTokenValid(token, opt.JWT.Key)
+	if err != nil {
+		logger.Warnf("jwt is not valid: %s", err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	for _, v := range opt.JWT.Scoped.Normal {
+		if token == v.Token {
+			return true
+		}
+	}
+
+	return false
+}
@@ -124,9 +124,10 @@ func initConfigfile(dir, keyname string) error {
 	viper.SetDefault("ConnsHi", defaultConnsHi)
 	viper.SetDefault("SignKeyMap", map[string]string{})
 	viper.SetDefault("JWT", JWT{
-		Key:   utils.GetRandomStr(JWTKeyLength),
-		Chain: &JWTListItem{},
-		Node:  map[string]*JWTListItem{},
+		Key:    utils.GetRandomStr(JWTKeyLength),
+		Chain:  &JWTListItem{},
+		Node:   map[string]*JWTListItem{},
+		Scoped: &JWTListItem{},
 	})
 	viper.SetDefault("EnableSnapshot", true)
 	viper.SetDefault("EnablePubQue", true)
@@ -6,6 +6,7 @@ package options
 import (
 	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/relay"
 	"github.com/rumsystem/quorum/internal/pkg/utils"
@@ -18,9 +19,20 @@ type RelayNodeOptions struct {
 	NetworkName string
 	SignKeyMap  map[string]string
 	RC          relay.Resources `mapstructure:",remain"`
+	Quota       RelayQuotaOptions
 	mu          sync.RWMutex
 }
 
+// RelayQuotaOptions limits the bytes relayed for a peer in a window, 0 means unlimited.
+// The quota of a peer is its own limit or the limit of its tier (set by the relay api), or the default one
+type RelayQuotaOptions struct {
+	Window       time.Duration
+	DefaultLimit int64
+	Tiers        map[string]int64
+}
+
+const defaultRelayQuotaWindow = 24 * time.Hour
+
 func InitRelayNodeOptions(configdir, peername string) (*RelayNodeOptions, error) {
 	nodeopts, err := loadRelayNodeOptions(configdir, peername)
 	nodeopts.ConfigDir = configdir
@@ -86,6 +98,11 @@ func writeDefaultRelayNodeConfig(v *viper.Viper) error {
 	json.Unmarshal(rcBytes, &rcMap)
 
 	v.Set("RC", rcMap)
+	v.Set("Quota", map[string]interface{}{
+		"Window":       defaultRelayQuotaWindow.String(),
+		"DefaultLimit": 0,
+		"Tiers":        map[string]int64{},
+	})
 	return v.SafeWriteConfig()
 }
 
@@ -116,5 +133,18 @@ func loadRelayNodeOptions(dir string, keyname string) (*RelayNodeOptions, error)
 		options.RC = relay.DefaultResources()
 		options.RC.Limit = nil /* make it unlimit, so that it wont be a transient connection */
 	}
+
+	if v.Get("Quota") != nil {
+		err = v.UnmarshalKey("Quota", &options.Quota)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if options.Quota.Window <= 0 {
+		options.Quota.Window = defaultRelayQuotaWindow
+	}
+	if options.Quota.Tiers == nil {
+		options.Quota.Tiers = make(map[string]int64)
+	}
 	return options, nil
 }
@@ -2,6 +2,7 @@ package chainstorage
 
 import (
 	"errors"
+	"strconv"
 
 	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
 	s "github.com/rumsystem/quorum/internal/pkg/storage"
@@ -33,6 +34,58 @@ func (cs *Storage) GetBlock(groupId string, blockId uint64, cached bool, prefix
 	return cs.dbmgr.GetBlock(groupId, blockId, cached, prefix...)
 }
 
+// max blocks scanned back from the head to find a trx not in the index, the blocks saved before the index was added
+var TRX_BLOCK_SCAN_LIMIT uint64 = 1000
+
+// GetTrxBlock returns the block on chain packaged the trx, nil if it is not found
+func (cs *Storage) GetTrxBlock(groupId string, trxId string, head uint64, prefix ...string) (*quorumpb.Block, error) {
+	value, err := cs.dbmgr.Db.Get([]byte(s.GetTrxBlockKey(groupId, trxId, prefix...)))
+	if err != nil {
+		return nil, err
+	}
+	if value != nil {
+		blockId, err := strconv.ParseUint(string(value), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		block, err := cs.dbmgr.GetBlock(groupId, blockId, false, prefix...)
+		if err != nil {
+			return nil, err
+		}
+		if blockHasTrx(block, trxId) {
+			return block, nil
+		}
+	}
+
+	for blockId := head; blockId > 0 && head-blockId < TRX_BLOCK_SCAN_LIMIT; blockId-- {
+		exist, err := cs.dbmgr.IsBlockExist(groupId, blockId, false, prefix...)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			//pruned
+			break
+		}
+		block, err := cs.dbmgr.GetBlock(groupId, blockId, false, prefix...)
+		if err != nil {
+			return nil, err
+		}
+		if blockHasTrx(block, trxId) {
+			return block, nil
+		}
+	}
+	return nil, nil
+}
+
+func blockHasTrx(block *quorumpb.Block, trxId string) bool {
+	for _, trx := range block.Trxs {
+		if trx.TrxId == trxId {
+			return true
+		}
+	}
+	return false
+}
+
 // check if block exist
 func (cs *Storage) IsBlockExist(groupId string, blockId uint64, cached bool, prefix ...string) (bool, error) {
 	return cs.dbmgr.IsBlockExist(groupId, blockId, cached, prefix...)
@@ -0,0 +1,51 @@
+//go:build !js
+// +build !js
+
+package chainstorage
+
+import (
+	"testing"
+
+	s "github.com/rumsystem/quorum/internal/pkg/storage"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+func TestGetTrxBlock(t *testing.T) {
+	cs, dbMgr := newTestChainStorage(t)
+	owner := newTestSigner(t)
+	groupItem := &quorumpb.GroupItem{
+		GroupId:   "9f3b2c1d-4e5a-4b6c-8d7e-0a1b2c3d4e5f",
+		CipherKey: "71eff58163d557b609a15050a5f7561568eb8bb582156697ba9fc99ca9236582",
+	}
+
+	genesis := owner.newBlock(t, nil, groupItem.GroupId, 0, nil)
+	block1 := owner.newBlock(t, genesis, groupItem.GroupId, 1, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-1", quorumpb.TrxType_POST, []byte("one")),
+	})
+	block2 := owner.newBlock(t, block1, groupItem.GroupId, 2, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-2", quorumpb.TrxType_POST, []byte("two")),
+	})
+	for _, block := range []*quorumpb.Block{genesis, block1, block2} {
+		if err := cs.AddBlock(block, false, testNodename); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	block, err := cs.GetTrxBlock(groupItem.GroupId, "trx-1", 2, testNodename)
+	if err != nil || block == nil || block.BlockId != 1 {
+		t.Fatalf("expect trx-1 in block 1, got %+v, %v", block, err)
+	}
+
+	//blocks saved without the index are scanned back from the head
+	if err := dbMgr.Db.Delete([]byte(s.GetTrxBlockKey(groupItem.GroupId, "trx-2", testNodename))); err != nil {
+		t.Fatal(err)
+	}
+	block, err = cs.GetTrxBlock(groupItem.GroupId, "trx-2", 2, testNodename)
+	if err != nil || block == nil || block.BlockId != 2 {
+		t.Fatalf("expect trx-2 in block 2, got %+v, %v", block, err)
+	}
+
+	if block, err := cs.GetTrxBlock(groupItem.GroupId, "trx-unknown", 2, testNodename); err != nil || block != nil {
+		t.Errorf("expect unknown trx not found, got %+v, %v", block, err)
+	}
+}
@@ -2,9 +2,11 @@ package chainstorage
 
 import (
 	"errors"
+	"fmt"
 
 	s "github.com/rumsystem/quorum/internal/pkg/storage"
 	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
 	quorumpb "github.com/rumsystem/quorum/pkg/pb"
 	"google.golang.org/protobuf/proto"
 )
@@ -64,6 +66,25 @@ func (cs *Storage) UpdateChainConfig(data []byte, prefix ...string) (err error)
 		}
 
 		return cs.dbmgr.Db.Delete([]byte(key))
+	} else if item.Type == quorumpb.ChainConfigType_SET_PACKING_POLICY {
+		policyItem := &quorumpb.SetPackingPolicyItem{}
+		if err := proto.Unmarshal(item.Data, policyItem); err != nil {
+			return err
+		}
+
+		key := s.GetChainConfigPackingPolicyKey(item.GroupId, prefix...)
+		return cs.dbmgr.Db.Set([]byte(key), data)
+	} else if item.Type == quorumpb.ChainConfigType_SET_BLOCK_VERSION {
+		versionItem := &quorumpb.SetBlockVersionItem{}
+		if err := proto.Unmarshal(item.Data, versionItem); err != nil {
+			return err
+		}
+		if versionItem.Version > rumchaindata.BLOCK_VERSION_TRX_ROOT {
+			return fmt.Errorf("unsupported block version %d", versionItem.Version)
+		}
+
+		key := s.GetChainConfigBlockVersionKey(item.GroupId, prefix...)
+		return cs.dbmgr.Db.Set([]byte(key), data)
 	} else {
 		return errors.New("Unsupported ChainConfig type")
 	}
@@ -78,6 +99,10 @@ func (cs *Storage) GetTrxAuthModeByGroupId(groupId string, trxType quorumpb.TrxT
 	//if not in deny list, access granted
 	isExist, err := cs.dbmgr.Db.IsExist([]byte(key))
 	if !isExist {
+		//stake costs nothing, only the stakers allow-listed by the owner can stake by default
+		if trxType == quorumpb.TrxType_STAKE {
+			return quorumpb.TrxAuthMode_FOLLOW_ALW_LIST, nil
+		}
 		return quorumpb.TrxAuthMode_FOLLOW_DNY_LIST, nil
 	}
 
@@ -100,6 +125,60 @@ func (cs *Storage) GetTrxAuthModeByGroupId(groupId string, trxType quorumpb.TrxT
 	return trxAuthitem.Mode, nil
 }
 
+// GetPackingPolicyByGroupId returns nil if not specified by group owner
+func (cs *Storage) GetPackingPolicyByGroupId(groupId string, prefix ...string) (*quorumpb.SetPackingPolicyItem, error) {
+	key := s.GetChainConfigPackingPolicyKey(groupId, prefix...)
+	isExist, err := cs.dbmgr.Db.IsExist([]byte(key))
+	if err != nil || !isExist {
+		return nil, err
+	}
+
+	value, err := cs.dbmgr.Db.Get([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+
+	chainConfigItem := &quorumpb.ChainConfigItem{}
+	if err := proto.Unmarshal(value, chainConfigItem); err != nil {
+		return nil, err
+	}
+
+	policyItem := &quorumpb.SetPackingPolicyItem{}
+	if err := proto.Unmarshal(chainConfigItem.Data, policyItem); err != nil {
+		return nil, err
+	}
+	return policyItem, nil
+}
+
+// GetBlockVersionByGroupId returns the version of the blocks produced at the epoch,
+// rumchaindata.BLOCK_VERSION if not specified by group owner or not activated yet
+func (cs *Storage) GetBlockVersionByGroupId(groupId string, epoch uint64, prefix ...string) (uint32, error) {
+	key := s.GetChainConfigBlockVersionKey(groupId, prefix...)
+	isExist, err := cs.dbmgr.Db.IsExist([]byte(key))
+	if err != nil || !isExist {
+		return rumchaindata.BLOCK_VERSION, err
+	}
+
+	value, err := cs.dbmgr.Db.Get([]byte(key))
+	if err != nil {
+		return rumchaindata.BLOCK_VERSION, err
+	}
+
+	chainConfigItem := &quorumpb.ChainConfigItem{}
+	if err := proto.Unmarshal(value, chainConfigItem); err != nil {
+		return rumchaindata.BLOCK_VERSION, err
+	}
+
+	versionItem := &quorumpb.SetBlockVersionItem{}
+	if err := proto.Unmarshal(chainConfigItem.Data, versionItem); err != nil {
+		return rumchaindata.BLOCK_VERSION, err
+	}
+	if epoch < versionItem.FromEpoch {
+		return rumchaindata.BLOCK_VERSION, nil
+	}
+	return versionItem.Version, nil
+}
+
 func (cs *Storage) GetSendTrxAuthListByGroupId(groupId string, listType quorumpb.AuthListType, prefix ...string) ([]*quorumpb.ChainConfigItem, []*quorumpb.ChainSendTrxRuleListItem, error) {
 	var chainConfigList []*quorumpb.ChainConfigItem
 	var sendTrxRuleList []*quorumpb.ChainSendTrxRuleListItem
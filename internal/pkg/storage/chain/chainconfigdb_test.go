@@ -0,0 +1,41 @@
+//go:build !js
+// +build !js
+
+package chainstorage
+
+import (
+	"testing"
+
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBlockVersionByChainConfig(t *testing.T) {
+	cs, _ := newTestChainStorage(t)
+	groupId := "6d1f3a2b-8c4e-4f5a-9b7d-0e2c4a6b8d10"
+
+	setBlockVersion := func(version uint32, fromEpoch uint64) error {
+		data, _ := proto.Marshal(&quorumpb.SetBlockVersionItem{Version: version, FromEpoch: fromEpoch})
+		config, _ := proto.Marshal(&quorumpb.ChainConfigItem{GroupId: groupId, Type: quorumpb.ChainConfigType_SET_BLOCK_VERSION, Data: data})
+		return cs.UpdateChainConfig(config, testNodename)
+	}
+
+	if version, err := cs.GetBlockVersionByGroupId(groupId, 10, testNodename); err != nil || version != rumchaindata.BLOCK_VERSION_TRX_LIST {
+		t.Fatalf("expect version %d by default, got %d, %v", rumchaindata.BLOCK_VERSION_TRX_LIST, version, err)
+	}
+
+	if err := setBlockVersion(rumchaindata.BLOCK_VERSION_TRX_ROOT+1, 10); err == nil {
+		t.Errorf("unsupported block version should be rejected")
+	}
+	if err := setBlockVersion(rumchaindata.BLOCK_VERSION_TRX_ROOT, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	if version, _ := cs.GetBlockVersionByGroupId(groupId, 9, testNodename); version != rumchaindata.BLOCK_VERSION_TRX_LIST {
+		t.Errorf("expect version %d before the activate epoch, got %d", rumchaindata.BLOCK_VERSION_TRX_LIST, version)
+	}
+	if version, _ := cs.GetBlockVersionByGroupId(groupId, 10, testNodename); version != rumchaindata.BLOCK_VERSION_TRX_ROOT {
+		t.Errorf("expect version %d from the activate epoch, got %d", rumchaindata.BLOCK_VERSION_TRX_ROOT, version)
+	}
+}
@@ -8,8 +8,8 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
-func (cs *Storage) AddTrxHBB(trx *quorumpb.Trx, queueId string) error {
-	key := s.GetTrxHBBKey(queueId, trx.TrxId)
+func (cs *Storage) AddTrxHBB(trx *quorumpb.Trx, queueId string, prefix ...string) error {
+	key := s.GetTrxHBBKey(queueId, trx.TrxId, prefix...)
 	exist, err := cs.dbmgr.Db.IsExist([]byte(key))
 	if err != nil {
 		return err
@@ -27,9 +27,9 @@ func (cs *Storage) AddTrxHBB(trx *quorumpb.Trx, queueId string) error {
 	}
 }
 
-func (cs *Storage) GetAllTrxHBB(queueId string) ([]*quorumpb.Trx, error) {
+func (cs *Storage) GetAllTrxHBB(queueId string, prefix ...string) ([]*quorumpb.Trx, error) {
 	var trxs []*quorumpb.Trx
-	key := s.GetTrxHBBPrefix(queueId)
+	key := s.GetTrxHBBPrefix(queueId, prefix...)
 	err := cs.dbmgr.Db.PrefixForeach([]byte(key), func(k []byte, v []byte, err error) error {
 		if err != nil {
 			return err
@@ -46,16 +46,16 @@ func (cs *Storage) GetAllTrxHBB(queueId string) ([]*quorumpb.Trx, error) {
 	return trxs, err
 }
 
-func (cs *Storage) GeBufferedTrxLenHBB(queueId string) (int, error) {
-	trxs, err := cs.GetAllTrxHBB(queueId)
+func (cs *Storage) GeBufferedTrxLenHBB(queueId string, prefix ...string) (int, error) {
+	trxs, err := cs.GetAllTrxHBB(queueId, prefix...)
 	if err != nil {
 		return -1, err
 	}
 	return len(trxs), nil
 }
 
-func (cs *Storage) RemoveTrxHBB(trxId, queueId string) error {
-	key := s.GetTrxHBBKey(queueId, trxId)
+func (cs *Storage) RemoveTrxHBB(trxId, queueId string, prefix ...string) error {
+	key := s.GetTrxHBBKey(queueId, trxId, prefix...)
 	exist, err := cs.dbmgr.Db.IsExist([]byte(key))
 	if err != nil {
 		return err
@@ -69,14 +69,14 @@ func (cs *Storage) RemoveTrxHBB(trxId, queueId string) error {
 	return err
 }
 
-func (cs *Storage) RemoveAllTrxHBB(queueId string) error {
-	key_prefix := s.GetTrxHBBPrefix(queueId)
+func (cs *Storage) RemoveAllTrxHBB(queueId string, prefix ...string) error {
+	key_prefix := s.GetTrxHBBPrefix(queueId, prefix...)
 	_, err := cs.dbmgr.Db.PrefixDelete([]byte(key_prefix))
 	return err
 }
 
-func (cs *Storage) GetTrxByIdHBB(trxId string, queueId string) (*quorumpb.Trx, error) {
-	key := s.GetTrxHBBKey(queueId, trxId)
+func (cs *Storage) GetTrxByIdHBB(trxId string, queueId string, prefix ...string) (*quorumpb.Trx, error) {
+	key := s.GetTrxHBBKey(queueId, trxId, prefix...)
 
 	exist, err := cs.dbmgr.Db.IsExist([]byte(key))
 	if err != nil {
@@ -99,3 +99,32 @@ func (cs *Storage) GetTrxByIdHBB(trxId string, queueId string) (*quorumpb.Trx, e
 
 	return trx, nil
 }
+
+// MoveTrxHBB moves the trxs buffered before the buffer is kept by nodename to the buffer of the node,
+// it is safe to run again if the node is stopped in the middle
+func (cs *Storage) MoveTrxHBB(queueId string, prefix ...string) (int, error) {
+	if len(prefix) == 0 {
+		return 0, nil
+	}
+	oldPrefix := s.GetTrxHBBPrefix(queueId)
+	var keys, values [][]byte
+	err := cs.dbmgr.Db.PrefixForeach([]byte(oldPrefix), func(k []byte, v []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		trxId := string(k[len(oldPrefix):])
+		keys = append(keys, []byte(s.GetTrxHBBKey(queueId, trxId, prefix...)))
+		values = append(values, append([]byte(nil), v...))
+		return nil
+	})
+	if err != nil || len(keys) == 0 {
+		return 0, err
+	}
+	if err := cs.dbmgr.Db.BatchWrite(keys, values); err != nil {
+		return 0, err
+	}
+	if _, err := cs.dbmgr.Db.PrefixDelete([]byte(oldPrefix)); err != nil {
+		return 0, err
+	}
+	return len(keys), nil
+}
@@ -0,0 +1,41 @@
+//go:build !js
+// +build !js
+
+package chainstorage
+
+import (
+	"testing"
+
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+func TestMoveTrxHBB(t *testing.T) {
+	cs, _ := newTestChainStorage(t)
+	queueId := "3e9b7c1a-5d2f-4a8e-b6c0-1f4d7e2a9c53"
+
+	//buffered by older version, without nodename
+	for _, trxId := range []string{"trx-1", "trx-2"} {
+		if err := cs.AddTrxHBB(&quorumpb.Trx{TrxId: trxId, GroupId: queueId}, queueId); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cs.AddTrxHBB(&quorumpb.Trx{TrxId: "trx-3", GroupId: queueId}, queueId, testNodename); err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := cs.MoveTrxHBB(queueId, testNodename)
+	if err != nil || moved != 2 {
+		t.Fatalf("expect 2 trxs moved, got %d, %v", moved, err)
+	}
+	if trxs, _ := cs.GetAllTrxHBB(queueId, testNodename); len(trxs) != 3 {
+		t.Errorf("expect 3 trxs buffered by node, got %d", len(trxs))
+	}
+	if trxs, _ := cs.GetAllTrxHBB(queueId); len(trxs) != 0 {
+		t.Errorf("old buffer should be empty, got %d", len(trxs))
+	}
+
+	//nothing to move on next open
+	if moved, err := cs.MoveTrxHBB(queueId, testNodename); err != nil || moved != 0 {
+		t.Errorf("expect nothing moved, got %d, %v", moved, err)
+	}
+}
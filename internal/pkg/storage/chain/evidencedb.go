@@ -0,0 +1,39 @@
+package chainstorage
+
+import (
+	s "github.com/rumsystem/quorum/internal/pkg/storage"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// AddEvidence saves the evidence, returns false if it is saved before
+func (cs *Storage) AddEvidence(evidence *quorumpb.Evidence, prefix ...string) (bool, error) {
+	key := s.GetEvidenceKey(evidence.GroupId, evidence.EvidenceId, prefix...)
+	exist, err := cs.dbmgr.Db.IsExist([]byte(key))
+	if err != nil || exist {
+		return false, err
+	}
+
+	value, err := proto.Marshal(evidence)
+	if err != nil {
+		return false, err
+	}
+	return true, cs.dbmgr.Db.Set([]byte(key), value)
+}
+
+func (cs *Storage) GetEvidences(groupId string, prefix ...string) ([]*quorumpb.Evidence, error) {
+	var items []*quorumpb.Evidence
+	key := s.GetEvidencePrefix(groupId, prefix...)
+	err := cs.dbmgr.Db.PrefixForeach([]byte(key), func(k []byte, v []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		item := &quorumpb.Evidence{}
+		if err := proto.Unmarshal(v, item); err != nil {
+			return err
+		}
+		items = append(items, item)
+		return nil
+	})
+	return items, err
+}
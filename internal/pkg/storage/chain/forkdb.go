@@ -0,0 +1,153 @@
+package chainstorage
+
+import (
+	"encoding/json"
+	"fmt"
+
+	s "github.com/rumsystem/quorum/internal/pkg/storage"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+type ForkResolution string
+
+const (
+	ForkSwitched ForkResolution = "switched" //the block on chain is replaced, the state is rolled back and reapplied from the fork point
+	ForkKept     ForkResolution = "kept"     //both blocks are valid, the block on chain is kept
+	ForkRejected ForkResolution = "rejected" //the conflicting block is invalid
+	ForkFailed   ForkResolution = "failed"   //the block on chain should be replaced but the rollback failed
+)
+
+// ForkRecord is a block received with the BlockId of a block on chain but a different hash, and how it is resolved
+type ForkRecord struct {
+	GroupId        string         `json:"group_id"`
+	BlockId        uint64         `json:"block_id"`
+	LocalHash      string         `json:"local_hash"`
+	LocalProducer  string         `json:"local_producer"`
+	LocalIssue     string         `json:"local_issue,omitempty"`
+	RemoteHash     string         `json:"remote_hash"`
+	RemoteProducer string         `json:"remote_producer"`
+	RemoteIssue    string         `json:"remote_issue,omitempty"`
+	Resolution     ForkResolution `json:"resolution"`
+	Reason         string         `json:"reason"`
+	RolledBack     int            `json:"rolled_back"` //blocks after the fork point removed from chain
+	TimeStamp      int64          `json:"timestamp"`
+}
+
+// IsForkRecorded checks if the conflicting block is handled before
+func (cs *Storage) IsForkRecorded(groupId string, blockId uint64, blockHash string, prefix ...string) (bool, error) {
+	return cs.dbmgr.Db.IsExist([]byte(s.GetForkKey(groupId, blockId, blockHash, prefix...)))
+}
+
+func (cs *Storage) AddForkRecord(record *ForkRecord, prefix ...string) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return cs.dbmgr.Db.Set([]byte(s.GetForkKey(record.GroupId, record.BlockId, record.RemoteHash, prefix...)), value)
+}
+
+func (cs *Storage) GetForkRecords(groupId string, prefix ...string) ([]*ForkRecord, error) {
+	var items []*ForkRecord
+	key := s.GetForkPrefix(groupId, prefix...)
+	err := cs.dbmgr.Db.PrefixForeach([]byte(key), func(k []byte, v []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		item := &ForkRecord{}
+		if err := json.Unmarshal(v, item); err != nil {
+			return err
+		}
+		items = append(items, item)
+		return nil
+	})
+	return items, err
+}
+
+// VerifyBlocksAtHeight verifies the blocks with the same BlockId against the chain before them: the link to the parent
+// on chain, the block hash, the producer in effect at that height and the producer sign, and the sender sign of the trxs.
+// The producer list is rebuilt from the blocks on chain, a pruned chain starts with the current producer list as VerifyChain.
+func (cs *Storage) VerifyBlocksAtHeight(groupId string, blockId uint64, blocks []*quorumpb.Block, prefix ...string) ([]*ChainVerifyResult, error) {
+	if blockId == 0 {
+		return nil, fmt.Errorf("genesis block can't be verified against the chain")
+	}
+	groupItem, err := cs.GetGroupInfo(groupId)
+	if err != nil {
+		return nil, err
+	}
+	lowest, err := cs.GetLowestBlockId(groupId, prefix...)
+	if err != nil {
+		return nil, err
+	}
+
+	producers := newVerifyProducers(groupItem)
+	if producers.groupKeys, err = cs.GetGroupKeys(groupId, prefix...); err != nil {
+		return nil, err
+	}
+	producers.stakeAllowed = cs.isStakeAllowed(groupId, prefix...)
+	assumed := false
+	from := uint64(0)
+	if lowest > 1 {
+		if lowest >= blockId {
+			return nil, fmt.Errorf("blocks before %d are pruned", blockId)
+		}
+		from = lowest
+		assumed = true
+		if err := cs.assumeCurrentProducers(groupId, producers, prefix...); err != nil {
+			return nil, err
+		}
+	}
+
+	var parent *quorumpb.Block
+	for id := from; id < blockId; id++ {
+		exist, err := cs.dbmgr.IsBlockExist(groupId, id, false, prefix...)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, fmt.Errorf("block %d not found", id)
+		}
+		block, err := cs.dbmgr.GetBlock(groupId, id, false, prefix...)
+		if err != nil {
+			return nil, err
+		}
+		if id == 0 {
+			producers.add(block.ProducerPubkey)
+		} else {
+			producers.activate(block.Epoch)
+		}
+		//the blocks on chain are verified when added, only the producer list is updated
+		for _, trx := range block.Trxs {
+			if trx.Type == quorumpb.TrxType_PRODUCER {
+				producers.update(trx, groupItem, block.BlockId)
+			} else if trx.Type == quorumpb.TrxType_STAKE {
+				producers.updateStake(trx, groupItem, block.BlockId)
+			}
+		}
+		parent = block
+	}
+
+	results := make([]*ChainVerifyResult, 0, len(blocks))
+	for _, block := range blocks {
+		result := &ChainVerifyResult{
+			GroupId:          groupItem.GroupId,
+			GroupName:        groupItem.GroupName,
+			HeadBlock:        blockId,
+			LowestBlock:      lowest,
+			ProducersAssumed: assumed,
+			Ok:               true,
+			Issues:           []*ChainVerifyIssue{},
+		}
+		results = append(results, result)
+		if block.GroupId != groupId || block.BlockId != blockId {
+			result.addIssue(ChainIssueBadBlock, blockId, "", "block <%s:%d> is verified as <%s:%d>", block.GroupId, block.BlockId, groupId, blockId)
+			continue
+		}
+
+		result.BlocksChecked++
+		blockProducers := producers.clone()
+		blockProducers.activate(block.Epoch)
+		cs.verifyBlock(block, parent, blockProducers, result)
+		cs.verifyTrxs(block, groupItem, blockProducers, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,167 @@
+//go:build !js
+// +build !js
+
+package chainstorage
+
+import (
+	"encoding/hex"
+	"testing"
+
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestVerifyBlocksAtHeight(t *testing.T) {
+	cs, _ := newTestChainStorage(t)
+	owner := newTestSigner(t)
+	producer := newTestSigner(t)
+	stranger := newTestSigner(t)
+
+	groupItem := &quorumpb.GroupItem{
+		GroupId:     "7e2d4c6a-1b3f-4a5e-9c8d-0f1e2d3c4b5a",
+		GroupName:   "fork",
+		OwnerPubKey: owner.pubkey,
+		CipherKey:   "71eff58163d557b609a15050a5f7561568eb8bb582156697ba9fc99ca9236582",
+	}
+	genesis := owner.newBlock(t, nil, groupItem.GroupId, 0, nil)
+	groupItem.GenesisBlock = genesis
+	if err := cs.AddGroup(groupItem); err != nil {
+		t.Fatal(err)
+	}
+
+	//block 1 adds the producer, block 2 from the producer removes it
+	added, _ := proto.Marshal(&quorumpb.BFTProducerBundleItem{Producers: []*quorumpb.ProducerItem{
+		{GroupId: groupItem.GroupId, ProducerPubkey: producer.pubkey},
+	}})
+	removed, _ := proto.Marshal(&quorumpb.BFTProducerBundleItem{})
+	block1 := owner.newBlock(t, genesis, groupItem.GroupId, 1, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-add", quorumpb.TrxType_PRODUCER, added),
+	})
+	block2 := producer.newBlock(t, block1, groupItem.GroupId, 2, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-remove", quorumpb.TrxType_PRODUCER, removed),
+	})
+	for _, block := range []*quorumpb.Block{genesis, block1, block2} {
+		if err := cs.AddBlock(block, false, testNodename); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	//the producer is in effect at block 2, the stranger never is
+	results, err := cs.VerifyBlocksAtHeight(groupItem.GroupId, 2, []*quorumpb.Block{
+		block2,
+		stranger.newBlock(t, block1, groupItem.GroupId, 2, nil),
+		owner.newBlock(t, genesis, groupItem.GroupId, 2, nil),
+	}, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0].Ok {
+		t.Errorf("expect block 2 from producer valid, got %+v", results[0].Issues)
+	}
+	if results[1].Ok || !issueKinds(results[1])[ChainIssueUnknownProducer] {
+		t.Errorf("expect block 2 from stranger invalid, got %+v", results[1].Issues)
+	}
+	if results[2].Ok || !issueKinds(results[2])[ChainIssuePrevHash] {
+		t.Errorf("expect block 2 not linked to block 1 invalid, got %+v", results[2].Issues)
+	}
+
+	//the producer is removed by block 2, the owner is always a producer
+	results, err = cs.VerifyBlocksAtHeight(groupItem.GroupId, 3, []*quorumpb.Block{
+		producer.newBlock(t, block2, groupItem.GroupId, 3, nil),
+		owner.newBlock(t, block2, groupItem.GroupId, 3, nil),
+	}, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Ok || !results[1].Ok {
+		t.Errorf("expect block 3 from removed producer invalid and from owner valid, got %+v, %+v", results[0].Issues, results[1].Issues)
+	}
+
+	if _, err := cs.VerifyBlocksAtHeight(groupItem.GroupId, 5, []*quorumpb.Block{owner.newBlock(t, block2, groupItem.GroupId, 5, nil)}, testNodename); err == nil {
+		t.Errorf("expect block after a gap not verified")
+	}
+}
+
+func TestForkRecords(t *testing.T) {
+	cs, _ := newTestChainStorage(t)
+	groupId := "7e2d4c6a-1b3f-4a5e-9c8d-0f1e2d3c4b5a"
+	record := &ForkRecord{
+		GroupId:    groupId,
+		BlockId:    12,
+		LocalHash:  hex.EncodeToString([]byte("local")),
+		RemoteHash: hex.EncodeToString([]byte("remote")),
+		Resolution: ForkRejected,
+	}
+
+	if recorded, err := cs.IsForkRecorded(groupId, 12, record.RemoteHash, testNodename); err != nil || recorded {
+		t.Fatalf("expect fork not recorded, got %v, %v", recorded, err)
+	}
+	if err := cs.AddForkRecord(record, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if recorded, err := cs.IsForkRecorded(groupId, 12, record.RemoteHash, testNodename); err != nil || !recorded {
+		t.Errorf("expect fork recorded, got %v, %v", recorded, err)
+	}
+
+	records, err := cs.GetForkRecords(groupId, testNodename)
+	if err != nil || len(records) != 1 || records[0].Resolution != ForkRejected || records[0].BlockId != 12 {
+		t.Errorf("unexpected fork records %+v, %v", records, err)
+	}
+}
+
+func TestVerifySudoBlock(t *testing.T) {
+	cs, _ := newTestChainStorage(t)
+	owner := newTestSigner(t)
+	producer := newTestSigner(t)
+
+	groupItem := &quorumpb.GroupItem{
+		GroupId:     "0b7c3f5e-8d2a-4e61-b9c4-5a6d7e8f9012",
+		GroupName:   "sudo",
+		OwnerPubKey: owner.pubkey,
+		CipherKey:   "71eff58163d557b609a15050a5f7561568eb8bb582156697ba9fc99ca9236582",
+	}
+	genesis := owner.newBlock(t, nil, groupItem.GroupId, 0, nil)
+	groupItem.GenesisBlock = genesis
+	if err := cs.AddGroup(groupItem); err != nil {
+		t.Fatal(err)
+	}
+	added, _ := proto.Marshal(&quorumpb.BFTProducerBundleItem{Producers: []*quorumpb.ProducerItem{
+		{GroupId: groupItem.GroupId, ProducerPubkey: producer.pubkey},
+	}})
+	block1 := owner.newBlock(t, genesis, groupItem.GroupId, 1, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-add", quorumpb.TrxType_PRODUCER, added),
+	})
+	for _, block := range []*quorumpb.Block{genesis, block1} {
+		if err := cs.AddBlock(block, false, testNodename); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sudo := func(signer *testSigner) *quorumpb.Block {
+		block := &quorumpb.Block{
+			GroupId:        groupItem.GroupId,
+			BlockId:        2,
+			Epoch:          2,
+			PrevHash:       block1.BlockHash,
+			ProducerPubkey: signer.pubkey,
+			Sudo:           true,
+			TimeStamp:      3,
+		}
+		block.BlockHash, _ = rumchaindata.GetBlockHash(block)
+		block.ProducerSign = signer.sign(t, block.BlockHash)
+		return block
+	}
+
+	//only the owner can sign a sudo block, even if the producer is approved
+	results, err := cs.VerifyBlocksAtHeight(groupItem.GroupId, 2, []*quorumpb.Block{sudo(owner), sudo(producer)}, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0].Ok {
+		t.Errorf("expect sudo block from owner valid, got %+v", results[0].Issues)
+	}
+	if results[1].Ok || !issueKinds(results[1])[ChainIssueUnknownProducer] {
+		t.Errorf("expect sudo block from producer invalid, got %+v", results[1].Issues)
+	}
+}
@@ -80,50 +80,43 @@ func (cs *Storage) RemoveGroupData(groupId string, prefix ...string) error {
 }
 
 func RemoveGroupData(db s.QuorumStorage, groupId string, prefix ...string) error {
-	var keys []string
+	//all group state, posts, producers, users, announced items, schema, chain_config, app_config and producer trx_id
+	keys := GetGroupStatePrefixes(groupId, prefix...)
 
-	//remove all group POST
-	key := s.GetPostPrefix(groupId, prefix...)
+	//state snapshot and pruning info
+	key := s.GetSnapshotKey(groupId, prefix...)
 	keys = append(keys, key)
-
-	//all group producer
-	key = s.GetProducerPrefix(groupId, prefix...)
-	keys = append(keys, key)
-
-	//all group users
-	key = s.GetUserPrefix(groupId, prefix...)
+	key = s.GetChainInfoLowestBlock(groupId, prefix...)
 	keys = append(keys, key)
 
-	//all group announced item
-	key = s.GetAnnouncedPrefix(groupId, prefix...)
+	// cached block
+	key = s.GetCachedBlockPrefix(groupId, prefix...)
 	keys = append(keys, key)
 
-	//all group schema item
-	key = s.GetSchemaPrefix(groupId, prefix...)
+	// block
+	key = s.GetBlockPrefix(groupId, prefix...)
 	keys = append(keys, key)
 
-	//all group chain_config item
-	key = s.GetChainConfigPrefix(groupId, prefix...)
+	// trx
+	key = s.GetTrxPrefix(groupId, prefix...)
 	keys = append(keys, key)
-
-	//all group app_config item
-	key = s.GetAppConfigPrefix(groupId, prefix...)
+	key = s.GetTrxBlockPrefix(groupId, prefix...)
 	keys = append(keys, key)
 
-	//trx_id for producer update trx
-	key = s.GetProducerTrxIDKey(groupId, prefix...)
+	// status of trxs sent by this node
+	key = s.GetTrxStatusPrefix(groupId, prefix...)
 	keys = append(keys, key)
 
-	// cached block
-	key = s.GetCachedBlockPrefix(groupId, prefix...)
+	// evidence of producer misbehaviour found by this node
+	key = s.GetEvidencePrefix(groupId, prefix...)
 	keys = append(keys, key)
 
-	// block
-	key = s.GetBlockPrefix(groupId, prefix...)
+	// conflicting blocks found by this node
+	key = s.GetForkPrefix(groupId, prefix...)
 	keys = append(keys, key)
 
-	// trx
-	key = s.GetTrxPrefix(groupId, prefix...)
+	// group cipher keys rotated by owner
+	key = s.GetGroupKeyPrefix(groupId, prefix...)
 	keys = append(keys, key)
 
 	//remove all
@@ -0,0 +1,175 @@
+package chainstorage
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	s "github.com/rumsystem/quorum/internal/pkg/storage"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrNotKeyRecipient is returned when the rotated group cipher key is not encrypted to this node,
+// e.g. the node is removed from the group, or joins after the key is rotated
+var ErrNotKeyRecipient = errors.New("not a recipient of the group cipher key")
+
+// ErrRevokedGroupKey is returned when the trx is encrypted by a group cipher key revoked before the block packaged it
+var ErrRevokedGroupKey = errors.New("encrypted by a revoked group cipher key")
+
+// the revoked group cipher key is accepted for the trxs packaged in GROUP_KEY_GRACE_BLOCKS blocks after the rotation,
+// for the senders not applied the rotation yet
+var GROUP_KEY_GRACE_BLOCKS uint64 = 10
+
+// GroupKeyItem is a group cipher key rotated by a GROUP_KEY trx, the trxs not encrypted by the seed key
+// are encrypted by it after the block packaged the GROUP_KEY trx
+type GroupKeyItem struct {
+	GroupId   string `json:"group_id"`
+	BlockId   uint64 `json:"block_id"` // block packaged the GROUP_KEY trx
+	TrxId     string `json:"trx_id"`
+	TimeStamp int64  `json:"timestamp"`  // timestamp of the block
+	CipherKey string `json:"cipher_key"` // empty if the key is not encrypted to this node
+}
+
+func (cs *Storage) AddGroupKey(item *GroupKeyItem, prefix ...string) error {
+	value, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	return cs.dbmgr.Db.Set([]byte(s.GetGroupKeyKey(item.GroupId, item.BlockId, prefix...)), value)
+}
+
+// GetGroupKeys returns the group cipher keys rotated, in the order of BlockId
+func (cs *Storage) GetGroupKeys(groupId string, prefix ...string) ([]*GroupKeyItem, error) {
+	var items []*GroupKeyItem
+	key := s.GetGroupKeyPrefix(groupId, prefix...)
+	err := cs.dbmgr.Db.PrefixForeach([]byte(key), func(k []byte, v []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		item := &GroupKeyItem{}
+		if err := json.Unmarshal(v, item); err != nil {
+			return err
+		}
+		items = append(items, item)
+		return nil
+	})
+	sort.Slice(items, func(i, j int) bool { return items[i].BlockId < items[j].BlockId })
+	return items, err
+}
+
+// GetLatestGroupKey returns the group cipher key in use, nil if the key in the seed is never rotated
+func (cs *Storage) GetLatestGroupKey(groupId string, prefix ...string) (*GroupKeyItem, error) {
+	items, err := cs.GetGroupKeys(groupId, prefix...)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return items[len(items)-1], nil
+}
+
+// UpdateGroupKeyTrx saves the cipher key rotated by the GROUP_KEY trx (data decrypted) packaged in the block.
+// decrypt decrypts the key encrypted by age to this node, if it fails the rotation is saved without the key
+// and ErrNotKeyRecipient is returned
+func (cs *Storage) UpdateGroupKeyTrx(groupItem *quorumpb.GroupItem, trx *quorumpb.Trx, blockId uint64, blockTimeStamp int64, decrypt func(data []byte) ([]byte, error), prefix ...string) (*GroupKeyItem, error) {
+	if trx.SenderPubkey != groupItem.OwnerPubKey {
+		return nil, fmt.Errorf("group key can only be rotated by owner, trx <%s> is sent by <%s>", trx.TrxId, trx.SenderPubkey)
+	}
+	item := &quorumpb.GroupKeyItem{}
+	if err := proto.Unmarshal(trx.Data, item); err != nil {
+		return nil, err
+	}
+	if item.GroupId != groupItem.GroupId {
+		return nil, fmt.Errorf("group id <%s> of the key mismatch with trx", item.GroupId)
+	}
+
+	keyItem := &GroupKeyItem{
+		GroupId:   groupItem.GroupId,
+		BlockId:   blockId,
+		TrxId:     trx.TrxId,
+		TimeStamp: blockTimeStamp,
+	}
+	var key []byte
+	var err error
+	if decrypt != nil {
+		key, err = decrypt(item.EncryptedKey)
+	}
+	if decrypt == nil || err != nil {
+		if err := cs.AddGroupKey(keyItem, prefix...); err != nil {
+			return nil, err
+		}
+		return keyItem, ErrNotKeyRecipient
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("invalid group cipher key length %d", len(key))
+	}
+
+	keyItem.CipherKey = hex.EncodeToString(key)
+	return keyItem, cs.AddGroupKey(keyItem, prefix...)
+}
+
+// DecryptTrxData decrypts the data of a trx packaged in the block blockId. The trxs not encrypted by the seed key are
+// decrypted by the latest key rotated before the block, or the key revoked by it if the block is in GROUP_KEY_GRACE_BLOCKS
+// after the rotation. The height of the block is used rather than the timestamp of the trx, which is set by the sender
+func (cs *Storage) DecryptTrxData(groupItem *quorumpb.GroupItem, trx *quorumpb.Trx, blockId uint64, prefix ...string) ([]byte, error) {
+	var keys []*GroupKeyItem
+	if !rumchaindata.IsSeedKeyTrx(trx.Type) {
+		var err error
+		if keys, err = cs.GetGroupKeys(groupItem.GroupId, prefix...); err != nil {
+			return nil, err
+		}
+	}
+	return decryptTrxData(groupItem, keys, trx, blockId)
+}
+
+func decryptTrxData(groupItem *quorumpb.GroupItem, keys []*GroupKeyItem, trx *quorumpb.Trx, blockId uint64) ([]byte, error) {
+	var latest *GroupKeyItem
+	var revokedAt uint64 //block rotated the key after the one tried
+	for i := len(keys) - 1; i >= 0; i-- {
+		if keys[i].BlockId >= blockId {
+			continue
+		}
+		if latest != nil && blockId > revokedAt+GROUP_KEY_GRACE_BLOCKS {
+			break
+		}
+		if latest == nil {
+			latest = keys[i]
+		}
+		if keys[i].CipherKey != "" {
+			if data, err := aesDecode(trx.Data, keys[i].CipherKey); err == nil {
+				return data, nil
+			}
+		}
+		revokedAt = keys[i].BlockId
+	}
+	if latest == nil {
+		return aesDecode(trx.Data, groupItem.CipherKey)
+	}
+
+	//the key in the seed
+	if blockId <= revokedAt+GROUP_KEY_GRACE_BLOCKS {
+		if data, err := aesDecode(trx.Data, groupItem.CipherKey); err == nil {
+			return data, nil
+		}
+	}
+	if latest.CipherKey == "" {
+		return nil, fmt.Errorf("trx <%s> can't be decrypted: %w", trx.TrxId, ErrNotKeyRecipient)
+	}
+	return nil, fmt.Errorf("trx <%s> %w", trx.TrxId, ErrRevokedGroupKey)
+}
+
+// IsGroupKeyErr returns true if the trx can't be decrypted because of the group key rotation
+func IsGroupKeyErr(err error) bool {
+	return errors.Is(err, ErrNotKeyRecipient) || errors.Is(err, ErrRevokedGroupKey)
+}
+
+func aesDecode(data []byte, cipherKey string) ([]byte, error) {
+	key, err := hex.DecodeString(cipherKey)
+	if err != nil {
+		return nil, err
+	}
+	return localcrypto.AesDecode(data, key)
+}
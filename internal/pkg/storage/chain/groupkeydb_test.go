@@ -0,0 +1,98 @@
+//go:build !js
+// +build !js
+
+package chainstorage
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGroupKeyRotation(t *testing.T) {
+	cs, _ := newTestChainStorage(t)
+	owner := newTestSigner(t)
+	user := newTestSigner(t)
+	groupItem := &quorumpb.GroupItem{
+		GroupId:     "6a1d9c7e-2b4f-4e8a-9c3d-5f7b1e2a4c6d",
+		OwnerPubKey: owner.pubkey,
+		CipherKey:   "71eff58163d557b609a15050a5f7561568eb8bb582156697ba9fc99ca9236582",
+		EncryptType: quorumpb.GroupEncryptType_PRIVATE,
+	}
+	seedKey, _ := hex.DecodeString(groupItem.CipherKey)
+	newKey := bytes.Repeat([]byte{7}, 32)
+	rotatedAt := int64(1700000000000000000)
+	rotatedBlock := uint64(5)
+	grace := GROUP_KEY_GRACE_BLOCKS
+
+	groupKeyTrx := func(sender *testSigner, id string) *quorumpb.Trx {
+		data, _ := proto.Marshal(&quorumpb.GroupKeyItem{GroupId: groupItem.GroupId, EncryptedKey: []byte("encrypted")})
+		return &quorumpb.Trx{TrxId: id, GroupId: groupItem.GroupId, Type: quorumpb.TrxType_GROUP_KEY, SenderPubkey: sender.pubkey, Data: data}
+	}
+	encryptedTrx := func(trxType quorumpb.TrxType, key []byte, timestamp int64) *quorumpb.Trx {
+		data, err := localcrypto.AesEncrypt([]byte("data"), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &quorumpb.Trx{TrxId: "trx", GroupId: groupItem.GroupId, Type: trxType, Data: data, TimeStamp: timestamp}
+	}
+	recipient := func(key []byte) func(data []byte) ([]byte, error) {
+		return func(data []byte) ([]byte, error) { return key, nil }
+	}
+
+	if _, err := cs.UpdateGroupKeyTrx(groupItem, groupKeyTrx(user, "trx-user"), rotatedBlock, rotatedAt, recipient(newKey), testNodename); err == nil {
+		t.Fatalf("only owner can rotate the group key")
+	}
+	if _, err := cs.UpdateGroupKeyTrx(groupItem, groupKeyTrx(owner, "trx-key-1"), rotatedBlock, rotatedAt, recipient(newKey), testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if latest, _ := cs.GetLatestGroupKey(groupItem.GroupId, testNodename); latest == nil || latest.CipherKey != hex.EncodeToString(newKey) {
+		t.Fatalf("expect the rotated key in use, got %+v", latest)
+	}
+
+	for _, c := range []struct {
+		name    string
+		trx     *quorumpb.Trx
+		blockId uint64
+		err     error
+	}{
+		{"rotated key", encryptedTrx(quorumpb.TrxType_USER, newKey, rotatedAt+1), rotatedBlock + 1, nil},
+		{"seed key before rotation", encryptedTrx(quorumpb.TrxType_USER, seedKey, rotatedAt-1), rotatedBlock - 1, nil},
+		{"seed key in grace blocks", encryptedTrx(quorumpb.TrxType_USER, seedKey, rotatedAt+1), rotatedBlock + grace, nil},
+		{"revoked seed key", encryptedTrx(quorumpb.TrxType_USER, seedKey, rotatedAt+1), rotatedBlock + grace + 1, ErrRevokedGroupKey},
+		{"revoked seed key with timestamp before rotation", encryptedTrx(quorumpb.TrxType_USER, seedKey, rotatedAt-1), rotatedBlock + grace + 1, ErrRevokedGroupKey},
+		{"seed key trx", encryptedTrx(quorumpb.TrxType_ANNOUNCE, seedKey, rotatedAt+1), rotatedBlock + grace + 1, nil},
+	} {
+		data, err := cs.DecryptTrxData(groupItem, c.trx, c.blockId, testNodename)
+		if c.err == nil && (err != nil || string(data) != "data") {
+			t.Errorf("%s: expect decrypted, got %v", c.name, err)
+		} else if c.err != nil && !errors.Is(err, c.err) {
+			t.Errorf("%s: expect %v, got %v", c.name, c.err, err)
+		}
+	}
+
+	//rotated again without this node, e.g. the user is removed
+	notRecipient := func(data []byte) ([]byte, error) { return nil, errors.New("no identity matched") }
+	if _, err := cs.UpdateGroupKeyTrx(groupItem, groupKeyTrx(owner, "trx-key-2"), rotatedBlock+2*grace, rotatedAt+1, notRecipient, testNodename); err != ErrNotKeyRecipient {
+		t.Fatalf("expect ErrNotKeyRecipient, got %v", err)
+	}
+	trx := encryptedTrx(quorumpb.TrxType_USER, bytes.Repeat([]byte{9}, 32), rotatedAt+2)
+	if _, err := cs.DecryptTrxData(groupItem, trx, rotatedBlock+2*grace+1, testNodename); !errors.Is(err, ErrNotKeyRecipient) || !IsGroupKeyErr(err) {
+		t.Errorf("expect ErrNotKeyRecipient, got %v", err)
+	}
+	//the key revoked by the rotation is accepted in the grace blocks only
+	if data, err := cs.DecryptTrxData(groupItem, encryptedTrx(quorumpb.TrxType_USER, newKey, rotatedAt+2), rotatedBlock+3*grace, testNodename); err != nil || string(data) != "data" {
+		t.Errorf("expect decrypted by the revoked key in grace blocks, got %v", err)
+	}
+	if _, err := cs.DecryptTrxData(groupItem, encryptedTrx(quorumpb.TrxType_USER, newKey, rotatedAt+2), rotatedBlock+3*grace+1, testNodename); !errors.Is(err, ErrNotKeyRecipient) {
+		t.Errorf("expect ErrNotKeyRecipient after grace blocks, got %v", err)
+	}
+	if keys, _ := cs.GetGroupKeys(groupItem.GroupId, testNodename); len(keys) != 2 || keys[1].CipherKey != "" {
+		t.Errorf("expect the rotation saved without the key, got %+v", keys)
+	}
+}
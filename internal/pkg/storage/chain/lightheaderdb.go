@@ -0,0 +1,407 @@
+package chainstorage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	s "github.com/rumsystem/quorum/internal/pkg/storage"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrLightBlockNotNext   = errors.New("block is not the next one of the verified headers")
+	ErrLightBlockRejected  = errors.New("block failed the header verification")
+	ErrLightTrxMismatch    = errors.New("trx mismatch with the one in the verified block")
+	ErrLightTrxInvalidSign = errors.New("trx sender sign is invalid")
+)
+
+// LightHeader is the header of a block verified by a light node, the trxs of the block are kept as LightTrxProof
+type LightHeader struct {
+	GroupId        string `json:"group_id"`
+	BlockId        uint64 `json:"block_id"`
+	Epoch          uint64 `json:"epoch"`
+	BlockHash      []byte `json:"block_hash"`
+	PrevHash       []byte `json:"prev_hash"`
+	ProducerPubkey string `json:"producer_pubkey"`
+	TimeStamp      int64  `json:"timestamp"`
+	Version        uint32 `json:"version"`
+	TrxRoot        []byte `json:"trx_root,omitempty"`
+	TrxCount       int    `json:"trx_count"` // -1 if the header is synced without the trxs
+}
+
+// LightTrxProof records the verified block which packaged the trx, and the hash of the trx in that block
+type LightTrxProof struct {
+	GroupId string `json:"group_id"`
+	TrxId   string `json:"trx_id"`
+	BlockId uint64 `json:"block_id"`
+	TrxHash []byte `json:"trx_hash"`
+}
+
+// LightHeaderState is the head of the verified headers, and the producers in effect after it
+type LightHeaderState struct {
+	GroupId   string                            `json:"group_id"`
+	Head      *LightHeader                      `json:"head"`
+	Producers []string                          `json:"producers"`
+	Stakes    []*quorumpb.StakeItem             `json:"stakes,omitempty"`
+	Scheduled []*quorumpb.BFTProducerBundleItem `json:"scheduled,omitempty"`
+	UpdatedAt int64                             `json:"updated_at"`
+	LastError string                            `json:"last_error,omitempty"`
+}
+
+func newLightHeader(block *quorumpb.Block, trxCount int) *LightHeader {
+	return &LightHeader{
+		GroupId:        block.GroupId,
+		BlockId:        block.BlockId,
+		Epoch:          block.Epoch,
+		BlockHash:      block.BlockHash,
+		PrevHash:       block.PrevHash,
+		ProducerPubkey: block.ProducerPubkey,
+		TimeStamp:      block.TimeStamp,
+		Version:        block.Version,
+		TrxRoot:        block.TrxRoot,
+		TrxCount:       trxCount,
+	}
+}
+
+// GetLightTrxHash is the hash of the signed content of the trx, to match a trx from the chain api with the one
+// packaged in a verified block. StorageType and the recovery id of the sign may differ between the copies.
+func GetLightTrxHash(trx *quorumpb.Trx) ([]byte, error) {
+	signed := &quorumpb.Trx{
+		TrxId:        trx.TrxId,
+		Type:         trx.Type,
+		GroupId:      trx.GroupId,
+		SenderPubkey: trx.SenderPubkey,
+		Data:         trx.Data,
+		TimeStamp:    trx.TimeStamp,
+		Version:      trx.Version,
+		Expired:      trx.Expired,
+	}
+	data, err := proto.Marshal(signed)
+	if err != nil {
+		return nil, err
+	}
+	return localcrypto.Hash(data), nil
+}
+
+// GetLightHeaderState returns the verified head of the group, the genesis block in the group item
+// is the trust anchor and is verified and saved as the first header if nothing is verified yet
+func (cs *Storage) GetLightHeaderState(groupItem *quorumpb.GroupItem, prefix ...string) (*LightHeaderState, error) {
+	value, err := cs.dbmgr.Db.Get([]byte(s.GetLightHeaderStateKey(groupItem.GroupId, prefix...)))
+	if err != nil {
+		return nil, err
+	}
+	if value != nil {
+		state := &LightHeaderState{}
+		if err := json.Unmarshal(value, state); err != nil {
+			return nil, err
+		}
+		return state, nil
+	}
+
+	genesis := groupItem.GenesisBlock
+	if genesis == nil || genesis.GroupId != groupItem.GroupId {
+		return nil, fmt.Errorf("%w: genesis block of group <%s> is not found", ErrLightBlockRejected, groupItem.GroupId)
+	}
+	if valid, err := rumchaindata.ValidGenesisBlock(genesis); !valid {
+		msg := "producer sign is invalid"
+		if err != nil {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("%w: genesis block is invalid: %s", ErrLightBlockRejected, msg)
+	}
+
+	producers := newVerifyProducers(groupItem)
+	producers.add(genesis.ProducerPubkey)
+	state := &LightHeaderState{GroupId: groupItem.GroupId}
+	if err := cs.saveLightBlock(state, genesis, groupItem, producers, len(genesis.Trxs), prefix...); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// AddLightBlock verifies the block as the next one of the verified headers: the hash of the content,
+// the link to the head, the producer in effect at the epoch and the producer sign, and the sender sign of every trx.
+// The header and the trx proofs are saved and the state moves to the block if it passes.
+func (cs *Storage) AddLightBlock(groupItem *quorumpb.GroupItem, state *LightHeaderState, block *quorumpb.Block, prefix ...string) error {
+	producers, parent, err := lightNextBlock(groupItem, state, block)
+	if err != nil {
+		return err
+	}
+	result := &ChainVerifyResult{GroupId: groupItem.GroupId, Ok: true}
+	cs.verifyBlock(block, parent, producers, result)
+	if !result.Ok {
+		return fmt.Errorf("%w: %s", ErrLightBlockRejected, result.Issues[0].Message)
+	}
+	return cs.saveLightBlock(state, block, groupItem, producers, len(block.Trxs), prefix...)
+}
+
+// AddLightHeader verifies the header of a block with trx root as AddLightBlock without the whole block.
+// The trxs changing the producers (PRODUCER and STAKE) are given with their proofs, the other trxs of
+// the block are proved later by VerifyLightTrxInclusion. A block without trx root is verified as a whole.
+func (cs *Storage) AddLightHeader(groupItem *quorumpb.GroupItem, state *LightHeaderState, header *quorumpb.Block, trxs []*quorumpb.Trx, proofs []*rumchaindata.TrxInclusionProof, prefix ...string) error {
+	if header.Version != rumchaindata.BLOCK_VERSION_TRX_ROOT {
+		return cs.AddLightBlock(groupItem, state, header, prefix...)
+	}
+	producers, parent, err := lightNextBlock(groupItem, state, header)
+	if err != nil {
+		return err
+	}
+
+	hash, err := rumchaindata.GetBlockHeaderHash(header)
+	if err != nil || !bytes.Equal(hash, header.BlockHash) {
+		return fmt.Errorf("%w: block hash mismatch with the header", ErrLightBlockRejected)
+	}
+	if len(trxs) != len(proofs) {
+		return fmt.Errorf("%w: <%d> trxs with <%d> proofs", ErrLightBlockRejected, len(trxs), len(proofs))
+	}
+	for i, trx := range trxs {
+		if trx.Type != quorumpb.TrxType_PRODUCER && trx.Type != quorumpb.TrxType_STAKE {
+			return fmt.Errorf("%w: unexpected trx <%s> with type <%s>", ErrLightBlockRejected, trx.TrxId, trx.Type)
+		}
+		if valid, err := rumchaindata.VerifyTrxInclusionProof(trx, proofs[i], header); !valid {
+			return fmt.Errorf("%w: trx <%s> is not proved: %v", ErrLightBlockRejected, trx.TrxId, err)
+		}
+	}
+
+	result := &ChainVerifyResult{GroupId: groupItem.GroupId, Ok: true}
+	cs.verifyBlockHeader(header, parent, producers, result)
+	if !result.Ok {
+		return fmt.Errorf("%w: %s", ErrLightBlockRejected, result.Issues[0].Message)
+	}
+
+	block := proto.Clone(header).(*quorumpb.Block)
+	block.Trxs = trxs
+	trxCount := -1
+	if len(proofs) > 0 {
+		trxCount = proofs[0].Total
+	}
+	return cs.saveLightBlock(state, block, groupItem, producers, trxCount, prefix...)
+}
+
+// TrustLightHeader moves the verified head to a header trusted by the user, e.g. the head of a node the user runs,
+// so the headers before it are not synced. The producers, stakes and scheduled producers in effect after the header
+// are trusted as well.
+func (cs *Storage) TrustLightHeader(groupItem *quorumpb.GroupItem, header *quorumpb.Block, producerPubkeys []string, stakes []*quorumpb.StakeItem, scheduled []*quorumpb.BFTProducerBundleItem, prefix ...string) (*LightHeaderState, error) {
+	state, err := cs.GetLightHeaderState(groupItem, prefix...)
+	if err != nil {
+		return nil, err
+	}
+	if header.GroupId != groupItem.GroupId || header.BlockId <= state.Head.BlockId {
+		return nil, fmt.Errorf("%w: trusted block <%s:%d> is not after the head <%d>", ErrLightBlockNotNext, header.GroupId, header.BlockId, state.Head.BlockId)
+	}
+	if header.Version == rumchaindata.BLOCK_VERSION_TRX_ROOT {
+		hash, err := rumchaindata.GetBlockHeaderHash(header)
+		if err != nil || !bytes.Equal(hash, header.BlockHash) {
+			return nil, fmt.Errorf("%w: block hash mismatch with the header", ErrLightBlockRejected)
+		}
+	}
+	if valid, _ := rumchaindata.VerifyBlockSign(header); !valid {
+		return nil, fmt.Errorf("%w: producer sign is invalid", ErrLightBlockRejected)
+	}
+
+	producers := newVerifyProducers(groupItem)
+	for _, pubkey := range producerPubkeys {
+		producers.add(pubkey)
+	}
+	for _, item := range stakes {
+		producers.stakes[ethPubkey(item.StakerPubkey)] = item
+	}
+	producers.scheduled = append(producers.scheduled, scheduled...)
+	block := proto.Clone(header).(*quorumpb.Block)
+	block.Trxs = nil
+	if err := cs.saveLightBlock(state, block, groupItem, producers, -1, prefix...); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// lightNextBlock checks the block is the next one of the head, returns the producers in effect at its epoch and the head as parent
+func lightNextBlock(groupItem *quorumpb.GroupItem, state *LightHeaderState, block *quorumpb.Block) (*verifyProducers, *quorumpb.Block, error) {
+	if block.GroupId != state.GroupId || block.BlockId != state.Head.BlockId+1 {
+		return nil, nil, fmt.Errorf("%w: got block <%s:%d>, head is <%d>", ErrLightBlockNotNext, block.GroupId, block.BlockId, state.Head.BlockId)
+	}
+
+	producers := newVerifyProducers(groupItem)
+	for _, pubkey := range state.Producers {
+		producers.add(pubkey)
+	}
+	for _, item := range state.Stakes {
+		producers.stakes[ethPubkey(item.StakerPubkey)] = item
+	}
+	producers.scheduled = append(producers.scheduled, state.Scheduled...)
+	producers.activate(block.Epoch)
+
+	parent := &quorumpb.Block{
+		GroupId:   state.Head.GroupId,
+		BlockId:   state.Head.BlockId,
+		Epoch:     state.Head.Epoch,
+		BlockHash: state.Head.BlockHash,
+		TimeStamp: state.Head.TimeStamp,
+	}
+	return producers, parent, nil
+}
+
+// saveLightBlock checks the trxs of a verified block, and saves the header, the trx proofs and the state in one batch
+func (cs *Storage) saveLightBlock(state *LightHeaderState, block *quorumpb.Block, groupItem *quorumpb.GroupItem, producers *verifyProducers, trxCount int, prefix ...string) error {
+	result := &ChainVerifyResult{GroupId: groupItem.GroupId, Ok: true}
+	cs.verifyTrxs(block, groupItem, producers, result)
+	if !result.Ok {
+		return fmt.Errorf("%w: %s", ErrLightBlockRejected, result.Issues[0].Message)
+	}
+
+	var keys, values [][]byte
+	header := newLightHeader(block, trxCount)
+	value, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	keys = append(keys, []byte(s.GetLightHeaderKey(block.GroupId, block.BlockId, prefix...)))
+	values = append(values, value)
+
+	for _, trx := range block.Trxs {
+		hash, err := GetLightTrxHash(trx)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(&LightTrxProof{GroupId: trx.GroupId, TrxId: trx.TrxId, BlockId: block.BlockId, TrxHash: hash})
+		if err != nil {
+			return err
+		}
+		keys = append(keys, []byte(s.GetLightTrxProofKey(block.GroupId, trx.TrxId, prefix...)))
+		values = append(values, value)
+	}
+
+	state.Head = header
+	state.Producers = state.Producers[:0]
+	for pubkey := range producers.keys {
+		state.Producers = append(state.Producers, pubkey)
+	}
+	state.Stakes = producers.stakeList()
+	state.Scheduled = producers.scheduled
+	state.UpdatedAt = time.Now().UnixNano()
+	state.LastError = ""
+	value, err = json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	keys = append(keys, []byte(s.GetLightHeaderStateKey(block.GroupId, prefix...)))
+	values = append(values, value)
+
+	return cs.dbmgr.Db.BatchWrite(keys, values)
+}
+
+// SaveLightHeaderState saves the state with the error of the last sync, the head is not changed
+func (cs *Storage) SaveLightHeaderState(state *LightHeaderState, prefix ...string) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return cs.dbmgr.Db.Set([]byte(s.GetLightHeaderStateKey(state.GroupId, prefix...)), value)
+}
+
+// GetLightHeader returns nil if the block is not verified
+func (cs *Storage) GetLightHeader(groupId string, blockId uint64, prefix ...string) (*LightHeader, error) {
+	value, err := cs.dbmgr.Db.Get([]byte(s.GetLightHeaderKey(groupId, blockId, prefix...)))
+	if err != nil || value == nil {
+		return nil, err
+	}
+	header := &LightHeader{}
+	if err := json.Unmarshal(value, header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// GetLightTrxProof returns nil if the trx is not found in the verified blocks
+func (cs *Storage) GetLightTrxProof(groupId string, trxId string, prefix ...string) (*LightTrxProof, error) {
+	value, err := cs.dbmgr.Db.Get([]byte(s.GetLightTrxProofKey(groupId, trxId, prefix...)))
+	if err != nil || value == nil {
+		return nil, err
+	}
+	proof := &LightTrxProof{}
+	if err := json.Unmarshal(value, proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VerifyLightTrx checks the sender sign of the trx, and matches it with the trx in the verified blocks.
+// It returns nil proof without error if the trx is not packaged in the verified blocks yet.
+func (cs *Storage) VerifyLightTrx(trx *quorumpb.Trx, prefix ...string) (*LightTrxProof, error) {
+	hash, err := GetLightTrxHash(trx)
+	if err != nil {
+		return nil, err
+	}
+	//VerifyTrx may modify the recovery id of the sign
+	checkTrx := proto.Clone(trx).(*quorumpb.Trx)
+	if valid, _ := rumchaindata.VerifyTrx(checkTrx); !valid {
+		return nil, fmt.Errorf("%w: <%s>", ErrLightTrxInvalidSign, trx.TrxId)
+	}
+
+	proof, err := cs.GetLightTrxProof(trx.GroupId, trx.TrxId, prefix...)
+	if err != nil || proof == nil {
+		return nil, err
+	}
+	if !bytes.Equal(proof.TrxHash, hash) {
+		return nil, fmt.Errorf("%w: <%s> in block <%d>", ErrLightTrxMismatch, trx.TrxId, proof.BlockId)
+	}
+	return proof, nil
+}
+
+// VerifyLightTrxInclusion checks the trx is packaged in a verified header by the merkle proof from the chain api,
+// the proof is saved so the trx is verified by VerifyLightTrx later. It returns nil proof without error if the
+// header is not verified yet.
+func (cs *Storage) VerifyLightTrxInclusion(trx *quorumpb.Trx, proof *rumchaindata.TrxInclusionProof, header *quorumpb.Block, prefix ...string) (*LightTrxProof, error) {
+	saved, err := cs.GetLightHeader(trx.GroupId, proof.BlockId, prefix...)
+	if err != nil || saved == nil {
+		return nil, err
+	}
+	if !bytes.Equal(saved.BlockHash, header.BlockHash) {
+		return nil, fmt.Errorf("%w: block <%d> mismatch with the verified header", ErrLightBlockRejected, proof.BlockId)
+	}
+	//VerifyTrx may modify the recovery id of the sign
+	checkTrx := proto.Clone(trx).(*quorumpb.Trx)
+	if valid, _ := rumchaindata.VerifyTrx(checkTrx); !valid {
+		return nil, fmt.Errorf("%w: <%s>", ErrLightTrxInvalidSign, trx.TrxId)
+	}
+	if valid, err := rumchaindata.VerifyTrxInclusionProof(trx, proof, header); !valid {
+		return nil, fmt.Errorf("%w: <%s> in block <%d>: %v", ErrLightTrxMismatch, trx.TrxId, proof.BlockId, err)
+	}
+
+	hash, err := GetLightTrxHash(trx)
+	if err != nil {
+		return nil, err
+	}
+	result := &LightTrxProof{GroupId: trx.GroupId, TrxId: trx.TrxId, BlockId: proof.BlockId, TrxHash: hash}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	if err := cs.dbmgr.Db.Set([]byte(s.GetLightTrxProofKey(trx.GroupId, trx.TrxId, prefix...)), value); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// VerifyLightBlock checks a block with the verified header at the same height,
+// it returns false without error if the block is not verified yet
+func (cs *Storage) VerifyLightBlock(block *quorumpb.Block, prefix ...string) (bool, error) {
+	header, err := cs.GetLightHeader(block.GroupId, block.BlockId, prefix...)
+	if err != nil || header == nil {
+		return false, err
+	}
+	hash, err := rumchaindata.GetBlockHash(block)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(hash, header.BlockHash) || !bytes.Equal(block.BlockHash, header.BlockHash) {
+		return false, fmt.Errorf("%w: block <%d> mismatch with the verified header", ErrLightBlockRejected, block.BlockId)
+	}
+	return true, nil
+}
@@ -0,0 +1,226 @@
+//go:build !js
+// +build !js
+
+package chainstorage
+
+import (
+	"errors"
+	"testing"
+
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLightHeaders(t *testing.T) {
+	cs, _ := newTestChainStorage(t)
+	owner := newTestSigner(t)
+	producer := newTestSigner(t)
+	stranger := newTestSigner(t)
+
+	groupItem := &quorumpb.GroupItem{
+		GroupId:     "5d7c1e2a-8b3f-4c6d-9a0e-1f2b3c4d5e6f",
+		GroupName:   "light",
+		OwnerPubKey: owner.pubkey,
+		CipherKey:   "71eff58163d557b609a15050a5f7561568eb8bb582156697ba9fc99ca9236582",
+	}
+	genesis := owner.newBlock(t, nil, groupItem.GroupId, 0, nil)
+	groupItem.GenesisBlock = genesis
+
+	state, err := cs.GetLightHeaderState(groupItem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Head.BlockId != 0 {
+		t.Fatalf("expect the genesis block as head, got %+v", state.Head)
+	}
+
+	bundle, _ := proto.Marshal(&quorumpb.BFTProducerBundleItem{Producers: []*quorumpb.ProducerItem{
+		{GroupId: groupItem.GroupId, ProducerPubkey: producer.pubkey},
+	}})
+	post := stranger.newTrx(t, groupItem, "trx-post", quorumpb.TrxType_POST, []byte("hello"))
+	block1 := owner.newBlock(t, genesis, groupItem.GroupId, 1, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-producer", quorumpb.TrxType_PRODUCER, bundle),
+		post,
+	})
+
+	//not produced by a producer, or not linked to the head
+	if err := cs.AddLightBlock(groupItem, state, stranger.newBlock(t, genesis, groupItem.GroupId, 1, nil)); !errors.Is(err, ErrLightBlockRejected) {
+		t.Errorf("expect block from stranger rejected, got %v", err)
+	}
+	if err := cs.AddLightBlock(groupItem, state, owner.newBlock(t, block1, groupItem.GroupId, 1, nil)); !errors.Is(err, ErrLightBlockRejected) {
+		t.Errorf("expect block with wrong prev hash rejected, got %v", err)
+	}
+	if err := cs.AddLightBlock(groupItem, state, owner.newBlock(t, genesis, groupItem.GroupId, 2, nil)); !errors.Is(err, ErrLightBlockNotNext) {
+		t.Errorf("expect block after a gap rejected, got %v", err)
+	}
+	tampered := proto.Clone(block1).(*quorumpb.Block)
+	tampered.Trxs = tampered.Trxs[:1]
+	if err := cs.AddLightBlock(groupItem, state, tampered); !errors.Is(err, ErrLightBlockRejected) {
+		t.Errorf("expect block with trx removed rejected, got %v", err)
+	}
+
+	if err := cs.AddLightBlock(groupItem, state, block1); err != nil {
+		t.Fatal(err)
+	}
+	//the producer added by block 1 produces block 2, the state is reloaded from db
+	block2 := producer.newBlock(t, block1, groupItem.GroupId, 2, nil)
+	state, err = cs.GetLightHeaderState(groupItem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.AddLightBlock(groupItem, state, block2); err != nil {
+		t.Fatal(err)
+	}
+	if state.Head.BlockId != 2 {
+		t.Errorf("expect head 2, got %+v", state.Head)
+	}
+
+	proof, err := cs.VerifyLightTrx(proto.Clone(post).(*quorumpb.Trx))
+	if err != nil || proof == nil || proof.BlockId != 1 {
+		t.Errorf("expect trx proved by block 1, got %+v, %v", proof, err)
+	}
+	forged := proto.Clone(post).(*quorumpb.Trx)
+	forged.TimeStamp = 2
+	if _, err := cs.VerifyLightTrx(forged); !errors.Is(err, ErrLightTrxInvalidSign) {
+		t.Errorf("expect forged trx rejected, got %v", err)
+	}
+	resigned := stranger.newTrx(t, groupItem, "trx-post", quorumpb.TrxType_POST, []byte("changed"))
+	if _, err := cs.VerifyLightTrx(resigned); !errors.Is(err, ErrLightTrxMismatch) {
+		t.Errorf("expect trx not in the block rejected, got %v", err)
+	}
+	unknown := stranger.newTrx(t, groupItem, "trx-unknown", quorumpb.TrxType_POST, []byte("later"))
+	if proof, err := cs.VerifyLightTrx(unknown); err != nil || proof != nil {
+		t.Errorf("expect trx not packaged yet unproved, got %+v, %v", proof, err)
+	}
+
+	if ok, err := cs.VerifyLightBlock(proto.Clone(block2).(*quorumpb.Block)); !ok || err != nil {
+		t.Errorf("expect block 2 verified, got %v, %v", ok, err)
+	}
+	if _, err := cs.VerifyLightBlock(tampered); !errors.Is(err, ErrLightBlockRejected) {
+		t.Errorf("expect tampered block rejected, got %v", err)
+	}
+}
+
+// newRootBlock creates a block with trx root signed by the signer, and its header without trxs
+func (signer *testSigner) newRootBlock(t *testing.T, parent *quorumpb.Block, blockId uint64, trxs []*quorumpb.Trx) (*quorumpb.Block, *quorumpb.Block) {
+	root, err := rumchaindata.GetTrxRoot(trxs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &quorumpb.Block{
+		GroupId:        parent.GroupId,
+		BlockId:        blockId,
+		Epoch:          blockId,
+		PrevHash:       parent.BlockHash,
+		ProducerPubkey: signer.pubkey,
+		Trxs:           trxs,
+		TimeStamp:      int64(blockId + 1),
+		Version:        rumchaindata.BLOCK_VERSION_TRX_ROOT,
+		TrxRoot:        root,
+	}
+	hash, err := rumchaindata.GetBlockHash(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block.BlockHash = hash
+	block.ProducerSign = signer.sign(t, hash)
+
+	header := proto.Clone(block).(*quorumpb.Block)
+	header.Trxs = nil
+	return block, header
+}
+
+func TestLightHeadersWithTrxRoot(t *testing.T) {
+	cs, _ := newTestChainStorage(t)
+	owner := newTestSigner(t)
+	producer := newTestSigner(t)
+	stranger := newTestSigner(t)
+
+	groupItem := &quorumpb.GroupItem{
+		GroupId:     "7e1d2c3b-4a5f-4e6d-8c7b-9a0f1e2d3c4b",
+		GroupName:   "light",
+		OwnerPubKey: owner.pubkey,
+		CipherKey:   "71eff58163d557b609a15050a5f7561568eb8bb582156697ba9fc99ca9236582",
+	}
+	genesis := owner.newBlock(t, nil, groupItem.GroupId, 0, nil)
+	groupItem.GenesisBlock = genesis
+	state, err := cs.GetLightHeaderState(groupItem)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, _ := proto.Marshal(&quorumpb.BFTProducerBundleItem{Producers: []*quorumpb.ProducerItem{
+		{GroupId: groupItem.GroupId, ProducerPubkey: producer.pubkey},
+	}})
+	producerTrx := owner.newTrx(t, groupItem, "trx-producer", quorumpb.TrxType_PRODUCER, bundle)
+	post := stranger.newTrx(t, groupItem, "trx-post", quorumpb.TrxType_POST, []byte("hello"))
+	block1, header1 := owner.newRootBlock(t, genesis, 1, []*quorumpb.Trx{producerTrx, post})
+	producerProof, err := rumchaindata.GetTrxInclusionProof(block1, producerTrx.TrxId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	postProof, err := rumchaindata.GetTrxInclusionProof(block1, post.TrxId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//only the trxs changing the producers are synced with the header, and they should be proved
+	if err := cs.AddLightHeader(groupItem, state, header1, []*quorumpb.Trx{post}, []*rumchaindata.TrxInclusionProof{postProof}); !errors.Is(err, ErrLightBlockRejected) {
+		t.Errorf("expect post trx with header rejected, got %v", err)
+	}
+	if err := cs.AddLightHeader(groupItem, state, header1, []*quorumpb.Trx{producerTrx}, []*rumchaindata.TrxInclusionProof{postProof}); !errors.Is(err, ErrLightBlockRejected) {
+		t.Errorf("expect trx with wrong proof rejected, got %v", err)
+	}
+	tampered := proto.Clone(header1).(*quorumpb.Block)
+	tampered.TimeStamp++
+	if err := cs.AddLightHeader(groupItem, state, tampered, nil, nil); !errors.Is(err, ErrLightBlockRejected) {
+		t.Errorf("expect tampered header rejected, got %v", err)
+	}
+	if err := cs.AddLightHeader(groupItem, state, header1, []*quorumpb.Trx{producerTrx}, []*rumchaindata.TrxInclusionProof{producerProof}); err != nil {
+		t.Fatal(err)
+	}
+	if state.Head.BlockId != 1 || state.Head.TrxCount != 2 {
+		t.Errorf("unexpected head %+v", state.Head)
+	}
+
+	//the producer added by the header produces block 2
+	block2, header2 := producer.newRootBlock(t, block1, 2, nil)
+	if err := cs.AddLightHeader(groupItem, state, header2, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	//the post is proved by the merkle proof with the verified header
+	if proof, err := cs.VerifyLightTrx(post); err != nil || proof != nil {
+		t.Errorf("expect post not proved by the header sync, got %+v, %v", proof, err)
+	}
+	if _, err := cs.VerifyLightTrxInclusion(post, postProof, header2); !errors.Is(err, ErrLightBlockRejected) {
+		t.Errorf("expect proof with another header rejected, got %v", err)
+	}
+	if proof, err := cs.VerifyLightTrxInclusion(post, postProof, header1); err != nil || proof == nil || proof.BlockId != 1 {
+		t.Fatalf("expect post proved by block 1, got %+v, %v", proof, err)
+	}
+	if proof, err := cs.VerifyLightTrx(post); err != nil || proof == nil {
+		t.Errorf("expect post verified after proved, got %+v, %v", proof, err)
+	}
+
+	//another light node starts from the trusted block 2 with the producers in effect
+	other, _ := newTestChainStorage(t)
+	if _, err := other.TrustLightHeader(groupItem, tampered, []string{producer.pubkey}, nil, nil); !errors.Is(err, ErrLightBlockRejected) {
+		t.Errorf("expect tampered trusted header rejected, got %v", err)
+	}
+	trusted, err := other.TrustLightHeader(groupItem, header2, []string{producer.pubkey}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trusted.Head.BlockId != 2 {
+		t.Errorf("expect head 2, got %+v", trusted.Head)
+	}
+	if _, err := other.TrustLightHeader(groupItem, header1, []string{producer.pubkey}, nil, nil); !errors.Is(err, ErrLightBlockNotNext) {
+		t.Errorf("expect trusted header before the head rejected, got %v", err)
+	}
+	_, header3 := producer.newRootBlock(t, block2, 3, nil)
+	if err := other.AddLightHeader(groupItem, trusted, header3, nil, nil); err != nil {
+		t.Errorf("expect block 3 verified after the trusted header, got %v", err)
+	}
+}
@@ -8,7 +8,35 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// ScheduledProducers is a producer list waiting for its activate epoch
+type ScheduledProducers struct {
+	TrxId         string
+	ActivateEpoch uint64
+	Producers     []*quorumpb.ProducerItem
+}
+
+// UpdateProducerTrx updates the producer list with the trx, the list is scheduled instead if the trx has an activate epoch,
+// see ActivateScheduledProducers
 func (cs *Storage) UpdateProducerTrx(trx *quorumpb.Trx, prefix ...string) error {
+	item := &quorumpb.BFTProducerBundleItem{}
+	if err := proto.Unmarshal(trx.Data, item); err != nil {
+		return err
+	}
+
+	if item.ActivateEpoch > 0 {
+		value, err := proto.Marshal(trx)
+		if err != nil {
+			return err
+		}
+		key := s.GetScheduledProducerKey(trx.GroupId, item.ActivateEpoch, trx.TrxId, prefix...)
+		chaindb_log.Infof("<%s> producer list of trx <%s> scheduled at epoch <%d>", trx.GroupId, trx.TrxId, item.ActivateEpoch)
+		return cs.dbmgr.Db.Set([]byte(key), value)
+	}
+
+	return cs.applyProducerTrx(trx, prefix...)
+}
+
+func (cs *Storage) applyProducerTrx(trx *quorumpb.Trx, prefix ...string) error {
 	err := cs.UpdateProducer(trx.GroupId, trx.Data, prefix...)
 	if err != nil {
 		return err
@@ -24,6 +52,72 @@ func (cs *Storage) UpdateProducerTrx(trx *quorumpb.Trx, prefix ...string) error
 	return cs.dbmgr.Db.Set([]byte(key), []byte(trx.TrxId))
 }
 
+func (cs *Storage) getScheduledProducerTrxs(groupId string, prefix ...string) ([]string, []*quorumpb.Trx, error) {
+	var keys []string
+	var trxs []*quorumpb.Trx
+	key := s.GetScheduledProducerPrefix(groupId, prefix...)
+	err := cs.dbmgr.Db.PrefixForeach([]byte(key), func(k []byte, v []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		trx := &quorumpb.Trx{}
+		if err := proto.Unmarshal(v, trx); err != nil {
+			return err
+		}
+		keys = append(keys, string(k))
+		trxs = append(trxs, trx)
+		return nil
+	})
+	return keys, trxs, err
+}
+
+// GetScheduledProducers returns the producer lists not activated yet, in the order of activate epoch
+func (cs *Storage) GetScheduledProducers(groupId string, prefix ...string) ([]*ScheduledProducers, error) {
+	_, trxs, err := cs.getScheduledProducerTrxs(groupId, prefix...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*ScheduledProducers{}
+	for _, trx := range trxs {
+		item := &quorumpb.BFTProducerBundleItem{}
+		if err := proto.Unmarshal(trx.Data, item); err != nil {
+			return nil, err
+		}
+		result = append(result, &ScheduledProducers{TrxId: trx.TrxId, ActivateEpoch: item.ActivateEpoch, Producers: item.Producers})
+	}
+	return result, nil
+}
+
+// ActivateScheduledProducers applies the scheduled producer lists with activate epoch <= epoch in order,
+// returns true if the producer list is changed
+func (cs *Storage) ActivateScheduledProducers(groupId string, epoch uint64, prefix ...string) (bool, error) {
+	keys, trxs, err := cs.getScheduledProducerTrxs(groupId, prefix...)
+	if err != nil {
+		return false, err
+	}
+
+	activated := false
+	for i, trx := range trxs {
+		item := &quorumpb.BFTProducerBundleItem{}
+		if err := proto.Unmarshal(trx.Data, item); err != nil {
+			return activated, err
+		}
+		if item.ActivateEpoch > epoch {
+			break
+		}
+		if err := cs.applyProducerTrx(trx, prefix...); err != nil {
+			return activated, err
+		}
+		if err := cs.dbmgr.Db.Delete([]byte(keys[i])); err != nil {
+			return activated, err
+		}
+		chaindb_log.Infof("<%s> producer list of trx <%s> activated at epoch <%d>", groupId, trx.TrxId, epoch)
+		activated = true
+	}
+	return activated, nil
+}
+
 func (cs *Storage) GetUpdProducerListTrx(groupId string, prefix ...string) (*quorumpb.Trx, error) {
 	key := s.GetProducerTrxIDKey(groupId, prefix...)
 	btrx_id, err := cs.dbmgr.Db.Get([]byte(key))
@@ -66,8 +160,7 @@ func (cs *Storage) UpdateProducer(groupId string, data []byte, prefix ...string)
 		}
 
 		if item.ProducerPubkey != groupInfo.OwnerPubKey {
-			pkey := key + "_" + item.ProducerPubkey
-			cplist = append(cplist, pkey)
+			cplist = append(cplist, string(k))
 		}
 
 		return nil
@@ -159,3 +252,32 @@ func (cs *Storage) IsProducerAnnounced(groupId, pubkey string, prefix ...string)
 	key := s.GetAnnounceAsProducerKey(groupId, pubkey, prefix...)
 	return cs.dbmgr.Db.IsExist([]byte(key))
 }
+
+// UpdateAnnouncedProducerResults marks the announced producers approved if they are in the producer list, or not approved
+func (cs *Storage) UpdateAnnouncedProducerResults(groupId string, prefix ...string) error {
+	producers, err := cs.GetProducers(groupId, prefix...)
+	if err != nil {
+		return err
+	}
+	producerPool := make(map[string]bool)
+	for _, item := range producers {
+		pk, err := localcrypto.Libp2pPubkeyToEthBase64(item.ProducerPubkey)
+		if err != nil {
+			pk = item.ProducerPubkey
+		}
+		producerPool[pk] = true
+	}
+
+	announcedProducers, err := cs.GetAnnounceProducersByGroup(groupId, prefix...)
+	if err != nil {
+		return err
+	}
+	var firstErr error
+	for _, item := range announcedProducers {
+		err := cs.UpdateAnnounceResult(quorumpb.AnnounceType_AS_PRODUCER, groupId, item.SignPubkey, producerPool[item.SignPubkey], prefix...)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,134 @@
+//go:build !js
+// +build !js
+
+package chainstorage
+
+import (
+	"testing"
+
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestScheduledProducers(t *testing.T) {
+	cs, _ := newTestChainStorage(t)
+	owner := newTestSigner(t)
+	producer := newTestSigner(t)
+	groupItem := &quorumpb.GroupItem{GroupId: "6a1d8c3e-2b4f-4e7a-9c0d-5f8e7a6b3c21", OwnerPubKey: owner.pubkey}
+	if err := cs.AddGroup(groupItem); err != nil {
+		t.Fatal(err)
+	}
+
+	producerTrx := func(trxId string, activateEpoch uint64, pubkeys ...string) *quorumpb.Trx {
+		item := &quorumpb.BFTProducerBundleItem{ActivateEpoch: activateEpoch}
+		for _, pubkey := range pubkeys {
+			item.Producers = append(item.Producers, &quorumpb.ProducerItem{GroupId: groupItem.GroupId, ProducerPubkey: pubkey})
+		}
+		data, _ := proto.Marshal(item)
+		return &quorumpb.Trx{GroupId: groupItem.GroupId, TrxId: trxId, Type: quorumpb.TrxType_PRODUCER, Data: data}
+	}
+
+	//scheduled out of order, activated in the order of activate epoch
+	if err := cs.UpdateProducerTrx(producerTrx("trx-2", 20, owner.pubkey), testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.UpdateProducerTrx(producerTrx("trx-1", 10, owner.pubkey, producer.pubkey), testNodename); err != nil {
+		t.Fatal(err)
+	}
+	scheduled, err := cs.GetScheduledProducers(groupItem.GroupId, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scheduled) != 2 || scheduled[0].TrxId != "trx-1" || scheduled[1].ActivateEpoch != 20 {
+		t.Fatalf("unexpected scheduled producers %+v", scheduled)
+	}
+	if producers, _ := cs.GetProducers(groupItem.GroupId, testNodename); len(producers) != 0 {
+		t.Errorf("producer list should not be changed before the activate epoch, got %+v", producers)
+	}
+
+	if activated, err := cs.ActivateScheduledProducers(groupItem.GroupId, 9, testNodename); err != nil || activated {
+		t.Fatalf("nothing should be activated before epoch 10, got %v, %v", activated, err)
+	}
+	if activated, err := cs.ActivateScheduledProducers(groupItem.GroupId, 10, testNodename); err != nil || !activated {
+		t.Fatalf("expect activated at epoch 10, got %v, %v", activated, err)
+	}
+	if producers, _ := cs.GetProducers(groupItem.GroupId, testNodename); len(producers) != 2 {
+		t.Errorf("expect 2 producers, got %+v", producers)
+	}
+	if scheduled, _ := cs.GetScheduledProducers(groupItem.GroupId, testNodename); len(scheduled) != 1 || scheduled[0].TrxId != "trx-2" {
+		t.Errorf("expect trx-2 left scheduled, got %+v", scheduled)
+	}
+
+	//trx without activate epoch takes effect immediately, the producers not in the list are removed, the scheduled ones are kept
+	if err := cs.UpdateProducerTrx(producerTrx("trx-3", 0, owner.pubkey), testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if producers, _ := cs.GetProducers(groupItem.GroupId, testNodename); len(producers) != 1 {
+		t.Errorf("expect 1 producer, got %+v", producers)
+	}
+	if scheduled, _ := cs.GetScheduledProducers(groupItem.GroupId, testNodename); len(scheduled) != 1 {
+		t.Errorf("expect trx-2 left scheduled, got %+v", scheduled)
+	}
+}
+
+func TestVerifyScheduledProducers(t *testing.T) {
+	cs, dbMgr := newTestChainStorage(t)
+	owner := newTestSigner(t)
+	producer := newTestSigner(t)
+
+	groupItem := &quorumpb.GroupItem{
+		GroupId:     "c4e2a7b9-1d3f-4a6c-8e5b-7f9d0a2c4e61",
+		GroupName:   "scheduled",
+		OwnerPubKey: owner.pubkey,
+		CipherKey:   "71eff58163d557b609a15050a5f7561568eb8bb582156697ba9fc99ca9236582",
+	}
+	genesis := owner.newBlock(t, nil, groupItem.GroupId, 0, nil)
+	groupItem.GenesisBlock = genesis
+	if err := cs.AddGroup(groupItem); err != nil {
+		t.Fatal(err)
+	}
+
+	//block 1 schedules the producer from epoch 3, block 2 is still produced by the owner
+	bundle, _ := proto.Marshal(&quorumpb.BFTProducerBundleItem{ActivateEpoch: 3, Producers: []*quorumpb.ProducerItem{
+		{GroupId: groupItem.GroupId, ProducerPubkey: producer.pubkey},
+	}})
+	block1 := owner.newBlock(t, genesis, groupItem.GroupId, 1, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-producer", quorumpb.TrxType_PRODUCER, bundle),
+	})
+	block2 := owner.newBlock(t, block1, groupItem.GroupId, 2, nil)
+	block3 := producer.newBlock(t, block2, groupItem.GroupId, 3, nil)
+	for _, block := range []*quorumpb.Block{genesis, block1, block2, block3} {
+		if err := dbMgr.SaveBlock(block, false, testNodename); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := cs.VerifyChain(groupItem.GroupId, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Ok {
+		t.Fatalf("expect a valid chain, got %+v", result.Issues)
+	}
+
+	//the scheduled list is replayed and activated after block 2
+	replayed, _ := newTestChainStorage(t)
+	if _, err := replayed.ReplayState(cs, groupItem.GroupId, &ReplayOptions{ToBlock: 1}, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if scheduled, _ := replayed.GetScheduledProducers(groupItem.GroupId, testNodename); len(scheduled) != 1 {
+		t.Errorf("expect the producer list scheduled after block 1, got %+v", scheduled)
+	}
+	replayed, _ = newTestChainStorage(t)
+	if _, err := replayed.ReplayState(cs, groupItem.GroupId, &ReplayOptions{ToBlock: 2}, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	producers, _ := replayed.GetProducers(groupItem.GroupId, testNodename)
+	found := false
+	for _, item := range producers {
+		found = found || item.ProducerPubkey == producer.pubkey
+	}
+	if !found {
+		t.Errorf("expect the producer activated after block 2, got %+v", producers)
+	}
+}
@@ -0,0 +1,309 @@
+package chainstorage
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	s "github.com/rumsystem/quorum/internal/pkg/storage"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+type ReplayOptions struct {
+	ToBlock      uint64 // stop after this block applied, 0 means the head of the chain
+	ProducerNode bool   // apply trxs as a producer node, POST and APP_CONFIG are skipped
+	// decrypt POST of private groups, the data is set to empty if it is nil or failed
+	DecryptPost func(data []byte) ([]byte, error)
+	// decrypt the group cipher key in GROUP_KEY trxs encrypted to this node
+	DecryptGroupKey func(data []byte) ([]byte, error)
+}
+
+type ReplayResult struct {
+	GroupId      string `json:"group_id"`
+	FromBlock    uint64 `json:"from_block"` // the snapshot height +1 if replayed from a snapshot, or 0
+	ToBlock      uint64 `json:"to_block"`
+	FromSnapshot bool   `json:"from_snapshot"`
+	Blocks       int    `json:"blocks"`
+	Trxs         int    `json:"trxs"`         // trxs applied
+	SkippedTrxs  int    `json:"skipped_trxs"` // trxs already applied or not handled by the node type
+	ItemsCount   int    `json:"items_count"`
+	StateDigest  string `json:"state_digest"` // hex state hash of all derived items, see GetStateDigest
+
+	// filled by the caller, compare the replayed state with the current state if replayed to the head
+	CurrentDigest string `json:"current_digest,omitempty"`
+	Match         bool   `json:"match"`
+	Applied       bool   `json:"applied"`
+}
+
+// GetStateDigest returns the hex state hash and the count of all state items of the group, comparable across nodes.
+// The owner producer item is added locally with the timestamp and sign of each node when the group is created or joined,
+// so only its GroupId and pubkeys are hashed.
+func (cs *Storage) GetStateDigest(groupId string, prefix ...string) (string, int, error) {
+	groupItem, err := cs.GetGroupInfo(groupId)
+	if err != nil {
+		return "", 0, err
+	}
+	items, err := cs.GetStateItems(groupId, prefix...)
+	if err != nil {
+		return "", 0, err
+	}
+
+	producerPrefix := s.GetProducerPrefix(groupId)
+	owner := ethPubkey(groupItem.OwnerPubKey)
+	for i, item := range items {
+		if !strings.HasPrefix(item.Key, producerPrefix) {
+			continue
+		}
+		producer := &quorumpb.ProducerItem{}
+		if err := proto.Unmarshal(item.Value, producer); err != nil || ethPubkey(producer.ProducerPubkey) != owner {
+			continue
+		}
+		value, err := proto.Marshal(ownerProducerItem(groupItem))
+		if err != nil {
+			return "", 0, err
+		}
+		items[i] = &quorumpb.SnapshotItem{Key: item.Key, Value: value}
+	}
+
+	hash, err := rumchaindata.GetSnapshotStateHash(items)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hash), len(items), nil
+}
+
+// ReplayState re-derives the state of the group by applying the blocks stored in src to cs from scratch,
+// in the same way as the chain applies trxs. cs should be a fresh db or src itself, the state and trxs of the group in it are removed first.
+// A pruned chain is replayed from the latest snapshot saved in src.
+func (cs *Storage) ReplayState(src *Storage, groupId string, opts *ReplayOptions, prefix ...string) (*ReplayResult, error) {
+	if opts == nil {
+		opts = &ReplayOptions{}
+	}
+	groupItem, err := src.GetGroupInfo(groupId)
+	if err != nil {
+		return nil, err
+	}
+	if cs != src {
+		if err := cs.AddGroup(groupItem); err != nil {
+			return nil, err
+		}
+	}
+
+	//the owner is added as the first producer when the group is created or joined, not by a trx.
+	//read it before the state is cleared, src is cs when replayed in place
+	owner := ownerProducerItem(groupItem)
+	producers, err := src.GetProducers(groupId, prefix...)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range producers {
+		if ethPubkey(item.ProducerPubkey) == ethPubkey(groupItem.OwnerPubKey) {
+			owner = item
+			break
+		}
+	}
+
+	//clear the derived state and applied trxs
+	clearPrefixes := append(GetGroupStatePrefixes(groupId, prefix...), s.GetTrxPrefix(groupId, prefix...))
+	for _, key := range clearPrefixes {
+		if _, err := cs.dbmgr.Db.PrefixDelete([]byte(key)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cs.AddProducer(owner, prefix...); err != nil {
+		return nil, err
+	}
+
+	head, err := src.GetHeadBlockId(groupId, prefix...)
+	if err != nil {
+		return nil, err
+	}
+	toBlock := head
+	if opts.ToBlock > 0 {
+		if opts.ToBlock > head {
+			return nil, fmt.Errorf("block %d is after the head %d", opts.ToBlock, head)
+		}
+		toBlock = opts.ToBlock
+	}
+
+	result := &ReplayResult{GroupId: groupId, ToBlock: toBlock}
+
+	lowest, err := src.GetLowestBlockId(groupId, prefix...)
+	if err != nil {
+		return nil, err
+	}
+	if lowest > 1 {
+		snapshot, err := src.GetSnapshot(groupId, prefix...)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot == nil || snapshot.BlockId+1 < lowest || snapshot.BlockId > toBlock {
+			return nil, fmt.Errorf("blocks before %d are pruned, no snapshot to replay from", lowest)
+		}
+		if valid, err := rumchaindata.ValidSnapshotItems(snapshot, snapshot.Items); !valid {
+			if err == nil {
+				err = fmt.Errorf("state hash mismatch")
+			}
+			return nil, fmt.Errorf("invalid snapshot at block %d: %s", snapshot.BlockId, err)
+		}
+		if err := cs.ApplyStateItems(groupId, snapshot.Items, prefix...); err != nil {
+			return nil, err
+		}
+		result.FromSnapshot = true
+		result.FromBlock = snapshot.BlockId + 1
+	}
+
+	for blockId := result.FromBlock; blockId <= toBlock; blockId++ {
+		exist, err := src.dbmgr.IsBlockExist(groupId, blockId, false, prefix...)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, fmt.Errorf("block %d not found, replay stopped", blockId)
+		}
+		block, err := src.dbmgr.GetBlock(groupId, blockId, false, prefix...)
+		if err != nil {
+			return nil, err
+		}
+		if err := cs.replayTrxs(groupItem, block, opts, result, prefix...); err != nil {
+			return nil, fmt.Errorf("apply block %d failed: %s", blockId, err)
+		}
+		//producer lists scheduled are activated when the chain moves to the next epoch
+		if activated, err := cs.ActivateScheduledProducers(groupId, block.Epoch+1, prefix...); err != nil {
+			return nil, err
+		} else if activated {
+			if err := cs.UpdateAnnouncedProducerResults(groupId, prefix...); err != nil {
+				return nil, err
+			}
+		}
+		result.Blocks++
+	}
+
+	result.StateDigest, result.ItemsCount, err = cs.GetStateDigest(groupId, prefix...)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CopyTrxs copies the trxs applied in src to cs, the trxs not applied in src are removed from cs.
+// It follows ApplyStateItems when the state is replayed into another db and swapped in.
+func (cs *Storage) CopyTrxs(src *Storage, groupId string, trxIds []string, prefix ...string) error {
+	var keys, values [][]byte
+	for _, trxId := range trxIds {
+		key := []byte(s.GetTrxKey(groupId, trxId, prefix...))
+		exist, err := src.dbmgr.Db.IsExist(key)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			if err := cs.dbmgr.Db.Delete(key); err != nil {
+				return err
+			}
+			continue
+		}
+		value, err := src.dbmgr.Db.Get(key)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return cs.dbmgr.Db.BatchWrite(keys, values)
+}
+
+func ownerProducerItem(groupItem *quorumpb.GroupItem) *quorumpb.ProducerItem {
+	return &quorumpb.ProducerItem{
+		GroupId:          groupItem.GroupId,
+		ProducerPubkey:   groupItem.OwnerPubKey,
+		GroupOwnerPubkey: groupItem.OwnerPubKey,
+	}
+}
+
+// replayTrxs applies the trxs as ApplyTrxsFullNode/ApplyTrxsProducerNode
+func (cs *Storage) replayTrxs(groupItem *quorumpb.GroupItem, block *quorumpb.Block, opts *ReplayOptions, result *ReplayResult, prefix ...string) error {
+	for _, blockTrx := range block.Trxs {
+		if opts.ProducerNode && (blockTrx.Type == quorumpb.TrxType_APP_CONFIG || blockTrx.Type == quorumpb.TrxType_POST) {
+			result.SkippedTrxs++
+			continue
+		}
+
+		isExist, err := cs.IsTrxExist(blockTrx.GroupId, blockTrx.TrxId, prefix...)
+		if err != nil {
+			return err
+		}
+		if isExist {
+			result.SkippedTrxs++
+			continue
+		}
+
+		//keep the block untouched
+		trx := proto.Clone(blockTrx).(*quorumpb.Trx)
+		if trx.Type == quorumpb.TrxType_POST && groupItem.EncryptType == quorumpb.GroupEncryptType_PRIVATE {
+			trx.Data = []byte("")
+			if opts.DecryptPost != nil {
+				if decryptData, err := opts.DecryptPost(blockTrx.Data); err == nil {
+					trx.Data = decryptData
+				}
+			}
+		} else {
+			decryptData, err := cs.DecryptTrxData(groupItem, trx, block.BlockId, prefix...)
+			if IsGroupKeyErr(err) {
+				//the chain saves the trx without applied too
+				logger.Warnf("<%s> replay trx <%s> skipped: %s", groupItem.GroupId, trx.TrxId, err.Error())
+				if err := cs.AddTrx(blockTrx, prefix...); err != nil {
+					return err
+				}
+				result.SkippedTrxs++
+				continue
+			} else if err != nil {
+				return err
+			}
+			trx.Data = decryptData
+		}
+
+		switch trx.Type {
+		case quorumpb.TrxType_POST:
+			err = cs.AddPost(trx, prefix...)
+		case quorumpb.TrxType_PRODUCER:
+			if err = cs.UpdateProducerTrx(trx, prefix...); err == nil {
+				err = cs.UpdateAnnouncedProducerResults(groupItem.GroupId, prefix...)
+			}
+		case quorumpb.TrxType_USER:
+			if err = cs.UpdateUserTrx(trx, prefix...); err == nil {
+				err = cs.UpdateAnnouncedUserResults(groupItem.GroupId, prefix...)
+			}
+		case quorumpb.TrxType_ANNOUNCE:
+			err = cs.UpdateAnnounce(trx.Data, prefix...)
+		case quorumpb.TrxType_APP_CONFIG:
+			err = cs.UpdateAppConfigTrx(trx, prefix...)
+		case quorumpb.TrxType_CHAIN_CONFIG:
+			err = cs.UpdateChainConfigTrx(trx, prefix...)
+		case quorumpb.TrxType_STAKE:
+			//ignored by the groups not POS
+			if groupItem.ConsenseType == quorumpb.GroupConsenseType_POS {
+				err = cs.UpdateStakeTrx(trx, prefix...)
+			}
+		case quorumpb.TrxType_GROUP_KEY:
+			if _, err = cs.UpdateGroupKeyTrx(groupItem, trx, block.BlockId, block.TimeStamp, opts.DecryptGroupKey, prefix...); err == ErrNotKeyRecipient {
+				err = nil
+			}
+		}
+		if err != nil {
+			//the chain ignores the apply errors too, the trx is still saved
+			logger.Warnf("<%s> replay trx <%s> failed: %s", groupItem.GroupId, trx.TrxId, err.Error())
+		}
+
+		if err := cs.AddTrx(blockTrx, prefix...); err != nil {
+			return err
+		}
+		result.Trxs++
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+//go:build !js
+// +build !js
+
+package chainstorage
+
+import (
+	"testing"
+
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReplayState(t *testing.T) {
+	src, srcMgr := newTestChainStorage(t)
+	owner := newTestSigner(t)
+	producer := newTestSigner(t)
+
+	groupItem := &quorumpb.GroupItem{
+		GroupId:     "5e8a43c4-3b7c-4ac1-a4ad-0cf0f6a1e1b2",
+		GroupName:   "replay",
+		OwnerPubKey: owner.pubkey,
+		CipherKey:   "71eff58163d557b609a15050a5f7561568eb8bb582156697ba9fc99ca9236582",
+	}
+	genesis := owner.newBlock(t, nil, groupItem.GroupId, 0, nil)
+	groupItem.GenesisBlock = genesis
+	if err := src.AddGroup(groupItem); err != nil {
+		t.Fatal(err)
+	}
+	//added locally when the group is created, not by a trx
+	if err := src.AddProducer(&quorumpb.ProducerItem{GroupId: groupItem.GroupId, ProducerPubkey: owner.pubkey, GroupOwnerPubkey: owner.pubkey, TimeStamp: 42, Memo: "owner"}, testNodename); err != nil {
+		t.Fatal(err)
+	}
+
+	announce, _ := proto.Marshal(&quorumpb.AnnounceItem{GroupId: groupItem.GroupId, SignPubkey: producer.pubkey, Type: quorumpb.AnnounceType_AS_PRODUCER, Action: quorumpb.ActionType_ADD})
+	bundle, _ := proto.Marshal(&quorumpb.BFTProducerBundleItem{Producers: []*quorumpb.ProducerItem{
+		{GroupId: groupItem.GroupId, ProducerPubkey: producer.pubkey},
+	}})
+	block1 := owner.newBlock(t, genesis, groupItem.GroupId, 1, []*quorumpb.Trx{
+		producer.newTrx(t, groupItem, "trx-announce", quorumpb.TrxType_ANNOUNCE, announce),
+		owner.newTrx(t, groupItem, "trx-post", quorumpb.TrxType_POST, []byte(`{"type":"Note","content":"hello"}`)),
+	})
+	block2 := owner.newBlock(t, block1, groupItem.GroupId, 2, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-producer", quorumpb.TrxType_PRODUCER, bundle),
+		//applied once only
+		block1.Trxs[1],
+	})
+	for _, block := range []*quorumpb.Block{genesis, block1, block2} {
+		if err := srcMgr.SaveBlock(block, false, testNodename); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	replay := func(opts *ReplayOptions) (*Storage, *ReplayResult) {
+		dst, _ := newTestChainStorage(t)
+		result, err := dst.ReplayState(src, groupItem.GroupId, opts, testNodename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dst, result
+	}
+
+	dst, result := replay(nil)
+	if result.ToBlock != 2 || result.Blocks != 3 || result.Trxs != 3 || result.SkippedTrxs != 1 || result.StateDigest == "" {
+		t.Fatalf("unexpected replay result %+v", result)
+	}
+	announced, err := dst.GetAnnouncedProducer(groupItem.GroupId, producer.pubkey, testNodename)
+	if err != nil || announced.Result != quorumpb.ApproveType_APPROVED {
+		t.Errorf("announced producer should be approved after the producer trx, got %+v, %v", announced, err)
+	}
+	if producers, _ := dst.GetProducers(groupItem.GroupId, testNodename); len(producers) != 2 {
+		t.Errorf("expect owner and producer, got %+v", producers)
+	}
+
+	//deterministic
+	_, again := replay(nil)
+	if again.StateDigest != result.StateDigest {
+		t.Errorf("replay digest changed: %s != %s", again.StateDigest, result.StateDigest)
+	}
+
+	//stop at block 1
+	_, partial := replay(&ReplayOptions{ToBlock: 1})
+	if partial.ToBlock != 1 || partial.Trxs != 2 || partial.StateDigest == result.StateDigest {
+		t.Errorf("unexpected partial replay result %+v", partial)
+	}
+
+	//producer node skips POST
+	_, producerResult := replay(&ReplayOptions{ProducerNode: true})
+	if producerResult.Trxs != 2 || producerResult.StateDigest == result.StateDigest {
+		t.Errorf("unexpected producer replay result %+v", producerResult)
+	}
+
+	//the digest of the replayed state applied to src is the same, the local owner item is not hashed
+	items, err := dst.GetStateItems(groupItem.GroupId, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.ApplyStateItems(groupItem.GroupId, items, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.AddProducer(&quorumpb.ProducerItem{GroupId: groupItem.GroupId, ProducerPubkey: owner.pubkey, GroupOwnerPubkey: owner.pubkey, TimeStamp: 43}, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	digest, _, err := src.GetStateDigest(groupItem.GroupId, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != result.StateDigest {
+		t.Errorf("digest of src %s != replayed %s", digest, result.StateDigest)
+	}
+
+	if _, err := dst.ReplayState(src, groupItem.GroupId, &ReplayOptions{ToBlock: 3}, testNodename); err == nil {
+		t.Errorf("replay after the head should fail")
+	}
+}
+
+func TestReplaySwapFork(t *testing.T) {
+	cs, dbMgr := newTestChainStorage(t)
+	owner := newTestSigner(t)
+	producer := newTestSigner(t)
+
+	groupItem := &quorumpb.GroupItem{
+		GroupId:     "9b4e2d7a-6c1f-4e3b-8a5d-2f0c7e9b1a46",
+		OwnerPubKey: owner.pubkey,
+		CipherKey:   "71eff58163d557b609a15050a5f7561568eb8bb582156697ba9fc99ca9236582",
+	}
+	genesis := owner.newBlock(t, nil, groupItem.GroupId, 0, nil)
+	groupItem.GenesisBlock = genesis
+	if err := cs.AddGroup(groupItem); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, _ := proto.Marshal(&quorumpb.BFTProducerBundleItem{Producers: []*quorumpb.ProducerItem{
+		{GroupId: groupItem.GroupId, ProducerPubkey: owner.pubkey},
+		{GroupId: groupItem.GroupId, ProducerPubkey: producer.pubkey},
+	}})
+	block1 := owner.newBlock(t, genesis, groupItem.GroupId, 1, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-a", quorumpb.TrxType_POST, []byte(`{"type":"Note","content":"a"}`)),
+	})
+	block2 := owner.newBlock(t, block1, groupItem.GroupId, 2, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-producer", quorumpb.TrxType_PRODUCER, bundle),
+		owner.newTrx(t, groupItem, "trx-b", quorumpb.TrxType_POST, []byte(`{"type":"Note","content":"b"}`)),
+	})
+	for _, block := range []*quorumpb.Block{genesis, block1, block2} {
+		if err := dbMgr.SaveBlock(block, false, testNodename); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := cs.ReplayState(cs, groupItem.GroupId, nil, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if producers, _ := cs.GetProducers(groupItem.GroupId, testNodename); len(producers) != 2 {
+		t.Fatalf("expect 2 producers before the fork, got %+v", producers)
+	}
+
+	//block 2 is replaced by a block without the producer trx
+	fork := owner.newBlock(t, block1, groupItem.GroupId, 2, []*quorumpb.Trx{block2.Trxs[1]})
+	if err := cs.RmBlock(groupItem.GroupId, 2, false, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if err := dbMgr.SaveBlock(fork, false, testNodename); err != nil {
+		t.Fatal(err)
+	}
+
+	dst, _ := newTestChainStorage(t)
+	result, err := dst.ReplayState(cs, groupItem.GroupId, &ReplayOptions{ToBlock: 2}, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := dst.GetStateItems(groupItem.GroupId, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.ApplyStateItems(groupItem.GroupId, items, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.CopyTrxs(dst, groupItem.GroupId, []string{"trx-b", "trx-producer"}, testNodename); err != nil {
+		t.Fatal(err)
+	}
+
+	if producers, _ := cs.GetProducers(groupItem.GroupId, testNodename); len(producers) != 1 {
+		t.Errorf("producer trx of the replaced block should be rolled back, got %+v", producers)
+	}
+	if digest, _, _ := cs.GetStateDigest(groupItem.GroupId, testNodename); digest != result.StateDigest {
+		t.Errorf("digest %s != replayed %s", digest, result.StateDigest)
+	}
+	for trxId, expect := range map[string]bool{"trx-a": true, "trx-b": true, "trx-producer": false} {
+		if exist, _ := cs.IsTrxExist(groupItem.GroupId, trxId, testNodename); exist != expect {
+			t.Errorf("trx <%s> exist should be %v", trxId, expect)
+		}
+	}
+}
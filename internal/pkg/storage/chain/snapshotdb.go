@@ -0,0 +1,197 @@
+package chainstorage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	s "github.com/rumsystem/quorum/internal/pkg/storage"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// keys per batch when write snapshot items
+const snapshotBatchSize = 1000
+
+// GetGroupStatePrefixes returns the key prefixes of all state derived from the applied trxs of a group
+func GetGroupStatePrefixes(groupId string, prefix ...string) []string {
+	return []string{
+		s.GetPostPrefix(groupId, prefix...),
+		s.GetProducerPrefix(groupId, prefix...),
+		s.GetUserPrefix(groupId, prefix...),
+		s.GetAnnouncedPrefix(groupId, prefix...),
+		s.GetSchemaPrefix(groupId, prefix...),
+		s.GetChainConfigPrefix(groupId, prefix...),
+		s.GetAppConfigPrefix(groupId, prefix...),
+		s.GetProducerTrxIDKey(groupId, prefix...),
+		s.GetScheduledProducerPrefix(groupId, prefix...),
+		s.GetStakePrefix(groupId, prefix...),
+	}
+}
+
+// GetStateItems returns all state items of a group, keys are without the node prefix
+func (cs *Storage) GetStateItems(groupId string, prefix ...string) ([]*quorumpb.SnapshotItem, error) {
+	var items []*quorumpb.SnapshotItem
+	nodeprefix := utils.GetPrefix(prefix...)
+
+	for _, key := range GetGroupStatePrefixes(groupId, prefix...) {
+		err := cs.dbmgr.Db.PrefixForeach([]byte(key), func(k []byte, v []byte, err error) error {
+			if err != nil {
+				return err
+			}
+			items = append(items, &quorumpb.SnapshotItem{
+				Key:   strings.TrimPrefix(string(k), nodeprefix),
+				Value: v,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+// ApplyStateItems replaces all state of a group with the snapshot items
+func (cs *Storage) ApplyStateItems(groupId string, items []*quorumpb.SnapshotItem, prefix ...string) error {
+	statePrefixes := GetGroupStatePrefixes(groupId)
+	nodeprefix := utils.GetPrefix(prefix...)
+
+	//snapshot items should only touch the state of this group
+	for _, item := range items {
+		valid := false
+		for _, p := range statePrefixes {
+			if strings.HasPrefix(item.Key, p) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid snapshot item key <%s>", item.Key)
+		}
+	}
+
+	for _, key := range GetGroupStatePrefixes(groupId, prefix...) {
+		if _, err := cs.dbmgr.Db.PrefixDelete([]byte(key)); err != nil {
+			return err
+		}
+	}
+
+	for start := 0; start < len(items); start += snapshotBatchSize {
+		end := start + snapshotBatchSize
+		if end > len(items) {
+			end = len(items)
+		}
+
+		keys := [][]byte{}
+		values := [][]byte{}
+		for _, item := range items[start:end] {
+			keys = append(keys, []byte(nodeprefix+item.Key))
+			values = append(values, item.Value)
+		}
+		if err := cs.dbmgr.Db.BatchWrite(keys, values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SaveSnapshot saves the snapshot as the latest snapshot of the group
+func (cs *Storage) SaveSnapshot(snapshot *quorumpb.Snapshot, prefix ...string) error {
+	key := s.GetSnapshotKey(snapshot.GroupId, prefix...)
+	value, err := proto.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return cs.dbmgr.Db.Set([]byte(key), value)
+}
+
+// GetSnapshot returns the latest snapshot of the group, nil if no snapshot saved
+func (cs *Storage) GetSnapshot(groupId string, prefix ...string) (*quorumpb.Snapshot, error) {
+	key := s.GetSnapshotKey(groupId, prefix...)
+	value, err := cs.dbmgr.Db.Get([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	snapshot := &quorumpb.Snapshot{}
+	if err := proto.Unmarshal(value, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetLowestBlockId returns the lowest block kept on chain, blocks before it (except the genesis block) are pruned
+func (cs *Storage) GetLowestBlockId(groupId string, prefix ...string) (uint64, error) {
+	key := s.GetChainInfoLowestBlock(groupId, prefix...)
+	value, err := cs.dbmgr.Db.Get([]byte(key))
+	if err != nil {
+		return 0, err
+	}
+	if len(value) != 8 {
+		return 0, nil
+	}
+	return binary.LittleEndian.Uint64(value), nil
+}
+
+func (cs *Storage) SetLowestBlockId(groupId string, blockId uint64, prefix ...string) error {
+	key := s.GetChainInfoLowestBlock(groupId, prefix...)
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, blockId)
+	return cs.dbmgr.Db.Set([]byte(key), b)
+}
+
+// PruneBlocks removes blocks before toBlock and their trxs, POST trxs are kept since app content is read from them.
+// The genesis block is always kept. Returns the number of blocks removed.
+func (cs *Storage) PruneBlocks(groupId string, toBlock uint64, prefix ...string) (int, error) {
+	fromBlock, err := cs.GetLowestBlockId(groupId, prefix...)
+	if err != nil {
+		return 0, err
+	}
+	if fromBlock == 0 {
+		fromBlock = 1
+	}
+
+	pruned := 0
+	for blockId := fromBlock; blockId < toBlock; blockId++ {
+		key := s.GetBlockKey(groupId, blockId, prefix...)
+		value, err := cs.dbmgr.Db.Get([]byte(key))
+		if err != nil {
+			return pruned, err
+		}
+		if value == nil {
+			continue
+		}
+
+		block := &quorumpb.Block{}
+		if err := proto.Unmarshal(value, block); err != nil {
+			return pruned, err
+		}
+		for _, trx := range block.Trxs {
+			if trx.Type == quorumpb.TrxType_POST {
+				continue
+			}
+			if err := cs.dbmgr.Db.Delete([]byte(s.GetTrxKey(groupId, trx.TrxId, prefix...))); err != nil {
+				return pruned, err
+			}
+		}
+		if err := cs.dbmgr.Db.Delete([]byte(key)); err != nil {
+			return pruned, err
+		}
+		pruned++
+	}
+
+	if toBlock > fromBlock {
+		if err := cs.SetLowestBlockId(groupId, toBlock, prefix...); err != nil {
+			return pruned, err
+		}
+	}
+
+	return pruned, nil
+}
@@ -0,0 +1,153 @@
+package chainstorage
+
+import (
+	"errors"
+	"fmt"
+
+	s "github.com/rumsystem/quorum/internal/pkg/storage"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// UpdateStakeTrx applies a STAKE trx, a producer of a POS group can only stake or unstake for itself,
+// and only stake if allowed by the trx auth of STAKE, which follows the allow list by default
+func (cs *Storage) UpdateStakeTrx(trx *quorumpb.Trx, prefix ...string) error {
+	item, err := decodeStakeTrx(trx.GroupId, trx.SenderPubkey, trx.Data)
+	if err != nil {
+		return err
+	}
+	if item.Action == quorumpb.ActionType_ADD && !cs.isStakeAllowed(item.GroupId, prefix...)(item.StakerPubkey) {
+		return fmt.Errorf("<%s> is not allowed to stake", item.StakerPubkey)
+	}
+	return cs.UpdateStake(item, prefix...)
+}
+
+// isStakeAllowed returns the check of the trx auth of STAKE, the errors are taken as not allowed
+func (cs *Storage) isStakeAllowed(groupId string, prefix ...string) func(pubkey string) bool {
+	return func(pubkey string) bool {
+		allowed, err := cs.CheckTrxTypeAuth(groupId, pubkey, quorumpb.TrxType_STAKE, prefix...)
+		if err != nil {
+			chaindb_log.Warningf("<%s> check stake auth of <%s> failed: %s", groupId, pubkey, err.Error())
+		}
+		return err == nil && allowed
+	}
+}
+
+// decodeStakeTrx decodes the decrypted data of a STAKE trx and checks it is sent by the staker
+func decodeStakeTrx(groupId string, sender string, data []byte) (*quorumpb.StakeItem, error) {
+	item := &quorumpb.StakeItem{}
+	if err := proto.Unmarshal(data, item); err != nil {
+		return nil, err
+	}
+
+	if item.GroupId != groupId {
+		return nil, fmt.Errorf("stake item group <%s> mismatch with trx", item.GroupId)
+	}
+	if ethPubkey(item.StakerPubkey) != ethPubkey(sender) {
+		return nil, errors.New("staker mismatch with trx sender")
+	}
+	return item, nil
+}
+
+// UpdateStake adds or removes the amount to the stake of the staker, the stake is removed if nothing left
+func (cs *Storage) UpdateStake(item *quorumpb.StakeItem, prefix ...string) error {
+	key := s.GetStakeKey(item.GroupId, item.StakerPubkey, prefix...)
+	curr, err := cs.GetStake(item.GroupId, item.StakerPubkey, prefix...)
+	if err != nil {
+		return err
+	}
+
+	stake, err := applyStake(curr, item)
+	if err != nil {
+		return err
+	}
+	if stake == nil {
+		chaindb_log.Infof("<%s> remove all stake of <%s>", item.GroupId, item.StakerPubkey)
+		return cs.dbmgr.Db.Delete([]byte(key))
+	}
+
+	chaindb_log.Infof("<%s> update stake of <%s> to <%d>", item.GroupId, item.StakerPubkey, stake.Amount)
+	value, err := proto.Marshal(stake)
+	if err != nil {
+		return err
+	}
+	return cs.dbmgr.Db.Set([]byte(key), value)
+}
+
+// applyStake returns the stake after the item applied to curr, nil if nothing left
+func applyStake(curr *quorumpb.StakeItem, item *quorumpb.StakeItem) (*quorumpb.StakeItem, error) {
+	if item.Amount == 0 {
+		return nil, errors.New("stake amount should be greater than 0")
+	}
+
+	stake := &quorumpb.StakeItem{
+		GroupId:      item.GroupId,
+		StakerPubkey: item.StakerPubkey,
+		Action:       quorumpb.ActionType_ADD,
+		TimeStamp:    item.TimeStamp,
+		Memo:         item.Memo,
+	}
+
+	switch item.Action {
+	case quorumpb.ActionType_ADD:
+		if curr != nil {
+			stake.Amount = curr.Amount
+		}
+		if stake.Amount+item.Amount < stake.Amount {
+			return nil, errors.New("stake amount overflow")
+		}
+		stake.Amount += item.Amount
+		//stake costs nothing, a stake over the cap would win every slot
+		if stake.Amount > rumchaindata.POS_MAX_STAKE {
+			return nil, fmt.Errorf("stake amount <%d> exceeds the max stake <%d>", stake.Amount, rumchaindata.POS_MAX_STAKE)
+		}
+	case quorumpb.ActionType_REMOVE:
+		if curr == nil {
+			return nil, errors.New("Stake Not Found")
+		}
+		if item.Amount >= curr.Amount {
+			return nil, nil
+		}
+		stake.Amount = curr.Amount - item.Amount
+	default:
+		return nil, errors.New("unknow msgType")
+	}
+	return stake, nil
+}
+
+// GetStake returns nil if the pubkey has no stake
+func (cs *Storage) GetStake(groupId string, pubkey string, prefix ...string) (*quorumpb.StakeItem, error) {
+	key := s.GetStakeKey(groupId, pubkey, prefix...)
+	exist, err := cs.dbmgr.Db.IsExist([]byte(key))
+	if err != nil || !exist {
+		return nil, err
+	}
+
+	value, err := cs.dbmgr.Db.Get([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	item := &quorumpb.StakeItem{}
+	if err := proto.Unmarshal(value, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (cs *Storage) GetStakes(groupId string, prefix ...string) ([]*quorumpb.StakeItem, error) {
+	var items []*quorumpb.StakeItem
+	key := s.GetStakePrefix(groupId, prefix...)
+	err := cs.dbmgr.Db.PrefixForeach([]byte(key), func(k []byte, v []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		item := &quorumpb.StakeItem{}
+		if err := proto.Unmarshal(v, item); err != nil {
+			return err
+		}
+		items = append(items, item)
+		return nil
+	})
+	return items, err
+}
@@ -0,0 +1,188 @@
+//go:build !js
+// +build !js
+
+package chainstorage
+
+import (
+	"testing"
+
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// allowStakeConfig is the chain config allow-listing the staker to stake
+func allowStakeConfig(groupId string, pubkey string) []byte {
+	rule, _ := proto.Marshal(&quorumpb.ChainSendTrxRuleListItem{Action: quorumpb.ActionType_ADD, Pubkey: pubkey, Type: []quorumpb.TrxType{quorumpb.TrxType_STAKE}})
+	config, _ := proto.Marshal(&quorumpb.ChainConfigItem{GroupId: groupId, Type: quorumpb.ChainConfigType_UPD_ALW_LIST, Data: rule})
+	return config
+}
+
+func TestUpdateStakeTrx(t *testing.T) {
+	cs, _ := newTestChainStorage(t)
+	staker := newTestSigner(t)
+	other := newTestSigner(t)
+	groupId := "9b0e6f2c-7f1f-4d3c-8f5e-2a6c1d4b7e90"
+
+	stakeTrx := func(sender *testSigner, pubkey string, action quorumpb.ActionType, amount uint64) *quorumpb.Trx {
+		data, _ := proto.Marshal(&quorumpb.StakeItem{GroupId: groupId, StakerPubkey: pubkey, Action: action, Amount: amount})
+		return &quorumpb.Trx{GroupId: groupId, Type: quorumpb.TrxType_STAKE, SenderPubkey: sender.pubkey, Data: data}
+	}
+
+	//stake costs nothing, only the stakers allow-listed by the owner can stake by default
+	if err := cs.UpdateStakeTrx(stakeTrx(staker, staker.pubkey, quorumpb.ActionType_ADD, 10), testNodename); err == nil {
+		t.Fatalf("stake of the sender not in the allow list should be rejected")
+	}
+	if err := cs.UpdateChainConfig(allowStakeConfig(groupId, staker.pubkey), testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.UpdateStakeTrx(stakeTrx(other, other.pubkey, quorumpb.ActionType_ADD, 10), testNodename); err == nil {
+		t.Errorf("stake of the sender not in the allow list should be rejected")
+	}
+
+	if err := cs.UpdateStakeTrx(stakeTrx(staker, staker.pubkey, quorumpb.ActionType_ADD, 10), testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.UpdateStakeTrx(stakeTrx(staker, staker.pubkey, quorumpb.ActionType_ADD, 5), testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.UpdateStakeTrx(stakeTrx(staker, staker.pubkey, quorumpb.ActionType_ADD, rumchaindata.POS_MAX_STAKE), testNodename); err == nil {
+		t.Errorf("stake over the max stake should be rejected")
+	}
+	if err := cs.UpdateStakeTrx(stakeTrx(other, staker.pubkey, quorumpb.ActionType_REMOVE, 15), testNodename); err == nil {
+		t.Errorf("only the staker can unstake")
+	}
+	if err := cs.UpdateStakeTrx(stakeTrx(staker, staker.pubkey, quorumpb.ActionType_REMOVE, 3), testNodename); err != nil {
+		t.Fatal(err)
+	}
+
+	stake, err := cs.GetStake(groupId, staker.pubkey, testNodename)
+	if err != nil || stake == nil || stake.Amount != 12 {
+		t.Fatalf("expect stake 12, got %+v, %v", stake, err)
+	}
+
+	if err := cs.UpdateStakeTrx(stakeTrx(staker, staker.pubkey, quorumpb.ActionType_REMOVE, 20), testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if stakes, _ := cs.GetStakes(groupId, testNodename); len(stakes) != 0 {
+		t.Errorf("stake should be removed, got %+v", stakes)
+	}
+}
+
+func TestVerifyPosChain(t *testing.T) {
+	cs, dbMgr := newTestChainStorage(t)
+	owner := newTestSigner(t)
+	staker := newTestSigner(t)
+
+	groupItem := &quorumpb.GroupItem{
+		GroupId:      "3f7c2d1e-5b6a-4c8d-9e0f-1a2b3c4d5e6f",
+		GroupName:    "pos",
+		OwnerPubKey:  owner.pubkey,
+		CipherKey:    "71eff58163d557b609a15050a5f7561568eb8bb582156697ba9fc99ca9236582",
+		ConsenseType: quorumpb.GroupConsenseType_POS,
+	}
+	genesis := owner.newBlock(t, nil, groupItem.GroupId, 0, nil)
+	groupItem.GenesisBlock = genesis
+	if err := cs.AddGroup(groupItem); err != nil {
+		t.Fatal(err)
+	}
+
+	//no stake before block 1, the owner proposes it and allow-lists the staker
+	stake, _ := proto.Marshal(&quorumpb.StakeItem{GroupId: groupItem.GroupId, StakerPubkey: staker.pubkey, Action: quorumpb.ActionType_ADD, Amount: 10})
+	allow := allowStakeConfig(groupItem.GroupId, staker.pubkey)
+	block1 := owner.newBlock(t, genesis, groupItem.GroupId, 1, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-allow", quorumpb.TrxType_CHAIN_CONFIG, allow),
+		staker.newTrx(t, groupItem, "trx-stake", quorumpb.TrxType_STAKE, stake),
+	})
+	if err := cs.UpdateChainConfig(allow, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	//the staker is the only one can propose after block 1
+	block2 := staker.newBlock(t, block1, groupItem.GroupId, 2, nil)
+	for _, block := range []*quorumpb.Block{genesis, block1, block2} {
+		if err := dbMgr.SaveBlock(block, false, testNodename); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := cs.VerifyChain(groupItem.GroupId, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Ok {
+		t.Fatalf("expect a valid pos chain, got %+v", result.Issues)
+	}
+
+	//block proposed by the owner is rejected once someone staked
+	block3 := owner.newBlock(t, block2, groupItem.GroupId, 3, nil)
+	if err := dbMgr.SaveBlock(block3, false, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	result, err = cs.VerifyChain(groupItem.GroupId, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !issueKinds(result)[ChainIssueUnknownProducer] {
+		t.Errorf("expect unknown producer issue, got %+v", result.Issues)
+	}
+
+	//the stake is replayed as state
+	replayed, _ := newTestChainStorage(t)
+	if _, err := replayed.ReplayState(cs, groupItem.GroupId, &ReplayOptions{ToBlock: 2}, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	stakes, _ := replayed.GetStakes(groupItem.GroupId, testNodename)
+	if len(stakes) != 1 || stakes[0].Amount != 10 {
+		t.Errorf("expect replayed stake, got %+v", stakes)
+	}
+	if proposer := rumchaindata.GetPosProposer(stakes, block2, 3, owner.pubkey); proposer != staker.pubkey {
+		t.Errorf("expect the staker as proposer, got %s", proposer)
+	}
+}
+
+func TestVerifyPosChainStakeNotAllowed(t *testing.T) {
+	cs, dbMgr := newTestChainStorage(t)
+	owner := newTestSigner(t)
+	staker := newTestSigner(t)
+
+	groupItem := &quorumpb.GroupItem{
+		GroupId:      "5e2d7a9c-1b3f-4e6d-8a0c-9f1e2d3c4b5a",
+		GroupName:    "pos",
+		OwnerPubKey:  owner.pubkey,
+		CipherKey:    "71eff58163d557b609a15050a5f7561568eb8bb582156697ba9fc99ca9236582",
+		ConsenseType: quorumpb.GroupConsenseType_POS,
+	}
+	genesis := owner.newBlock(t, nil, groupItem.GroupId, 0, nil)
+	groupItem.GenesisBlock = genesis
+	if err := cs.AddGroup(groupItem); err != nil {
+		t.Fatal(err)
+	}
+
+	//the stake of the sender not in the allow list is ignored, so the staker can't propose
+	stake, _ := proto.Marshal(&quorumpb.StakeItem{GroupId: groupItem.GroupId, StakerPubkey: staker.pubkey, Action: quorumpb.ActionType_ADD, Amount: 10})
+	block1 := owner.newBlock(t, genesis, groupItem.GroupId, 1, []*quorumpb.Trx{
+		staker.newTrx(t, groupItem, "trx-stake", quorumpb.TrxType_STAKE, stake),
+	})
+	block2 := staker.newBlock(t, block1, groupItem.GroupId, 2, nil)
+	for _, block := range []*quorumpb.Block{genesis, block1, block2} {
+		if err := dbMgr.SaveBlock(block, false, testNodename); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := cs.VerifyChain(groupItem.GroupId, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !issueKinds(result)[ChainIssueUnknownProducer] {
+		t.Errorf("expect unknown producer issue, got %+v", result.Issues)
+	}
+
+	replayed, _ := newTestChainStorage(t)
+	if _, err := replayed.ReplayState(cs, groupItem.GroupId, &ReplayOptions{ToBlock: 2}, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	if stakes, _ := replayed.GetStakes(groupItem.GroupId, testNodename); len(stakes) != 0 {
+		t.Errorf("stake not allowed should not be replayed, got %+v", stakes)
+	}
+}
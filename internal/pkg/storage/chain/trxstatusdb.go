@@ -0,0 +1,80 @@
+package chainstorage
+
+import (
+	"encoding/json"
+
+	s "github.com/rumsystem/quorum/internal/pkg/storage"
+)
+
+type TrxStatus string
+
+const (
+	TrxStatusPending TrxStatus = "pending"  //sent, waiting to be packaged
+	TrxStatusOnChain TrxStatus = "on-chain" //seen in an applied block
+	TrxStatusExpired TrxStatus = "expired"  //not packaged before it expired
+	TrxStatusFailed  TrxStatus = "failed"   //can't be sent, or not packaged after all resends
+)
+
+// TrxStatusItem tracks a trx sent by this node until it is packaged
+type TrxStatusItem struct {
+	TrxId       string    `json:"trx_id"`
+	GroupId     string    `json:"group_id"`
+	Type        string    `json:"type"`
+	Status      TrxStatus `json:"status"`
+	ResendCount int64     `json:"resend_count"`
+	TimeStamp   int64     `json:"timestamp"`
+	Expired     int64     `json:"expired"`
+	LastSent    int64     `json:"last_sent"`
+	UpdatedAt   int64     `json:"updated_at"`
+	Error       string    `json:"error,omitempty"`
+	Trx         []byte    `json:"trx,omitempty"` //signed trx to resend, dropped when the status is final
+}
+
+func (item *TrxStatusItem) IsFinal() bool {
+	return item.Status != TrxStatusPending
+}
+
+func (cs *Storage) SaveTrxStatus(item *TrxStatusItem, prefix ...string) error {
+	value, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	key := s.GetTrxStatusKey(item.GroupId, item.TrxId, prefix...)
+	return cs.dbmgr.Db.Set([]byte(key), value)
+}
+
+// GetTrxStatus returns nil if the trx is not tracked
+func (cs *Storage) GetTrxStatus(groupId, trxId string, prefix ...string) (*TrxStatusItem, error) {
+	key := s.GetTrxStatusKey(groupId, trxId, prefix...)
+	value, err := cs.dbmgr.Db.Get([]byte(key))
+	if err != nil || value == nil {
+		return nil, err
+	}
+	item := &TrxStatusItem{}
+	if err := json.Unmarshal(value, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (cs *Storage) GetAllTrxStatus(groupId string, prefix ...string) ([]*TrxStatusItem, error) {
+	var items []*TrxStatusItem
+	key := s.GetTrxStatusPrefix(groupId, prefix...)
+	err := cs.dbmgr.Db.PrefixForeach([]byte(key), func(k []byte, v []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		item := &TrxStatusItem{}
+		if err := json.Unmarshal(v, item); err != nil {
+			return err
+		}
+		items = append(items, item)
+		return nil
+	})
+	return items, err
+}
+
+func (cs *Storage) RemoveTrxStatus(groupId, trxId string, prefix ...string) error {
+	key := s.GetTrxStatusKey(groupId, trxId, prefix...)
+	return cs.dbmgr.Db.Delete([]byte(key))
+}
@@ -103,3 +103,28 @@ func (cs *Storage) IsUser(groupId, userPubKey string, prefix ...string) (bool, e
 	key := s.GetAnnounceAsUserKey(groupId, userPubKey, prefix...)
 	return cs.dbmgr.Db.IsExist([]byte(key))
 }
+
+// UpdateAnnouncedUserResults marks the announced users approved if they are in the user list, or not approved
+func (cs *Storage) UpdateAnnouncedUserResults(groupId string, prefix ...string) error {
+	users, err := cs.GetUsers(groupId, prefix...)
+	if err != nil {
+		return err
+	}
+	userPool := make(map[string]bool)
+	for _, item := range users {
+		userPool[item.UserPubkey] = true
+	}
+
+	announcedUsers, err := cs.GetAnnounceUsersByGroup(groupId, prefix...)
+	if err != nil {
+		return err
+	}
+	var firstErr error
+	for _, item := range announcedUsers {
+		err := cs.UpdateAnnounceResult(quorumpb.AnnounceType_AS_USER, groupId, item.SignPubkey, userPool[item.SignPubkey], prefix...)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,497 @@
+package chainstorage
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	s "github.com/rumsystem/quorum/internal/pkg/storage"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// kinds of the issues found by VerifyChain
+const (
+	ChainIssueMissingBlock    = "missing_block"    // a block between the lowest block and the head is not found
+	ChainIssueBadBlock        = "bad_block"        // the block can't be decoded, or its group id/block id mismatch with the key
+	ChainIssueBlockHash       = "block_hash"       // the block hash mismatch with the block content
+	ChainIssuePrevHash        = "prev_hash"        // the prev hash mismatch with the parent block, the chain is forked
+	ChainIssueProducerSign    = "producer_sign"    // the producer sign is invalid
+	ChainIssueUnknownProducer = "unknown_producer" // the block is produced by a pubkey not in the producer list at that height, or not the proposer selected by stake for POS groups
+	ChainIssueTrxSign         = "trx_sign"         // the sender sign of a trx is invalid
+	ChainIssueFork            = "fork"             // a cached block conflicts with the block on chain at the same height
+	ChainIssueBeyondHead      = "beyond_head"      // a block is stored after the head of the chain
+)
+
+// max issues reported per group, the counters are still updated after it
+var CHAIN_VERIFY_MAX_ISSUES = 1000
+
+type ChainVerifyIssue struct {
+	Kind    string `json:"kind"`
+	BlockId uint64 `json:"block_id"`
+	TrxId   string `json:"trx_id,omitempty"`
+	Message string `json:"message"`
+}
+
+type ChainVerifyResult struct {
+	GroupId          string              `json:"group_id"`
+	GroupName        string              `json:"group_name"`
+	HeadBlock        uint64              `json:"head_block"`
+	LowestBlock      uint64              `json:"lowest_block"` // blocks before it (except the genesis block) are pruned
+	BlocksChecked    int                 `json:"blocks_checked"`
+	TrxsChecked      int                 `json:"trxs_checked"`
+	MissingBlocks    int                 `json:"missing_blocks"`
+	InvalidBlocks    int                 `json:"invalid_blocks"`
+	InvalidTrxs      int                 `json:"invalid_trxs"`
+	Forks            int                 `json:"forks"`
+	ProducersAssumed bool                `json:"producers_assumed"` // pruned chain, the producer list before the lowest block is taken from the current state
+	Ok               bool                `json:"ok"`
+	Issues           []*ChainVerifyIssue `json:"issues"`
+	IssuesTruncated  bool                `json:"issues_truncated"`
+}
+
+func (r *ChainVerifyResult) addIssue(kind string, blockId uint64, trxId string, format string, args ...interface{}) {
+	switch kind {
+	case ChainIssueTrxSign:
+		r.InvalidTrxs++
+	case ChainIssuePrevHash, ChainIssueFork:
+		r.Forks++
+	case ChainIssueMissingBlock, ChainIssueBeyondHead:
+	default:
+		r.InvalidBlocks++
+	}
+	r.Ok = false
+
+	if len(r.Issues) >= CHAIN_VERIFY_MAX_ISSUES {
+		r.IssuesTruncated = true
+		return
+	}
+	r.Issues = append(r.Issues, &ChainVerifyIssue{
+		Kind:    kind,
+		BlockId: blockId,
+		TrxId:   trxId,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// VerifyChain walks all stored blocks of the group from the genesis block to the head,
+// rechecks block hashes, prev hash links, producer signs with the producer list in effect at each height
+// and the sender sign of every trx. Only storage errors are returned as err, problems of the data are in the issues.
+func (cs *Storage) VerifyChain(groupId string, prefix ...string) (*ChainVerifyResult, error) {
+	groupItem, err := cs.GetGroupInfo(groupId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ChainVerifyResult{
+		GroupId:   groupItem.GroupId,
+		GroupName: groupItem.GroupName,
+		Ok:        true,
+		Issues:    []*ChainVerifyIssue{},
+	}
+
+	maxStored, err := cs.getMaxStoredBlockId(groupId, prefix...)
+	if err != nil {
+		return nil, err
+	}
+	head, err := cs.GetHeadBlockId(groupId, prefix...)
+	if err != nil {
+		return nil, err
+	}
+	result.HeadBlock = head
+
+	lowest, err := cs.GetLowestBlockId(groupId, prefix...)
+	if err != nil {
+		return nil, err
+	}
+	result.LowestBlock = lowest
+
+	producers := newVerifyProducers(groupItem)
+	if producers.groupKeys, err = cs.GetGroupKeys(groupId, prefix...); err != nil {
+		return nil, err
+	}
+	producers.stakeAllowed = cs.isStakeAllowed(groupId, prefix...)
+
+	//genesis block
+	var parent *quorumpb.Block
+	genesis, err := cs.getVerifyBlock(groupId, 0, result, prefix...)
+	if err != nil {
+		return nil, err
+	}
+	if genesis != nil {
+		result.BlocksChecked++
+		if valid, err := rumchaindata.ValidGenesisBlock(genesis); !valid {
+			msg := "producer sign is invalid"
+			if err != nil {
+				msg = err.Error()
+			}
+			result.addIssue(ChainIssueBlockHash, 0, "", "genesis block is invalid: %s", msg)
+		}
+		if groupItem.GenesisBlock != nil && !bytes.Equal(groupItem.GenesisBlock.BlockHash, genesis.BlockHash) {
+			result.addIssue(ChainIssueFork, 0, "", "genesis block mismatch with the group info")
+		}
+		producers.add(genesis.ProducerPubkey)
+		cs.verifyTrxs(genesis, groupItem, producers, result)
+		parent = genesis
+	}
+
+	from := uint64(1)
+	if lowest > 1 {
+		//the producer trxs before the lowest block are pruned, start with the current producer list
+		from = lowest
+		parent = nil
+		result.ProducersAssumed = true
+		if err := cs.assumeCurrentProducers(groupId, producers, prefix...); err != nil {
+			return nil, err
+		}
+	}
+
+	var missingFrom uint64
+	missing := false
+	for blockId := from; blockId <= head; blockId++ {
+		block, err := cs.getVerifyBlock(groupId, blockId, result, prefix...)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			if !missing {
+				missing = true
+				missingFrom = blockId
+			}
+			result.MissingBlocks++
+			parent = nil
+			continue
+		}
+		if missing {
+			missing = false
+			result.addIssue(ChainIssueMissingBlock, missingFrom, "", "blocks %d-%d are missing", missingFrom, blockId-1)
+		}
+
+		result.BlocksChecked++
+		producers.activate(block.Epoch)
+		cs.verifyBlock(block, parent, producers, result)
+		cs.verifyTrxs(block, groupItem, producers, result)
+		parent = block
+	}
+	if missing {
+		result.addIssue(ChainIssueMissingBlock, missingFrom, "", "blocks %d-%d are missing", missingFrom, head)
+	}
+
+	if maxStored > head {
+		result.addIssue(ChainIssueBeyondHead, maxStored, "", "blocks are stored up to %d, after the head %d", maxStored, head)
+	}
+
+	if err := cs.verifyCachedBlocks(groupId, head, result, prefix...); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// assumeCurrentProducers adds the current producer list, scheduled lists and stakes of the group,
+// used when the producer trxs before the lowest block are pruned
+func (cs *Storage) assumeCurrentProducers(groupId string, producers *verifyProducers, prefix ...string) error {
+	current, err := cs.GetProducers(groupId, prefix...)
+	if err != nil {
+		return err
+	}
+	for _, item := range current {
+		producers.add(item.ProducerPubkey)
+	}
+	scheduled, err := cs.GetScheduledProducers(groupId, prefix...)
+	if err != nil {
+		return err
+	}
+	for _, item := range scheduled {
+		producers.scheduled = append(producers.scheduled, &quorumpb.BFTProducerBundleItem{Producers: item.Producers, ActivateEpoch: item.ActivateEpoch})
+	}
+	stakes, err := cs.GetStakes(groupId, prefix...)
+	if err != nil {
+		return err
+	}
+	for _, item := range stakes {
+		producers.stakes[ethPubkey(item.StakerPubkey)] = item
+	}
+	return nil
+}
+
+// getVerifyBlock returns nil if the block is not found or can't be decoded
+func (cs *Storage) getVerifyBlock(groupId string, blockId uint64, result *ChainVerifyResult, prefix ...string) (*quorumpb.Block, error) {
+	exist, err := cs.dbmgr.IsBlockExist(groupId, blockId, false, prefix...)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+
+	block, err := cs.dbmgr.GetBlock(groupId, blockId, false, prefix...)
+	if err != nil {
+		result.addIssue(ChainIssueBadBlock, blockId, "", "decode block failed: %s", err.Error())
+		return nil, nil
+	}
+	if block.GroupId != groupId || block.BlockId != blockId {
+		result.addIssue(ChainIssueBadBlock, blockId, "", "block <%s:%d> is stored as <%s:%d>", block.GroupId, block.BlockId, groupId, blockId)
+		return nil, nil
+	}
+	return block, nil
+}
+
+func (cs *Storage) verifyBlock(block, parent *quorumpb.Block, producers *verifyProducers, result *ChainVerifyResult) {
+	hash, err := rumchaindata.GetBlockHash(block)
+	if err != nil {
+		result.addIssue(ChainIssueBadBlock, block.BlockId, "", "calculate block hash failed: %s", err.Error())
+		return
+	}
+	if !bytes.Equal(hash, block.BlockHash) {
+		result.addIssue(ChainIssueBlockHash, block.BlockId, "", "block hash mismatch with the content")
+	}
+	cs.verifyBlockHeader(block, parent, producers, result)
+}
+
+// verifyBlockHeader checks the link to the parent, the producer and the producer sign, the hash is checked by the caller
+func (cs *Storage) verifyBlockHeader(block, parent *quorumpb.Block, producers *verifyProducers, result *ChainVerifyResult) {
+	//parent is nil after a gap, the link can't be checked
+	if parent != nil && !bytes.Equal(block.PrevHash, parent.BlockHash) {
+		result.addIssue(ChainIssuePrevHash, block.BlockId, "", "prev hash mismatch with block %d", parent.BlockId)
+	}
+
+	if block.Sudo {
+		//sudo blocks are signed by the owner out of the consensus
+		if ethPubkey(block.ProducerPubkey) != producers.owner {
+			result.addIssue(ChainIssueUnknownProducer, block.BlockId, "", "sudo block is produced by <%s>, not the owner", block.ProducerPubkey)
+		}
+	} else if producers.pos && parent != nil {
+		if valid, err := rumchaindata.ValidPosBlock(block, parent, producers.stakeList(), producers.owner, time.Now().UnixNano()); !valid {
+			result.addIssue(ChainIssueUnknownProducer, block.BlockId, "", "%s", err.Error())
+		}
+	} else if !producers.has(block.ProducerPubkey) {
+		result.addIssue(ChainIssueUnknownProducer, block.BlockId, "", "producer <%s> is not in the producer list", block.ProducerPubkey)
+	}
+	if valid, err := rumchaindata.VerifyBlockSign(block); !valid {
+		msg := "invalid signature"
+		if err != nil {
+			msg = err.Error()
+		}
+		result.addIssue(ChainIssueProducerSign, block.BlockId, "", "verify producer sign failed: %s", msg)
+	}
+}
+
+// verifyTrxs checks the trxs of the block, and updates the producer list with the PRODUCER and STAKE trxs
+// for the blocks after it
+func (cs *Storage) verifyTrxs(block *quorumpb.Block, groupItem *quorumpb.GroupItem, producers *verifyProducers, result *ChainVerifyResult) {
+	for _, trx := range block.Trxs {
+		result.TrxsChecked++
+		if trx.GroupId != block.GroupId {
+			result.addIssue(ChainIssueTrxSign, block.BlockId, trx.TrxId, "trx group id <%s> mismatch with block", trx.GroupId)
+			continue
+		}
+		//VerifyTrx may modify the recovery id of the sign
+		checkTrx := proto.Clone(trx).(*quorumpb.Trx)
+		if valid, err := rumchaindata.VerifyTrx(checkTrx); !valid {
+			msg := "invalid signature"
+			if err != nil {
+				msg = err.Error()
+			}
+			result.addIssue(ChainIssueTrxSign, block.BlockId, trx.TrxId, "verify sender sign failed: %s", msg)
+			continue
+		}
+
+		if trx.Type == quorumpb.TrxType_PRODUCER {
+			if err := producers.update(trx, groupItem, block.BlockId); err != nil {
+				result.addIssue(ChainIssueBadBlock, block.BlockId, trx.TrxId, "decode producer trx failed: %s", err.Error())
+			}
+		} else if trx.Type == quorumpb.TrxType_STAKE {
+			//invalid stake trxs are ignored by the chain too
+			producers.updateStake(trx, groupItem, block.BlockId)
+		}
+	}
+}
+
+// verifyCachedBlocks reports the cached blocks conflict with the blocks on chain
+func (cs *Storage) verifyCachedBlocks(groupId string, head uint64, result *ChainVerifyResult, prefix ...string) error {
+	key := s.GetCachedBlockPrefix(groupId, prefix...)
+	return cs.dbmgr.Db.PrefixForeach([]byte(key), func(k []byte, v []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		cached := &quorumpb.Block{}
+		if err := proto.Unmarshal(v, cached); err != nil {
+			return nil
+		}
+		if cached.BlockId > head {
+			return nil
+		}
+		onchain, err := cs.dbmgr.GetBlock(groupId, cached.BlockId, false, prefix...)
+		if err != nil || len(onchain.BlockHash) == 0 {
+			return nil
+		}
+		if !bytes.Equal(onchain.BlockHash, cached.BlockHash) {
+			result.addIssue(ChainIssueFork, cached.BlockId, "", "cached block <%s> conflicts with block <%s> on chain",
+				hex.EncodeToString(cached.BlockHash), hex.EncodeToString(onchain.BlockHash))
+		}
+		return nil
+	})
+}
+
+func (cs *Storage) getMaxStoredBlockId(groupId string, prefix ...string) (uint64, error) {
+	key := s.GetBlockPrefix(groupId, prefix...)
+	maxId := uint64(0)
+	_, err := cs.dbmgr.Db.PrefixForeachKey([]byte(key), []byte(key), false, func(k []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		blockId, perr := strconv.ParseUint(strings.TrimPrefix(string(k), key), 10, 64)
+		if perr == nil && blockId > maxId {
+			maxId = blockId
+		}
+		return nil
+	})
+	return maxId, err
+}
+
+// GetHeadBlockId returns the current block in the chain info, or the max block stored if the chain info is not saved
+func (cs *Storage) GetHeadBlockId(groupId string, prefix ...string) (uint64, error) {
+	exist, err := cs.dbmgr.Db.IsExist([]byte(s.GetChainInfoBlock(groupId, prefix...)))
+	if err != nil {
+		return 0, err
+	}
+	if !exist {
+		return cs.getMaxStoredBlockId(groupId, prefix...)
+	}
+	head, _, _, err := cs.GetChainInfo(groupId, prefix...)
+	return head, err
+}
+
+// verifyProducers is the producer list and stakes in effect while walking the chain, the owner is always a producer
+type verifyProducers struct {
+	owner     string
+	keys      map[string]bool
+	pos       bool
+	stakes    map[string]*quorumpb.StakeItem
+	scheduled []*quorumpb.BFTProducerBundleItem //in the order of activate epoch
+	groupKeys []*GroupKeyItem                   //group cipher keys rotated, to decrypt the PRODUCER and STAKE trxs
+	//the stakers allowed by the chain config of this node, nil if the chain config is not synced, e.g. on light nodes
+	stakeAllowed func(pubkey string) bool
+}
+
+func newVerifyProducers(groupItem *quorumpb.GroupItem) *verifyProducers {
+	p := &verifyProducers{keys: make(map[string]bool), stakes: make(map[string]*quorumpb.StakeItem)}
+	p.owner = ethPubkey(groupItem.OwnerPubKey)
+	p.keys[p.owner] = true
+	p.pos = groupItem.ConsenseType == quorumpb.GroupConsenseType_POS
+	return p
+}
+
+func (p *verifyProducers) clone() *verifyProducers {
+	c := &verifyProducers{owner: p.owner, pos: p.pos, keys: make(map[string]bool), stakes: make(map[string]*quorumpb.StakeItem), groupKeys: p.groupKeys, stakeAllowed: p.stakeAllowed}
+	for k, v := range p.keys {
+		c.keys[k] = v
+	}
+	for k, v := range p.stakes {
+		c.stakes[k] = v
+	}
+	c.scheduled = append(c.scheduled, p.scheduled...)
+	return c
+}
+
+func (p *verifyProducers) add(pubkey string) {
+	p.keys[ethPubkey(pubkey)] = true
+}
+
+// has checks the producer list, and the active stakes of POS groups
+func (p *verifyProducers) has(pubkey string) bool {
+	pk := ethPubkey(pubkey)
+	if p.keys[pk] {
+		return true
+	}
+	stake, ok := p.stakes[pk]
+	return p.pos && ok && stake.Amount >= rumchaindata.POS_MIN_STAKE
+}
+
+func (p *verifyProducers) stakeList() []*quorumpb.StakeItem {
+	var items []*quorumpb.StakeItem
+	for _, item := range p.stakes {
+		items = append(items, item)
+	}
+	return items
+}
+
+// updateStake applies a STAKE trx as UpdateStakeTrx
+func (p *verifyProducers) updateStake(trx *quorumpb.Trx, groupItem *quorumpb.GroupItem, blockId uint64) error {
+	data, err := decryptTrxData(groupItem, p.groupKeys, trx, blockId)
+	if err != nil {
+		return err
+	}
+	item, err := decodeStakeTrx(trx.GroupId, trx.SenderPubkey, data)
+	if err != nil {
+		return err
+	}
+
+	if item.Action == quorumpb.ActionType_ADD && p.stakeAllowed != nil && !p.stakeAllowed(item.StakerPubkey) {
+		return fmt.Errorf("<%s> is not allowed to stake", item.StakerPubkey)
+	}
+
+	pk := ethPubkey(item.StakerPubkey)
+	stake, err := applyStake(p.stakes[pk], item)
+	if err != nil {
+		return err
+	}
+	if stake == nil {
+		delete(p.stakes, pk)
+	} else {
+		p.stakes[pk] = stake
+	}
+	return nil
+}
+
+// update replaces the producer list with a PRODUCER trx, same as UpdateProducer
+func (p *verifyProducers) update(trx *quorumpb.Trx, groupItem *quorumpb.GroupItem, blockId uint64) error {
+	data, err := decryptTrxData(groupItem, p.groupKeys, trx, blockId)
+	if err != nil {
+		return err
+	}
+	item := &quorumpb.BFTProducerBundleItem{}
+	if err := proto.Unmarshal(data, item); err != nil {
+		return err
+	}
+
+	if item.ActivateEpoch > 0 {
+		i := len(p.scheduled)
+		for i > 0 && p.scheduled[i-1].ActivateEpoch > item.ActivateEpoch {
+			i--
+		}
+		p.scheduled = append(p.scheduled[:i], append([]*quorumpb.BFTProducerBundleItem{item}, p.scheduled[i:]...)...)
+		return nil
+	}
+	p.replace(item)
+	return nil
+}
+
+// activate replaces the producer list with the scheduled lists in effect at the epoch, as ActivateScheduledProducers
+func (p *verifyProducers) activate(epoch uint64) {
+	for len(p.scheduled) > 0 && p.scheduled[0].ActivateEpoch <= epoch {
+		p.replace(p.scheduled[0])
+		p.scheduled = p.scheduled[1:]
+	}
+}
+
+func (p *verifyProducers) replace(item *quorumpb.BFTProducerBundleItem) {
+	p.keys = map[string]bool{p.owner: true}
+	for _, producer := range item.Producers {
+		p.add(producer.ProducerPubkey)
+	}
+}
+
+func ethPubkey(pubkey string) string {
+	pk, _ := localcrypto.Libp2pPubkeyToEthBase64(pubkey)
+	if pk == "" {
+		pk = pubkey
+	}
+	return pk
+}
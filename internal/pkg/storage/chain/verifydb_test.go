@@ -0,0 +1,199 @@
+//go:build !js
+// +build !js
+
+package chainstorage
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	s "github.com/rumsystem/quorum/internal/pkg/storage"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+const testNodename = "fullnode_default"
+
+type testSigner struct {
+	key    *ecdsa.PrivateKey
+	pubkey string
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testSigner{key: key, pubkey: base64.RawURLEncoding.EncodeToString(ethcrypto.CompressPubkey(&key.PublicKey))}
+}
+
+func (signer *testSigner) sign(t *testing.T, hash []byte) []byte {
+	sig, err := ethcrypto.Sign(hash, signer.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func (signer *testSigner) newTrx(t *testing.T, groupItem *quorumpb.GroupItem, id string, trxType quorumpb.TrxType, data []byte) *quorumpb.Trx {
+	cipherKey, _ := hex.DecodeString(groupItem.CipherKey)
+	encrypted, err := localcrypto.AesEncrypt(data, cipherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trx := &quorumpb.Trx{
+		TrxId:        id,
+		Type:         trxType,
+		GroupId:      groupItem.GroupId,
+		SenderPubkey: signer.pubkey,
+		Data:         encrypted,
+		TimeStamp:    1,
+		Version:      "2.0.0",
+	}
+	b, err := proto.Marshal(trx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trx.SenderSign = signer.sign(t, localcrypto.Hash(b))
+	return trx
+}
+
+func (signer *testSigner) newBlock(t *testing.T, parent *quorumpb.Block, groupId string, blockId uint64, trxs []*quorumpb.Trx) *quorumpb.Block {
+	block := &quorumpb.Block{
+		GroupId:        groupId,
+		BlockId:        blockId,
+		Epoch:          blockId,
+		ProducerPubkey: signer.pubkey,
+		Trxs:           trxs,
+		TimeStamp:      int64(blockId + 1),
+	}
+	if parent != nil {
+		block.PrevHash = parent.BlockHash
+	}
+	hash, err := rumchaindata.GetBlockHash(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block.BlockHash = hash
+	block.ProducerSign = signer.sign(t, hash)
+	return block
+}
+
+func newTestChainStorage(t *testing.T) (*Storage, *s.DbMgr) {
+	dir := t.TempDir()
+	groupDb, err := s.OpenStore(context.Background(), s.BoltBackend, dir, "groups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataDb, err := s.OpenStore(context.Background(), s.BoltBackend, dir, "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbMgr := &s.DbMgr{GroupInfoDb: groupDb, Db: dataDb, DataPath: dir}
+	t.Cleanup(dbMgr.CloseDb)
+	return NewChainStorage(dbMgr), dbMgr
+}
+
+func issueKinds(result *ChainVerifyResult) map[string]bool {
+	kinds := make(map[string]bool)
+	for _, issue := range result.Issues {
+		kinds[issue.Kind] = true
+	}
+	return kinds
+}
+
+func TestVerifyChain(t *testing.T) {
+	cs, dbMgr := newTestChainStorage(t)
+	owner := newTestSigner(t)
+	producer := newTestSigner(t)
+	stranger := newTestSigner(t)
+
+	groupItem := &quorumpb.GroupItem{
+		GroupId:     "0b9a4f6e-3f5a-4b0c-9f67-7f2f7a8d3c11",
+		GroupName:   "verify",
+		OwnerPubKey: owner.pubkey,
+		CipherKey:   "71eff58163d557b609a15050a5f7561568eb8bb582156697ba9fc99ca9236582",
+	}
+	genesis := owner.newBlock(t, nil, groupItem.GroupId, 0, nil)
+	groupItem.GenesisBlock = genesis
+	if err := cs.AddGroup(groupItem); err != nil {
+		t.Fatal(err)
+	}
+
+	//block 1 adds the producer, which produces block 2
+	bundle, _ := proto.Marshal(&quorumpb.BFTProducerBundleItem{Producers: []*quorumpb.ProducerItem{
+		{GroupId: groupItem.GroupId, ProducerPubkey: owner.pubkey},
+		{GroupId: groupItem.GroupId, ProducerPubkey: producer.pubkey},
+	}})
+	block1 := owner.newBlock(t, genesis, groupItem.GroupId, 1, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-producer", quorumpb.TrxType_PRODUCER, bundle),
+	})
+	block2 := producer.newBlock(t, block1, groupItem.GroupId, 2, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-post", quorumpb.TrxType_POST, []byte(`{"type":"Note"}`)),
+	})
+	for _, block := range []*quorumpb.Block{genesis, block1, block2} {
+		if err := dbMgr.SaveBlock(block, false, testNodename); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := cs.VerifyChain(groupItem.GroupId, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Ok || result.BlocksChecked != 3 || result.TrxsChecked != 2 || result.HeadBlock != 2 {
+		t.Fatalf("expect a valid chain of 3 blocks, got %+v, issues %+v", result, result.Issues)
+	}
+
+	//a block from a pubkey not in the producer list, the trx data is tampered after signed
+	block3 := stranger.newBlock(t, block2, groupItem.GroupId, 3, []*quorumpb.Trx{
+		owner.newTrx(t, groupItem, "trx-post-2", quorumpb.TrxType_POST, []byte(`{"type":"Note"}`)),
+	})
+	block3.Trxs[0].Data = append(block3.Trxs[0].Data, 0)
+	if err := dbMgr.SaveBlock(block3, false, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	//block 5 is linked to another block 4, block 4 is missing
+	block5 := owner.newBlock(t, block1, groupItem.GroupId, 5, nil)
+	if err := dbMgr.SaveBlock(block5, false, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	//a cached block conflicts with block 2
+	forked := owner.newBlock(t, block1, groupItem.GroupId, 2, nil)
+	if err := dbMgr.SaveBlock(forked, true, testNodename); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = cs.VerifyChain(groupItem.GroupId, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := issueKinds(result)
+	for _, kind := range []string{ChainIssueUnknownProducer, ChainIssueBlockHash, ChainIssueTrxSign, ChainIssueMissingBlock, ChainIssueFork} {
+		if !kinds[kind] {
+			t.Errorf("expect issue %s, got %+v", kind, result.Issues)
+		}
+	}
+	if result.Ok || result.MissingBlocks != 1 || result.InvalidTrxs != 1 || result.Forks != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	//prev hash link is checked when no gap between blocks
+	block4 := owner.newBlock(t, genesis, groupItem.GroupId, 4, nil)
+	if err := dbMgr.SaveBlock(block4, false, testNodename); err != nil {
+		t.Fatal(err)
+	}
+	result, err = cs.VerifyChain(groupItem.GroupId, testNodename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !issueKinds(result)[ChainIssuePrevHash] || result.MissingBlocks != 0 {
+		t.Errorf("expect prev hash issue, got %+v", result.Issues)
+	}
+}
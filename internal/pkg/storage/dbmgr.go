@@ -76,7 +76,19 @@ func (dbMgr *DbMgr) SaveBlock(block *quorumpb.Block, cached bool, prefix ...stri
 	if err != nil {
 		return err
 	}
-	return dbMgr.Db.Set([]byte(key), value)
+	if cached {
+		return dbMgr.Db.Set([]byte(key), value)
+	}
+
+	//index the trxs to the block on chain, for the trx inclusion proof
+	keys := [][]byte{[]byte(key)}
+	values := [][]byte{value}
+	blockId := []byte(strconv.FormatUint(block.BlockId, 10))
+	for _, trx := range block.Trxs {
+		keys = append(keys, []byte(GetTrxBlockKey(block.GroupId, trx.TrxId, prefix...)))
+		values = append(values, blockId)
+	}
+	return dbMgr.Db.BatchWrite(keys, values)
 }
 
 func (dbMgr *DbMgr) RmBlock(groupId string, blockId uint64, cached bool, prefix ...string) error {
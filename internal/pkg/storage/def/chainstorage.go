@@ -20,6 +20,7 @@ type APIHandlerIface interface {
 	IsProducerAnnounced(groupId, producerSignPubkey string, prefix ...string) (bool, error)
 	GetSendTrxAuthListByGroupId(groupId string, listType quorumpb.AuthListType, prefix ...string) ([]*quorumpb.ChainConfigItem, []*quorumpb.ChainSendTrxRuleListItem, error)
 	GetTrxAuthModeByGroupId(groupId string, trxType quorumpb.TrxType, prefix ...string) (quorumpb.TrxAuthMode, error)
+	GetPackingPolicyByGroupId(groupId string, prefix ...string) (*quorumpb.SetPackingPolicyItem, error)
 	GetAnnounceProducersByGroup(groupId string, prefix ...string) ([]*quorumpb.AnnounceItem, error)
 	GetAnnounceUsersByGroup(groupId string, prefix ...string) ([]*quorumpb.AnnounceItem, error)
 	GetProducers(groupId string, prefix ...string) ([]*quorumpb.ProducerItem, error)
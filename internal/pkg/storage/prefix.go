@@ -11,6 +11,7 @@ import (
 
 const (
 	TRX_PREFIX           = "trx"       //trx
+	TRX_BLK_PREFIX       = "tbi"       //index of the block packaged the trx
 	BLK_PREFIX           = "blk"       //block
 	GRP_PREFIX           = "grp"       //group
 	CHNINFO_PREFIX       = "chain"     //chaininfo
@@ -25,7 +26,19 @@ const (
 	TRX_AUTH_TYPE_PREFIX = "trx_auth"  //trx auth type
 	ALLW_LIST_PREFIX     = "alw_list"  //allow list
 	DENY_LIST_PREFIX     = "dny_list"  //deny list
+	PACKING_POLICY       = "pck_plcy"  //trx packing policy
+	BLOCK_VERSION        = "blk_ver"   //version of the blocks produced
 	PRD_TRX_ID_PREFIX    = "prd_trxid" //trxid of latest trx which update group producer list
+	SCH_PRD_PREFIX       = "sch_prd"   //producer list scheduled to activate at an epoch
+	SNAPSHOT_PREFIX      = "snapshot"  //state snapshot
+	TRX_STATUS_PREFIX    = "trxstu"    //status of trx sent by this node
+	STK_PREFIX           = "stk"       //stake
+	EVD_PREFIX           = "evd"       //evidence of producer misbehaviour
+	FRK_PREFIX           = "frk"       //conflicting block found at an existing height
+	GKY_PREFIX           = "gky"       //group cipher key rotated by owner
+	LHD_PREFIX           = "lhd"       //block header verified by light node
+	LHD_STATE_PREFIX     = "lhd_state" //head and producers of the headers verified by light node
+	LTX_PREFIX           = "ltx"       //proof of trx in a block verified by light node
 
 	// groupinfo db
 	GROUPITEM_PREFIX = "grpitem"
@@ -99,6 +112,16 @@ func GetChainInfoBlock(groupId string, prefix ...string) string {
 	return nodeprefix + CHNINFO_PREFIX + "_" + groupId + "_" + "currblock"
 }
 
+func GetChainInfoLowestBlock(groupId string, prefix ...string) string {
+	nodeprefix := utils.GetPrefix(prefix...)
+	return nodeprefix + CHNINFO_PREFIX + "_" + groupId + "_" + "lowestblock"
+}
+
+func GetSnapshotKey(groupId string, prefix ...string) string {
+	nodeprefix := utils.GetPrefix(prefix...)
+	return nodeprefix + SNAPSHOT_PREFIX + "_" + groupId
+}
+
 func GetPostPrefix(groupId string, prefix ...string) string {
 	nodeprefix := utils.GetPrefix(prefix...)
 	return nodeprefix + GRP_PREFIX + "_" + CNT_PREFIX + "_" + groupId
@@ -130,6 +153,17 @@ func GetUserKey(groupId string, pubkey string, prefix ...string) string {
 	return _prefix + pk
 }
 
+func GetStakePrefix(groupId string, prefix ...string) string {
+	nodeprefix := utils.GetPrefix(prefix...)
+	return nodeprefix + STK_PREFIX + "_" + groupId + "_"
+}
+
+func GetStakeKey(groupId string, pubkey string, prefix ...string) string {
+	_prefix := GetStakePrefix(groupId, prefix...)
+	pk := _getEthPubkey(pubkey)
+	return _prefix + pk
+}
+
 func GetAnnouncedPrefix(groupId string, prefix ...string) string {
 	nodeprefix := utils.GetPrefix(prefix...)
 	return nodeprefix + ANN_PREFIX + "_" + groupId + "_"
@@ -198,6 +232,66 @@ func GetChainConfigDenyPrefix(groupId string, prefix ...string) string {
 	return _prefix + "_" + DENY_LIST_PREFIX
 }
 
+func GetChainConfigPackingPolicyKey(groupId string, prefix ...string) string {
+	_prefix := GetChainConfigPrefix(groupId, prefix...)
+	return _prefix + "_" + PACKING_POLICY
+}
+
+func GetChainConfigBlockVersionKey(groupId string, prefix ...string) string {
+	_prefix := GetChainConfigPrefix(groupId, prefix...)
+	return _prefix + "_" + BLOCK_VERSION
+}
+
+func GetEvidencePrefix(groupId string, prefix ...string) string {
+	nodeprefix := utils.GetPrefix(prefix...)
+	return nodeprefix + EVD_PREFIX + "_" + groupId + "_"
+}
+
+func GetEvidenceKey(groupId string, evidenceId string, prefix ...string) string {
+	return GetEvidencePrefix(groupId, prefix...) + evidenceId
+}
+
+func GetLightHeaderPrefix(groupId string, prefix ...string) string {
+	nodeprefix := utils.GetPrefix(prefix...)
+	return nodeprefix + LHD_PREFIX + "_" + groupId + "_"
+}
+
+func GetLightHeaderKey(groupId string, blockId uint64, prefix ...string) string {
+	return GetLightHeaderPrefix(groupId, prefix...) + strconv.FormatUint(blockId, 10)
+}
+
+func GetLightHeaderStateKey(groupId string, prefix ...string) string {
+	nodeprefix := utils.GetPrefix(prefix...)
+	return nodeprefix + LHD_STATE_PREFIX + "_" + groupId
+}
+
+func GetLightTrxProofPrefix(groupId string, prefix ...string) string {
+	nodeprefix := utils.GetPrefix(prefix...)
+	return nodeprefix + LTX_PREFIX + "_" + groupId + "_"
+}
+
+func GetLightTrxProofKey(groupId string, trxId string, prefix ...string) string {
+	return GetLightTrxProofPrefix(groupId, prefix...) + trxId
+}
+
+func GetForkPrefix(groupId string, prefix ...string) string {
+	nodeprefix := utils.GetPrefix(prefix...)
+	return nodeprefix + FRK_PREFIX + "_" + groupId + "_"
+}
+
+func GetForkKey(groupId string, blockId uint64, blockHash string, prefix ...string) string {
+	return GetForkPrefix(groupId, prefix...) + strconv.FormatUint(blockId, 10) + "_" + blockHash
+}
+
+func GetGroupKeyPrefix(groupId string, prefix ...string) string {
+	nodeprefix := utils.GetPrefix(prefix...)
+	return nodeprefix + GKY_PREFIX + "_" + groupId + "_"
+}
+
+func GetGroupKeyKey(groupId string, blockId uint64, prefix ...string) string {
+	return GetGroupKeyPrefix(groupId, prefix...) + strconv.FormatUint(blockId, 10)
+}
+
 func GetAppConfigPrefix(groupId string, prefix ...string) string {
 	nodeprefix := utils.GetPrefix(prefix...)
 	return nodeprefix + APP_CONFIG_PREFIX + "_" + groupId
@@ -212,12 +306,32 @@ func GetProducerTrxIDKey(groupId string, prefix ...string) string {
 	nodeprefix := utils.GetPrefix(prefix...)
 	return nodeprefix + PRD_TRX_ID_PREFIX + "_" + groupId
 }
+
+func GetScheduledProducerPrefix(groupId string, prefix ...string) string {
+	nodeprefix := utils.GetPrefix(prefix...)
+	return nodeprefix + SCH_PRD_PREFIX + "_" + groupId + "_"
+}
+
+// GetScheduledProducerKey pads the epoch, so the scheduled lists are iterated in the order of activate epoch
+func GetScheduledProducerKey(groupId string, epoch uint64, trxId string, prefix ...string) string {
+	return GetScheduledProducerPrefix(groupId, prefix...) + fmt.Sprintf("%020d", epoch) + "_" + trxId
+}
+
 func GetTrxPrefix(groupId string, prefix ...string) string {
 	nodeprefix := utils.GetPrefix(prefix...)
 	key := nodeprefix + TRX_PREFIX + "_" + groupId + "_"
 	return key
 }
 
+func GetTrxBlockPrefix(groupId string, prefix ...string) string {
+	nodeprefix := utils.GetPrefix(prefix...)
+	return nodeprefix + TRX_BLK_PREFIX + "_" + groupId + "_"
+}
+
+func GetTrxBlockKey(groupId, trxId string, prefix ...string) string {
+	return GetTrxBlockPrefix(groupId, prefix...) + trxId
+}
+
 func GetTrxKey(groupId, trxId string, prefix ...string) string {
 	nodeprefix := utils.GetPrefix(prefix...)
 	key := nodeprefix + TRX_PREFIX + "_" + groupId + "_"
@@ -227,17 +341,26 @@ func GetTrxKey(groupId, trxId string, prefix ...string) string {
 	return key
 }
 
+func GetTrxStatusPrefix(groupId string, prefix ...string) string {
+	nodeprefix := utils.GetPrefix(prefix...)
+	return nodeprefix + TRX_STATUS_PREFIX + "_" + groupId + "_"
+}
+
+func GetTrxStatusKey(groupId, trxId string, prefix ...string) string {
+	return GetTrxStatusPrefix(groupId, prefix...) + trxId
+}
+
 func GetSeedKey(groupID string) []byte {
 	return []byte(fmt.Sprintf("%s_%s", GROUPSEED_PREFIX, groupID))
 }
 
-func GetTrxHBBPrefix(queueId string) string {
-	return CNS_BUFD_TRX + "_" + queueId + "_"
+func GetTrxHBBPrefix(queueId string, prefix ...string) string {
+	nodeprefix := utils.GetPrefix(prefix...)
+	return nodeprefix + CNS_BUFD_TRX + "_" + queueId + "_"
 }
 
-func GetTrxHBBKey(queueId string, trxId string) string {
-	prefix := GetTrxHBBPrefix(queueId)
-	return prefix + trxId
+func GetTrxHBBKey(queueId string, trxId string, prefix ...string) string {
+	return GetTrxHBBPrefix(queueId, prefix...) + trxId
 }
 
 // Relay
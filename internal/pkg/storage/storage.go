@@ -22,3 +22,9 @@ type Sequence interface {
 	Next() (uint64, error)
 	Release() error
 }
+
+// storage backends of QuorumStorage
+const (
+	BoltBackend   = "bolt"
+	BadgerBackend = "badger"
+)
@@ -0,0 +1,312 @@
+//go:build !js
+// +build !js
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dgraph-io/badger/v3"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
+)
+
+const (
+	badgerDirSuffix = ".badger"
+	// keys per write batch when a prefix delete is flushed
+	badgerDeleteBatchSize = 1000
+)
+
+// BadgerStore is a LSM-tree backed QuorumStorage, one badger db per bucket
+type BadgerStore struct {
+	db           *badger.DB
+	databasePath string
+	ctx          context.Context
+}
+
+// badgerLogger adapts the quorum logger to badger.Logger
+type badgerLogger struct{}
+
+func (l *badgerLogger) Errorf(f string, v ...interface{})   { dbmgr_log.Errorf(f, v...) }
+func (l *badgerLogger) Warningf(f string, v ...interface{}) { dbmgr_log.Warnf(f, v...) }
+func (l *badgerLogger) Infof(f string, v ...interface{})    { dbmgr_log.Debugf(f, v...) }
+func (l *badgerLogger) Debugf(f string, v ...interface{})   { dbmgr_log.Debugf(f, v...) }
+
+func getBadgerDBPath(dir string, bucket string) string {
+	return filepath.Join(dir, bucket+badgerDirSuffix)
+}
+
+func NewBadgerStore(ctx context.Context, dir string, bucket string) (*BadgerStore, error) {
+	if err := utils.EnsureDir(dir); err != nil {
+		dbmgr_log.Errorf("check or create directory failed: %w", err)
+		return nil, err
+	}
+
+	dbPath := getBadgerDBPath(dir, bucket)
+	opts := badger.DefaultOptions(dbPath).WithLogger(&badgerLogger{})
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	store := BadgerStore{
+		db:           db,
+		databasePath: dbPath,
+		ctx:          ctx,
+	}
+
+	return &store, nil
+}
+
+func (s *BadgerStore) Init(path string) error {
+	return nil
+}
+
+// ClearDB removes the previously stored database in the data directory.
+func (s *BadgerStore) ClearDB() error {
+	if err := os.RemoveAll(s.databasePath); err != nil {
+		return errors.New(fmt.Sprintf("could not remove database dir: %s", err))
+	}
+	return nil
+}
+
+// Close closes the underlying badger database.
+func (s *BadgerStore) Close() error {
+	return s.db.Close()
+}
+
+// DatabasePath at which this database writes files.
+func (s *BadgerStore) DatabasePath() string {
+	return s.databasePath
+}
+
+func (s *BadgerStore) Set(key []byte, val []byte) error {
+	return s.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(key, val)
+	})
+}
+
+func (s *BadgerStore) Delete(key []byte) error {
+	return s.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	})
+}
+
+// Get retrieves the value for a key. Returns a nil value if the key does not exist, same as the bolt store.
+func (s *BadgerStore) Get(key []byte) ([]byte, error) {
+	if key == nil || len(key) == 0 {
+		return nil, rumerrors.ErrEmptyKey
+	}
+
+	var val []byte
+	err := s.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return nil
+			}
+			return err
+		}
+		val, err = item.ValueCopy(nil)
+		return err
+	})
+	if err != nil {
+		dbmgr_log.Warnf("kvdb Get %s failed: %s", key, err)
+		return nil, err
+	}
+
+	return val, nil
+}
+
+func (s *BadgerStore) IsExist(key []byte) (bool, error) {
+	if key == nil || len(key) == 0 {
+		return false, rumerrors.ErrEmptyKey
+	}
+
+	exist := false
+	err := s.db.View(func(txn *badger.Txn) error {
+		_, err := txn.Get(key)
+		if err != nil {
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return nil
+			}
+			return err
+		}
+		exist = true
+		return nil
+	})
+	return exist, err
+}
+
+// deleteKeys deletes keys with write batches, badger transactions have a size limit
+func (s *BadgerStore) deleteKeys(keys [][]byte) error {
+	wb := s.db.NewWriteBatch()
+	defer wb.Cancel()
+
+	for _, k := range keys {
+		if err := wb.Delete(k); err != nil {
+			return err
+		}
+	}
+	return wb.Flush()
+}
+
+func (s *BadgerStore) PrefixDelete(prefix []byte) (int, error) {
+	dbmgr_log.Debugf("delete key by prefix: %s", prefix)
+
+	return s.PrefixCondDelete(prefix, func(k []byte, v []byte, err error) (bool, error) {
+		return true, nil
+	})
+}
+
+func (s *BadgerStore) PrefixCondDelete(prefix []byte, fn func(k []byte, v []byte, err error) (bool, error)) (int, error) {
+	keys := [][]byte{}
+	matched := 0
+
+	err := s.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			v, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			ok, err := fn(item.Key(), v, nil)
+			if err != nil {
+				return err
+			}
+			if ok {
+				keys = append(keys, item.KeyCopy(nil))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return matched, err
+	}
+
+	for start := 0; start < len(keys); start += badgerDeleteBatchSize {
+		end := start + badgerDeleteBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		if err := s.deleteKeys(keys[start:end]); err != nil {
+			return matched, err
+		}
+		matched = end
+	}
+
+	return matched, nil
+}
+
+func (s *BadgerStore) PrefixForeachKey(prefix []byte, valid []byte, reverse bool, fn func([]byte, error) error) (int, error) {
+	matched := 0
+
+	err := s.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		opts.Reverse = reverse
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		if reverse {
+			// seek to the next key after all the keys with the valid prefix, which is skipped if exists
+			if next := nextPrefix(valid); next != nil {
+				it.Seek(next)
+				if it.Valid() && bytes.Equal(it.Item().Key(), next) {
+					it.Next()
+				}
+			} else {
+				it.Rewind()
+			}
+		} else {
+			it.Seek(prefix)
+		}
+		for ; it.ValidForPrefix(valid); it.Next() {
+			if err := fn(it.Item().KeyCopy(nil), nil); err != nil {
+				return err
+			}
+			matched += 1
+		}
+		return nil
+	})
+
+	return matched, err
+}
+
+// nextPrefix returns the least key greater than all the keys with the prefix, nil if there is none
+func nextPrefix(prefix []byte) []byte {
+	next := append([]byte{}, prefix...)
+	for i := len(next) - 1; i >= 0; i-- {
+		if next[i] < 0xff {
+			next[i]++
+			return next[:i+1]
+		}
+	}
+	return nil
+}
+
+func (s *BadgerStore) PrefixForeach(prefix []byte, fn func([]byte, []byte, error) error) error {
+	return s.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			v, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if err := fn(item.KeyCopy(nil), v, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BadgerStore) Foreach(fn func(k []byte, v []byte, err error) error) error {
+	return s.PrefixForeach(nil, fn)
+}
+
+func (s *BadgerStore) BatchWrite(keys [][]byte, vals [][]byte) error {
+	if len(keys) != len(vals) {
+		return errors.New("keys' and values' length should be equal")
+	}
+
+	return s.db.Update(func(txn *badger.Txn) error {
+		for i, k := range keys {
+			if err := txn.Set(k, vals[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetSequence returns a badger sequence, the lease is stored as a big endian uint64 under key, same as StoreSequence
+func (s *BadgerStore) GetSequence(key []byte, bandwidth uint64) (Sequence, error) {
+	if len(key) == 0 {
+		return nil, errors.New("empty key")
+	}
+	if bandwidth == 0 {
+		return nil, errors.New("zero bandwidth")
+	}
+	// badger keeps a reference to key, do not share it with the caller
+	seq, err := s.db.GetSequence(bytes.Clone(key), bandwidth)
+	if err != nil {
+		return nil, err
+	}
+	return seq, nil
+}
@@ -314,13 +314,61 @@ func (s *Store) GetSequence(key []byte, bandwidth uint64) (Sequence, error) {
 	return seq, err
 }
 
+// OpenStore opens bucket under dir with the given storage backend
+func OpenStore(ctx context.Context, backend string, dir string, bucket string) (QuorumStorage, error) {
+	switch backend {
+	case BoltBackend:
+		return NewStore(ctx, dir, bucket)
+	case BadgerBackend:
+		return NewBadgerStore(ctx, dir, bucket)
+	}
+	return nil, fmt.Errorf("unsupported storage backend: %s", backend)
+}
+
+// DetectBackend returns the backend of an existing bucket under dir, or empty string if the bucket does not exist
+func DetectBackend(dir string, bucket string) string {
+	if _, err := os.Stat(getDBPath(dir, bucket)); err == nil {
+		return BoltBackend
+	}
+	if _, err := os.Stat(getBadgerDBPath(dir, bucket)); err == nil {
+		return BadgerBackend
+	}
+	return ""
+}
+
+// ResolveBackend checks the wanted backend against the data already in dir.
+// An empty backend means use whatever is on disk, or bolt for a new data dir.
+func ResolveBackend(dir string, bucket string, backend string) (string, error) {
+	existing := DetectBackend(dir, bucket)
+	if backend == "" {
+		if existing == "" {
+			return BoltBackend, nil
+		}
+		return existing, nil
+	}
+	if existing != "" && existing != backend {
+		return "", fmt.Errorf("%s data in %s is stored with %s backend, run `quorum db migrate` to convert it to %s", bucket, dir, existing, backend)
+	}
+	return backend, nil
+}
+
+// CreateDb opens the chain dbs under path with the backend already on disk, new dbs use bolt
 func CreateDb(path string) (*DbMgr, error) {
+	return CreateDbWithBackend(path, "")
+}
+
+func CreateDbWithBackend(path string, backend string) (*DbMgr, error) {
+	backend, err := ResolveBackend(path, "db", backend)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx := context.Background()
-	groupDb, err := NewStore(ctx, path, "groups")
+	groupDb, err := OpenStore(ctx, backend, path, "groups")
 	if err != nil {
 		return nil, err
 	}
-	dataDb, err := NewStore(ctx, path, "db")
+	dataDb, err := OpenStore(ctx, backend, path, "db")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,241 @@
+//go:build !js
+// +build !js
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+var testBackends = []string{BoltBackend, BadgerBackend}
+
+func openTestStore(t *testing.T, backend string) QuorumStorage {
+	s, err := OpenStore(context.Background(), backend, t.TempDir(), "db")
+	if err != nil {
+		t.Fatalf("open %s store failed: %s", backend, err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func FactoryTestSetGetDelete(s QuorumStorage) func(t *testing.T) {
+	return func(t *testing.T) {
+		key := []byte("key_1")
+		if err := s.Set(key, []byte("value_1")); err != nil {
+			t.Fatalf("set failed: %s", err)
+		}
+		val, err := s.Get(key)
+		if err != nil || string(val) != "value_1" {
+			t.Fatalf("get %s, expect value_1, got %s, err: %v", key, val, err)
+		}
+		if exist, err := s.IsExist(key); err != nil || !exist {
+			t.Fatalf("key %s should exist, err: %v", key, err)
+		}
+		if err := s.Delete(key); err != nil {
+			t.Fatalf("delete failed: %s", err)
+		}
+		val, err = s.Get(key)
+		if err != nil || val != nil {
+			t.Fatalf("get deleted key should return nil value and nil err, got %s, %v", val, err)
+		}
+		if exist, err := s.IsExist(key); err != nil || exist {
+			t.Fatalf("key %s should not exist, err: %v", key, err)
+		}
+		if _, err := s.Get(nil); err == nil {
+			t.Fatalf("get empty key should fail")
+		}
+	}
+}
+
+func FactoryTestPrefix(s QuorumStorage) func(t *testing.T) {
+	return func(t *testing.T) {
+		keys := [][]byte{}
+		vals := [][]byte{}
+		for i := 0; i < 10; i++ {
+			keys = append(keys, []byte(fmt.Sprintf("a_%02d", i)))
+			vals = append(vals, []byte(fmt.Sprintf("%d", i)))
+			keys = append(keys, []byte(fmt.Sprintf("b_%02d", i)))
+			vals = append(vals, []byte(fmt.Sprintf("%d", i)))
+		}
+		if err := s.BatchWrite(keys, vals); err != nil {
+			t.Fatalf("batch write failed: %s", err)
+		}
+		if err := s.BatchWrite(keys, vals[1:]); err == nil {
+			t.Fatalf("batch write with mismatched keys and values should fail")
+		}
+
+		result := []string{}
+		err := s.PrefixForeach([]byte("a_"), func(k []byte, v []byte, err error) error {
+			result = append(result, string(k))
+			return err
+		})
+		if err != nil || len(result) != 10 || result[0] != "a_00" || result[9] != "a_09" {
+			t.Fatalf("prefix foreach got %v, err: %v", result, err)
+		}
+
+		result = []string{}
+		n, err := s.PrefixForeachKey([]byte("b_05"), []byte("b_"), false, func(k []byte, err error) error {
+			result = append(result, string(k))
+			return err
+		})
+		if err != nil || n != 5 || result[0] != "b_05" || result[4] != "b_09" {
+			t.Fatalf("prefix foreach key got %v, err: %v", result, err)
+		}
+
+		result = []string{}
+		n, err = s.PrefixForeachKey([]byte("a_"), []byte("a_"), true, func(k []byte, err error) error {
+			result = append(result, string(k))
+			return err
+		})
+		if err != nil || n != 10 || result[0] != "a_09" || result[9] != "a_00" {
+			t.Fatalf("reverse prefix foreach key got %v, err: %v", result, err)
+		}
+
+		n, err = s.PrefixCondDelete([]byte("a_"), func(k []byte, v []byte, err error) (bool, error) {
+			return string(v) < "5", err
+		})
+		if err != nil || n != 5 {
+			t.Fatalf("prefix cond delete should delete 5 keys, got %d, err: %v", n, err)
+		}
+		n, err = s.PrefixDelete([]byte("a_"))
+		if err != nil || n != 5 {
+			t.Fatalf("prefix delete should delete 5 keys, got %d, err: %v", n, err)
+		}
+
+		count := 0
+		err = s.Foreach(func(k []byte, v []byte, err error) error {
+			count++
+			return err
+		})
+		if err != nil || count != 10 {
+			t.Fatalf("foreach should get 10 keys, got %d, err: %v", count, err)
+		}
+	}
+}
+
+func FactoryTestReversePrefixBoundary(s QuorumStorage) func(t *testing.T) {
+	return func(t *testing.T) {
+		keys := [][]byte{[]byte("c_"), []byte("c_\x01"), []byte("c_\xff"), []byte("c_\xff\xff"), []byte("c`"), []byte("d")}
+		vals := [][]byte{}
+		for range keys {
+			vals = append(vals, []byte("v"))
+		}
+		if err := s.BatchWrite(keys, vals); err != nil {
+			t.Fatalf("batch write failed: %s", err)
+		}
+
+		result := []string{}
+		n, err := s.PrefixForeachKey([]byte("c_"), []byte("c_"), true, func(k []byte, err error) error {
+			result = append(result, string(k))
+			return err
+		})
+		if err != nil || n != 4 || result[0] != "c_\xff\xff" || result[1] != "c_\xff" || result[3] != "c_" {
+			t.Fatalf("reverse prefix foreach key got %q, err: %v", result, err)
+		}
+
+		//the prefix with no next key
+		if err := s.Set([]byte("\xff\xff"), []byte("v")); err != nil {
+			t.Fatalf("set failed: %s", err)
+		}
+		n, err = s.PrefixForeachKey([]byte("\xff"), []byte("\xff"), true, func(k []byte, err error) error {
+			return err
+		})
+		if err != nil || n != 1 {
+			t.Fatalf("reverse prefix foreach key of 0xff should get 1 key, got %d, err: %v", n, err)
+		}
+	}
+}
+
+func FactoryTestSequence(s QuorumStorage) func(t *testing.T) {
+	return func(t *testing.T) {
+		seq, err := s.GetSequence([]byte("seq_test"), 10)
+		if err != nil {
+			t.Fatalf("get sequence failed: %s", err)
+		}
+		for i := uint64(0); i < 25; i++ {
+			n, err := seq.Next()
+			if err != nil || n != i {
+				t.Fatalf("sequence next expect %d, got %d, err: %v", i, n, err)
+			}
+		}
+		if err := seq.Release(); err != nil {
+			t.Fatalf("release sequence failed: %s", err)
+		}
+
+		seq, err = s.GetSequence([]byte("seq_test"), 10)
+		if err != nil {
+			t.Fatalf("get sequence again failed: %s", err)
+		}
+		if n, err := seq.Next(); err != nil || n != 25 {
+			t.Fatalf("sequence should continue from 25, got %d, err: %v", n, err)
+		}
+		if _, err := s.GetSequence(nil, 10); err == nil {
+			t.Fatalf("get sequence with empty key should fail")
+		}
+	}
+}
+
+func FactoryTestBlock(s QuorumStorage) func(t *testing.T) {
+	return func(t *testing.T) {
+		dbMgr := &DbMgr{GroupInfoDb: s, Db: s}
+		groupId := "5ed3f9fe-81e2-450d-9146-7a329aac2b62"
+
+		for i := uint64(0); i < 3; i++ {
+			block := &quorumpb.Block{GroupId: groupId, BlockId: i, PrevHash: []byte{byte(i)}}
+			if err := dbMgr.SaveBlock(block, false); err != nil {
+				t.Fatalf("save block %d failed: %s", i, err)
+			}
+		}
+		if err := dbMgr.SaveBlock(&quorumpb.Block{GroupId: groupId, BlockId: 1}, false); err == nil {
+			t.Fatalf("save an existing block should fail")
+		}
+
+		block, err := dbMgr.GetBlock(groupId, 2, false)
+		if err != nil || block.BlockId != 2 || block.PrevHash[0] != 2 {
+			t.Fatalf("get block 2 failed: %v, %v", block, err)
+		}
+		if exist, err := dbMgr.IsBlockExist(groupId, 1, true); err != nil || exist {
+			t.Fatalf("block 1 should not be cached, err: %v", err)
+		}
+		if err := dbMgr.RmBlock(groupId, 1, false); err != nil {
+			t.Fatalf("remove block 1 failed: %s", err)
+		}
+		if exist, err := dbMgr.IsBlockExist(groupId, 1, false); err != nil || exist {
+			t.Fatalf("block 1 should be removed, err: %v", err)
+		}
+	}
+}
+
+func TestStorageBackends(t *testing.T) {
+	for _, backend := range testBackends {
+		t.Run(backend+"/SetGetDelete", FactoryTestSetGetDelete(openTestStore(t, backend)))
+		t.Run(backend+"/Prefix", FactoryTestPrefix(openTestStore(t, backend)))
+		t.Run(backend+"/ReversePrefixBoundary", FactoryTestReversePrefixBoundary(openTestStore(t, backend)))
+		t.Run(backend+"/Sequence", FactoryTestSequence(openTestStore(t, backend)))
+		t.Run(backend+"/Block", FactoryTestBlock(openTestStore(t, backend)))
+	}
+}
+
+func TestResolveBackend(t *testing.T) {
+	dir := t.TempDir()
+	if backend, err := ResolveBackend(dir, "db", ""); err != nil || backend != BoltBackend {
+		t.Fatalf("new data dir should default to bolt, got %s, err: %v", backend, err)
+	}
+
+	s, err := NewBadgerStore(context.Background(), dir, "db")
+	if err != nil {
+		t.Fatalf("open badger store failed: %s", err)
+	}
+	s.Close()
+
+	if backend, err := ResolveBackend(dir, "db", ""); err != nil || backend != BadgerBackend {
+		t.Fatalf("should detect badger backend, got %s, err: %v", backend, err)
+	}
+	if _, err := ResolveBackend(dir, "db", BoltBackend); err == nil {
+		t.Fatalf("open badger data with bolt backend should fail")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 )
 
 // NewJWTToken creates a new jwt token
@@ -20,6 +21,32 @@ func NewJWTToken(name string, role string, allowGroup string, jwtKey string, exp
 	return token.SignedString([]byte(jwtKey))
 }
 
+// scopes of the scoped jwt token, admin of a group can access all the apis of the group
+const (
+	JWTScopeRead  = "read"
+	JWTScopePost  = "post"
+	JWTScopeAdmin = "admin"
+)
+
+// NewScopedJWTToken creates a new jwt token with the scopes (read, post or admin) of the allow groups,
+// rateLimit is the max requests per minute, 0 for no limit
+func NewScopedJWTToken(name string, allowGroups []string, scopes []string, rateLimit int, jwtKey string, exp time.Time) (string, error) {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["name"] = name
+	claims["role"] = "scoped"
+	claims["allowGroups"] = allowGroups
+	claims["scopes"] = scopes
+	if rateLimit > 0 {
+		claims["rateLimit"] = rateLimit
+	}
+	claims["exp"] = exp.Unix()
+	//tokens issued with the same claims in the same second are told apart by jti
+	claims["jti"] = uuid.NewString()
+
+	return token.SignedString([]byte(jwtKey))
+}
+
 // ParseJWTToken parse jwt token string to *jwt.Token
 func ParseJWTToken(tokenStr string, jwtKey string) (*jwt.Token, error) {
 	claims := jwt.MapClaims{}
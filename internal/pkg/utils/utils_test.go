@@ -2,7 +2,9 @@ package utils
 
 import (
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	maddr "github.com/multiformats/go-multiaddr"
 )
 
@@ -37,3 +39,25 @@ func TestGetRandomStr(t *testing.T) {
 		t.Errorf("random two string are equal: %s, %s", a, b)
 	}
 }
+
+func TestNewScopedJWTTokenJti(t *testing.T) {
+	exp := time.Now().Add(time.Hour)
+	jwtKey := GetRandomStr(32)
+	jtis := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		tokenStr, err := NewScopedJWTToken("dashboard", []string{"group"}, []string{"read"}, 0, jwtKey, exp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, err := ParseJWTToken(tokenStr, jwtKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		claims := token.Claims.(jwt.MapClaims)
+		jti, _ := claims["jti"].(string)
+		if jti == "" || jtis[jti] {
+			t.Errorf("Test failed, jti %q should be unique", jti)
+		}
+		jtis[jti] = true
+	}
+}
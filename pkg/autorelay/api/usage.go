@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	handlers "github.com/rumsystem/quorum/pkg/autorelay/handlers"
+)
+
+func (h *RelayServerHandler) GetUsage(c echo.Context) (err error) {
+	// peer is optional, returns the usage of all peers with traffic if it is empty
+	peer := c.QueryParam("peer")
+
+	trafficAudit := h.node.Audit
+	if err := trafficAudit.Flush(); err != nil {
+		return rumerrors.NewInternalServerError(err)
+	}
+
+	result, err := handlers.GetUsage(h.db, trafficAudit.Quota(), peer)
+	if err != nil {
+		return rumerrors.NewInternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RelayServerHandler) SetQuota(c echo.Context) (err error) {
+	param := handlers.SetQuotaParam{}
+	if err := c.Bind(&param); err != nil {
+		return rumerrors.NewBadRequestError(err.Error())
+	}
+
+	result, err := handlers.SetQuota(h.db, h.node.Audit.Quota(), param)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
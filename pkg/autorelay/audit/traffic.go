@@ -1,24 +1,181 @@
 package audit
 
 import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/metrics"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/proto"
 	"github.com/rumsystem/quorum/internal/pkg/logging"
+	"github.com/rumsystem/quorum/internal/pkg/options"
 	"github.com/rumsystem/quorum/internal/pkg/storage"
+	"github.com/rumsystem/quorum/pkg/autorelay/handlers"
 )
 
 /* to record traffic consumption of a peer */
 
 var auditLogger = logging.Logger("main")
 
+// QuorumTrafficAudit counts the bytes relayed for each server peer (the dest peer of the relayed connections),
+// and forbids the peer when it exceeds its quota in the window. It works as the bandwidth reporter of the relay host,
+// the traffic of the stop streams is the traffic relayed for the peer.
+// Bytes are counted in memory and flushed to db periodically.
 type QuorumTrafficAudit struct {
-	db storage.QuorumStorage
+	*metrics.BandwidthCounter
+	db    storage.QuorumStorage
+	quota options.RelayQuotaOptions
+
+	mu      sync.Mutex
+	pending map[peer.ID]int64
+
+	flushMu sync.Mutex
+	now     func() time.Time
 }
 
-func NewQuorumTrafficAudit(db storage.QuorumStorage) *QuorumTrafficAudit {
-	a := QuorumTrafficAudit{db}
+func NewQuorumTrafficAudit(db storage.QuorumStorage, quota options.RelayQuotaOptions) *QuorumTrafficAudit {
+	a := QuorumTrafficAudit{
+		BandwidthCounter: metrics.NewBandwidthCounter(),
+		db:               db,
+		quota:            quota,
+		pending:          make(map[peer.ID]int64),
+		now:              time.Now,
+	}
 	return &a
 }
 
-func (a *QuorumTrafficAudit) OnRelay(src peer.ID, dest peer.ID, count int64) {
-	auditLogger.Infof("%s -> %s: %d bytes", src.String(), dest.String(), count)
+func (a *QuorumTrafficAudit) Quota() *options.RelayQuotaOptions {
+	return &a.quota
+}
+
+func (a *QuorumTrafficAudit) LogSentMessageStream(size int64, protoId protocol.ID, p peer.ID) {
+	a.BandwidthCounter.LogSentMessageStream(size, protoId, p)
+	a.onStream(size, protoId, p)
+}
+
+func (a *QuorumTrafficAudit) LogRecvMessageStream(size int64, protoId protocol.ID, p peer.ID) {
+	a.BandwidthCounter.LogRecvMessageStream(size, protoId, p)
+	a.onStream(size, protoId, p)
+}
+
+func (a *QuorumTrafficAudit) onStream(size int64, protoId protocol.ID, p peer.ID) {
+	if protoId == proto.ProtoIDv2Stop {
+		a.consume(p, size)
+	}
+}
+
+func (a *QuorumTrafficAudit) consume(p peer.ID, count int64) {
+	if count <= 0 {
+		return
+	}
+	a.mu.Lock()
+	a.pending[p] += count
+	a.mu.Unlock()
+}
+
+// Start flushes the counted bytes every interval until ctx is done
+func (a *QuorumTrafficAudit) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := a.Flush(); err != nil {
+				auditLogger.Errorf("flush traffic audit failed: %s", err.Error())
+			}
+		case <-ctx.Done():
+			if err := a.Flush(); err != nil {
+				auditLogger.Errorf("flush traffic audit failed: %s", err.Error())
+			}
+			return
+		}
+	}
+}
+
+// Flush adds the counted bytes to the usage of the peers, forbids the peers exceeded their quota,
+// and allows the forbidden peers again whose window is reset
+func (a *QuorumTrafficAudit) Flush() error {
+	a.flushMu.Lock()
+	defer a.flushMu.Unlock()
+
+	a.mu.Lock()
+	pending := a.pending
+	a.pending = make(map[peer.ID]int64)
+	a.mu.Unlock()
+
+	now := a.now()
+	var errs []error
+	for p, count := range pending {
+		if err := a.addUsage(p.String(), count, now); err != nil {
+			//put it back, retry in the next flush
+			a.consume(p, count)
+			errs = append(errs, err)
+		}
+	}
+
+	items, err := handlers.GetUsageItems(a.db)
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	for _, item := range items {
+		if item.Exceeded && a.expired(item, now) {
+			if err := a.resetWindow(item, now); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if err := handlers.SaveUsageItem(a.db, item); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (a *QuorumTrafficAudit) addUsage(p string, count int64, now time.Time) error {
+	item, err := handlers.GetUsageItem(a.db, p)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		item = &handlers.UsageItem{Peer: p, WindowStart: now}
+	} else if a.expired(item, now) {
+		if err := a.resetWindow(item, now); err != nil {
+			return err
+		}
+	}
+	item.Bytes += count
+
+	limit, tier, err := handlers.GetPeerLimit(a.db, &a.quota, p)
+	if err != nil {
+		return err
+	}
+	if limit > 0 && item.Bytes > limit && !item.Exceeded {
+		auditLogger.Warningf("peer <%s> of tier <%s> relayed %d bytes, exceeds its quota %d bytes, forbid it until %s",
+			p, tier, item.Bytes, limit, item.WindowStart.Add(a.quota.Window))
+		if _, err := handlers.ForbidPeer(a.db, handlers.ForbidParam{Peer: p}); err != nil {
+			return err
+		}
+		item.Exceeded = true
+	}
+	return handlers.SaveUsageItem(a.db, item)
+}
+
+func (a *QuorumTrafficAudit) expired(item *handlers.UsageItem, now time.Time) bool {
+	return !now.Before(item.WindowStart.Add(a.quota.Window))
+}
+
+func (a *QuorumTrafficAudit) resetWindow(item *handlers.UsageItem, now time.Time) error {
+	if item.Exceeded {
+		auditLogger.Infof("quota window of peer <%s> is reset, allow it again", item.Peer)
+		if err := handlers.AllowPeer(a.db, item.Peer); err != nil {
+			return err
+		}
+	}
+	item.WindowStart = now
+	item.Bytes = 0
+	item.Exceeded = false
+	return nil
 }
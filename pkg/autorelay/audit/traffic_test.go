@@ -0,0 +1,140 @@
+//go:build !js
+// +build !js
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/proto"
+	"github.com/rumsystem/quorum/internal/pkg/options"
+	"github.com/rumsystem/quorum/internal/pkg/storage"
+	"github.com/rumsystem/quorum/pkg/autorelay/handlers"
+)
+
+func TestTrafficQuota(t *testing.T) {
+	db, err := storage.NewStore(context.Background(), t.TempDir(), "relaydb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	now := time.Unix(1700000000, 0)
+	a := NewQuorumTrafficAudit(db, options.RelayQuotaOptions{
+		Window:       time.Hour,
+		DefaultLimit: 100,
+		Tiers:        map[string]int64{"premium": 1000},
+	})
+	a.now = func() time.Time { return now }
+
+	server := peer.ID("server")
+	allowed := func() bool {
+		permission, err := handlers.GetPermissions(db, server.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return permission.AllowConnect
+	}
+	flush := func() {
+		if err := a.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	//only the relayed traffic is counted
+	a.LogRecvMessageStream(60, proto.ProtoIDv2Stop, server)
+	a.LogSentMessageStream(500, proto.ProtoIDv2Hop, server)
+	a.LogSentMessageStream(500, "/quorum/nevis/meshsub/1.1.0", server)
+	flush()
+	if usage, _ := handlers.GetUsageItem(db, server.String()); usage == nil || usage.Bytes != 60 || !allowed() {
+		t.Fatalf("expect 60 bytes relayed and allowed, got %+v", usage)
+	}
+
+	//exceeds the default quota
+	a.LogSentMessageStream(50, proto.ProtoIDv2Stop, server)
+	flush()
+	if usage, _ := handlers.GetUsageItem(db, server.String()); !usage.Exceeded || allowed() {
+		t.Fatalf("expect peer forbidden after exceeding its quota, got %+v", usage)
+	}
+
+	//allowed again when the window is reset
+	now = now.Add(time.Hour)
+	flush()
+	if usage, _ := handlers.GetUsageItem(db, server.String()); usage.Exceeded || usage.Bytes != 0 || !allowed() {
+		t.Fatalf("expect peer allowed after the window reset, got %+v", usage)
+	}
+
+	//allowed again when the quota is raised
+	a.LogSentMessageStream(200, proto.ProtoIDv2Stop, server)
+	flush()
+	if allowed() {
+		t.Fatalf("expect peer forbidden after exceeding its quota")
+	}
+	if _, err := handlers.SetQuota(db, a.Quota(), handlers.SetQuotaParam{Peer: server.String(), Tier: "premium"}); err != nil {
+		t.Fatal(err)
+	}
+	if !allowed() {
+		t.Fatalf("expect peer allowed after moved to a higher tier")
+	}
+	result, err := handlers.GetUsage(db, a.Quota(), "")
+	if err != nil || len(result.Peers) != 1 || result.Peers[0].Limit != 1000 || result.Peers[0].Bytes != 200 || result.Peers[0].Tier != "premium" {
+		t.Errorf("unexpected usage %+v, %v", result, err)
+	}
+
+	if _, err := handlers.SetQuota(db, a.Quota(), handlers.SetQuotaParam{Peer: server.String(), Tier: "unknown"}); err == nil {
+		t.Errorf("expect unknown tier rejected")
+	}
+}
+
+// failStore fails to save the usage of the peer
+type failStore struct {
+	storage.QuorumStorage
+	peer string
+}
+
+func (s *failStore) Set(key []byte, value []byte) error {
+	if bytes.Equal(key, []byte(handlers.GetUsageKey(s.peer))) {
+		return errors.New("set failed")
+	}
+	return s.QuorumStorage.Set(key, value)
+}
+
+func TestTrafficFlushFailed(t *testing.T) {
+	db, err := storage.NewStore(context.Background(), t.TempDir(), "relaydb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	store := &failStore{QuorumStorage: db, peer: peer.ID("bad").String()}
+	a := NewQuorumTrafficAudit(store, options.RelayQuotaOptions{Window: time.Hour, DefaultLimit: 1000})
+	peers := []peer.ID{"bad", "good1", "good2"}
+	for _, p := range peers {
+		a.LogRecvMessageStream(10, proto.ProtoIDv2Stop, p)
+	}
+
+	if err := a.Flush(); err == nil {
+		t.Fatalf("expect flush failed")
+	}
+	for _, p := range peers[1:] {
+		if usage, _ := handlers.GetUsageItem(db, p.String()); usage == nil || usage.Bytes != 10 {
+			t.Errorf("usage of <%s> should be saved even if other peers failed, got %+v", p, usage)
+		}
+	}
+
+	//only the failed one is retried
+	store.peer = ""
+	if err := a.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range peers {
+		if usage, _ := handlers.GetUsageItem(db, p.String()); usage == nil || usage.Bytes != 10 {
+			t.Errorf("expect 10 bytes of <%s>, got %+v", p, usage)
+		}
+	}
+}
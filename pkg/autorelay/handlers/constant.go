@@ -9,6 +9,8 @@ const (
 	PREFIX_ALLOW_RESERVE = "AllowReserve"
 	PREFIX_ALLOW_CONNECT = "AllowConnect"
 	PREFIX_BLACKLIST     = "Blacklist"
+	PREFIX_USAGE         = "Usage"
+	PREFIX_QUOTA         = "Quota"
 )
 
 func GetAllowConnectKey(peer string) string {
@@ -19,6 +21,18 @@ func GetAllowReserveKey(peer string) string {
 	return fmt.Sprintf("%s_%s", PREFIX_ALLOW_RESERVE, peer)
 }
 
+func GetUsagePrefixKey() string {
+	return fmt.Sprintf("%s_", PREFIX_USAGE)
+}
+
+func GetUsageKey(peer string) string {
+	return fmt.Sprintf("%s_%s", PREFIX_USAGE, peer)
+}
+
+func GetQuotaKey(peer string) string {
+	return fmt.Sprintf("%s_%s", PREFIX_QUOTA, peer)
+}
+
 func GetBlackListPrefixKey(serverPeer string) string {
 	return fmt.Sprintf("%s_%s", PREFIX_BLACKLIST, serverPeer)
 }
@@ -25,3 +25,8 @@ func ForbidPeer(db storage.QuorumStorage, param ForbidParam) (*ForbidResult, err
 
 	return res, nil
 }
+
+/* AllowPeer removes the forbidden flag of a server peer */
+func AllowPeer(db storage.QuorumStorage, peer string) error {
+	return db.Delete([]byte(GetAllowConnectKey(peer)))
+}
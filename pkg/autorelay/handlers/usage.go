@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rumsystem/quorum/internal/pkg/options"
+	"github.com/rumsystem/quorum/internal/pkg/storage"
+)
+
+// UsageItem is the bytes relayed for a server peer in its current window
+type UsageItem struct {
+	Peer        string    `json:"peer"`
+	WindowStart time.Time `json:"window_start"`
+	Bytes       int64     `json:"bytes"`
+	Exceeded    bool      `json:"exceeded"` // forbidden by the traffic audit, allowed again when the window resets
+}
+
+// QuotaItem is the quota set for a server peer, the limit overrides the tier
+type QuotaItem struct {
+	Peer  string `json:"peer"`
+	Tier  string `json:"tier"`
+	Limit int64  `json:"limit"`
+}
+
+type PeerUsage struct {
+	Peer        string    `json:"peer"`
+	Tier        string    `json:"tier"`
+	Limit       int64     `json:"limit"` // 0 means unlimited
+	Bytes       int64     `json:"bytes"`
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+	Exceeded    bool      `json:"exceeded"`
+	Forbidden   bool      `json:"forbidden"`
+}
+
+type GetUsageResult struct {
+	Window string       `json:"window"`
+	Peers  []*PeerUsage `json:"peers"`
+}
+
+type SetQuotaParam struct {
+	Peer  string `json:"peer"`
+	Tier  string `json:"tier"`
+	Limit int64  `json:"limit"`
+}
+
+type SetQuotaResult struct {
+	Ok bool `json:"ok"`
+}
+
+func GetUsageItem(db storage.QuorumStorage, peer string) (*UsageItem, error) {
+	v, err := db.Get([]byte(GetUsageKey(peer)))
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+	item := &UsageItem{}
+	if err := json.Unmarshal(v, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func SaveUsageItem(db storage.QuorumStorage, item *UsageItem) error {
+	v, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	return db.Set([]byte(GetUsageKey(item.Peer)), v)
+}
+
+func GetUsageItems(db storage.QuorumStorage) ([]*UsageItem, error) {
+	var items []*UsageItem
+	err := db.PrefixForeach([]byte(GetUsagePrefixKey()), func(k []byte, v []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		item := &UsageItem{}
+		if err := json.Unmarshal(v, item); err != nil {
+			return err
+		}
+		items = append(items, item)
+		return nil
+	})
+	return items, err
+}
+
+func GetQuotaItem(db storage.QuorumStorage, peer string) (*QuotaItem, error) {
+	v, err := db.Get([]byte(GetQuotaKey(peer)))
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+	item := &QuotaItem{}
+	if err := json.Unmarshal(v, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+/* GetPeerLimit returns the bytes the peer can relay in a window and its tier, 0 means unlimited */
+func GetPeerLimit(db storage.QuorumStorage, quota *options.RelayQuotaOptions, peer string) (int64, string, error) {
+	item, err := GetQuotaItem(db, peer)
+	if err != nil {
+		return 0, "", err
+	}
+	if item == nil {
+		return quota.DefaultLimit, "", nil
+	}
+	if item.Limit > 0 {
+		return item.Limit, item.Tier, nil
+	}
+	if limit, ok := quota.Tiers[item.Tier]; ok {
+		return limit, item.Tier, nil
+	}
+	return quota.DefaultLimit, item.Tier, nil
+}
+
+/* SetQuota sets the tier or limit of a server peer, the peer is allowed again if it is under the new quota */
+func SetQuota(db storage.QuorumStorage, quota *options.RelayQuotaOptions, param SetQuotaParam) (*SetQuotaResult, error) {
+	if param.Peer == "" {
+		return nil, fmt.Errorf("peer can't be nil")
+	}
+	if param.Limit < 0 {
+		return nil, fmt.Errorf("limit can't be negative")
+	}
+	if _, ok := quota.Tiers[param.Tier]; param.Tier != "" && !ok {
+		return nil, fmt.Errorf("tier <%s> is not configured", param.Tier)
+	}
+
+	item := QuotaItem(param)
+	v, err := json.Marshal(&item)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Set([]byte(GetQuotaKey(param.Peer)), v); err != nil {
+		return nil, err
+	}
+
+	usage, err := GetUsageItem(db, param.Peer)
+	if err != nil {
+		return nil, err
+	}
+	if usage != nil && usage.Exceeded {
+		limit, _, err := GetPeerLimit(db, quota, param.Peer)
+		if err != nil {
+			return nil, err
+		}
+		if limit == 0 || usage.Bytes <= limit {
+			if err := AllowPeer(db, param.Peer); err != nil {
+				return nil, err
+			}
+			usage.Exceeded = false
+			if err := SaveUsageItem(db, usage); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &SetQuotaResult{true}, nil
+}
+
+/* GetUsage returns the traffic consumption of the peer, or all peers with traffic if peer is empty */
+func GetUsage(db storage.QuorumStorage, quota *options.RelayQuotaOptions, peer string) (*GetUsageResult, error) {
+	var items []*UsageItem
+	if peer != "" {
+		item, err := GetUsageItem(db, peer)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			item = &UsageItem{Peer: peer}
+		}
+		items = append(items, item)
+	} else {
+		var err error
+		if items, err = GetUsageItems(db); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &GetUsageResult{Window: quota.Window.String(), Peers: []*PeerUsage{}}
+	for _, item := range items {
+		limit, tier, err := GetPeerLimit(db, quota, item.Peer)
+		if err != nil {
+			return nil, err
+		}
+		permission, err := GetPermissions(db, item.Peer)
+		if err != nil {
+			return nil, err
+		}
+		usage := &PeerUsage{
+			Peer:        item.Peer,
+			Tier:        tier,
+			Limit:       limit,
+			Bytes:       item.Bytes,
+			WindowStart: item.WindowStart,
+			Exceeded:    item.Exceeded,
+			Forbidden:   !permission.AllowConnect,
+		}
+		if !item.WindowStart.IsZero() {
+			usage.WindowEnd = item.WindowStart.Add(quota.Window)
+		}
+		res.Peers = append(res.Peers, usage)
+	}
+	return res, nil
+}
@@ -40,9 +40,11 @@ func StartRelayServer(config cli.RelayNodeFlag, quitCh chan os.Signal, h *api.Re
 	r.POST("/v1/blacklist", h.AddBlacklist)
 	r.DELETE("/v1/blacklist", h.DeleteBlacklist)
 	r.POST("/v1/disconnect", h.Disconnect)
+	r.POST("/v1/quota", h.SetQuota)
 
 	r.GET("/v1/permissions", h.GetPermissions)
 	r.GET("/v1/blacklist", h.GetBlacklist)
+	r.GET("/v1/usage", h.GetUsage)
 
 	e.Logger.Fatal(e.Start(fmt.Sprintf("%s:%d", config.APIHost, config.APIPort)))
 }
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
+)
+
+// @Tags Management
+// @Summary GetGroupEvidences
+// @Description Get the evidence of producer misbehaviour found by this node, each one has the 2 conflicting messages signed by the producer
+// @Produce json
+// @Param group_id path string  true "Group Id"
+// @Success 200 {object} handlers.GroupEvidencesResult
+// @Router /api/v1/group/{group_id}/evidences [get]
+func (h *Handler) GetGroupEvidences(c echo.Context) (err error) {
+	groupid := c.Param("group_id")
+	if groupid == "" {
+		return rumerrors.NewBadRequestError(rumerrors.ErrInvalidGroupID)
+	}
+
+	res, err := handlers.GetGroupEvidences(groupid)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
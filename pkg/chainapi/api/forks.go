@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
+)
+
+// @Tags Management
+// @Summary GetGroupForks
+// @Description Get the blocks received with the BlockId of a block on chain but a different hash, and how they are resolved
+// @Produce json
+// @Param group_id path string  true "Group Id"
+// @Success 200 {object} handlers.GroupForksResult
+// @Router /api/v1/group/{group_id}/forks [get]
+func (h *Handler) GetGroupForks(c echo.Context) (err error) {
+	groupid := c.Param("group_id")
+	if groupid == "" {
+		return rumerrors.NewBadRequestError(rumerrors.ErrInvalidGroupID)
+	}
+
+	res, err := handlers.GetGroupForks(groupid)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
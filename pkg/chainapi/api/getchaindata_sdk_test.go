@@ -297,3 +297,43 @@ func TestGetChainDataAppConfigNSdk(t *testing.T) {
 		t.Errorf("get appconfig by name failed: %s, except name: %s type: %s, actual name: %s type: %s", err, name, _type, appconfig.Name, appconfig.Type)
 	}
 }
+
+func TestGetBlockHeadersNSdk(t *testing.T) {
+	t.Parallel()
+
+	createGroupParam := handlers.CreateGroupParam{
+		GroupName:       "test-block-headers",
+		ConsensusType:   "poa",
+		EncryptionType:  "public",
+		AppKey:          "default",
+		IncludeChainUrl: true,
+	}
+	group, err := createGroup(peerapi, createGroupParam)
+	if err != nil {
+		t.Fatalf("createGroup failed: %s, payload: %+v", err, createGroupParam)
+	}
+
+	_, urls, err := handlers.UrlToGroupSeed(group.Seed)
+	if err != nil {
+		t.Errorf("convert group send url failed: %s", err)
+	}
+
+	var headers []*handlers.BlockHeaderItem
+	param := handlers.GetBlockHeadersParam{GroupId: group.GroupId, BlockId: 0, Num: 10}
+	path := fmt.Sprintf("/api/v1/node/%s/headers/%d", param.GroupId, param.BlockId)
+	if _, _, err := requestNSdk(urls, path, "GET", param, nil, &headers, true); err != nil {
+		t.Fatalf("get block headers failed: %s", err)
+	}
+	if len(headers) == 0 || headers[0].Header.BlockId != 0 || headers[0].Header.GroupId != group.GroupId {
+		t.Fatalf("expect headers from the genesis block, got %+v", headers)
+	}
+
+	var trusted handlers.TrustedHeaderResult
+	path = fmt.Sprintf("/api/v1/node/%s/headers/trusted", group.GroupId)
+	if _, _, err := requestNSdk(urls, path, "GET", nil, nil, &trusted, true); err != nil {
+		t.Fatalf("get trusted header failed: %s", err)
+	}
+	if trusted.Header == nil || len(trusted.Header.Trxs) != 0 || len(trusted.Producers) == 0 {
+		t.Errorf("unexpected trusted header %+v", trusted)
+	}
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
+	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
+)
+
+// @Tags Management
+// @Summary GetChainPackingPolicy
+// @Description Get the trx packing policy, batch size and bundle size of the group
+// @Produce json
+// @Param group_id path string  true "Group Id"
+// @Success 200 {object} handlers.PackingPolicyItem
+// @Router /api/v1/group/{group_id}/trx/packingpolicy [get]
+func (h *Handler) GetChainPackingPolicy(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	params := new(handlers.PackingPolicyQueryParams)
+	if err := cc.BindAndValidate(params); err != nil {
+		return err
+	}
+
+	res, err := handlers.GetChainPackingPolicy(h.ChainAPIdb, params.GroupId)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
+	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
+)
+
+// @Tags Management
+// @Summary GetConsensus
+// @Description Get the consensus state of the group, with the RBC and agreement state of recent epochs traced by this node
+// @Produce json
+// @Param group_id path string  true "Group Id"
+// @Success 200 {object} handlers.ConsensusInfo
+// @Router /api/v1/group/{group_id}/consensus [get]
+func (h *Handler) GetConsensus(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	params := new(handlers.GetConsensusParams)
+	if err := cc.BindAndValidate(params); err != nil {
+		return err
+	}
+
+	res, err := handlers.GetConsensusInfo(params.GroupId)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
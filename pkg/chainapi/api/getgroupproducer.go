@@ -28,3 +28,24 @@ func (h *Handler) GetGroupProducers(c echo.Context) (err error) {
 
 	return c.JSON(http.StatusOK, res)
 }
+
+// @Tags Management
+// @Summary GetGroupScheduledProducers
+// @Description Get the group producers in effect, and the producer lists scheduled to take over at later epochs
+// @Produce json
+// @Param group_id path string  true "Group Id"
+// @Success 200 {object} handlers.GroupProducersResult
+// @Router /api/v1/group/{group_id}/producers/scheduled [get]
+func (h *Handler) GetGroupScheduledProducers(c echo.Context) (err error) {
+	groupid := c.Param("group_id")
+	if groupid == "" {
+		return rumerrors.NewBadRequestError(rumerrors.ErrInvalidGroupID)
+	}
+
+	res, err := handlers.GetGroupProducerSets(h.ChainAPIdb, groupid)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -31,3 +31,49 @@ func (h *Handler) GetTrx(c echo.Context) (err error) {
 
 	return c.JSON(http.StatusOK, trx)
 }
+
+// @Tags Chain
+// @Summary GetTrxStatus
+// @Description Get the status of a transaction sent by this node: pending, on-chain, expired or failed
+// @Produce json
+// @Param group_id path string  true "Group Id"
+// @Param trx_id path string  true "Transaction Id"
+// @Success 200 {object} handlers.TrxStatusResult
+// @Router /api/v1/trx/{group_id}/{trx_id}/status [get]
+func (h *Handler) GetTrxStatus(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	var params handlers.GetTrxParam
+	if err := cc.BindAndValidate(&params); err != nil {
+		return err
+	}
+
+	res, err := handlers.GetTrxStatus(params.GroupId, params.TrxId)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// @Tags Chain
+// @Summary GetTrxProof
+// @Description Get the merkle proof of a trx in the block packaged it, with the block header to verify the proof
+// @Produce json
+// @Param group_id path string  true "Group Id"
+// @Param trx_id path string  true "Transaction Id"
+// @Success 200 {object} handlers.TrxProofResult
+// @Router /api/v1/trx/{group_id}/{trx_id}/proof [get]
+func (h *Handler) GetTrxProof(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	var params handlers.GetTrxParam
+	if err := cc.BindAndValidate(&params); err != nil {
+		return err
+	}
+
+	res, err := handlers.GetTrxProof(params.GroupId, params.TrxId)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -1,12 +1,14 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
 	"github.com/rumsystem/quorum/pkg/pb"
 	"google.golang.org/protobuf/encoding/protojson"
 )
@@ -31,6 +33,20 @@ func getTrx(api string, groupID string, trxID string) (*pb.Trx, error) {
 	return &result, nil
 }
 
+func getTrxProof(api string, groupID string, trxID string) (*handlers.TrxProofResult, error) {
+	urlSuffix := fmt.Sprintf("/api/v1/trx/%s/%s/proof", groupID, trxID)
+	_, resp, err := requestAPI(api, urlSuffix, "GET", nil, nil, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result handlers.TrxProofResult
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 func TestGetTrx(t *testing.T) {
 	t.Parallel()
 
@@ -46,6 +62,25 @@ func TestGetTrx(t *testing.T) {
 		t.Errorf("createGroup failed: %s, payload: %+v", err, createGroupParam)
 	}
 
+	// blocks cover the trx root from the next epoch
+	producers, err := getScheduledProducers(peerapi, group.GroupId)
+	if err != nil {
+		t.Fatalf("getScheduledProducers failed: %s", err)
+	}
+	versionBytes, err := json.Marshal(handlers.BlockVersionParams{Version: "trx_root", FromEpoch: producers.CurrEpoch + 1})
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %s", err)
+	}
+	chainConfigParam := handlers.ChainConfigParams{
+		GroupId: group.GroupId,
+		Type:    "set_block_version",
+		Config:  string(versionBytes),
+	}
+	if _, err := updateChainConfig(peerapi, chainConfigParam); err != nil {
+		t.Fatalf("update chain config with payload: %+v failed: %s", chainConfigParam, err)
+	}
+	time.Sleep(time.Second * 25)
+
 	// post to group
 	content := fmt.Sprintf("%s hello world", RandString(4))
 	name := fmt.Sprintf("%s post to group testing", RandString(4))
@@ -78,4 +113,13 @@ func TestGetTrx(t *testing.T) {
 	if trx.TrxId != postResult.TrxId {
 		t.Errorf("getTrx failed: TrxId is not equal, expected: %s, actual: %s", postResult.TrxId, trx.TrxId)
 	}
+
+	// the trx is proved by the merkle proof and the block header
+	proof, err := getTrxProof(peerapi, group.GroupId, postResult.TrxId)
+	if err != nil {
+		t.Fatalf("getTrxProof failed: %s, groupID: %s, trxID: %s", err, group.GroupId, postResult.TrxId)
+	}
+	if valid, err := rumchaindata.VerifyTrxInclusionProof(trx, proof.Proof, proof.Header); !valid {
+		t.Errorf("verify trx proof failed: %v, proof: %+v", err, proof.Proof)
+	}
 }
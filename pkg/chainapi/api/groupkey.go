@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
+	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
+)
+
+// @Tags Management
+// @Summary RotateGroupKey
+// @Description Owner only, private group only. Rotate the group cipher key with a GROUP_KEY trx, the new key is encrypted to the users in the group and the extra encrypt pubkeys (e.g. of the producer nodes). Run it after a user is removed, the removed user can't decrypt the trxs sent after the trx is packaged.
+// @Accept json
+// @Produce json
+// @Param group_id path string true "Group Id"
+// @Param data body handlers.RotateGroupKeyParam true "RotateGroupKeyParam"
+// @Success 200 {object} handlers.RotateGroupKeyResult
+// @Router /api/v1/group/{group_id}/rekey [post]
+func (h *Handler) RotateGroupKey(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	params := new(handlers.RotateGroupKeyParam)
+	if err := cc.BindAndValidate(params); err != nil {
+		return err
+	}
+
+	res, err := handlers.RotateGroupKey(params)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// @Tags Management
+// @Summary GetGroupKeys
+// @Description Get the history of the group cipher key rotations, and if each key is encrypted to this node
+// @Produce json
+// @Param group_id path string  true "Group Id"
+// @Success 200 {object} handlers.GroupKeysResult
+// @Router /api/v1/group/{group_id}/keys [get]
+func (h *Handler) GetGroupKeys(c echo.Context) (err error) {
+	groupid := c.Param("group_id")
+	if groupid == "" {
+		return rumerrors.NewBadRequestError(rumerrors.ErrInvalidGroupID)
+	}
+
+	res, err := handlers.GetGroupKeys(groupid)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -72,9 +72,10 @@ func (h *Handler) JoinGroupV2() echo.HandlerFunc {
 
 		var groupSignPubkey []byte
 		ks := nodectx.GetNodeCtx().Keystore
-		dirks, ok := ks.(*localcrypto.DirKeyStore)
+		dirks, ok := localcrypto.AsDirKeyStore(ks)
 		if ok {
-			base64key, err := dirks.GetEncodedPubkey(seed.GenesisBlock.GroupId, localcrypto.Sign)
+			//the group key may be held by the signer, new keys are created in the local keystore
+			base64key, err := ks.GetEncodedPubkey(seed.GenesisBlock.GroupId, localcrypto.Sign)
 			if err != nil && strings.HasPrefix(err.Error(), "key not exist") {
 				newsignaddr, err := dirks.NewKeyWithDefaultPassword(seed.GenesisBlock.GroupId, localcrypto.Sign)
 				if err == nil && newsignaddr != "" {
@@ -84,14 +85,14 @@ func (h *Handler) JoinGroupV2() echo.HandlerFunc {
 						msg := fmt.Sprintf("save key map %s err: %s", newsignaddr, err.Error())
 						return rumerrors.NewBadRequestError(msg)
 					}
-					base64key, _ = dirks.GetEncodedPubkey(seed.GenesisBlock.GroupId, localcrypto.Sign)
+					base64key, _ = ks.GetEncodedPubkey(seed.GenesisBlock.GroupId, localcrypto.Sign)
 				} else {
 					_, err := dirks.GetKeyFromUnlocked(localcrypto.Sign.NameString(seed.GenesisBlock.GroupId))
 					if err != nil {
 						msg := "create new group key err:" + err.Error()
 						return rumerrors.NewBadRequestError(msg)
 					}
-					base64key, _ = dirks.GetEncodedPubkey(seed.GenesisBlock.GroupId, localcrypto.Sign)
+					base64key, _ = ks.GetEncodedPubkey(seed.GenesisBlock.GroupId, localcrypto.Sign)
 				}
 			}
 			groupSignPubkey, err = base64.RawURLEncoding.DecodeString(base64key)
@@ -110,7 +111,7 @@ func (h *Handler) JoinGroupV2() echo.HandlerFunc {
 			return rumerrors.NewBadRequestError(msg)
 		}
 
-		groupEncryptkey, err := dirks.GetEncodedPubkey(seed.GenesisBlock.GroupId, localcrypto.Encrypt)
+		groupEncryptkey, err := ks.GetEncodedPubkey(seed.GenesisBlock.GroupId, localcrypto.Encrypt)
 		if err != nil {
 			if strings.HasPrefix(err.Error(), "key not exist") {
 				_, _ = dirks.NewKeyWithDefaultPassword(seed.GenesisBlock.GroupId, localcrypto.Encrypt)
@@ -119,7 +120,7 @@ func (h *Handler) JoinGroupV2() echo.HandlerFunc {
 					msg := "Create key pair failed with msg:" + err.Error()
 					return rumerrors.NewBadRequestError(msg)
 				}
-				groupEncryptkey, _ = dirks.GetEncodedPubkey(seed.GenesisBlock.GroupId, localcrypto.Encrypt)
+				groupEncryptkey, _ = ks.GetEncodedPubkey(seed.GenesisBlock.GroupId, localcrypto.Encrypt)
 			} else {
 				msg := "Create key pair failed with msg:" + err.Error()
 				return rumerrors.NewBadRequestError(msg)
@@ -153,7 +154,7 @@ func (h *Handler) JoinGroupV2() echo.HandlerFunc {
 
 		item.UserSignPubkey = base64.RawURLEncoding.EncodeToString(groupSignPubkey)
 
-		userEncryptKey, err := dirks.GetEncodedPubkey(seed.GenesisBlock.GroupId, localcrypto.Encrypt)
+		userEncryptKey, err := ks.GetEncodedPubkey(seed.GenesisBlock.GroupId, localcrypto.Encrypt)
 		if err != nil {
 			if strings.HasPrefix(err.Error(), "key not exist") {
 				userEncryptKey, err = dirks.NewKeyWithDefaultPassword(seed.GenesisBlock.GroupId, localcrypto.Encrypt)
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
+	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
+	_ "github.com/rumsystem/quorum/pkg/pb" //import for swaggo
+)
+
+type GetNSdkBlockParams struct {
+	GroupId string `param:"group_id" json:"group_id" validate:"required,uuid4"`
+	BlockId uint64 `param:"block_id" json:"block_id"`
+}
+
+// @Tags LightNode
+// @Summary GetNSdkBlock
+// @Description get a block with all trxs, for the light node to verify the block header and the trxs in it
+// @Produce json
+// @Param   group_id path string true "Group Id"
+// @Param   block_id path string true "Block Id"
+// @Success 200 {object} pb.Block
+// @Router  /api/v1/node/{group_id}/block/{block_id} [get]
+func (h *Handler) GetNSdkBlock(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	params := new(GetNSdkBlockParams)
+	if err := cc.BindAndValidate(params); err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	group, ok := chain.GetGroupMgr().Groups[params.GroupId]
+	if !ok {
+		return rumerrors.NewBadRequestError(rumerrors.ErrGroupNotFound)
+	}
+
+	block, err := group.GetBlock(params.BlockId)
+	if err != nil {
+		return rumerrors.NewBadRequestError(rumerrors.ErrBlockIDNotFound)
+	}
+	return c.JSON(http.StatusOK, block)
+}
+
+// @Tags LightNode
+// @Summary GetNSdkTrxProof
+// @Description get the merkle proof of a trx with the header of the block packaged it
+// @Produce json
+// @Param   group_id path string true "Group Id"
+// @Param   trx_id path string true "Trx Id"
+// @Success 200 {object} handlers.TrxProofResult
+// @Router  /api/v1/node/{group_id}/trx/{trx_id}/proof [get]
+func (h *Handler) GetNSdkTrxProof(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	var params handlers.GetTrxParam
+	if err := cc.BindAndValidate(&params); err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	res, err := handlers.GetTrxProof(params.GroupId, params.TrxId)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// @Tags LightNode
+// @Summary GetNSdkBlockHeaders
+// @Description get the headers of the blocks from the block id, for the light node to verify the chain without the trxs
+// @Produce json
+// @Param   group_id path string true "Group Id"
+// @Param   block_id path string true "Block Id"
+// @Param   num query int false "max number of headers"
+// @Success 200 {array} handlers.BlockHeaderItem
+// @Router  /api/v1/node/{group_id}/headers/{block_id} [get]
+func (h *Handler) GetNSdkBlockHeaders(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	params := new(handlers.GetBlockHeadersParam)
+	if err := cc.BindAndValidate(params); err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	res, err := handlers.GetBlockHeaders(params.GroupId, params.BlockId, params.Num)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// @Tags LightNode
+// @Summary GetNSdkTrustedHeader
+// @Description get the head block header and the producers in effect, for the light node trusting this node to start from it
+// @Produce json
+// @Param   group_id path string true "Group Id"
+// @Success 200 {object} handlers.TrustedHeaderResult
+// @Router  /api/v1/node/{group_id}/headers/trusted [get]
+func (h *Handler) GetNSdkTrustedHeader(c echo.Context) (err error) {
+	groupid := c.Param("group_id")
+	if groupid == "" {
+		return rumerrors.NewBadRequestError(rumerrors.ErrInvalidGroupID)
+	}
+
+	res, err := handlers.GetTrustedHeader(groupid)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
@@ -23,5 +23,6 @@ func opaInputFunc(c echo.Context) interface{} {
 		"path":         strings.Split(strings.Trim(r.URL.Path, "/"), "/"),
 		"role":         appapi.GetJWTRole(token),
 		"allow_groups": appapi.GetJWTAllowGroups(token),
+		"scopes":       appapi.GetJWTScopes(token),
 	}
 }
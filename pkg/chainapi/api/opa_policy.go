@@ -23,6 +23,7 @@ allow {
 
 # allow all for "*" in allow_groups
 allow {
+	input.role == "node"
 	input.allow_groups == ["*"]
 	input.path[0] == "api"
 	input.path[1] == "v1"
@@ -36,6 +37,7 @@ allow {
   input.method == "GET"
   input.path = ["api", "v1", "trx", group_id, trx_id]
   input.allow_groups[_] == group_id
+  input.role == "node"
 }
 
 # Allow access /api/v1/node/:group_id/trx
@@ -44,6 +46,7 @@ allow {
   input.method == "POST"
   input.path = ["api", "v1", "node", group_id, "trx"]
   input.allow_groups[_] == group_id
+  input.role == "node"
 }
 
 # Allow access /api/v1/node/:group_id/groupctn
@@ -52,6 +55,37 @@ allow {
   input.method == "GET"
   input.path = ["api", "v1", "node", group_id, "groupctn"]
   input.allow_groups[_] == group_id
+  input.role == "node"
+}
+
+# Allow access /api/v1/node/:group_id/block/:block_id
+allow {
+  some group_id
+  some block_id
+  input.method == "GET"
+  input.path = ["api", "v1", "node", group_id, "block", block_id]
+  input.allow_groups[_] == group_id
+  input.role == "node"
+}
+
+# Allow access /api/v1/node/:group_id/headers/:block_id and /api/v1/node/:group_id/headers/trusted
+allow {
+  some group_id
+  some block_id
+  input.method == "GET"
+  input.path = ["api", "v1", "node", group_id, "headers", block_id]
+  input.allow_groups[_] == group_id
+  input.role == "node"
+}
+
+# Allow access /api/v1/node/:group_id/trx/:trx_id/proof
+allow {
+  some group_id
+  some trx_id
+  input.method == "GET"
+  input.path = ["api", "v1", "node", group_id, "trx", trx_id, "proof"]
+  input.allow_groups[_] == group_id
+  input.role == "node"
 }
 
 # Allow access /api/v1/node/:group_id/announce
@@ -60,6 +94,7 @@ allow {
   input.method == "POST"
   input.path = ["api", "v1", "node", group_id, "announce"]
   input.allow_groups[_] == group_id
+  input.role == "node"
 }
 
 # Allow access /api/v1/node/:group_id/auth/by/:trx_type
@@ -69,6 +104,7 @@ allow {
   input.method == "GET"
   input.path = ["api", "v1", "node", group_id, "auth", "by", trx_type]
   input.allow_groups[_] == group_id
+  input.role == "node"
 }
 
 # Allow access /api/v1/node/:group_id/auth/alwlist
@@ -77,6 +113,7 @@ allow {
   input.method == "GET"
   input.path = ["api", "v1", "node", group_id, "auth", "alwlist"]
   input.allow_groups[_] == group_id
+  input.role == "node"
 }
 
 # Allow access /api/v1/node/:group_id/auth/denylist
@@ -85,6 +122,7 @@ allow {
   input.method == "GET"
   input.path = ["api", "v1", "node", group_id, "auth", "denylist"]
   input.allow_groups[_] == group_id
+  input.role == "node"
 }
 
 # Allow access /api/v1/node/:group_id/appconfig/keylist
@@ -93,6 +131,7 @@ allow {
   input.method == "GET"
   input.path = ["api", "v1", "node", group_id, "appconfig", "keylist"]
   input.allow_groups[_] == group_id
+  input.role == "node"
 }
 
 # Allow access /api/v1/node/:group_id/appconfig/by/:key
@@ -102,6 +141,7 @@ allow {
   input.method == "GET"
   input.path = ["api", "v1", "node", group_id, "appconfig", "by", key]
   input.allow_groups[_] == group_id
+  input.role == "node"
 }
 
 # Allow access /api/v1/node/:group_id/announced/producer
@@ -110,6 +150,7 @@ allow {
   input.method == "GET"
   input.path = ["api", "v1", "node", group_id, "announced", "producer"]
   input.allow_groups[_] == group_id
+  input.role == "node"
 }
 
 # Allow access /api/v1/node/:group_id/announced/user
@@ -118,6 +159,7 @@ allow {
   input.method == "GET"
   input.path = ["api", "v1", "node", group_id, "announced", "user"]
   input.allow_groups[_] == group_id
+  input.role == "node"
 }
 
 # Allow access /api/v1/node/:group_id/producers
@@ -126,6 +168,7 @@ allow {
   input.method == "GET"
   input.path = ["api", "v1", "node", group_id, "producers"]
   input.allow_groups[_] == group_id
+  input.role == "node"
 }
 
 # Allow access /api/v1/node/:group_id/info
@@ -134,6 +177,7 @@ allow {
   input.method == "GET"
   input.path = ["api", "v1", "node", group_id, "info"]
   input.allow_groups[_] == group_id
+  input.role == "node"
 }
 
 # Allow access /api/v1/node/:group_id/encryptpubkeys
@@ -142,5 +186,113 @@ allow {
   input.method == "GET"
   input.path = ["api", "v1", "node", group_id, "encryptpubkeys"]
   input.allow_groups[_] == group_id
+  input.role == "node"
+}
+
+#########################
+# rules for scoped role #
+#########################
+
+# the token has the scope, admin has all the scopes
+scope_allowed(scope) {
+  input.role == "scoped"
+  input.scopes[_] == scope
+}
+
+scope_allowed(scope) {
+  input.role == "scoped"
+  input.scopes[_] == "admin"
+}
+
+# "*" in allow_groups allows all groups
+group_allowed(group_id) {
+  input.allow_groups[_] == "*"
+}
+
+group_allowed(group_id) {
+  input.allow_groups[_] == group_id
+}
+
+# group id in the path: /api/v1/group|trx|block|node/:group_id/...
+path_group_id = group_id {
+  input.path[0] == "api"
+  input.path[1] == "v1"
+  input.path[2] in {"group", "trx", "block", "node"}
+  group_id := input.path[3]
+}
+
+# group id in the path: /app/api/v1/group/:group_id/...
+path_group_id = group_id {
+  input.path[0] == "app"
+  input.path[1] == "api"
+  input.path[2] == "v1"
+  input.path[3] == "group"
+  group_id := input.path[4]
+}
+
+# the seed has the cipher key of the group, only admin can get it: GET /api/v1/group/:group_id/seed
+admin_only_path {
+  input.path[0] == "api"
+  input.path[1] == "v1"
+  input.path[2] == "group"
+  input.path[4] == "seed"
+}
+
+# Allow read the allowed groups, GET /api/v1/group/:group_id/..., /api/v1/trx/:group_id/... etc.
+allow {
+  input.method == "GET"
+  scope_allowed("read")
+  group_allowed(path_group_id)
+  not admin_only_path
+}
+
+# Allow read the node info and the group list for "*" in allow_groups
+allow {
+  input.method == "GET"
+  scope_allowed("read")
+  input.allow_groups[_] == "*"
+  input.path in [["api", "v1", "node"], ["api", "v1", "network"], ["api", "v1", "groups"]]
+}
+
+# Allow post to the allowed groups, POST /api/v1/group/:group_id/content
+allow {
+  some group_id
+  input.method == "POST"
+  input.path = ["api", "v1", "group", group_id, "content"]
+  scope_allowed("post")
+  group_allowed(group_id)
+}
+
+# Allow post to the allowed groups, POST /api/v1/node/:group_id/trx
+allow {
+  some group_id
+  input.method == "POST"
+  input.path = ["api", "v1", "node", group_id, "trx"]
+  scope_allowed("post")
+  group_allowed(group_id)
+}
+
+# Allow announce to the allowed groups, POST /api/v1/node/:group_id/announce
+allow {
+  some group_id
+  input.method == "POST"
+  input.path = ["api", "v1", "node", group_id, "announce"]
+  scope_allowed("post")
+  group_allowed(group_id)
+}
+
+# Allow admin access all the apis of the allowed groups
+allow {
+  input.role == "scoped"
+  input.scopes[_] == "admin"
+  group_allowed(path_group_id)
+}
+
+# Allow admin of "*" in allow_groups access all the apis, except the token apis
+allow {
+  input.role == "scoped"
+  input.scopes[_] == "admin"
+  input.allow_groups[_] == "*"
+  input.path[0] == "api"
 }
 `
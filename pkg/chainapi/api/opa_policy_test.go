@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-policy-agent/opa/rego"
+)
+
+func TestOpaPolicyScopes(t *testing.T) {
+	ctx := context.Background()
+	query, err := rego.New(
+		rego.Query("x = data.quorum.restapi.authz.allow"),
+		rego.Module("policy.rego", policyStr),
+	).PrepareForEval(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupA := "513bd3f2-a0bc-470b-8063-ec9549f34b7d"
+	groupB := "9e54c173-c1dd-429d-91fa-a6b43c14da77"
+	bot := map[string]interface{}{"role": "scoped", "allow_groups": []string{groupA}, "scopes": []string{"post"}}
+	dashboard := map[string]interface{}{"role": "scoped", "allow_groups": []string{"*"}, "scopes": []string{"read"}}
+	groupAdmin := map[string]interface{}{"role": "scoped", "allow_groups": []string{groupA}, "scopes": []string{"admin"}}
+	admin := map[string]interface{}{"role": "scoped", "allow_groups": []string{"*"}, "scopes": []string{"admin"}}
+	node := map[string]interface{}{"role": "node", "allow_groups": []string{groupA}, "scopes": []string{}}
+
+	testcases := []struct {
+		token  map[string]interface{}
+		method string
+		path   string
+		allow  bool
+	}{
+		{bot, "POST", "/api/v1/group/" + groupA + "/content", true},
+		{bot, "POST", "/api/v1/node/" + groupA + "/trx", true},
+		{bot, "POST", "/api/v1/group/" + groupB + "/content", false},
+		{bot, "GET", "/api/v1/group/" + groupA, false},
+		{bot, "POST", "/api/v1/group/" + groupA + "/rekey", false},
+
+		{dashboard, "GET", "/api/v1/group/" + groupB, true},
+		{dashboard, "GET", "/app/api/v1/group/" + groupB + "/content", true},
+		{dashboard, "GET", "/api/v1/trx/" + groupA + "/trxid", true},
+		{dashboard, "GET", "/api/v1/groups", true},
+		{dashboard, "POST", "/api/v1/group/" + groupA + "/content", false},
+		{dashboard, "GET", "/app/api/v1/token/list", false},
+		{dashboard, "GET", "/api/v1/group/" + groupA + "/seed", false},
+
+		{groupAdmin, "POST", "/api/v1/group/" + groupA + "/rekey", true},
+		{groupAdmin, "GET", "/api/v1/group/" + groupA + "/seed", true},
+		{groupAdmin, "POST", "/api/v1/group/" + groupB + "/rekey", false},
+		{groupAdmin, "POST", "/api/v1/group/chainconfig", false},
+
+		{admin, "POST", "/api/v1/group/chainconfig", true},
+		{admin, "GET", "/app/api/v1/token/list", false},
+
+		// node tokens keep the rules of the node role only
+		{node, "GET", "/api/v1/node/" + groupA + "/groupctn", true},
+		{node, "GET", "/api/v1/node/" + groupA + "/headers/12", true},
+		{node, "GET", "/api/v1/node/" + groupB + "/headers/trusted", false},
+		{node, "POST", "/api/v1/group/" + groupA + "/content", false},
+		{node, "GET", "/api/v1/group/" + groupA, false},
+	}
+
+	for _, tc := range testcases {
+		input := map[string]interface{}{
+			"method": tc.method,
+			"path":   strings.Split(strings.Trim(tc.path, "/"), "/"),
+		}
+		for k, v := range tc.token {
+			input[k] = v
+		}
+		results, err := query.Eval(ctx, rego.EvalInput(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results) == 0 {
+			t.Fatalf("undefined result for %s %s", tc.method, tc.path)
+		}
+		if allow := results[0].Bindings["x"].(bool); allow != tc.allow {
+			t.Errorf("%v %s %s: expect allow %v, got %v", tc.token["scopes"], tc.method, tc.path, tc.allow, allow)
+		}
+	}
+}
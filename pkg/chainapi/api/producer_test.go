@@ -103,6 +103,25 @@ func getProducers(api string, groupID string) ([]handlers.ProducerListItem, erro
 	return producers, nil
 }
 
+func getScheduledProducers(api string, groupID string) (*handlers.GroupProducersResult, error) {
+	urlSuffix := fmt.Sprintf("/api/v1/group/%s/producers/scheduled", groupID)
+	_, resp, err := testnode.RequestAPI(api, urlSuffix, "GET", "")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := getResponseError(resp); err != nil {
+		return nil, err
+	}
+
+	var result handlers.GroupProducersResult
+	if err := json.Unmarshal(resp, &result); err != nil {
+		e := fmt.Errorf("json.Unmarshal failed: %s, response: %s", err, resp)
+		return nil, e
+	}
+	return &result, nil
+}
+
 // add producer by group owner
 func addProducer(api string, payload handlers.GrpProducerParam) (*handlers.GrpProducerResult, error) {
 	var result handlers.GrpProducerResult
@@ -217,4 +236,12 @@ func TestAnnounceProducer(t *testing.T) {
 	if !foundProducer {
 		t.Fatalf("producer should be in the producers list")
 	}
+	// the scheduled producer sets are listed with the producers in effect
+	scheduled, err := getScheduledProducers(peerapi, group.GroupId)
+	if err != nil {
+		t.Fatalf("getScheduledProducers failed: %s", err)
+	}
+	if scheduled.GroupId != group.GroupId || len(scheduled.Producers) != len(producers) {
+		t.Errorf("unexpected scheduled producers result: %+v", scheduled)
+	}
 }
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
+	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
+)
+
+// @Tags Management
+// @Summary ReplayState
+// @Description Re-derive the producer, user, announce and config state of a group from the stored blocks into a fresh db, and return the state digest which can be compared across nodes. The current state is replaced if apply is set and the digests mismatch.
+// @Accept json
+// @Produce json
+// @Param group_id path string true "Group Id"
+// @Param data body handlers.ReplayStateParam true "ReplayStateParam"
+// @Success 200 {object} chainstorage.ReplayResult
+// @Router /api/v1/group/{group_id}/replay [post]
+func (h *Handler) ReplayState(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	params := new(handlers.ReplayStateParam)
+	if err := cc.BindAndValidate(params); err != nil {
+		return err
+	}
+
+	res, err := handlers.ReplayState(params)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -40,6 +40,7 @@ func StartBootstrapNodeServer(config StartServerParam, signalch chan os.Signal,
 		Query:     "x = data.quorum.restapi.authz.allow", // FIXME: hardcode
 		InputFunc: opaInputFunc,
 	}))
+	e.Use(appapi.JWTRateLimit)
 
 	r := e.Group("/api")
 	r.GET("/quit", quitapp)
@@ -75,6 +76,7 @@ func StartProducerServer(config StartServerParam, signalch chan os.Signal, h *Ha
 		Query:     "x = data.quorum.restapi.authz.allow", // FIXME: hardcode
 		InputFunc: opaInputFunc,
 	}))
+	e.Use(appapi.JWTRateLimit)
 	r := e.Group("/api")
 	r.GET("/quit", quitapp)
 
@@ -90,17 +92,31 @@ func StartProducerServer(config StartServerParam, signalch chan os.Signal, h *Ha
 	//r.GET("/v1/network/stats", h.GetNetworkStatsSummary)
 	r.GET("/v1/block/:group_id/:block_id", h.GetBlock)
 	r.GET("/v1/trx/:group_id/:trx_id", h.GetTrx)
+	r.GET("/v1/trx/:group_id/:trx_id/status", h.GetTrxStatus)
+	r.GET("/v1/trx/:group_id/:trx_id/proof", h.GetTrxProof)
 
 	r.GET("/v1/groups", h.GetGroups)
 	r.GET("/v1/group/:group_id", h.GetGroupById)
 	r.GET("/v1/group/:group_id/trx/allowlist", h.GetChainTrxAllowList)
 	r.GET("/v1/group/:group_id/trx/denylist", h.GetChainTrxDenyList)
 	r.GET("/v1/group/:group_id/trx/auth/:trx_type", h.GetChainTrxAuthMode)
+	r.GET("/v1/group/:group_id/trx/packingpolicy", h.GetChainPackingPolicy)
 	r.GET("/v1/group/:group_id/producers", h.GetGroupProducers)
+	r.GET("/v1/group/:group_id/producers/scheduled", h.GetGroupScheduledProducers)
 	r.GET("/v1/group/:group_id/announced/users", h.GetAnnouncedGroupUsers)
 	r.GET("/v1/group/:group_id/announced/user/:sign_pubkey", h.GetAnnouncedGroupUser)
 	r.GET("/v1/group/:group_id/announced/producers", h.GetAnnouncedGroupProducer)
 	r.GET("/v1/group/:group_id/seed", h.GetGroupSeedHandler)
+	r.GET("/v1/group/:group_id/trxbuffer", h.GetTrxBuffer)
+	r.DELETE("/v1/group/:group_id/trxbuffer", h.FlushTrxBuffer)
+	r.DELETE("/v1/group/:group_id/trxbuffer/:trx_id", h.EvictTrxFromBuffer)
+	r.POST("/v1/group/:group_id/replay", h.ReplayState)
+	r.POST("/v1/group/stake", h.Stake)
+	r.GET("/v1/group/:group_id/stakes", h.GetGroupStakes)
+	r.GET("/v1/group/:group_id/consensus", h.GetConsensus)
+	r.GET("/v1/group/:group_id/evidences", h.GetGroupEvidences)
+	r.GET("/v1/group/:group_id/forks", h.GetGroupForks)
+	r.GET("/v1/group/:group_id/keys", h.GetGroupKeys)
 
 	// start https or http server
 	host := config.APIHost
@@ -133,6 +149,7 @@ func StartFullNodeServer(config StartServerParam, signalch chan os.Signal, h *Ha
 		Query:     "x = data.quorum.restapi.authz.allow", // FIXME: hardcode
 		InputFunc: opaInputFunc,
 	}))
+	e.Use(appapi.JWTRateLimit)
 
 	// prometheus metric
 	e.GET("/metrics", h.Metrics)
@@ -153,6 +170,8 @@ func StartFullNodeServer(config StartServerParam, signalch chan os.Signal, h *Ha
 	r.POST("/v1/group/appconfig", h.MgrAppConfig)
 	r.POST("/v1/group/chainconfig", h.MgrChainConfig)
 	r.POST("/v1/group/producer", h.GroupProducer)
+	r.POST("/v1/group/:group_id/sudo", h.SudoBlock)
+	r.POST("/v1/group/:group_id/rekey", h.RotateGroupKey)
 	r.POST("/v1/group/user", h.GroupUser)
 	r.POST("/v1/group/announce", h.Announce)
 
@@ -162,18 +181,32 @@ func StartFullNodeServer(config StartServerParam, signalch chan os.Signal, h *Ha
 	//r.GET("/v1/network/peers/ping", h.PingPeers(node))
 	r.GET("/v1/block/:group_id/:block_id", h.GetBlock)
 	r.GET("/v1/trx/:group_id/:trx_id", h.GetTrx)
+	r.GET("/v1/trx/:group_id/:trx_id/status", h.GetTrxStatus)
+	r.GET("/v1/trx/:group_id/:trx_id/proof", h.GetTrxProof)
 	r.GET("/v1/groups", h.GetGroups)
 	r.GET("/v1/group/:group_id", h.GetGroupById)
 	r.GET("/v1/group/:group_id/trx/allowlist", h.GetChainTrxAllowList)
 	r.GET("/v1/group/:group_id/trx/denylist", h.GetChainTrxDenyList)
 	r.GET("/v1/group/:group_id/trx/auth/:trx_type", h.GetChainTrxAuthMode)
+	r.GET("/v1/group/:group_id/trx/packingpolicy", h.GetChainPackingPolicy)
 	r.GET("/v1/group/:group_id/producers", h.GetGroupProducers)
+	r.GET("/v1/group/:group_id/producers/scheduled", h.GetGroupScheduledProducers)
 	r.GET("/v1/group/:group_id/announced/users", h.GetAnnouncedGroupUsers)
 	r.GET("/v1/group/:group_id/announced/user/:sign_pubkey", h.GetAnnouncedGroupUser)
 	r.GET("/v1/group/:group_id/announced/producers", h.GetAnnouncedGroupProducer)
 	r.GET("/v1/group/:group_id/appconfig/keylist", h.GetAppConfigKey)
 	r.GET("/v1/group/:group_id/appconfig/:key", h.GetAppConfigItem)
 	r.GET("/v1/group/:group_id/seed", h.GetGroupSeedHandler)
+	r.GET("/v1/group/:group_id/trxbuffer", h.GetTrxBuffer)
+	r.DELETE("/v1/group/:group_id/trxbuffer", h.FlushTrxBuffer)
+	r.DELETE("/v1/group/:group_id/trxbuffer/:trx_id", h.EvictTrxFromBuffer)
+	r.POST("/v1/group/:group_id/replay", h.ReplayState)
+	r.POST("/v1/group/stake", h.Stake)
+	r.GET("/v1/group/:group_id/stakes", h.GetGroupStakes)
+	r.GET("/v1/group/:group_id/consensus", h.GetConsensus)
+	r.GET("/v1/group/:group_id/evidences", h.GetGroupEvidences)
+	r.GET("/v1/group/:group_id/forks", h.GetGroupForks)
+	r.GET("/v1/group/:group_id/keys", h.GetGroupKeys)
 
 	//app api
 	a.POST("/v1/token", apph.CreateToken)
@@ -183,6 +216,10 @@ func StartFullNodeServer(config StartServerParam, signalch chan os.Signal, h *Ha
 	a.GET("/v1/token/list", apph.ListToken)
 
 	a.GET("/v1/group/:group_id/content", apph.ContentByPeers)
+	a.GET("/v1/group/:group_id/search", apph.SearchGroupContent)
+	a.GET("/v1/group/:group_id/objects", apph.GetGroupObjects)
+	a.GET("/v1/group/:group_id/object/:object_id", apph.GetGroupObject)
+	a.GET("/v1/group/:group_id/object/:object_id/thread", apph.GetObjectThread)
 
 	if nodeopt.EnableRelay {
 		r.POST("/v1/network/relay", h.AddRelayServers)
@@ -200,6 +237,10 @@ func StartFullNodeServer(config StartServerParam, signalch chan os.Signal, h *Ha
 
 		n.POST("/:group_id/trx", h.NSdkSendTrx)
 		n.GET("/:group_id/groupctn", h.GetNSdkContent)
+		n.GET("/:group_id/block/:block_id", h.GetNSdkBlock)
+		n.GET("/:group_id/trx/:trx_id/proof", h.GetNSdkTrxProof)
+		n.GET("/:group_id/headers/trusted", h.GetNSdkTrustedHeader)
+		n.GET("/:group_id/headers/:block_id", h.GetNSdkBlockHeaders)
 
 		// auth
 		n.GET("/:group_id/auth/by/:trx_type", h.GetNSdkAuthType)
@@ -11,7 +11,6 @@ import (
 	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
 	"github.com/rumsystem/quorum/internal/pkg/nodectx"
 	"github.com/rumsystem/quorum/internal/pkg/utils"
-	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
 )
 
 type (
@@ -111,14 +110,10 @@ func (h *Handler) SignTx(c echo.Context) (err error) {
 	}
 
 	ks := nodectx.GetNodeCtx().Keystore
-	dirks, ok := ks.(*localcrypto.DirKeyStore)
-	if !ok {
-		return rumerrors.NewBadRequestError("Open keystore failed")
-	}
 
 	var data string
 	if param.Keyalias != "" {
-		data, err = dirks.SignTxByKeyAlias(
+		data, err = ks.SignTxByKeyAlias(
 			param.Keyalias,
 			param.Nonce,
 			param.To,
@@ -129,7 +124,7 @@ func (h *Handler) SignTx(c echo.Context) (err error) {
 			param.ChainID,
 		)
 	} else {
-		data, err = dirks.SignTxByKeyName(
+		data, err = ks.SignTxByKeyName(
 			param.Keyname,
 			param.Nonce,
 			param.To,
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
+	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
+)
+
+// @Tags Management
+// @Summary Stake
+// @Description Add or remove the stake of this node in a POS group, producers are selected by stake
+// @Accept json
+// @Produce json
+// @Param data body handlers.StakeParam true "StakeParam"
+// @Success 200 {object} handlers.StakeResult
+// @Router /api/v1/group/stake [post]
+func (h *Handler) Stake(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	params := new(handlers.StakeParam)
+	if err := cc.BindAndValidate(params); err != nil {
+		return err
+	}
+
+	res, err := handlers.StakeHandler(params)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// @Tags Management
+// @Summary GetGroupStakes
+// @Description Get the stakes of a POS group
+// @Produce json
+// @Param group_id path string  true "Group Id"
+// @Success 200 {object} handlers.GroupStakesResult
+// @Router /api/v1/group/{group_id}/stakes [get]
+func (h *Handler) GetGroupStakes(c echo.Context) (err error) {
+	groupid := c.Param("group_id")
+	if groupid == "" {
+		return rumerrors.NewBadRequestError(rumerrors.ErrInvalidGroupID)
+	}
+
+	res, err := handlers.GetGroupStakes(groupid)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
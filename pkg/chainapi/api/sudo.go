@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
+	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
+)
+
+// @Tags Management
+// @Summary SudoBlock
+// @Description Owner only. Package the pending trxs of this node, e.g. a PRODUCER trx adding new producers, into a sudo block signed by the owner after the current block and broadcast it. Nodes accept sudo blocks signed by the group owner only, they are used to recover a group whose producers are gone or stuck.
+// @Accept json
+// @Produce json
+// @Param group_id path string true "Group Id"
+// @Param data body handlers.SudoBlockParam true "SudoBlockParam"
+// @Success 200 {object} handlers.SudoBlockResult
+// @Router /api/v1/group/{group_id}/sudo [post]
+func (h *Handler) SudoBlock(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	params := new(handlers.SudoBlockParam)
+	if err := cc.BindAndValidate(params); err != nil {
+		return err
+	}
+
+	res, err := handlers.SudoBlock(params)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
+	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
+)
+
+// @Tags Management
+// @Summary GetTrxBuffer
+// @Description List the trxs buffered by the producer of a group, with buffer length and the age of the oldest trx
+// @Produce json
+// @Param group_id path string true "Group Id"
+// @Param sender query string false "only list trxs of the sender"
+// @Success 200 {object} handlers.TrxBufferResult
+// @Router /api/v1/group/{group_id}/trxbuffer [get]
+func (h *Handler) GetTrxBuffer(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	params := new(handlers.TrxBufferParam)
+	if err := cc.BindAndValidate(params); err != nil {
+		return err
+	}
+
+	res, err := handlers.GetTrxBuffer(params)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// @Tags Management
+// @Summary EvictTrxFromBuffer
+// @Description Remove a trx from the trx buffer of a group
+// @Produce json
+// @Param group_id path string true "Group Id"
+// @Param trx_id path string true "Trx Id"
+// @Success 200 {object} handlers.TrxBufferEvictResult
+// @Router /api/v1/group/{group_id}/trxbuffer/{trx_id} [delete]
+func (h *Handler) EvictTrxFromBuffer(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	params := new(handlers.TrxBufferTrxParam)
+	if err := cc.BindAndValidate(params); err != nil {
+		return err
+	}
+
+	res, err := handlers.EvictTrxFromBuffer(params)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// @Tags Management
+// @Summary FlushTrxBuffer
+// @Description Remove all trxs from the trx buffer of a group
+// @Produce json
+// @Param group_id path string true "Group Id"
+// @Param sender query string false "only remove trxs of the sender"
+// @Success 200 {object} handlers.TrxBufferFlushResult
+// @Router /api/v1/group/{group_id}/trxbuffer [delete]
+func (h *Handler) FlushTrxBuffer(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	params := new(handlers.TrxBufferParam)
+	if err := cc.BindAndValidate(params); err != nil {
+		return err
+	}
+
+	res, err := handlers.FlushTrxBuffer(params)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
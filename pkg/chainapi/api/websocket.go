@@ -1,7 +1,9 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,7 +12,10 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/rumsystem/quorum/internal/pkg/appdata"
 	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
 	"github.com/rumsystem/quorum/internal/pkg/logging"
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
 	quorumpb "github.com/rumsystem/quorum/pkg/pb"
 )
 
@@ -36,20 +41,52 @@ type (
 		Clients    map[string]*Client
 		Register   chan *Client
 		UnRegister chan *Client
+		Appdb      *appdata.AppDb
+	}
+
+	// filter and resume point of a trx subscription, all trxs of all groups are pushed if no filter given
+	WsTrxSubscribeParam struct {
+		GroupIds  []string `query:"group_ids" json:"group_ids"`
+		TrxTypes  []string `query:"trx_types" json:"trx_types" example:"POST"`
+		Senders   []string `query:"senders" json:"senders"`
+		FromBlock uint64   `query:"from_block" json:"from_block"` // replay from the block (included), only with a single group
+		FromTrx   string   `query:"from_trx" json:"from_trx"`     // replay trxs after this trx, only with a single group
 	}
 
 	Client struct {
 		Id              string
 		Socket          *websocket.Conn
-		OnChainTrxChann chan *quorumpb.Trx
+		OnChainTrxChann chan *onChainTrx
+
+		groups  map[string]bool
+		types   map[quorumpb.TrxType]bool
+		senders map[string]bool
+
+		mu        sync.Mutex
+		cursors   map[string]*trxCursor // key: group id
+		lagSignal chan struct{}
+	}
+
+	onChainTrx struct {
+		BlockId uint64
+		Trx     *quorumpb.Trx
+	}
+
+	// position of the last trx pushed to the client in a group
+	trxCursor struct {
+		BlockId  uint64 // block in progress
+		AfterTrx string // trxs in BlockId up to this one are pushed, empty if none
+		Replayed uint64 // trxs in blocks up to this one are pushed by replay, skip them in live trxs
+		Pending  bool   // live trxs are dropped, replay from storage
 	}
 )
 
-func NewWebsocketManager() *WebsocketManager {
+func NewWebsocketManager(appdb *appdata.AppDb) *WebsocketManager {
 	return &WebsocketManager{
 		Register:   make(chan *Client, maxChanBufferRegister),
 		UnRegister: make(chan *Client, maxChanBufferUnregister),
 		Clients:    make(map[string]*Client),
+		Appdb:      appdb,
 	}
 }
 
@@ -108,9 +145,20 @@ func (manager *WebsocketManager) handleEvent(event *appdata.OnChainTrxEvent) {
 		return
 	}
 
+	manager.Lock.Lock()
+	clients := make([]*Client, 0, len(manager.Clients))
 	for _, c := range manager.Clients {
+		clients = append(clients, c)
+	}
+	manager.Lock.Unlock()
+
+	item := &onChainTrx{BlockId: event.BlockId, Trx: trx}
+	for _, c := range clients {
+		if !c.match(trx) {
+			continue
+		}
 		wsLogger.Debugf("put event %+v to client: %s", event, c.Id)
-		c.OnChainTrxChann <- trx
+		c.push(item)
 	}
 }
 
@@ -138,6 +186,183 @@ func (manager *WebsocketManager) Start() {
 	}()
 }
 
+func newClient(params *WsTrxSubscribeParam) (*Client, error) {
+	client := &Client{
+		Id:              guuid.NewString(),
+		OnChainTrxChann: make(chan *onChainTrx, maxOnChainTrxs),
+		groups:          make(map[string]bool),
+		types:           make(map[quorumpb.TrxType]bool),
+		senders:         make(map[string]bool),
+		cursors:         make(map[string]*trxCursor),
+		lagSignal:       make(chan struct{}, 1),
+	}
+
+	for _, groupId := range params.GroupIds {
+		client.groups[groupId] = true
+	}
+	for _, name := range params.TrxTypes {
+		trxType, ok := quorumpb.TrxType_value[strings.ToUpper(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown trx type: %s", name)
+		}
+		client.types[quorumpb.TrxType(trxType)] = true
+	}
+	for _, sender := range params.Senders {
+		client.senders[sender] = true
+	}
+
+	return client, nil
+}
+
+// check if the trx is subscribed by the client
+func (c *Client) match(trx *quorumpb.Trx) bool {
+	if len(c.groups) > 0 && !c.groups[trx.GroupId] {
+		return false
+	}
+	if len(c.types) > 0 && !c.types[trx.Type] {
+		return false
+	}
+	if len(c.senders) > 0 && !c.senders[trx.SenderPubkey] {
+		return false
+	}
+	return true
+}
+
+// push a live trx to the client without blocking the manager. if the client can not keep up,
+// live trxs of the group are dropped and the client catches up from storage at its own pace
+func (c *Client) push(item *onChainTrx) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	groupId := item.Trx.GroupId
+	cursor := c.cursors[groupId]
+	if cursor != nil && cursor.Pending {
+		return
+	}
+
+	select {
+	case c.OnChainTrxChann <- item:
+	default:
+		wsLogger.Warnf("client %s is too slow, replay group %s from storage", c.Id, groupId)
+		if cursor == nil {
+			cursor = &trxCursor{BlockId: item.BlockId}
+			c.cursors[groupId] = cursor
+		}
+		cursor.Pending = true
+		c.signalLag()
+	}
+}
+
+func (c *Client) signalLag() {
+	select {
+	case c.lagSignal <- struct{}{}:
+	default:
+	}
+}
+
+// update the cursor before write a live trx, returns false if the trx is pushed by replay already
+func (c *Client) markPushed(item *onChainTrx) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cursor, ok := c.cursors[item.Trx.GroupId]
+	if !ok {
+		cursor = &trxCursor{}
+		c.cursors[item.Trx.GroupId] = cursor
+	}
+	if item.BlockId <= cursor.Replayed {
+		return false
+	}
+	cursor.BlockId = item.BlockId
+	cursor.AfterTrx = item.Trx.TrxId
+	return true
+}
+
+func (c *Client) pendingGroups() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	groups := []string{}
+	for groupId, cursor := range c.cursors {
+		if cursor.Pending {
+			groups = append(groups, groupId)
+		}
+	}
+	return groups
+}
+
+// replay trxs of the group from the cursor until all blocks parsed by appsync are pushed, then switch back to live trxs
+func (c *Client) replay(manager *WebsocketManager, groupId string) error {
+	for {
+		c.mu.Lock()
+		cursor := c.cursors[groupId]
+		synced := uint64(0)
+		var err error
+		if manager.Appdb != nil {
+			synced, err = manager.Appdb.GetSyncedBlockId(groupId)
+		}
+		group, ok := chain.GetGroupMgr().Groups[groupId]
+		if err != nil || !ok || manager.Appdb == nil || cursor.BlockId > synced {
+			if err != nil || !ok || manager.Appdb == nil {
+				wsLogger.Warnf("client %s can not replay group %s, err: %v", c.Id, groupId, err)
+			}
+			//no live trx of the group is pushed during the replay, so all of them are in blocks before the cursor
+			cursor.Pending = false
+			cursor.Replayed = synced
+			if cursor.BlockId > 0 {
+				cursor.Replayed = cursor.BlockId - 1
+			}
+			c.mu.Unlock()
+			return nil
+		}
+		fromBlock, afterTrx := cursor.BlockId, cursor.AfterTrx
+		c.mu.Unlock()
+
+		//blocks before the lowest block are pruned
+		lowestBlock, err := nodectx.GetNodeCtx().GetChainStorage().GetLowestBlockId(groupId, group.Nodename)
+		if err != nil {
+			return err
+		}
+		if fromBlock < lowestBlock {
+			wsLogger.Warnf("client %s replay group %s from block %d, blocks before it are pruned", c.Id, groupId, lowestBlock)
+			fromBlock, afterTrx = lowestBlock, ""
+		}
+
+		for blockId := fromBlock; blockId <= synced; blockId++ {
+			block, err := group.GetBlock(blockId)
+			if err != nil {
+				return err
+			}
+
+			skip := blockId == fromBlock && afterTrx != ""
+			for _, trx := range block.Trxs {
+				if skip {
+					skip = trx.TrxId != afterTrx
+					continue
+				}
+				if !c.match(trx) {
+					continue
+				}
+				trx.StorageType = quorumpb.TrxStroageType_CHAIN
+				if err := c.Socket.WriteJSON(trx); err != nil {
+					return err
+				}
+				c.setCursor(groupId, blockId, trx.TrxId)
+			}
+			c.setCursor(groupId, blockId+1, "")
+		}
+	}
+}
+
+func (c *Client) setCursor(groupId string, blockId uint64, afterTrx string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cursor := c.cursors[groupId]
+	cursor.BlockId = blockId
+	cursor.AfterTrx = afterTrx
+}
+
 func (c *Client) Read(manager *WebsocketManager) {
 	defer func() {
 		wsLogger.Debugf("close: %s, date: %s", c.Id, time.Now())
@@ -155,7 +380,7 @@ func (c *Client) Read(manager *WebsocketManager) {
 	}
 }
 
-func (c *Client) Write() error {
+func (c *Client) Write(manager *WebsocketManager) error {
 	defer func() {
 		wsLogger.Debugf("client [%s] disconnect", c.Id)
 		if err := c.Socket.Close(); err != nil {
@@ -164,36 +389,95 @@ func (c *Client) Write() error {
 	}()
 
 	for {
+		//replay first, socket writes block on slow clients so the replay goes at the client's pace
+		for _, groupId := range c.pendingGroups() {
+			if err := c.replay(manager, groupId); err != nil {
+				wsLogger.Debugf("client [%s] replay group %s failed: %s", c.Id, groupId, err)
+				return err
+			}
+		}
+
 		select {
-		case event, ok := <-c.OnChainTrxChann:
+		case item, ok := <-c.OnChainTrxChann:
 			if !ok {
 				return c.Socket.WriteMessage(websocket.CloseMessage, []byte{})
 			}
 
-			if err := c.Socket.WriteJSON(event); err != nil {
-				wsLogger.Debugf("client [%s] write event %+v failed: %s", c.Id, event, err)
+			if !c.markPushed(item) {
+				continue
+			}
+			if err := c.Socket.WriteJSON(item.Trx); err != nil {
+				wsLogger.Debugf("client [%s] write event %+v failed: %s", c.Id, item.Trx, err)
 				return err
 			}
+		case <-c.lagSignal:
 		}
 	}
 }
 
-// websocket handler
+// @Tags Chain
+// @Summary WsConnect
+// @Description Subscribe on chain trxs via websocket, filtered by groups, trx types and senders. With from_block or from_trx of a single group, trxs are replayed from storage before live trxs
+// @Param group_ids query []string false "Group Ids"
+// @Param trx_types query []string false "Trx Types, e.g. POST"
+// @Param senders query []string false "Sender Pubkeys"
+// @Param from_block query int false "Replay from the block"
+// @Param from_trx query string false "Replay trxs after the trx"
+// @Success 101
+// @Router /api/v1/ws/trx [get]
 func (manager *WebsocketManager) WsConnect(c echo.Context) error {
+	cc := c.(*utils.CustomContext)
+	params := new(WsTrxSubscribeParam)
+	if err := cc.BindAndValidate(params); err != nil {
+		return err
+	}
+
+	client, err := newClient(params)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	//resume point
+	var cursor *trxCursor
+	if params.FromBlock > 0 || params.FromTrx != "" {
+		if len(params.GroupIds) != 1 {
+			return rumerrors.NewBadRequestError("from_block and from_trx need a single group_ids")
+		}
+		groupId := params.GroupIds[0]
+		if _, ok := chain.GetGroupMgr().Groups[groupId]; !ok {
+			return rumerrors.NewBadRequestError(rumerrors.ErrGroupNotFound)
+		}
+
+		cursor = &trxCursor{BlockId: params.FromBlock, Pending: true}
+		if params.FromTrx != "" {
+			if manager.Appdb == nil {
+				return rumerrors.NewBadRequestError("resume from trx is not supported")
+			}
+			blockId, ok, err := manager.Appdb.GetTrxBlockId(groupId, params.FromTrx)
+			if err != nil {
+				return rumerrors.NewInternalServerError(err)
+			}
+			if !ok {
+				return rumerrors.NewBadRequestError(fmt.Sprintf("trx %s not found", params.FromTrx))
+			}
+			cursor = &trxCursor{BlockId: blockId, AfterTrx: params.FromTrx, Pending: true}
+		}
+	}
+
 	ws, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
 	if err != nil {
 		return err
 	}
 
-	client := &Client{
-		Id:              guuid.NewString(),
-		Socket:          ws,
-		OnChainTrxChann: make(chan *quorumpb.Trx, maxOnChainTrxs),
+	client.Socket = ws
+	if cursor != nil {
+		client.cursors[params.GroupIds[0]] = cursor
+		client.signalLag()
 	}
 
 	manager.RegisterClient(client)
 	go client.Read(manager)
-	go client.Write()
+	go client.Write(manager)
 
 	wsLogger.Debugf("new client: %+v", client)
 
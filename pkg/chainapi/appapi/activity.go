@@ -0,0 +1,121 @@
+package appapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rumsystem/quorum/internal/pkg/appdata"
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
+)
+
+type GetGroupObjectsParams struct {
+	GroupId        string   `param:"group_id" json:"group_id" url:"-" validate:"required,uuid4"`
+	Num            int      `query:"num" json:"num" url:"num" validate:"lte=100"`
+	StartObject    string   `query:"start" json:"start" url:"start"` // object id of the last page
+	Senders        []string `query:"senders" json:"senders" url:"senders"`
+	TopLevel       bool     `query:"top_level" json:"top_level" url:"top_level,omitempty"`
+	IncludeDeleted bool     `query:"include_deleted" json:"include_deleted" url:"include_deleted,omitempty"`
+}
+
+type GetGroupObjectParams struct {
+	GroupId  string `param:"group_id" json:"group_id" url:"-" validate:"required,uuid4"`
+	ObjectId string `param:"object_id" json:"object_id" url:"-" validate:"required"`
+}
+
+type GetObjectThreadParams struct {
+	GroupId  string `param:"group_id" json:"group_id" url:"-" validate:"required,uuid4"`
+	ObjectId string `param:"object_id" json:"object_id" url:"-" validate:"required"`
+	Depth    int    `query:"depth" json:"depth" url:"depth" validate:"gte=0,lte=50"`
+	Num      int    `query:"num" json:"num" url:"num" validate:"gte=0,lte=1000"` // max objects in the thread
+}
+
+// @Tags Apps
+// @Summary GetGroupObjects
+// @Description Get objects posted to a group with like, dislike and reply counts, newest first
+// @Produce json
+// @Param group_id path string  true "Group Id"
+// @Param params query GetGroupObjectsParams false "get group objects params"
+// @Success 200 {array} []appdata.ActivityObject
+// @Router /app/api/v1/group/{group_id}/objects [get]
+func (h *Handler) GetGroupObjects(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	var params GetGroupObjectsParams
+	if err := cc.BindAndValidate(&params); err != nil {
+		return err
+	}
+	if _, err := chain.GetGroupMgr().GetGroupItem(params.GroupId); err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	res, err := h.Appdb.GetActivityObjects(params.GroupId, &appdata.ActivityObjectsQuery{
+		Senders:        params.Senders,
+		StartObject:    params.StartObject,
+		Num:            params.Num,
+		TopLevel:       params.TopLevel,
+		IncludeDeleted: params.IncludeDeleted,
+	})
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// @Tags Apps
+// @Summary GetGroupObject
+// @Description Get an object posted to a group with like, dislike and reply counts
+// @Produce json
+// @Param group_id path string  true "Group Id"
+// @Param object_id path string  true "Object Id"
+// @Success 200 {object} appdata.ActivityObject
+// @Router /app/api/v1/group/{group_id}/object/{object_id} [get]
+func (h *Handler) GetGroupObject(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	var params GetGroupObjectParams
+	if err := cc.BindAndValidate(&params); err != nil {
+		return err
+	}
+
+	obj, err := h.Appdb.GetActivityObject(params.GroupId, params.ObjectId)
+	if err != nil {
+		return rumerrors.NewInternalServerError(err)
+	}
+	if obj == nil {
+		return rumerrors.NewNotFoundError(fmt.Sprintf("object %s not found", params.ObjectId))
+	}
+	return c.JSON(http.StatusOK, obj)
+}
+
+// @Tags Apps
+// @Summary GetObjectThread
+// @Description Get an object and its replies as a tree, replies are in time order
+// @Produce json
+// @Param group_id path string  true "Group Id"
+// @Param object_id path string  true "Object Id"
+// @Param params query GetObjectThreadParams false "get thread params"
+// @Success 200 {object} appdata.ActivityThread
+// @Router /app/api/v1/group/{group_id}/object/{object_id}/thread [get]
+func (h *Handler) GetObjectThread(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	var params GetObjectThreadParams
+	if err := cc.BindAndValidate(&params); err != nil {
+		return err
+	}
+	if params.Depth == 0 {
+		params.Depth = 10
+	}
+	if params.Num == 0 {
+		params.Num = 200
+	}
+
+	thread, err := h.Appdb.GetActivityThread(params.GroupId, params.ObjectId, params.Depth, params.Num)
+	if err != nil {
+		return rumerrors.NewInternalServerError(err)
+	}
+	if thread == nil {
+		return rumerrors.NewNotFoundError(fmt.Sprintf("object %s not found", params.ObjectId))
+	}
+	return c.JSON(http.StatusOK, thread)
+}
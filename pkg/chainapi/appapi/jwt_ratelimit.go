@@ -0,0 +1,91 @@
+package appapi
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+// the limiters of the expired tokens are evicted at most once in the interval
+var jwtLimitersEvictInterval = time.Minute
+
+type jwtLimiter struct {
+	limiter  *rate.Limiter
+	expireAt time.Time // the latest expiry of the tokens sharing the limiter
+}
+
+var (
+	jwtLimitersMu      sync.Mutex
+	jwtLimiters        = map[string]*jwtLimiter{} // jti or name of token => limiter
+	jwtLimitersEvictAt time.Time
+)
+
+// JWTRateLimit limits the requests of the tokens with the rateLimit claim (requests per minute).
+// The tokens with the same jti, or the same name if no jti (e.g. a refreshed token), share the limit.
+func JWTRateLimit(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		//no token for the requests skipped by jwt middleware, e.g. from localhost
+		token, ok := c.Get(jwtContextKey).(*jwt.Token)
+		if !ok {
+			return next(c)
+		}
+		limit := GetJWTRateLimit(token)
+		if limit <= 0 {
+			return next(c)
+		}
+
+		if !getJWTLimiter(token, limit, time.Now()).Allow() {
+			return echo.NewHTTPError(http.StatusTooManyRequests, "jwt rate limit exceeded")
+		}
+		return next(c)
+	}
+}
+
+func getJWTLimiter(token *jwt.Token, limit int, now time.Time) *rate.Limiter {
+	key := jwtLimiterKey(token)
+	expireAt := jwtExpireAt(token, now)
+
+	jwtLimitersMu.Lock()
+	defer jwtLimitersMu.Unlock()
+
+	if now.Sub(jwtLimitersEvictAt) > jwtLimitersEvictInterval {
+		for k, item := range jwtLimiters {
+			if now.After(item.expireAt) {
+				delete(jwtLimiters, k)
+			}
+		}
+		jwtLimitersEvictAt = now
+	}
+
+	item, ok := jwtLimiters[key]
+	if !ok || item.limiter.Burst() != limit {
+		item = &jwtLimiter{limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(limit)), limit)}
+		jwtLimiters[key] = item
+	}
+	if expireAt.After(item.expireAt) {
+		item.expireAt = expireAt
+	}
+	return item.limiter
+}
+
+func jwtLimiterKey(token *jwt.Token) string {
+	claims := token.Claims.(jwt.MapClaims)
+	if jti, ok := claims["jti"].(string); ok && jti != "" {
+		return "jti:" + jti
+	}
+	return "name:" + GetJWTName(token)
+}
+
+// jwtExpireAt returns the expiry of the token, tokens without exp are kept for the evict interval since last used
+func jwtExpireAt(token *jwt.Token, now time.Time) time.Time {
+	claims := token.Claims.(jwt.MapClaims)
+	//numbers of the jwt claims are decoded as float64
+	if exp, ok := claims["exp"].(float64); ok {
+		return time.Unix(int64(exp), 0)
+	}
+	return now.Add(jwtLimitersEvictInterval)
+}
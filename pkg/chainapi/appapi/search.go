@@ -0,0 +1,70 @@
+package appapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rumsystem/quorum/internal/pkg/appdata"
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
+)
+
+type SearchGroupContentParams struct {
+	GroupId    string   `param:"group_id" json:"group_id" url:"-" validate:"required,uuid4"`
+	Query      string   `query:"q" json:"q" url:"q"`
+	Senders    []string `query:"senders" json:"senders" url:"senders"`
+	StartTime  int64    `query:"start_time" json:"start_time" url:"start_time" validate:"gte=0"` // nanoseconds, included
+	EndTime    int64    `query:"end_time" json:"end_time" url:"end_time" validate:"gte=0"`       // nanoseconds, excluded
+	Type       string   `query:"type" json:"type" url:"type" example:"Create"`
+	ObjectType string   `query:"object_type" json:"object_type" url:"object_type" example:"Note"`
+	InReplyTo  string   `query:"in_reply_to" json:"in_reply_to" url:"in_reply_to"`
+	Num        int      `query:"num" json:"num" url:"num" validate:"lte=100"`
+}
+
+// @Tags Apps
+// @Summary SearchGroupContent
+// @Description Search the indexed post content of a group, newest first, the node should run with --searchindex
+// @Produce json
+// @Param group_id path string  true "Group Id"
+// @Param params query SearchGroupContentParams false "search params"
+// @Success 200 {array} []appdata.SearchDoc
+// @Router /app/api/v1/group/{group_id}/search [get]
+func (h *Handler) SearchGroupContent(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	var params SearchGroupContentParams
+	if err := cc.BindAndValidate(&params); err != nil {
+		return err
+	}
+	if params.Num <= 0 {
+		params.Num = 20
+	}
+	if params.EndTime > 0 && params.EndTime <= params.StartTime {
+		return rumerrors.NewBadRequestError(errors.New("end_time should be greater than start_time"))
+	}
+
+	if !h.Appdb.IsSearchIndexEnabled() {
+		return rumerrors.NewBadRequestError(errors.New("search index is not enabled, restart the node with --searchindex"))
+	}
+
+	if _, err := chain.GetGroupMgr().GetGroupItem(params.GroupId); err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	res, err := h.Appdb.Search(params.GroupId, &appdata.SearchQuery{
+		Query:      params.Query,
+		Senders:    params.Senders,
+		StartTime:  params.StartTime,
+		EndTime:    params.EndTime,
+		Type:       params.Type,
+		ObjectType: params.ObjectType,
+		InReplyTo:  params.InReplyTo,
+		Num:        params.Num,
+	})
+	if err != nil {
+		return rumerrors.NewInternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -67,13 +67,12 @@ func AnnounceHandler(params *AnnounceParam) (*AnnounceResult, error) {
 
 		item.SignPubkey = group.Item.UserSignPubkey
 
-		if item.Type == quorumpb.AnnounceType_AS_USER {
-			encryptPubkey, err := nodectx.GetNodeCtx().Keystore.GetEncodedPubkey(params.GroupId, localcrypto.Encrypt)
-			if err != nil {
-				return nil, err
-			}
-			item.EncryptPubkey = encryptPubkey
+		//producers decrypt the trxs to validate them, the rotated group key is encrypted to them too
+		encryptPubkey, err := nodectx.GetNodeCtx().Keystore.GetEncodedPubkey(params.GroupId, localcrypto.Encrypt)
+		if err != nil {
+			return nil, err
 		}
+		item.EncryptPubkey = encryptPubkey
 
 		item.OwnerPubkey = ""
 		item.OwnerSignature = ""
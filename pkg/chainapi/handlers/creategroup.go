@@ -64,10 +64,6 @@ func CreateGroup(params *CreateGroupParam, nodeoptions *options.NodeOptions, app
 		return nil, err
 	}
 
-	if params.ConsensusType != "poa" {
-		return nil, errors.New("consensus_type must be poa, other types are not supported yet")
-	}
-
 	groupid := guuid.New()
 
 	ks := nodectx.GetNodeCtx().Keystore
@@ -102,7 +98,11 @@ func CreateGroup(params *CreateGroupParam, nodeoptions *options.NodeOptions, app
 	item.OwnerPubKey = b64key
 	item.UserSignPubkey = item.OwnerPubKey
 	item.UserEncryptPubkey = groupEncryptPubkey
-	item.ConsenseType = pb.GroupConsenseType_POA
+	if params.ConsensusType == "pos" {
+		item.ConsenseType = pb.GroupConsenseType_POS
+	} else {
+		item.ConsenseType = pb.GroupConsenseType_POA
+	}
 
 	if params.EncryptionType == "public" {
 		item.EncryptType = pb.GroupEncryptType_PUBLIC
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"sort"
+
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/pkg/consensus"
+)
+
+type EvidenceItem struct {
+	EvidenceId     string `json:"evidence_id" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Type           string `json:"type" example:"BLOCK_EQUIVOCATION"`
+	OffenderPubkey string `json:"offender_pubkey" example:"AgrXMd6ow9RmKIKpjUvx41OcqPeHnPWqW8y0VfOA8OIC"`
+	Epoch          uint64 `json:"epoch" example:"1024"` // epoch of RBC messages, or BlockId of blocks
+	MsgA           []byte `json:"msg_a"`                // the conflicting messages signed by offender, protobuf encoded
+	MsgB           []byte `json:"msg_b"`
+	Verified       bool   `json:"verified" example:"true"`
+	TimeStamp      int64  `json:"timestamp" example:"1634756661280204800"`
+}
+
+type GroupEvidencesResult struct {
+	GroupId   string          `json:"group_id" example:"17a598a0-274b-45e7-a4b5-b81f9f274d50"`
+	Evidences []*EvidenceItem `json:"evidences"`
+}
+
+// GetGroupEvidences returns the evidence of producer misbehaviour found by this node, the latest first
+func GetGroupEvidences(groupid string) (*GroupEvidencesResult, error) {
+	groupmgr := chain.GetGroupMgr()
+	group, ok := groupmgr.Groups[groupid]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+
+	evidences, err := group.GetEvidences()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GroupEvidencesResult{GroupId: groupid, Evidences: []*EvidenceItem{}}
+	for _, evidence := range evidences {
+		result.Evidences = append(result.Evidences, &EvidenceItem{
+			EvidenceId:     evidence.EvidenceId,
+			Type:           evidence.Type.String(),
+			OffenderPubkey: evidence.OffenderPubkey,
+			Epoch:          evidence.Epoch,
+			MsgA:           evidence.MsgA,
+			MsgB:           evidence.MsgB,
+			Verified:       consensus.VerifyEvidence(evidence) == nil,
+			TimeStamp:      evidence.TimeStamp,
+		})
+	}
+	sort.Slice(result.Evidences, func(i, j int) bool {
+		return result.Evidences[i].TimeStamp > result.Evidences[j].TimeStamp
+	})
+	return result, nil
+}
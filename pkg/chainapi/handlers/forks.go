@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"sort"
+
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
+)
+
+type ForkItem struct {
+	BlockId        uint64 `json:"block_id" example:"1024"`
+	LocalHash      string `json:"local_hash" example:"5b1a5dd8c1ad2b0cc0f1e5d0a7e2b1c0d9f83e7a5b4c3d2e1f0a9b8c7d6e5f40"`
+	LocalProducer  string `json:"local_producer" example:"AgrXMd6ow9RmKIKpjUvx41OcqPeHnPWqW8y0VfOA8OIC"`
+	LocalIssue     string `json:"local_issue,omitempty" example:""`
+	RemoteHash     string `json:"remote_hash" example:"c9a3e1f5b7d2c4a6e8f0b1d3c5e7a9f2b4d6e8a0c1e3f5a7b9d0c2e4f6a8b1d3"`
+	RemoteProducer string `json:"remote_producer" example:"A7xvmPq2FzjS1u4fMwa3E9Yb9u7o2N0w1HFyq3CSkfvP"`
+	RemoteIssue    string `json:"remote_issue,omitempty" example:"producer <A7xvmPq2FzjS1u4fMwa3E9Yb9u7o2N0w1HFyq3CSkfvP> is not in the producer list"`
+	Resolution     string `json:"resolution" example:"rejected"` // switched, kept, rejected or failed
+	Reason         string `json:"reason" example:"the conflicting block is invalid"`
+	RolledBack     int    `json:"rolled_back" example:"0"` // blocks after the fork point removed from chain
+	TimeStamp      int64  `json:"timestamp" example:"1634756661280204800"`
+}
+
+type GroupForksResult struct {
+	GroupId string      `json:"group_id" example:"17a598a0-274b-45e7-a4b5-b81f9f274d50"`
+	Forks   []*ForkItem `json:"forks"`
+}
+
+// GetGroupForks returns the conflicting blocks found by this node and how they are resolved, the latest first
+func GetGroupForks(groupid string) (*GroupForksResult, error) {
+	groupmgr := chain.GetGroupMgr()
+	group, ok := groupmgr.Groups[groupid]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+
+	records, err := group.GetForkRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GroupForksResult{GroupId: groupid, Forks: []*ForkItem{}}
+	for _, record := range records {
+		result.Forks = append(result.Forks, newForkItem(record))
+	}
+	sort.Slice(result.Forks, func(i, j int) bool {
+		return result.Forks[i].TimeStamp > result.Forks[j].TimeStamp
+	})
+	return result, nil
+}
+
+func newForkItem(record *chainstorage.ForkRecord) *ForkItem {
+	return &ForkItem{
+		BlockId:        record.BlockId,
+		LocalHash:      record.LocalHash,
+		LocalProducer:  record.LocalProducer,
+		LocalIssue:     record.LocalIssue,
+		RemoteHash:     record.RemoteHash,
+		RemoteProducer: record.RemoteProducer,
+		RemoteIssue:    record.RemoteIssue,
+		Resolution:     string(record.Resolution),
+		Reason:         record.Reason,
+		RolledBack:     record.RolledBack,
+		TimeStamp:      record.TimeStamp,
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	"github.com/rumsystem/quorum/pkg/pb"
+)
+
+// max headers returned by one request
+var MAX_BLOCK_HEADERS = 100
+
+type GetBlockHeadersParam struct {
+	GroupId string `param:"group_id" json:"group_id" url:"-" validate:"required,uuid4" example:"ac0eea7c-2f3c-4c67-80b3-136e46b924a8"`
+	BlockId uint64 `param:"block_id" json:"block_id" url:"-" example:"12"`
+	Num     int    `query:"num" json:"num" url:"num" example:"20"`
+}
+
+type BlockHeaderItem struct {
+	Header *pb.Block                         `json:"header"` // the block without trxs, or the whole block if it has no trx root
+	Trxs   []*pb.Trx                         `json:"trxs"`   // the PRODUCER and STAKE trxs of the block, which change the producers
+	Proofs []*rumchaindata.TrxInclusionProof `json:"proofs"` // the merkle proofs of Trxs
+}
+
+type TrustedHeaderResult struct {
+	GroupId   string                      `json:"group_id" example:"ac0eea7c-2f3c-4c67-80b3-136e46b924a8"`
+	Header    *pb.Block                   `json:"header"` // the head block of this node without trxs
+	Producers []string                    `json:"producers"`
+	Stakes    []*pb.StakeItem             `json:"stakes"`
+	Scheduled []*pb.BFTProducerBundleItem `json:"scheduled"`
+}
+
+// GetBlockHeaders returns the headers of at most num blocks from the block id, for the light node to verify the chain
+// without the trxs. The headers end at the first block not found, e.g. a pruned block or a block not produced yet.
+func GetBlockHeaders(groupid string, blockId uint64, num int) ([]*BlockHeaderItem, error) {
+	group, ok := chain.GetGroupMgr().Groups[groupid]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+	if num <= 0 || num > MAX_BLOCK_HEADERS {
+		num = MAX_BLOCK_HEADERS
+	}
+
+	result := []*BlockHeaderItem{}
+	for id := blockId; id < blockId+uint64(num) && id <= group.GetCurrentBlockId(); id++ {
+		block, err := group.GetBlock(id)
+		if err != nil {
+			break
+		}
+		if block.Version != rumchaindata.BLOCK_VERSION_TRX_ROOT {
+			result = append(result, &BlockHeaderItem{Header: block})
+			continue
+		}
+
+		item := &BlockHeaderItem{Header: getBlockHeader(block)}
+		for _, trx := range block.Trxs {
+			if trx.Type != pb.TrxType_PRODUCER && trx.Type != pb.TrxType_STAKE {
+				continue
+			}
+			proof, err := rumchaindata.GetTrxInclusionProof(block, trx.TrxId)
+			if err != nil {
+				return nil, err
+			}
+			item.Trxs = append(item.Trxs, trx)
+			item.Proofs = append(item.Proofs, proof)
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// GetTrustedHeader returns the head of this node and the producers in effect after it, a light node trusting
+// this node starts to verify the headers from it instead of the genesis block
+func GetTrustedHeader(groupid string) (*TrustedHeaderResult, error) {
+	group, ok := chain.GetGroupMgr().Groups[groupid]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+
+	block, err := group.GetBlock(group.GetCurrentBlockId())
+	if err != nil {
+		return nil, err
+	}
+	producers, err := group.GetProducers()
+	if err != nil {
+		return nil, err
+	}
+	stakes, err := group.GetStakes()
+	if err != nil {
+		return nil, err
+	}
+	scheduled, err := group.GetScheduledProducers()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TrustedHeaderResult{
+		GroupId:   groupid,
+		Header:    getBlockHeader(block),
+		Producers: []string{},
+		Stakes:    stakes,
+		Scheduled: []*pb.BFTProducerBundleItem{},
+	}
+	for _, item := range producers {
+		result.Producers = append(result.Producers, item.ProducerPubkey)
+	}
+	for _, item := range scheduled {
+		result.Scheduled = append(result.Scheduled, &pb.BFTProducerBundleItem{Producers: item.Producers, ActivateEpoch: item.ActivateEpoch})
+	}
+	return result, nil
+}
+
+// getBlockHeader returns the block without trxs
+func getBlockHeader(block *pb.Block) *pb.Block {
+	return &pb.Block{
+		GroupId:        block.GroupId,
+		BlockId:        block.BlockId,
+		Epoch:          block.Epoch,
+		PrevHash:       block.PrevHash,
+		ProducerPubkey: block.ProducerPubkey,
+		Sudo:           block.Sudo,
+		TimeStamp:      block.TimeStamp,
+		BlockHash:      block.BlockHash,
+		ProducerSign:   block.ProducerSign,
+		Version:        block.Version,
+		TrxRoot:        block.TrxRoot,
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/storage/def"
+	"github.com/rumsystem/quorum/pkg/consensus"
+)
+
+type PackingPolicyItem struct {
+	GroupId        string `json:"group_id" example:"b3e1800a-af6e-4c67-af89-4ddcf831b6f7"`
+	Policy         string `json:"policy" example:"TRX_TYPE_PRIORITY"`
+	BatchSize      int    `json:"batch_size" example:"20"`
+	MaxBundleBytes int    `json:"max_bundle_bytes" example:"921600"`
+}
+
+type PackingPolicyQueryParams struct {
+	GroupId string `param:"group_id" validate:"required,uuid4" example:"b3e1800a-af6e-4c67-af89-4ddcf831b6f7"`
+}
+
+// GetChainPackingPolicy returns the packing policy in effect, with the default values applied
+func GetChainPackingPolicy(chainapidb def.APIHandlerIface, groupid string) (*PackingPolicyItem, error) {
+	if groupid == "" {
+		return nil, rumerrors.ErrInvalidGroupID
+	}
+
+	groupmgr := chain.GetGroupMgr()
+	group, ok := groupmgr.Groups[groupid]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+
+	item, err := chainapidb.GetPackingPolicyByGroupId(group.GroupId, group.Nodename)
+	if err != nil {
+		return nil, err
+	}
+
+	packing := consensus.NewPackingConfig(item)
+	return &PackingPolicyItem{
+		GroupId:        group.GroupId,
+		Policy:         packing.Policy.Name(),
+		BatchSize:      packing.BatchSize,
+		MaxBundleBytes: packing.MaxBundleBytes,
+	}, nil
+}
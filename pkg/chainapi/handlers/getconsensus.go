@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/pkg/consensus"
+)
+
+type GetConsensusParams struct {
+	GroupId string `param:"group_id" validate:"required,uuid4" example:"b3e1800a-af6e-4c67-af89-4ddcf831b6f7"`
+}
+
+type ConsensusInfo struct {
+	GroupId       string                  `json:"group_id" example:"b3e1800a-af6e-4c67-af89-4ddcf831b6f7"`
+	ConsensusType string                  `json:"consensus_type" example:"POA"`
+	Engine        string                  `json:"engine" example:"Molasses"`
+	Producers     []string                `json:"producers"`
+	CurrEpoch     uint64                  `json:"curr_epoch" example:"1024"`
+	CurrBlockId   uint64                  `json:"curr_block_id" example:"1000"`
+	LastUpdate    int64                   `json:"last_update" example:"1634756661280204800"`
+	Epochs        []*consensus.EpochTrace `json:"epochs"` // recent epochs traced by this node, the latest first, empty if this node is not a producer
+}
+
+// GetConsensusInfo returns the consensus state of the group seen by this node
+func GetConsensusInfo(groupid string) (*ConsensusInfo, error) {
+	groupmgr := chain.GetGroupMgr()
+	group, ok := groupmgr.Groups[groupid]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+
+	producers, err := group.GetProducers()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &ConsensusInfo{
+		GroupId:       group.Item.GroupId,
+		ConsensusType: group.Item.ConsenseType.String(),
+		Producers:     []string{},
+		CurrEpoch:     group.GetCurrentEpoch(),
+		CurrBlockId:   group.GetCurrentBlockId(),
+		LastUpdate:    group.GetLatestUpdate(),
+		Epochs:        consensus.GetEpochTraces(group.Item.GroupId),
+	}
+	if group.ChainCtx.Consensus != nil {
+		info.Engine = group.ChainCtx.Consensus.Name()
+	}
+	for _, producer := range producers {
+		info.Producers = append(info.Producers, producer.ProducerPubkey)
+	}
+	return info, nil
+}
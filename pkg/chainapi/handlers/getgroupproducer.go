@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
 	"github.com/rumsystem/quorum/internal/pkg/storage/def"
 )
 
@@ -16,6 +17,19 @@ type ProducerListItem struct {
 	BlockWithness  int64  `example:"0"`
 }
 
+type ScheduledProducerListItem struct {
+	TrxId         string              `json:"trx_id" example:"6bff5556-4dc9-4cb6-a595-2181aaebdc26"`
+	ActivateEpoch uint64              `json:"activate_epoch" example:"1024"`
+	Producers     []*ProducerListItem `json:"producers"` // the owner is always a producer and not listed
+}
+
+type GroupProducersResult struct {
+	GroupId   string                       `json:"group_id" example:"5ed3f9fe-81e2-450d-9146-7a329aac2b62"`
+	CurrEpoch uint64                       `json:"curr_epoch" example:"1023"`
+	Producers []*ProducerListItem          `json:"producers"`
+	Scheduled []*ScheduledProducerListItem `json:"scheduled"` // in the order of activate epoch
+}
+
 func GetGroupProducers(chainapidb def.APIHandlerIface, groupid string) ([]*ProducerListItem, error) {
 	if groupid == "" {
 		return nil, errors.New("group_id can't be nil.")
@@ -45,3 +59,41 @@ func GetGroupProducers(chainapidb def.APIHandlerIface, groupid string) ([]*Produ
 		return nil, fmt.Errorf("Group %s not exist", groupid)
 	}
 }
+
+// GetGroupProducerSets returns the producers in effect and the producer lists scheduled to take over at later epochs
+func GetGroupProducerSets(chainapidb def.APIHandlerIface, groupid string) (*GroupProducersResult, error) {
+	groupmgr := chain.GetGroupMgr()
+	group, ok := groupmgr.Groups[groupid]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+
+	producers, err := GetGroupProducers(chainapidb, groupid)
+	if err != nil {
+		return nil, err
+	}
+	scheduled, err := group.GetScheduledProducers()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GroupProducersResult{
+		GroupId:   groupid,
+		CurrEpoch: group.GetCurrentEpoch(),
+		Producers: producers,
+		Scheduled: []*ScheduledProducerListItem{},
+	}
+	for _, item := range scheduled {
+		scheduledItem := &ScheduledProducerListItem{TrxId: item.TrxId, ActivateEpoch: item.ActivateEpoch}
+		for _, prd := range item.Producers {
+			scheduledItem.Producers = append(scheduledItem.Producers, &ProducerListItem{
+				ProducerPubkey: prd.ProducerPubkey,
+				OwnerPubkey:    prd.GroupOwnerPubkey,
+				OwnerSign:      prd.GroupOwnerSign,
+				TimeStamp:      prd.TimeStamp,
+			})
+		}
+		result.Scheduled = append(result.Scheduled, scheduledItem)
+	}
+	return result, nil
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"fmt"
+
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	"github.com/rumsystem/quorum/pkg/pb"
+)
+
+type TrxProofResult struct {
+	GroupId string                          `json:"group_id" example:"ac0eea7c-2f3c-4c67-80b3-136e46b924a8"`
+	TrxId   string                          `json:"trx_id" example:"22d5c38d-5921-4b75-8562-c110dcfd5ee8"`
+	Header  *pb.Block                       `json:"header"` // the block packaged the trx, without trxs
+	Proof   *rumchaindata.TrxInclusionProof `json:"proof"`
+}
+
+// GetTrxProof returns the merkle proof of the trx in the block packaged it, with the block header to verify the proof.
+// The trxs in the blocks created before the trx root was added can't be proved this way.
+func GetTrxProof(groupid string, trxid string) (*TrxProofResult, error) {
+	group, ok := chain.GetGroupMgr().Groups[groupid]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+
+	block, err := group.GetTrxBlock(trxid)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("trx <%s> is not found in the blocks on chain", trxid)
+	}
+
+	proof, err := rumchaindata.GetTrxInclusionProof(block, trxid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TrxProofResult{GroupId: groupid, TrxId: trxid, Header: getBlockHeader(block), Proof: proof}, nil
+}
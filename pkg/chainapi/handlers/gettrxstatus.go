@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"fmt"
+
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
+)
+
+type TrxStatusResult struct {
+	TrxId       string `json:"trx_id" example:"22d5c38d-5921-4b75-8562-c110dcfd5ee8"`
+	GroupId     string `json:"group_id" example:"ac0eea7c-2f3c-4c67-80b3-136e46b924a8"`
+	Status      string `json:"status" example:"pending" enums:"pending,on-chain,expired,failed"`
+	Tracked     bool   `json:"tracked" example:"true"` // false if the trx is not sent by this node
+	Type        string `json:"type,omitempty" example:"POST"`
+	ResendCount int64  `json:"resend_count" example:"1"`
+	TimeStamp   int64  `json:"timestamp,omitempty" example:"1634756661280204800"`
+	Expired     int64  `json:"expired,omitempty" example:"1634756691280204800"`
+	LastSent    int64  `json:"last_sent,omitempty" example:"1634756671280204800"`
+	Error       string `json:"error,omitempty"`
+}
+
+// GetTrxStatus returns the status of a trx sent by this node, or on-chain for a packaged trx sent by others
+func GetTrxStatus(groupid string, trxid string) (*TrxStatusResult, error) {
+	group, ok := chain.GetGroupMgr().Groups[groupid]
+	if !ok {
+		return nil, fmt.Errorf("group %s not exist", groupid)
+	}
+
+	item, err := group.GetTrxStatus(trxid)
+	if err != nil {
+		return nil, err
+	}
+	if item != nil {
+		return &TrxStatusResult{
+			TrxId:       item.TrxId,
+			GroupId:     item.GroupId,
+			Status:      string(item.Status),
+			Tracked:     true,
+			Type:        item.Type,
+			ResendCount: item.ResendCount,
+			TimeStamp:   item.TimeStamp,
+			Expired:     item.Expired,
+			LastSent:    item.LastSent,
+			Error:       item.Error,
+		}, nil
+	}
+
+	trx, err := group.GetTrx(trxid)
+	if err != nil {
+		return nil, err
+	}
+	if trx == nil || trx.TrxId == "" {
+		return nil, fmt.Errorf("trx %s not found", trxid)
+	}
+	return &TrxStatusResult{
+		TrxId:       trx.TrxId,
+		GroupId:     trx.GroupId,
+		Status:      string(chainstorage.TrxStatusOnChain),
+		Type:        trx.Type.String(),
+		ResendCount: trx.ResendCount,
+		TimeStamp:   trx.TimeStamp,
+		Expired:     trx.Expired,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+)
+
+type RotateGroupKeyParam struct {
+	GroupId        string   `param:"group_id" json:"-" validate:"required,uuid4" example:"c0020941-e648-40c9-92dc-682645acd17e"`
+	EncryptPubkeys []string `json:"encrypt_pubkeys" validate:"dive,required" example:"age1lx3zh2yvxuz4h7ltj4dxqzmdmvqqqr3kxqm6xhxm2ce0gaw6hswqrmksy6"` // extra recipients besides the group users, e.g. the producer nodes
+	Memo           string   `json:"memo" example:"remove user"`
+}
+
+type RotateGroupKeyResult struct {
+	GroupId    string   `json:"group_id" example:"c0020941-e648-40c9-92dc-682645acd17e"`
+	TrxId      string   `json:"trx_id" example:"9e54c173-c1dd-429d-91fa-a6b43c14da77"`
+	Recipients []string `json:"recipients" example:"age1lx3zh2yvxuz4h7ltj4dxqzmdmvqqqr3kxqm6xhxm2ce0gaw6hswqrmksy6"`
+}
+
+type GroupKeyItem struct {
+	BlockId   uint64 `json:"block_id" example:"1024"`
+	TrxId     string `json:"trx_id" example:"9e54c173-c1dd-429d-91fa-a6b43c14da77"`
+	TimeStamp int64  `json:"timestamp" example:"1634756661280204800"`
+	Received  bool   `json:"received" example:"true"` // the key is encrypted to this node
+}
+
+type GroupKeysResult struct {
+	GroupId string          `json:"group_id" example:"c0020941-e648-40c9-92dc-682645acd17e"`
+	Keys    []*GroupKeyItem `json:"keys"`
+}
+
+// RotateGroupKey sends a GROUP_KEY trx with a new cipher key for a private group, encrypted to the group users
+// and the extra encrypt pubkeys. Run it after a user is removed, the removed user can't read the trxs since then
+func RotateGroupKey(params *RotateGroupKeyParam) (*RotateGroupKeyResult, error) {
+	group, ok := chain.GetGroupMgr().Groups[params.GroupId]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+	if group.Item.OwnerPubKey != group.Item.UserSignPubkey {
+		return nil, rumerrors.ErrOnlyGroupOwner
+	}
+
+	trxId, recipients, err := group.RotateGroupKey(params.EncryptPubkeys, params.Memo)
+	if err != nil {
+		return nil, err
+	}
+	return &RotateGroupKeyResult{GroupId: params.GroupId, TrxId: trxId, Recipients: recipients}, nil
+}
+
+// GetGroupKeys returns the history of the group cipher key rotations, the keys themselves are not returned
+func GetGroupKeys(groupid string) (*GroupKeysResult, error) {
+	group, ok := chain.GetGroupMgr().Groups[groupid]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+
+	items, err := group.GetGroupKeys()
+	if err != nil {
+		return nil, err
+	}
+	result := &GroupKeysResult{GroupId: groupid, Keys: []*GroupKeyItem{}}
+	for _, item := range items {
+		result.Keys = append(result.Keys, &GroupKeyItem{
+			BlockId:   item.BlockId,
+			TrxId:     item.TrxId,
+			TimeStamp: item.TimeStamp,
+			Received:  item.CipherKey != "",
+		})
+	}
+	return result, nil
+}
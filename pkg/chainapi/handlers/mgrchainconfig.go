@@ -15,21 +15,32 @@ import (
 	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
 	"github.com/rumsystem/quorum/internal/pkg/nodectx"
 	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
 	quorumpb "github.com/rumsystem/quorum/pkg/pb"
 	"google.golang.org/protobuf/proto"
 )
 
 type ChainConfigParams struct {
 	GroupId string `from:"group_id" json:"group_id"  validate:"required,uuid4" example:"ac0eea7c-2f3c-4c67-80b3-136e46b924a8"`
-	Type    string `from:"type"     json:"type"      validate:"required,oneof=set_trx_auth_mode upd_alw_list upd_dny_list" example:"upd_alw_list"`
+	Type    string `from:"type"     json:"type"      validate:"required,oneof=set_trx_auth_mode upd_alw_list upd_dny_list set_packing_policy set_block_version" example:"upd_alw_list"`
 	Config  string `from:"config"   json:"config"    validate:"required" example:"{\"action\":\"add\",  \"pubkey\":\"CAISIQNGAO67UTFSuWzySHKdy4IjBI/Q5XDMELPUSxHpBwQDcQ==\", \"trx_type\":[\"post\", \"announce\", \"req_block_forward\", \"req_block_backward\", \"ask_peerid\"]}"`
 	Memo    string `from:"memo"     json:"memo" example:"comment/remark"`
 }
 
 type TrxAuthModeParams struct {
-	TrxType     string `from:"trx_type"      json:"trx_type"     validate:"required,oneof=POST ANNOUNCE PRODUCER REQ_BLOCK USER CHAIN_CONFIG APP_CONFIG" example:"POST"`
+	TrxType     string `from:"trx_type"      json:"trx_type"     validate:"required,oneof=POST ANNOUNCE PRODUCER REQ_BLOCK USER CHAIN_CONFIG APP_CONFIG STAKE" example:"POST"`
 	TrxAuthMode string `from:"trx_auth_mode" json:"trx_auth_mode" validate:"required,oneof=follow_alw_list follow_dny_list" example:"follow_alw_list"`
 }
+type PackingPolicyParams struct {
+	Policy         string `from:"policy"           json:"policy"           validate:"required,oneof=timestamp_fifo sender_round_robin trx_type_priority" example:"trx_type_priority"`
+	BatchSize      uint32 `from:"batch_size"       json:"batch_size"       validate:"lte=1000" example:"20"`       // 0 for default
+	MaxBundleBytes uint32 `from:"max_bundle_bytes" json:"max_bundle_bytes" validate:"lte=921600" example:"524288"` // 0 for default, should not larger than 900Kib
+}
+type BlockVersionParams struct {
+	Version   string `from:"version"    json:"version"    validate:"required,oneof=trx_list trx_root" example:"trx_root"`
+	FromEpoch uint64 `from:"from_epoch" json:"from_epoch" validate:"required" example:"1000"` // should be later than the current epoch
+}
+
 type ChainSendTrxRuleListItemParams struct {
 	Action  string   `from:"action"   json:"action"   validate:"required,oneof=add remove" example:"add"`
 	Pubkey  string   `from:"pubkey"   json:"pubkey"   validate:"required" example:"CAISIQNGAO67UTFSuWzySHKdy4IjBI/Q5XDMELPUSxHpBwQDcQ=="`
@@ -139,6 +150,63 @@ func MgrChainConfig(params *ChainConfigParams) (*ChainConfigResult, error) {
 			configItem.Type = quorumpb.ChainConfigType_UPD_DNY_LIST
 		}
 		configItem.Data = encodedcontent
+	} else if params.Type == strings.ToLower(quorumpb.ChainConfigType_SET_PACKING_POLICY.String()) {
+		dataParams := PackingPolicyParams{}
+		err := json.Unmarshal([]byte(params.Config), &dataParams)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := validate.Struct(dataParams); err != nil {
+			return nil, err
+		}
+
+		policy, ok := quorumpb.PackingPolicyType_value[strings.ToUpper(dataParams.Policy)]
+		if !ok {
+			return nil, errors.New("Unsupported policy")
+		}
+		dataItem := quorumpb.SetPackingPolicyItem{
+			Policy:         quorumpb.PackingPolicyType(policy),
+			BatchSize:      dataParams.BatchSize,
+			MaxBundleBytes: dataParams.MaxBundleBytes,
+		}
+		encodedcontent, err := proto.Marshal(&dataItem)
+		if err != nil {
+			return nil, err
+		}
+
+		configItem.Type = quorumpb.ChainConfigType_SET_PACKING_POLICY
+		configItem.Data = encodedcontent
+	} else if params.Type == strings.ToLower(quorumpb.ChainConfigType_SET_BLOCK_VERSION.String()) {
+		dataParams := BlockVersionParams{}
+		err := json.Unmarshal([]byte(params.Config), &dataParams)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := validate.Struct(dataParams); err != nil {
+			return nil, err
+		}
+
+		//blocks already produced can't change their version
+		if dataParams.FromEpoch <= group.GetCurrentEpoch() {
+			return nil, errors.New("from_epoch should be later than the current epoch")
+		}
+
+		dataItem := quorumpb.SetBlockVersionItem{
+			Version:   rumchaindata.BLOCK_VERSION_TRX_LIST,
+			FromEpoch: dataParams.FromEpoch,
+		}
+		if dataParams.Version == "trx_root" {
+			dataItem.Version = rumchaindata.BLOCK_VERSION_TRX_ROOT
+		}
+		encodedcontent, err := proto.Marshal(&dataItem)
+		if err != nil {
+			return nil, err
+		}
+
+		configItem.Type = quorumpb.ChainConfigType_SET_BLOCK_VERSION
+		configItem.Data = encodedcontent
 	} else {
 		return nil, errors.New("Type not supported")
 	}
@@ -194,6 +262,10 @@ func getTrxTypeByString(typ string) (quorumpb.TrxType, error) {
 		return -1, errors.New("this trx type can not be configured")
 	case "APP_CONFIG":
 		return -1, errors.New("this trx type can not be configured")
+	case "STAKE":
+		return quorumpb.TrxType_STAKE, nil
+	case "GROUP_KEY":
+		return -1, errors.New("this trx type can not be configured")
 	default:
 		return -1, errors.New("Unsupported TrxType")
 	}
@@ -22,12 +22,15 @@ type GrpProducerResult struct {
 	Producers []*quorumpb.ProducerItem
 	Failable  *int   `json:"failable_producers" validate:"required" example:"1"`
 	Memo      string `json:"memo" example:"comment/remark"`
+	// the producers take over from this epoch, 0 for the next epoch
+	ActivateEpoch uint64 `json:"activate_epoch" example:"1024"`
 }
 
 type GrpProducerParam struct {
 	ProducerPubkey []string `from:"producer_pubkey" json:"producer_pubkey"  validate:"required" example:"CAISIQOxCH2yVZPR8t6gVvZapxcIPBwMh9jB80pDLNeuA5s8hQ=="`
 	GroupId        string   `json:"group_id" validate:"required,uuid4" example:"5ed3f9fe-81e2-450d-9146-7a329aac2b62"`
 	Memo           string   `from:"memo"            json:"memo" example:"comment/remark"`
+	ActivateEpoch  uint64   `from:"activate_epoch"  json:"activate_epoch" example:"1024"` // optional, 0 for the next epoch
 }
 
 func GroupProducer(chainapidb def.APIHandlerIface, params *GrpProducerParam) (*GrpProducerResult, error) {
@@ -47,6 +50,10 @@ func GroupProducer(chainapidb def.APIHandlerIface, params *GrpProducerParam) (*G
 			return nil, errors.New("producer pubkey list empty")
 		}
 
+		if params.ActivateEpoch != 0 && params.ActivateEpoch <= group.GetCurrentEpoch() {
+			return nil, fmt.Errorf("activate epoch %d should be after the current epoch %d", params.ActivateEpoch, group.GetCurrentEpoch())
+		}
+
 		//check if pubkey in producer list are unique
 		bundle := make(map[string]bool)
 
@@ -103,6 +110,7 @@ func GroupProducer(chainapidb def.APIHandlerIface, params *GrpProducerParam) (*G
 		}
 
 		bftProducerBundle.Producers = producers
+		bftProducerBundle.ActivateEpoch = params.ActivateEpoch
 
 		trxId, err := group.UpdProducer(bftProducerBundle)
 		if err != nil {
@@ -117,6 +125,7 @@ func GroupProducer(chainapidb def.APIHandlerIface, params *GrpProducerParam) (*G
 			Producers: bftProducerBundle.Producers,
 			Failable:  &failable,
 			Memo:      params.Memo, TrxId: trxId,
+			ActivateEpoch: params.ActivateEpoch,
 		}
 		return blockGrpUserResult, nil
 	}
@@ -0,0 +1,41 @@
+//go:build !js
+// +build !js
+
+package handlers
+
+import (
+	"os"
+
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/storage"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
+)
+
+type ReplayStateParam struct {
+	GroupId string `param:"group_id" json:"-" validate:"required,uuid4" example:"c0020941-e648-40c9-92dc-682645acd17e"`
+	ToBlock uint64 `json:"to_block" example:"100"` // 0 means the current block
+	Apply   bool   `json:"apply" example:"false"`  // replace the current state with the replayed state if they mismatch
+}
+
+// ReplayState re-derives the state of the group from the stored blocks into a temporary db
+func ReplayState(params *ReplayStateParam) (*chainstorage.ReplayResult, error) {
+	group, ok := chain.GetGroupMgr().Groups[params.GroupId]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+
+	dir, err := os.MkdirTemp("", "quorum-replay-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	dbMgr, err := storage.CreateDbWithBackend(dir, storage.BoltBackend)
+	if err != nil {
+		return nil, err
+	}
+	defer dbMgr.CloseDb()
+
+	return group.ReplayState(chainstorage.NewChainStorage(dbMgr), params.ToBlock, params.Apply)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+type StakeParam struct {
+	GroupId string `from:"group_id" json:"group_id" validate:"required,uuid4" example:"17a598a0-274b-45e7-a4b5-b81f9f274d50"`
+	Action  string `from:"action"   json:"action"   validate:"required,oneof=add remove" example:"add"`
+	Amount  uint64 `from:"amount"   json:"amount"   validate:"required,gt=0" example:"100"`
+	Memo    string `from:"memo"     json:"memo" example:"comment/remark"`
+}
+
+type StakeResult struct {
+	GroupId      string `json:"group_id" validate:"required,uuid4" example:"17a598a0-274b-45e7-a4b5-b81f9f274d50"`
+	StakerPubkey string `json:"staker_pubkey" validate:"required" example:"AgrXMd6ow9RmKIKpjUvx41OcqPeHnPWqW8y0VfOA8OIC"`
+	Action       string `json:"action" validate:"required" example:"ADD"`
+	Amount       uint64 `json:"amount" validate:"required" example:"100"`
+	TrxId        string `json:"trx_id" validate:"required,uuid4" example:"2e86c7fb-908e-4528-8f87-d3548e0137ab"`
+}
+
+type StakeListItem struct {
+	StakerPubkey string `json:"staker_pubkey" example:"AgrXMd6ow9RmKIKpjUvx41OcqPeHnPWqW8y0VfOA8OIC"`
+	Amount       uint64 `json:"amount" example:"100"`
+	Active       bool   `json:"active" example:"true"` // not less than the minimal stake, can be selected as producer
+	TimeStamp    int64  `json:"timestamp" example:"1634756661280204800"`
+	Memo         string `json:"memo" example:"comment/remark"`
+}
+
+type GroupStakesResult struct {
+	GroupId  string           `json:"group_id" example:"17a598a0-274b-45e7-a4b5-b81f9f274d50"`
+	MinStake uint64           `json:"min_stake" example:"1"`
+	MaxStake uint64           `json:"max_stake" example:"1000"`
+	Total    uint64           `json:"total" example:"100"` // total of active stakes
+	Stakes   []*StakeListItem `json:"stakes"`
+}
+
+// StakeHandler sends a STAKE trx to add or remove the stake of this node, the stake is in effect after packaged
+func StakeHandler(params *StakeParam) (*StakeResult, error) {
+	groupmgr := chain.GetGroupMgr()
+	group, ok := groupmgr.Groups[params.GroupId]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+	if group.Item.ConsenseType != quorumpb.GroupConsenseType_POS {
+		return nil, errors.New("stake is only supported by POS group")
+	}
+
+	item := &quorumpb.StakeItem{
+		GroupId:      group.Item.GroupId,
+		StakerPubkey: group.Item.UserSignPubkey,
+		Amount:       params.Amount,
+		TimeStamp:    time.Now().UnixNano(),
+		Memo:         params.Memo,
+	}
+	if params.Action == "add" {
+		if params.Amount > rumchaindata.POS_MAX_STAKE {
+			return nil, fmt.Errorf("amount should not be greater than the max stake %d", rumchaindata.POS_MAX_STAKE)
+		}
+		item.Action = quorumpb.ActionType_ADD
+	} else if params.Action == "remove" {
+		item.Action = quorumpb.ActionType_REMOVE
+	} else {
+		return nil, errors.New("Unknown action")
+	}
+
+	trxId, err := group.UpdStake(item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeResult{
+		GroupId:      item.GroupId,
+		StakerPubkey: item.StakerPubkey,
+		Action:       item.Action.String(),
+		Amount:       item.Amount,
+		TrxId:        trxId,
+	}, nil
+}
+
+func GetGroupStakes(groupid string) (*GroupStakesResult, error) {
+	groupmgr := chain.GetGroupMgr()
+	group, ok := groupmgr.Groups[groupid]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+
+	stakes, err := group.GetStakes()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GroupStakesResult{
+		GroupId:  groupid,
+		MinStake: rumchaindata.POS_MIN_STAKE,
+		MaxStake: rumchaindata.POS_MAX_STAKE,
+		Stakes:   []*StakeListItem{},
+	}
+	for _, stake := range stakes {
+		active := stake.Amount >= rumchaindata.POS_MIN_STAKE
+		if active {
+			result.Total += stake.Amount
+		}
+		result.Stakes = append(result.Stakes, &StakeListItem{
+			StakerPubkey: stake.StakerPubkey,
+			Amount:       stake.Amount,
+			Active:       active,
+			TimeStamp:    stake.TimeStamp,
+			Memo:         stake.Memo,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/hex"
+
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+)
+
+type SudoBlockParam struct {
+	GroupId string   `param:"group_id" json:"-" validate:"required,uuid4" example:"c0020941-e648-40c9-92dc-682645acd17e"`
+	TrxIds  []string `json:"trx_ids" validate:"required,min=1,dive,required" example:"9e54c173-c1dd-429d-91fa-a6b43c14da77"`
+}
+
+type SudoBlockResult struct {
+	GroupId   string   `json:"group_id" example:"c0020941-e648-40c9-92dc-682645acd17e"`
+	BlockId   uint64   `json:"block_id" example:"1025"`
+	Epoch     uint64   `json:"epoch" example:"1025"`
+	BlockHash string   `json:"block_hash" example:"5b1a5dd8c1ad2b0cc0f1e5d0a7e2b1c0d9f83e7a5b4c3d2e1f0a9b8c7d6e5f40"`
+	TrxIds    []string `json:"trx_ids" example:"9e54c173-c1dd-429d-91fa-a6b43c14da77"`
+}
+
+// SudoBlock packages the pending trxs into a sudo block signed by the group owner and broadcasts it
+func SudoBlock(params *SudoBlockParam) (*SudoBlockResult, error) {
+	group, ok := chain.GetGroupMgr().Groups[params.GroupId]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+	if group.Item.OwnerPubKey != group.Item.UserSignPubkey {
+		return nil, rumerrors.ErrOnlyGroupOwner
+	}
+
+	block, err := group.SudoBlock(params.TrxIds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SudoBlockResult{
+		GroupId:   params.GroupId,
+		BlockId:   block.BlockId,
+		Epoch:     block.Epoch,
+		BlockHash: hex.EncodeToString(block.BlockHash),
+		TrxIds:    []string{},
+	}
+	for _, trx := range block.Trxs {
+		result.TrxIds = append(result.TrxIds, trx.TrxId)
+	}
+	return result, nil
+}
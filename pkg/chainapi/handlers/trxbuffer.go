@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"sort"
+	"time"
+
+	chain "github.com/rumsystem/quorum/internal/pkg/chainsdk/core"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/pkg/consensus"
+)
+
+type TrxBufferParam struct {
+	GroupId string `param:"group_id" json:"group_id" validate:"required,uuid4" example:"c0020941-e648-40c9-92dc-682645acd17e"`
+	Sender  string `query:"sender" json:"sender" example:"CAISIQOxCH2yVZPR8t6gVvZapxcIPBwMh9jB80pDLNeuA5s8hQ=="`
+}
+
+type TrxBufferTrxParam struct {
+	GroupId string `param:"group_id" json:"group_id" validate:"required,uuid4" example:"c0020941-e648-40c9-92dc-682645acd17e"`
+	TrxId   string `param:"trx_id" json:"trx_id" validate:"required,uuid4" example:"9e54c173-c1dd-429d-91fa-a6b43c14da77"`
+}
+
+type BufferedTrxItem struct {
+	TrxId        string `json:"trx_id" example:"9e54c173-c1dd-429d-91fa-a6b43c14da77"`
+	Type         string `json:"type" example:"POST"`
+	SenderPubkey string `json:"sender_pubkey" example:"CAISIQOxCH2yVZPR8t6gVvZapxcIPBwMh9jB80pDLNeuA5s8hQ=="`
+	TimeStamp    int64  `json:"timestamp" example:"1634756661280204800"`
+	Age          int64  `json:"age" example:"12"` // seconds since the trx is created
+	DataSize     int    `json:"data_size" example:"1024"`
+}
+
+type TrxBufferResult struct {
+	GroupId     string             `json:"group_id" example:"c0020941-e648-40c9-92dc-682645acd17e"`
+	Length      int                `json:"length" example:"1"`
+	OldestAge   int64              `json:"oldest_age" example:"12"` // seconds
+	SenderLimit int                `json:"sender_limit" example:"100"`
+	GroupLimit  int                `json:"group_limit" example:"10000"`
+	Trxs        []*BufferedTrxItem `json:"trxs"`
+}
+
+type TrxBufferEvictResult struct {
+	GroupId string `json:"group_id" example:"c0020941-e648-40c9-92dc-682645acd17e"`
+	TrxId   string `json:"trx_id" example:"9e54c173-c1dd-429d-91fa-a6b43c14da77"`
+}
+
+type TrxBufferFlushResult struct {
+	GroupId string `json:"group_id" example:"c0020941-e648-40c9-92dc-682645acd17e"`
+	Flushed int    `json:"flushed" example:"1"`
+}
+
+// GetTrxBuffer lists the trxs buffered by producer of the group, oldest first
+func GetTrxBuffer(params *TrxBufferParam) (*TrxBufferResult, error) {
+	group, ok := chain.GetGroupMgr().Groups[params.GroupId]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+
+	trxs, err := consensus.NewTrxBuffer(params.GroupId, group.Nodename).GetAllTrxInBuffer()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixNano()
+	result := &TrxBufferResult{
+		GroupId:     params.GroupId,
+		Length:      len(trxs),
+		SenderLimit: consensus.TRX_BUFFER_SENDER_LIMIT,
+		GroupLimit:  consensus.TRX_BUFFER_GROUP_LIMIT,
+		Trxs:        []*BufferedTrxItem{},
+	}
+	for _, trx := range trxs {
+		age := int64(time.Duration(now - trx.TimeStamp).Seconds())
+		if age > result.OldestAge {
+			result.OldestAge = age
+		}
+		if params.Sender != "" && trx.SenderPubkey != params.Sender {
+			continue
+		}
+		result.Trxs = append(result.Trxs, &BufferedTrxItem{
+			TrxId:        trx.TrxId,
+			Type:         trx.Type.String(),
+			SenderPubkey: trx.SenderPubkey,
+			TimeStamp:    trx.TimeStamp,
+			Age:          age,
+			DataSize:     len(trx.Data),
+		})
+	}
+	sort.Slice(result.Trxs, func(i, j int) bool {
+		return result.Trxs[i].TimeStamp < result.Trxs[j].TimeStamp
+	})
+
+	return result, nil
+}
+
+// EvictTrxFromBuffer removes a trx from the buffer, it will not be proposed by this producer
+func EvictTrxFromBuffer(params *TrxBufferTrxParam) (*TrxBufferEvictResult, error) {
+	group, ok := chain.GetGroupMgr().Groups[params.GroupId]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+
+	if err := consensus.NewTrxBuffer(params.GroupId, group.Nodename).Delete(params.TrxId); err != nil {
+		return nil, err
+	}
+	return &TrxBufferEvictResult{GroupId: params.GroupId, TrxId: params.TrxId}, nil
+}
+
+// FlushTrxBuffer removes all trxs from the buffer, or only the trxs of the sender if given
+func FlushTrxBuffer(params *TrxBufferParam) (*TrxBufferFlushResult, error) {
+	group, ok := chain.GetGroupMgr().Groups[params.GroupId]
+	if !ok {
+		return nil, rumerrors.ErrGroupNotFound
+	}
+
+	buffer := consensus.NewTrxBuffer(params.GroupId, group.Nodename)
+	trxs, err := buffer.GetAllTrxInBuffer()
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Sender == "" {
+		if err := buffer.Clear(); err != nil {
+			return nil, err
+		}
+		return &TrxBufferFlushResult{GroupId: params.GroupId, Flushed: len(trxs)}, nil
+	}
+
+	flushed := 0
+	for _, trx := range trxs {
+		if trx.SenderPubkey != params.Sender {
+			continue
+		}
+		if err := buffer.Delete(trx.TrxId); err != nil {
+			return nil, err
+		}
+		flushed++
+	}
+	return &TrxBufferFlushResult{GroupId: params.GroupId, Flushed: flushed}, nil
+}
@@ -1,9 +1,18 @@
 package consensus
 
 type Config struct {
-	N         int      // participating nodes
-	f         int      // faulty nodes
-	Nodes     []string // pubkey list for all partticipating nodes
-	BatchSize int      // maximum number of trxs will be commited in one epoch
-	MyPubkey  string   // my pubkey
+	N        int            // participating nodes
+	f        int            // faulty nodes
+	Nodes    []string       // pubkey list for all partticipating nodes
+	Packing  *PackingConfig // trx packing policy, batch size and bundle size of the group
+	MyPubkey string         // my pubkey
+}
+
+func (c Config) hasProducer(pubkey string) bool {
+	for _, node := range c.Nodes {
+		if node == pubkey {
+			return true
+		}
+	}
+	return false
 }
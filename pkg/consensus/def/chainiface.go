@@ -8,8 +8,8 @@ import (
 type ChainMolassesIface interface {
 	GetTrxFactory() chaindef.TrxFactoryIface
 	SaveChainInfoToDb() error
-	ApplyTrxsFullNode(trxs []*quorumpb.Trx, nodename string) error
-	ApplyTrxsProducerNode(trxs []*quorumpb.Trx, nodename string) error
+	ApplyTrxsFullNode(trxs []*quorumpb.Trx, blockId uint64, nodename string) error
+	ApplyTrxsProducerNode(trxs []*quorumpb.Trx, blockId uint64, nodename string) error
 	SetCurrEpoch(currEpoch uint64)
 	IncCurrEpoch()
 	GetCurrEpoch() uint64
@@ -18,4 +18,6 @@ type ChainMolassesIface interface {
 	GetCurrBlockId() uint64
 	SetLastUpdate(lastUpdate int64)
 	GetLastUpdate() int64
+	UpdSnapshot()
+	ResolveFork(block *quorumpb.Block) error
 }
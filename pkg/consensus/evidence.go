@@ -0,0 +1,219 @@
+package consensus
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/rumsystem/quorum/internal/pkg/logging"
+	"github.com/rumsystem/quorum/internal/pkg/metric"
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+var evidence_log = logging.Logger("evidence")
+
+// NewEvidence creates the evidence of the 2 conflicting messages signed by offender, the evidence id
+// does not depend on the order of the messages
+func NewEvidence(groupId string, typ quorumpb.EvidenceType, offender string, epoch uint64, msgA, msgB proto.Message) (*quorumpb.Evidence, error) {
+	a, err := proto.Marshal(msgA)
+	if err != nil {
+		return nil, err
+	}
+	b, err := proto.Marshal(msgB)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Compare(a, b) > 0 {
+		a, b = b, a
+	}
+
+	h := sha256.New()
+	h.Write([]byte(groupId))
+	h.Write([]byte(typ.String()))
+	h.Write([]byte(offender))
+	bt := make([]byte, 8)
+	binary.BigEndian.PutUint64(bt, epoch)
+	h.Write(bt)
+	h.Write(localcrypto.Hash(a))
+	h.Write(localcrypto.Hash(b))
+
+	return &quorumpb.Evidence{
+		EvidenceId:     hex.EncodeToString(h.Sum(nil)),
+		GroupId:        groupId,
+		Type:           typ,
+		OffenderPubkey: offender,
+		Epoch:          epoch,
+		MsgA:           a,
+		MsgB:           b,
+		TimeStamp:      time.Now().UnixNano(),
+	}, nil
+}
+
+// VerifyEvidence checks both messages of the evidence are signed by the offender for the same epoch
+// (or BlockId), and they are conflicting
+func VerifyEvidence(evidence *quorumpb.Evidence) error {
+	switch evidence.Type {
+	case quorumpb.EvidenceType_RBC_PROPOSE_EQUIVOCATION:
+		a, b := &quorumpb.InitPropose{}, &quorumpb.InitPropose{}
+		if err := unmarshalEvidence(evidence, a, b); err != nil {
+			return err
+		}
+		for _, p := range []*quorumpb.InitPropose{a, b} {
+			if p.ProposerPubkey != evidence.OffenderPubkey || p.Epoch != evidence.Epoch {
+				return errors.New("InitPropose is not from the offender in the epoch")
+			}
+			if !verifyInitPropose(p) {
+				return errors.New("invalid InitPropose signature")
+			}
+		}
+		return checkConflict(a.RootHash, b.RootHash)
+	case quorumpb.EvidenceType_RBC_ECHO_EQUIVOCATION:
+		a, b := &quorumpb.Echo{}, &quorumpb.Echo{}
+		if err := unmarshalEvidence(evidence, a, b); err != nil {
+			return err
+		}
+		for _, echo := range []*quorumpb.Echo{a, b} {
+			if echo.EchoProviderPubkey != evidence.OffenderPubkey || echo.Epoch != evidence.Epoch || echo.OriginalProposerPubkey != a.OriginalProposerPubkey {
+				return errors.New("ECHO is not from the offender for the same proposer in the epoch")
+			}
+			if !verifyEcho(echo) {
+				return errors.New("invalid ECHO signature")
+			}
+		}
+		return checkConflict(a.RootHash, b.RootHash)
+	case quorumpb.EvidenceType_RBC_READY_EQUIVOCATION:
+		a, b := &quorumpb.Ready{}, &quorumpb.Ready{}
+		if err := unmarshalEvidence(evidence, a, b); err != nil {
+			return err
+		}
+		for _, ready := range []*quorumpb.Ready{a, b} {
+			if ready.ReadyProviderPubkey != evidence.OffenderPubkey || ready.Epoch != evidence.Epoch || ready.OriginalProposerPubkey != a.OriginalProposerPubkey {
+				return errors.New("READY is not from the offender for the same proposer in the epoch")
+			}
+			if !verifyReady(ready) {
+				return errors.New("invalid READY signature")
+			}
+		}
+		return checkConflict(a.RootHash, b.RootHash)
+	case quorumpb.EvidenceType_BLOCK_EQUIVOCATION:
+		a, b := &quorumpb.Block{}, &quorumpb.Block{}
+		if err := unmarshalEvidence(evidence, a, b); err != nil {
+			return err
+		}
+		for _, block := range []*quorumpb.Block{a, b} {
+			if block.ProducerPubkey != evidence.OffenderPubkey || block.BlockId != evidence.Epoch || block.GroupId != evidence.GroupId {
+				return errors.New("block is not produced by the offender with the BlockId")
+			}
+			hash, err := rumchaindata.GetBlockHash(block)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(hash, block.BlockHash) {
+				return errors.New("invalid block hash")
+			}
+			if ok, _ := rumchaindata.VerifyBlockSign(block); !ok {
+				return errors.New("invalid block signature")
+			}
+		}
+		return checkConflict(a.BlockHash, b.BlockHash)
+	default:
+		return fmt.Errorf("unknown evidence type <%s>", evidence.Type.String())
+	}
+}
+
+// ReportEvidence saves the evidence if it is valid and not reported before
+func ReportEvidence(evidence *quorumpb.Evidence, nodename string) error {
+	if err := VerifyEvidence(evidence); err != nil {
+		return err
+	}
+
+	isNew, err := nodectx.GetNodeCtx().GetChainStorage().AddEvidence(evidence, nodename)
+	if err != nil || !isNew {
+		return err
+	}
+
+	evidence_log.Warningf("<%s> producer <%s> misbehaved, <%s> at <%d>, evidence <%s>", evidence.GroupId, evidence.OffenderPubkey, evidence.Type.String(), evidence.Epoch, evidence.EvidenceId)
+	metric.ConsensusEvidenceCount.WithLabelValues(evidence.GroupId, evidence.Type.String()).Inc()
+	return nil
+}
+
+// checkBlockEquivocation reports the evidence if the producer signed another block with the same BlockId
+func checkBlockEquivocation(block *quorumpb.Block, nodename string) {
+	for _, cached := range []bool{false, true} {
+		existed, err := nodectx.GetNodeCtx().GetChainStorage().GetBlock(block.GroupId, block.BlockId, cached, nodename)
+		if err != nil || existed == nil {
+			continue
+		}
+		if existed.ProducerPubkey != block.ProducerPubkey || bytes.Equal(existed.BlockHash, block.BlockHash) {
+			continue
+		}
+
+		evidence, err := NewEvidence(block.GroupId, quorumpb.EvidenceType_BLOCK_EQUIVOCATION, block.ProducerPubkey, block.BlockId, existed, block)
+		if err == nil {
+			err = ReportEvidence(evidence, nodename)
+		}
+		if err != nil {
+			evidence_log.Debugf("<%s> conflicting block <%d> not reported <%s>", block.GroupId, block.BlockId, err.Error())
+		}
+		return
+	}
+}
+
+func unmarshalEvidence(evidence *quorumpb.Evidence, a, b proto.Message) error {
+	if err := proto.Unmarshal(evidence.MsgA, a); err != nil {
+		return err
+	}
+	return proto.Unmarshal(evidence.MsgB, b)
+}
+
+func checkConflict(hashA, hashB []byte) error {
+	if bytes.Equal(hashA, hashB) {
+		return errors.New("messages are not conflicting")
+	}
+	return nil
+}
+
+func verifyInitPropose(initp *quorumpb.InitPropose) bool {
+	msg := proto.Clone(initp).(*quorumpb.InitPropose)
+	msg.ProposerSign = nil
+	return verifyMsgSign(initp.ProposerPubkey, msg, initp.ProposerSign)
+}
+
+func verifyEcho(echo *quorumpb.Echo) bool {
+	msg := proto.Clone(echo).(*quorumpb.Echo)
+	msg.EchoProviderSign = nil
+	return verifyMsgSign(echo.EchoProviderPubkey, msg, echo.EchoProviderSign)
+}
+
+func verifyReady(ready *quorumpb.Ready) bool {
+	msg := proto.Clone(ready).(*quorumpb.Ready)
+	msg.ReadyProviderSign = nil
+	return verifyMsgSign(ready.ReadyProviderPubkey, msg, ready.ReadyProviderSign)
+}
+
+// verifyMsgSign verifies the signature of msg (without signature) signed by the eth key of pubkey
+func verifyMsgSign(pubkey string, msg proto.Message, sign []byte) bool {
+	bytespubkey, err := base64.RawURLEncoding.DecodeString(ethPubkey(pubkey))
+	if err != nil {
+		return false
+	}
+	key, err := ethcrypto.DecompressPubkey(bytespubkey)
+	if err != nil {
+		return false
+	}
+	bbytes, err := proto.Marshal(msg)
+	if err != nil {
+		return false
+	}
+	return localcrypto.EthVerifySign(localcrypto.Hash(bbytes), sign, key)
+}
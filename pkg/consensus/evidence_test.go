@@ -0,0 +1,231 @@
+package consensus
+
+import (
+	"crypto/ecdsa"
+	"encoding/base64"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+const evidenceTestGroupId = "0c5b0a7e-3f8d-4b52-9c1e-6d2f4a8b7e31"
+
+type evidenceSigner struct {
+	key    *ecdsa.PrivateKey
+	pubkey string
+}
+
+func newEvidenceSigner(t *testing.T) *evidenceSigner {
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &evidenceSigner{key: key, pubkey: base64.RawURLEncoding.EncodeToString(ethcrypto.CompressPubkey(&key.PublicKey))}
+}
+
+// sign signs msg as the Make*Message functions, before the signature is set
+func (signer *evidenceSigner) sign(t *testing.T, msg proto.Message) []byte {
+	bbytes, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := ethcrypto.Sign(localcrypto.Hash(bbytes), signer.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func (signer *evidenceSigner) ready(t *testing.T, proposer string, roothash []byte, epoch uint64) *quorumpb.Ready {
+	ready := &quorumpb.Ready{RootHash: roothash, OriginalProposerPubkey: proposer, ReadyProviderPubkey: signer.pubkey, Epoch: epoch}
+	ready.ReadyProviderSign = signer.sign(t, ready)
+	return ready
+}
+
+func (signer *evidenceSigner) block(t *testing.T, blockId uint64, trxId string) *quorumpb.Block {
+	block := &quorumpb.Block{
+		GroupId:        evidenceTestGroupId,
+		BlockId:        blockId,
+		PrevHash:       []byte("parent"),
+		ProducerPubkey: signer.pubkey,
+		Trxs:           []*quorumpb.Trx{{TrxId: trxId, GroupId: evidenceTestGroupId}},
+		TimeStamp:      1,
+	}
+	hash, err := rumchaindata.GetBlockHash(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block.BlockHash = hash
+	sig, err := ethcrypto.Sign(hash, signer.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block.ProducerSign = sig
+	return block
+}
+
+func TestReadyEvidence(t *testing.T) {
+	offender := newEvidenceSigner(t)
+	other := newEvidenceSigner(t)
+
+	a := offender.ready(t, other.pubkey, []byte("root-a"), 7)
+	b := offender.ready(t, other.pubkey, []byte("root-b"), 7)
+	evidence, err := NewEvidence(evidenceTestGroupId, quorumpb.EvidenceType_RBC_READY_EQUIVOCATION, offender.pubkey, 7, a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyEvidence(evidence); err != nil {
+		t.Errorf("expect valid evidence, got %s", err)
+	}
+	if reversed, _ := NewEvidence(evidenceTestGroupId, quorumpb.EvidenceType_RBC_READY_EQUIVOCATION, offender.pubkey, 7, b, a); reversed.EvidenceId != evidence.EvidenceId {
+		t.Errorf("evidence id should not depend on the order of messages")
+	}
+
+	//same root hash is not a conflict
+	same, _ := NewEvidence(evidenceTestGroupId, quorumpb.EvidenceType_RBC_READY_EQUIVOCATION, offender.pubkey, 7, a, offender.ready(t, other.pubkey, []byte("root-a"), 7))
+	if err := VerifyEvidence(same); err == nil {
+		t.Errorf("messages with same root hash should not be an evidence")
+	}
+
+	//messages of different epochs
+	epochs, _ := NewEvidence(evidenceTestGroupId, quorumpb.EvidenceType_RBC_READY_EQUIVOCATION, offender.pubkey, 7, a, offender.ready(t, other.pubkey, []byte("root-b"), 8))
+	if err := VerifyEvidence(epochs); err == nil {
+		t.Errorf("messages of different epochs should not be an evidence")
+	}
+
+	//can not frame other producers
+	forged := other.ready(t, other.pubkey, []byte("root-b"), 7)
+	forged.ReadyProviderPubkey = offender.pubkey
+	framed, _ := NewEvidence(evidenceTestGroupId, quorumpb.EvidenceType_RBC_READY_EQUIVOCATION, offender.pubkey, 7, a, forged)
+	if err := VerifyEvidence(framed); err == nil {
+		t.Errorf("message not signed by the offender should not be an evidence")
+	}
+}
+
+func TestProposeEvidenceFromEcho(t *testing.T) {
+	proposer := newEvidenceSigner(t)
+	echoProvider := newEvidenceSigner(t)
+
+	newInitPropose := func(root []byte, recv string) *quorumpb.InitPropose {
+		initp := &quorumpb.InitPropose{RootHash: root, Proof: [][]byte{[]byte("shard")}, Leaves: 4, OriginalDataSize: 5, RecvNodePubkey: recv, ProposerPubkey: proposer.pubkey, Epoch: 3}
+		initp.ProposerSign = proposer.sign(t, initp)
+		return initp
+	}
+
+	//the InitPropose received by the echo provider can be recovered from its ECHO
+	initp := newInitPropose([]byte("root-a"), echoProvider.pubkey)
+	echo := &quorumpb.Echo{
+		RootHash:               initp.RootHash,
+		Proof:                  initp.Proof,
+		Index:                  initp.Index,
+		Leaves:                 initp.Leaves,
+		OriginalDataSize:       initp.OriginalDataSize,
+		OriginalProposerPubkey: initp.ProposerPubkey,
+		EchoProviderPubkey:     echoProvider.pubkey,
+		ProposerSign:           initp.ProposerSign,
+		Epoch:                  initp.Epoch,
+	}
+	echo.EchoProviderSign = echoProvider.sign(t, echo)
+	if !verifyEcho(echo) {
+		t.Fatalf("invalid echo signature")
+	}
+	recovered := InitProposeFromEcho(echo)
+	if !verifyInitPropose(recovered) {
+		t.Fatalf("invalid signature of the InitPropose recovered from echo")
+	}
+
+	mine := newInitPropose([]byte("root-b"), "me")
+	evidence, err := NewEvidence(evidenceTestGroupId, quorumpb.EvidenceType_RBC_PROPOSE_EQUIVOCATION, proposer.pubkey, 3, mine, recovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyEvidence(evidence); err != nil {
+		t.Errorf("expect valid evidence, got %s", err)
+	}
+}
+
+func TestBlockEvidence(t *testing.T) {
+	producer := newEvidenceSigner(t)
+
+	a := producer.block(t, 10, "trx-a")
+	b := producer.block(t, 10, "trx-b")
+	evidence, _ := NewEvidence(evidenceTestGroupId, quorumpb.EvidenceType_BLOCK_EQUIVOCATION, producer.pubkey, 10, a, b)
+	if err := VerifyEvidence(evidence); err != nil {
+		t.Errorf("expect valid evidence, got %s", err)
+	}
+
+	//blocks of different BlockId
+	c := producer.block(t, 11, "trx-b")
+	evidence, _ = NewEvidence(evidenceTestGroupId, quorumpb.EvidenceType_BLOCK_EQUIVOCATION, producer.pubkey, 10, a, c)
+	if err := VerifyEvidence(evidence); err == nil {
+		t.Errorf("blocks with different BlockId should not be an evidence")
+	}
+
+	//block content changed after signed
+	b.Trxs[0].TrxId = "trx-c"
+	evidence, _ = NewEvidence(evidenceTestGroupId, quorumpb.EvidenceType_BLOCK_EQUIVOCATION, producer.pubkey, 10, a, b)
+	if err := VerifyEvidence(evidence); err == nil {
+		t.Errorf("block with invalid hash should not be an evidence")
+	}
+}
+
+func TestTrxRBCVerifySign(t *testing.T) {
+	proposer := newEvidenceSigner(t)
+	echoProvider := newEvidenceSigner(t)
+	r := &TrxRBC{}
+
+	initp := &quorumpb.InitPropose{RootHash: []byte("root-a"), Proof: [][]byte{[]byte("shard")}, Leaves: 4, OriginalDataSize: 5, RecvNodePubkey: echoProvider.pubkey, ProposerPubkey: proposer.pubkey, Epoch: 3}
+	initp.ProposerSign = proposer.sign(t, initp)
+	if !r.VerifySign(initp) {
+		t.Errorf("expect valid InitPropose signature")
+	}
+
+	newEcho := func(initp *quorumpb.InitPropose) *quorumpb.Echo {
+		echo := &quorumpb.Echo{
+			RootHash:               initp.RootHash,
+			Proof:                  initp.Proof,
+			Index:                  initp.Index,
+			Leaves:                 initp.Leaves,
+			OriginalDataSize:       initp.OriginalDataSize,
+			OriginalProposerPubkey: initp.ProposerPubkey,
+			EchoProviderPubkey:     echoProvider.pubkey,
+			ProposerSign:           initp.ProposerSign,
+			Epoch:                  initp.Epoch,
+		}
+		echo.EchoProviderSign = echoProvider.sign(t, echo)
+		return echo
+	}
+	if !r.VerifySign(newEcho(initp)) {
+		t.Errorf("expect valid ECHO signature")
+	}
+
+	ready := echoProvider.ready(t, proposer.pubkey, initp.RootHash, 3)
+	if !r.VerifySign(ready) {
+		t.Errorf("expect valid READY signature")
+	}
+
+	//epoch is signed
+	replayed := proto.Clone(ready).(*quorumpb.Ready)
+	replayed.Epoch = 4
+	if r.VerifySign(replayed) {
+		t.Errorf("READY of another epoch should be rejected")
+	}
+
+	//the echo provider can not change the root hash of the proposer
+	forged := proto.Clone(initp).(*quorumpb.InitPropose)
+	forged.RootHash = []byte("root-b")
+	if r.VerifySign(newEcho(forged)) {
+		t.Errorf("ECHO with a root hash not signed by the proposer should be rejected")
+	}
+
+	//signed by another producer
+	framed := proto.Clone(initp).(*quorumpb.InitPropose)
+	framed.ProposerSign = echoProvider.sign(t, initp)
+	if r.VerifySign(framed) {
+		t.Errorf("InitPropose not signed by the proposer should be rejected")
+	}
+}
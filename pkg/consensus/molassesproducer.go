@@ -11,11 +11,12 @@ import (
 var molaproducer_log = logging.Logger("producer")
 
 type MolassesProducer struct {
-	grpItem  *quorumpb.GroupItem
-	nodename string
-	cIface   def.ChainMolassesIface
-	groupId  string
-	bft      *TrxBft
+	grpItem   *quorumpb.GroupItem
+	nodename  string
+	cIface    def.ChainMolassesIface
+	groupId   string
+	bft       *TrxBft
+	transport Transport
 }
 
 func (producer *MolassesProducer) NewProducer(item *quorumpb.GroupItem, nodename string, iface def.ChainMolassesIface) {
@@ -24,6 +25,7 @@ func (producer *MolassesProducer) NewProducer(item *quorumpb.GroupItem, nodename
 	producer.cIface = iface
 	producer.nodename = nodename
 	producer.groupId = item.GroupId
+	producer.transport = &ConnTransport{}
 
 	config, err := producer.createBftConfig()
 	if err != nil {
@@ -34,6 +36,11 @@ func (producer *MolassesProducer) NewProducer(item *quorumpb.GroupItem, nodename
 	producer.bft = NewTrxBft(*config, producer)
 }
 
+// SetTransport replaces the transport to send consensus messages and blocks, should be called before StartPropose
+func (producer *MolassesProducer) SetTransport(transport Transport) {
+	producer.transport = transport
+}
+
 func (producer *MolassesProducer) StartPropose() {
 	molaproducer_log.Debug("StartPropose called")
 	producer_nodes, err := nodectx.GetNodeCtx().GetChainStorage().GetProducers(producer.groupId, producer.nodename)
@@ -55,16 +62,16 @@ func (producer *MolassesProducer) StartPropose() {
 	}
 }
 
+// RecreateBft is called when the producer list changed, the new list is loaded by the propose task of next epoch,
+// so the epoch in progress is finished by the old producers. The node starts to propose if it just became a producer
 func (producer *MolassesProducer) RecreateBft() {
-	molaproducer_log.Debug("RecreateBft called")
-	config, err := producer.createBftConfig()
-	if err != nil {
-		molaproducer_log.Errorf("recreate bft failed")
-		molaproducer_log.Error(err.Error())
+	molaproducer_log.Debugf("<%s> RecreateBft called", producer.groupId)
+	if producer.bft == nil {
 		return
 	}
-
-	producer.bft = NewTrxBft(*config, producer)
+	if producer.bft.status == IDLE {
+		producer.StartPropose()
+	}
 }
 
 func (producer *MolassesProducer) createBftConfig() (*Config, error) {
@@ -89,19 +96,16 @@ func (producer *MolassesProducer) createBftConfig() (*Config, error) {
 
 	molaproducer_log.Debugf("Failable node <%d>", f)
 
-	//use fixed scalar size
-	scalar := 20
-	//batchSize := (len(nodes) * 2) * scalar
-	batchSize := scalar
-
-	molaproducer_log.Debugf("batchSize <%d>", batchSize)
+	//batch size and packing policy are set by group owner with chain config
+	packing := LoadPackingConfig(producer.groupId, producer.nodename)
+	molaproducer_log.Debugf("packing policy <%s>, batchSize <%d>", packing.Policy.Name(), packing.BatchSize)
 
 	config := &Config{
-		N:         N,
-		f:         f,
-		Nodes:     nodes,
-		BatchSize: batchSize,
-		MyPubkey:  producer.grpItem.UserSignPubkey,
+		N:        N,
+		f:        f,
+		Nodes:    nodes,
+		Packing:  packing,
+		MyPubkey: producer.grpItem.UserSignPubkey,
 	}
 
 	return config, nil
@@ -110,10 +114,16 @@ func (producer *MolassesProducer) createBftConfig() (*Config, error) {
 func (producer *MolassesProducer) AddBlock(block *quorumpb.Block) error {
 	molaproducer_log.Debugf("<%s> AddBlock called, BlockId <%d>", producer.groupId, block.BlockId)
 
+	//report the producer signed another block with the same BlockId
+	checkBlockEquivocation(block, producer.nodename)
+
 	//check if block exist
 	blockExist, _ := nodectx.GetNodeCtx().GetChainStorage().IsBlockExist(block.GroupId, block.BlockId, false, producer.nodename)
 	if blockExist {
-		molaproducer_log.Debugf("Block exist, ignore")
+		//the chain checks if the block conflicts with the saved one
+		if err := producer.cIface.ResolveFork(block); err != nil {
+			molaproducer_log.Warningf("<%s> resolve fork at block <%d> failed <%s>", producer.groupId, block.BlockId, err.Error())
+		}
 	} else {
 		//check if block cached
 		isBlockCatched, _ := nodectx.GetNodeCtx().GetChainStorage().IsBlockExist(block.GroupId, block.BlockId, true, producer.nodename)
@@ -178,6 +188,14 @@ func (producer *MolassesProducer) AddBlock(block *quorumpb.Block) error {
 					return err
 				}
 
+				//trxs packaged by other producers should not be proposed again
+				producer.bft.removePackaged(blk.Trxs)
+
+				//apply trxs before moving to the epoch of block, the producer list changed by them is used by next epoch
+				if err := producer.cIface.ApplyTrxsProducerNode(blk.Trxs, blk.BlockId, producer.nodename); err != nil {
+					return err
+				}
+
 				if blk.BlockId > producer.cIface.GetCurrBlockId() {
 					//update latest group info
 					molaproducer_log.Debugf("<%s> UpdChainInfo, blockId from <%d> to <%d>",
@@ -196,16 +214,6 @@ func (producer *MolassesProducer) AddBlock(block *quorumpb.Block) error {
 					}
 				}
 			}
-
-			//get all trxs from blocks
-			var trxs []*quorumpb.Trx
-			trxs, err = rumchaindata.GetAllTrxs(blockfromcache)
-			if err != nil {
-				return err
-			}
-
-			//apply trxs
-			return producer.cIface.ApplyTrxsProducerNode(trxs, producer.nodename)
 		}
 	}
 	return nil
@@ -0,0 +1,417 @@
+package consensus
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	chaindef "github.com/rumsystem/quorum/internal/pkg/chainsdk/def"
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	"github.com/rumsystem/quorum/internal/pkg/storage"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
+	"github.com/rumsystem/quorum/pkg/consensus/simnet"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+const simTestGroupId = "7a1c3e5f-2b4d-4c6e-8f0a-1b2c3d4e5f60"
+
+// simKeystore signs with the key of each simulated node, the nodename is given as the option
+type simKeystore struct {
+	localcrypto.Keystore
+	keys map[string]*ecdsa.PrivateKey
+}
+
+func (ks *simKeystore) EthSignByKeyName(keyname string, digestHash []byte, opts ...string) ([]byte, error) {
+	if len(opts) == 0 {
+		return nil, fmt.Errorf("nodename is required to sign with key <%s>", keyname)
+	}
+	key, ok := ks.keys[opts[0]]
+	if !ok {
+		return nil, fmt.Errorf("no key of node <%s>", opts[0])
+	}
+	return ethcrypto.Sign(digestHash, key)
+}
+
+// simChain keeps the chain info of a simulated node
+type simChain struct {
+	mu         sync.Mutex
+	epoch      uint64
+	blockId    uint64
+	lastUpdate int64
+	onEpoch    func(epoch uint64) //called when the epoch changed, as the chain activates scheduled producers
+}
+
+func (c *simChain) GetTrxFactory() chaindef.TrxFactoryIface { return nil }
+func (c *simChain) SaveChainInfoToDb() error                { return nil }
+func (c *simChain) UpdSnapshot()                            {}
+
+func (c *simChain) ResolveFork(block *quorumpb.Block) error { return nil }
+
+func (c *simChain) ApplyTrxsFullNode(trxs []*quorumpb.Trx, blockId uint64, nodename string) error {
+	return nil
+}
+
+func (c *simChain) ApplyTrxsProducerNode(trxs []*quorumpb.Trx, blockId uint64, nodename string) error {
+	return nil
+}
+
+func (c *simChain) SetCurrEpoch(epoch uint64) {
+	c.mu.Lock()
+	c.epoch = epoch
+	c.mu.Unlock()
+	c.epochChanged()
+}
+
+func (c *simChain) IncCurrEpoch() {
+	c.mu.Lock()
+	c.epoch++
+	c.mu.Unlock()
+	c.epochChanged()
+}
+
+func (c *simChain) GetCurrEpoch() uint64     { c.mu.Lock(); defer c.mu.Unlock(); return c.epoch }
+func (c *simChain) SetCurrBlockId(id uint64) { c.mu.Lock(); c.blockId = id; c.mu.Unlock() }
+func (c *simChain) IncCurrBlockId()          { c.mu.Lock(); c.blockId++; c.mu.Unlock() }
+func (c *simChain) GetCurrBlockId() uint64   { c.mu.Lock(); defer c.mu.Unlock(); return c.blockId }
+func (c *simChain) SetLastUpdate(t int64)    { c.mu.Lock(); c.lastUpdate = t; c.mu.Unlock() }
+func (c *simChain) GetLastUpdate() int64     { c.mu.Lock(); defer c.mu.Unlock(); return c.lastUpdate }
+
+func (c *simChain) epochChanged() {
+	if c.onEpoch != nil {
+		c.onEpoch(c.GetCurrEpoch())
+	}
+}
+
+// simReceiver accepts the blocks produced by the group owner only, as the chain does
+type simReceiver struct {
+	*MolassesProducer
+	owner string
+}
+
+func (r *simReceiver) AddBlock(block *quorumpb.Block) error {
+	if block.ProducerPubkey != r.owner {
+		return nil
+	}
+	return r.MolassesProducer.AddBlock(block)
+}
+
+type simNode struct {
+	nodename string
+	pubkey   string
+	producer *MolassesProducer
+	chain    *simChain
+	endpoint *simnet.Endpoint
+}
+
+type simCluster struct {
+	net   *simnet.Network
+	ks    *simKeystore
+	nodes []*simNode
+}
+
+// newSimCluster creates n producers of a group connected by the simulated network, all of them share
+// the storage of the node context with their own nodename
+func newSimCluster(t *testing.T, n int, cfg simnet.Config) *simCluster {
+	return newSimClusterWithProducers(t, n, n, cfg)
+}
+
+// newSimClusterWithProducers creates n nodes, the first producers of them are in the producer list
+func newSimClusterWithProducers(t *testing.T, n, producers int, cfg simnet.Config) *simCluster {
+	dir := t.TempDir()
+	groupDb, err := storage.OpenStore(context.Background(), storage.BoltBackend, dir, "groups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataDb, err := storage.OpenStore(context.Background(), storage.BoltBackend, dir, "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbMgr := &storage.DbMgr{GroupInfoDb: groupDb, Db: dataDb, DataPath: dir}
+	nodectx.InitCtx(context.Background(), "sim", nil, dbMgr, chainstorage.NewChainStorage(dbMgr), "pubsub", "", nodectx.PRODUCER_NODE)
+
+	oldKs, oldPulse := localcrypto.GetKeystore(), DEFAULT_PROPOSE_PULSE
+	DEFAULT_PROPOSE_PULSE = 100
+	cluster := &simCluster{net: simnet.New(cfg), ks: &simKeystore{keys: make(map[string]*ecdsa.PrivateKey)}}
+	localcrypto.SetKeystore(cluster.ks)
+
+	for i := 0; i < n; i++ {
+		key, err := ethcrypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		node := &simNode{
+			nodename: fmt.Sprintf("sim%d", i),
+			pubkey:   base64.RawURLEncoding.EncodeToString(ethcrypto.CompressPubkey(&key.PublicKey)),
+			chain:    &simChain{},
+		}
+		cluster.ks.keys[node.nodename] = key
+		cluster.nodes = append(cluster.nodes, node)
+	}
+
+	genesis := &quorumpb.Block{GroupId: simTestGroupId, BlockId: 0, BlockHash: []byte("genesis"), TimeStamp: 1}
+	for _, node := range cluster.nodes {
+		if err := nodectx.GetNodeCtx().GetChainStorage().AddBlock(genesis, false, node.nodename); err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range cluster.nodes[:producers] {
+			item := &quorumpb.ProducerItem{GroupId: simTestGroupId, ProducerPubkey: p.pubkey, GroupOwnerPubkey: cluster.nodes[0].pubkey}
+			if err := nodectx.GetNodeCtx().GetChainStorage().AddProducer(item, node.nodename); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		item := &quorumpb.GroupItem{GroupId: simTestGroupId, OwnerPubKey: cluster.nodes[0].pubkey, UserSignPubkey: node.pubkey}
+		node.producer = &MolassesProducer{}
+		node.producer.NewProducer(item, node.nodename, node.chain)
+		node.endpoint = cluster.net.Join(node.nodename, &simReceiver{node.producer, item.OwnerPubKey})
+		node.producer.SetTransport(node.endpoint)
+	}
+
+	t.Cleanup(func() {
+		cluster.net.Stop()
+		for _, node := range cluster.nodes {
+			node.producer.bft.StopPropose()
+		}
+		DEFAULT_PROPOSE_PULSE = oldPulse
+		localcrypto.SetKeystore(oldKs)
+	})
+	return cluster
+}
+
+func (c *simCluster) start() {
+	for _, node := range c.nodes {
+		node.producer.StartPropose()
+	}
+}
+
+// addTrxs sends the trxs to all producers, as they are broadcasted to the producer channel
+func (c *simCluster) addTrxs(prefix string, n int) {
+	for i := 0; i < n; i++ {
+		trx := &quorumpb.Trx{
+			TrxId:        fmt.Sprintf("%s-%d", prefix, i),
+			GroupId:      simTestGroupId,
+			Type:         quorumpb.TrxType_POST,
+			SenderPubkey: fmt.Sprintf("user-%d", i%3),
+			Data:         []byte(prefix),
+			TimeStamp:    time.Now().UnixNano(),
+		}
+		for _, node := range c.nodes {
+			node.producer.AddTrx(proto.Clone(trx).(*quorumpb.Trx))
+		}
+	}
+}
+
+// packaged returns the number of trxs in the blocks on chain of the node
+func (node *simNode) packaged() int {
+	count := 0
+	for blockId := uint64(1); blockId <= node.chain.GetCurrBlockId(); blockId++ {
+		block, err := nodectx.GetNodeCtx().GetChainStorage().GetBlock(simTestGroupId, blockId, false, node.nodename)
+		if err == nil {
+			count += len(block.Trxs)
+		}
+	}
+	return count
+}
+
+// waitPackaged waits till all trxs sent are packaged by the honest nodes
+func (c *simCluster) waitPackaged(t *testing.T, honest []*simNode, total int) {
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		done := true
+		for _, node := range honest {
+			if node.packaged() < total {
+				done = false
+			}
+		}
+		if done {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	for _, node := range honest {
+		t.Errorf("node <%s> at epoch <%d> packaged <%d> of <%d> trxs", node.nodename, node.chain.GetCurrEpoch(), node.packaged(), total)
+	}
+	t.FailNow()
+}
+
+// checkSafety checks the honest nodes have the same trxs in the blocks of each BlockId and epoch,
+// and each trx is packaged once
+func (c *simCluster) checkSafety(t *testing.T, honest []*simNode) {
+	var expect []string
+	for i, node := range honest {
+		var blocks []string
+		packaged := make(map[string]uint64)
+		for blockId := uint64(1); blockId <= node.chain.GetCurrBlockId(); blockId++ {
+			block, err := nodectx.GetNodeCtx().GetChainStorage().GetBlock(simTestGroupId, blockId, false, node.nodename)
+			if err != nil {
+				t.Fatalf("node <%s> get block <%d> failed: %s", node.nodename, blockId, err)
+			}
+			var ids []string
+			for _, trx := range block.Trxs {
+				if prev, ok := packaged[trx.TrxId]; ok {
+					t.Errorf("node <%s> trx <%s> packaged in both block <%d> and <%d>", node.nodename, trx.TrxId, prev, blockId)
+				}
+				packaged[trx.TrxId] = blockId
+				ids = append(ids, trx.TrxId)
+			}
+			blocks = append(blocks, fmt.Sprintf("%d@%d:%v", block.BlockId, block.Epoch, ids))
+		}
+		if i == 0 {
+			expect = blocks
+			continue
+		}
+		if fmt.Sprint(blocks) != fmt.Sprint(expect) {
+			t.Errorf("node <%s> has blocks %v, node <%s> has %v", node.nodename, blocks, honest[0].nodename, expect)
+		}
+	}
+}
+
+func TestSimMolassesLatencyAndReorder(t *testing.T) {
+	cluster := newSimCluster(t, 4, simnet.Config{Seed: 1, MinLatency: 2 * time.Millisecond, MaxLatency: 10 * time.Millisecond, ReorderRate: 0.2})
+	cluster.addTrxs("a", 10)
+	cluster.start()
+	cluster.waitPackaged(t, cluster.nodes, 10)
+
+	cluster.addTrxs("b", 10)
+	cluster.waitPackaged(t, cluster.nodes, 20)
+	cluster.checkSafety(t, cluster.nodes)
+	if stats := cluster.net.Stats(); stats.Dropped != 0 || stats.Delivered == 0 {
+		t.Errorf("unexpected network stats %+v", stats)
+	}
+}
+
+func TestSimMolassesSilentProducer(t *testing.T) {
+	//f = 1 for 4 producers, the others go on without the crashed one
+	cluster := newSimCluster(t, 4, simnet.Config{Seed: 2, MinLatency: 2 * time.Millisecond, MaxLatency: 10 * time.Millisecond, ReorderRate: 0.1})
+	cluster.nodes[3].endpoint.SetSilent(true)
+	honest := cluster.nodes[:3]
+
+	cluster.addTrxs("a", 10)
+	cluster.start()
+	cluster.waitPackaged(t, honest, 10)
+	cluster.checkSafety(t, honest)
+
+	for _, trace := range GetEpochTraces(simTestGroupId) {
+		if p := trace.Proposers[cluster.nodes[3].pubkey]; p != nil && p.RbcDone {
+			t.Errorf("rbc of the silent producer should not be done at epoch <%d>", trace.Epoch)
+		}
+	}
+}
+
+func TestSimMolassesEquivocatingProducer(t *testing.T) {
+	cluster := newSimCluster(t, 4, simnet.Config{Seed: 3, MinLatency: 2 * time.Millisecond, MaxLatency: 10 * time.Millisecond})
+	byzantine := cluster.nodes[3]
+	honest := cluster.nodes[:3]
+
+	//the byzantine producer sends another READY with a fake root hash for each READY it sends
+	byzantine.endpoint.SetFault(func(to string, hbmsg *quorumpb.HBMsgv1) []*quorumpb.HBMsgv1 {
+		rbcMsg := &quorumpb.RBCMsg{}
+		ready := &quorumpb.Ready{}
+		if err := proto.Unmarshal(hbmsg.Payload, rbcMsg); err != nil || rbcMsg.Type != quorumpb.RBCMsgType_READY {
+			return []*quorumpb.HBMsgv1{hbmsg}
+		}
+		if err := proto.Unmarshal(rbcMsg.Payload, ready); err != nil {
+			return []*quorumpb.HBMsgv1{hbmsg}
+		}
+		fake, err := MakeRBCReadyMessage(simTestGroupId, byzantine.nodename, byzantine.pubkey, ready.OriginalProposerPubkey, []byte("fake root hash"), ready.Epoch)
+		if err != nil {
+			return []*quorumpb.HBMsgv1{hbmsg}
+		}
+		fakeb, _ := proto.Marshal(fake)
+		return []*quorumpb.HBMsgv1{hbmsg, {MsgId: hbmsg.MsgId + "-fake", Epoch: hbmsg.Epoch, PayloadType: hbmsg.PayloadType, Payload: fakeb}}
+	})
+
+	cluster.addTrxs("a", 10)
+	cluster.start()
+	cluster.waitPackaged(t, honest, 10)
+	cluster.checkSafety(t, honest)
+
+	for _, node := range honest {
+		evidences, err := nodectx.GetNodeCtx().GetChainStorage().GetEvidences(simTestGroupId, node.nodename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(evidences) == 0 {
+			t.Errorf("node <%s> should report the equivocation", node.nodename)
+		}
+		for _, evidence := range evidences {
+			if evidence.OffenderPubkey != byzantine.pubkey || evidence.Type != quorumpb.EvidenceType_RBC_READY_EQUIVOCATION {
+				t.Errorf("node <%s> reported unexpected evidence %v", node.nodename, evidence)
+			}
+		}
+	}
+}
+
+func TestSimMolassesScheduledProducers(t *testing.T) {
+	//sim3 leaves and sim4 joins the producers at the activate epoch, the epochs in progress are not dropped
+	cluster := newSimClusterWithProducers(t, 5, 4, simnet.Config{Seed: 4, MinLatency: 2 * time.Millisecond, MaxLatency: 10 * time.Millisecond, ReorderRate: 0.1})
+	chainStorage := nodectx.GetNodeCtx().GetChainStorage()
+	if err := chainStorage.AddGroup(&quorumpb.GroupItem{GroupId: simTestGroupId, OwnerPubKey: cluster.nodes[0].pubkey}); err != nil {
+		t.Fatal(err)
+	}
+	leaving, joining := cluster.nodes[3], cluster.nodes[4]
+	for _, node := range cluster.nodes {
+		node := node
+		node.chain.onEpoch = func(epoch uint64) {
+			if activated, err := chainStorage.ActivateScheduledProducers(simTestGroupId, epoch+1, node.nodename); err == nil && activated {
+				node.producer.RecreateBft()
+			}
+		}
+	}
+
+	cluster.addTrxs("a", 10)
+	cluster.start()
+	cluster.waitPackaged(t, cluster.nodes[:4], 10)
+
+	//the PRODUCER trx is applied by all nodes in the same block
+	activateEpoch := cluster.nodes[0].chain.GetCurrEpoch() + 5
+	bundle := &quorumpb.BFTProducerBundleItem{ActivateEpoch: activateEpoch}
+	newProducers := []*simNode{cluster.nodes[0], cluster.nodes[1], cluster.nodes[2], joining}
+	for _, node := range newProducers {
+		bundle.Producers = append(bundle.Producers, &quorumpb.ProducerItem{GroupId: simTestGroupId, ProducerPubkey: node.pubkey})
+	}
+	data, _ := proto.Marshal(bundle)
+	for _, node := range cluster.nodes {
+		trx := &quorumpb.Trx{GroupId: simTestGroupId, TrxId: "trx-producer", Type: quorumpb.TrxType_PRODUCER, Data: data}
+		if err := chainStorage.UpdateProducerTrx(trx, node.nodename); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cluster.addTrxs("b", 10)
+	cluster.waitPackaged(t, newProducers, 20)
+	cluster.checkSafety(t, newProducers)
+
+	//trxs sent after the activate epoch are packaged by the new producers only, the joining node
+	//starts to propose once it gets a block of the new epochs
+	deadline := time.Now().Add(30 * time.Second)
+	for cluster.nodes[0].chain.GetCurrEpoch() < activateEpoch && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	cluster.addTrxs("c", 10)
+	cluster.waitPackaged(t, newProducers, 30)
+	cluster.checkSafety(t, newProducers)
+	deadline = time.Now().Add(5 * time.Second)
+	for joining.producer.bft.status != RUNNING && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if joining.producer.bft.status != RUNNING {
+		t.Errorf("joining node should propose after epoch <%d>", activateEpoch)
+	}
+
+	for _, trace := range GetEpochTraces(simTestGroupId) {
+		if trace.Epoch <= activateEpoch {
+			continue
+		}
+		if p := trace.Proposers[leaving.pubkey]; p != nil {
+			t.Errorf("sim3 should not propose after epoch <%d>, got epoch <%d>", activateEpoch, trace.Epoch)
+		}
+	}
+}
@@ -28,11 +28,16 @@ func (user *MolassesUser) NewUser(item *quorumpb.GroupItem, nodename string, ifa
 func (user *MolassesUser) AddBlock(block *quorumpb.Block) error {
 	molauser_log.Debugf("<%s> AddBlock called, BlockId <%d>", user.groupId, block.BlockId)
 
+	//report the producer signed another block with the same BlockId
+	checkBlockEquivocation(block, user.nodename)
+
 	//check if block exist
 	blockExist, _ := nodectx.GetNodeCtx().GetChainStorage().IsBlockExist(block.GroupId, block.BlockId, false, user.nodename)
-	if blockExist { // check if we need to apply trxs again
-		// block already saved
-		molauser_log.Debugf("Block exist, ignore")
+	if blockExist {
+		//the chain checks if the block conflicts with the saved one
+		if err := user.cIface.ResolveFork(block); err != nil {
+			molauser_log.Warningf("<%s> resolve fork at block <%d> failed <%s>", user.groupId, block.BlockId, err.Error())
+		}
 	} else {
 		//check if block cached
 		isBlockCatched, _ := nodectx.GetNodeCtx().GetChainStorage().IsBlockExist(block.GroupId, block.BlockId, true, user.nodename)
@@ -97,6 +102,12 @@ func (user *MolassesUser) AddBlock(block *quorumpb.Block) error {
 					return err
 				}
 
+				//apply trxs before moving to the epoch of block, the producer list changed by them is used by next epoch
+				err = user.cIface.ApplyTrxsFullNode(bc.Trxs, bc.BlockId, user.nodename)
+				if err != nil {
+					return err
+				}
+
 				if bc.BlockId > user.cIface.GetCurrBlockId() {
 					//update latest group epoch
 					molauser_log.Debugf("<%s> UpdChainInfo, upd highest blockId from <%d> to <%d>", user.groupId, user.cIface.GetCurrBlockId(), bc.BlockId)
@@ -105,18 +116,11 @@ func (user *MolassesUser) AddBlock(block *quorumpb.Block) error {
 					user.cIface.SetLastUpdate(bc.TimeStamp)
 					user.cIface.SaveChainInfoToDb()
 				}
-
-			}
-
-			//get all trxs from blocks
-			var trxs []*quorumpb.Trx
-			trxs, err = rumchaindata.GetAllTrxs(blockfromcache)
-			if err != nil {
-				return err
 			}
 
-			//apply trxs
-			return user.cIface.ApplyTrxsFullNode(trxs, user.nodename)
+			//write state snapshot and prune old blocks
+			user.cIface.UpdSnapshot()
+			return nil
 		}
 	}
 	return nil
@@ -2,17 +2,11 @@ package consensus
 
 import (
 	guuid "github.com/google/uuid"
-	"github.com/rumsystem/quorum/internal/pkg/conn"
 	quorumpb "github.com/rumsystem/quorum/pkg/pb"
 	"google.golang.org/protobuf/proto"
 )
 
-func SendHBRBCMsg(groupId string, msg *quorumpb.RBCMsg, epoch uint64) error {
-	connMgr, err := conn.GetConn().GetConnMgr(groupId)
-	if err != nil {
-		return err
-	}
-
+func SendHBRBCMsg(transport Transport, groupId string, msg *quorumpb.RBCMsg, epoch uint64) error {
 	rbcb, err := proto.Marshal(msg)
 	if err != nil {
 		return err
@@ -25,10 +19,10 @@ func SendHBRBCMsg(groupId string, msg *quorumpb.RBCMsg, epoch uint64) error {
 		Payload:     rbcb,
 	}
 
-	return connMgr.BroadcastHBMsg(hbmsg)
+	return transport.BroadcastHBMsg(groupId, hbmsg)
 }
 
-func SendHBAABMsg(groupId string, msg *quorumpb.BBAMsg, epoch int64) error {
+func SendHBAABMsg(transport Transport, groupId string, msg *quorumpb.BBAMsg, epoch int64) error {
 	//TBD
 	return nil
 }
@@ -0,0 +1,221 @@
+package consensus
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+var DEFAULT_BATCH_SIZE = 20 // maximum number of trxs will be proposed in one epoch
+
+// FifoPolicy samples the trxs to propose from the earliest BatchSize * PACKING_CANDIDATE_FACTOR trxs,
+// so the producers propose different trxs in the same epoch
+var PACKING_CANDIDATE_FACTOR = 3
+
+// trxs with these types are packaged first by TRX_TYPE_PRIORITY policy, in this order
+var PRIORITY_TRX_TYPES = []quorumpb.TrxType{
+	quorumpb.TrxType_CHAIN_CONFIG,
+	quorumpb.TrxType_PRODUCER,
+	quorumpb.TrxType_USER,
+	quorumpb.TrxType_GROUP_KEY,
+}
+
+// PackingPolicy decides which trxs in buffer are proposed in an epoch,
+// and the order of the trxs agreed by producers when they are packaged into block
+type PackingPolicy interface {
+	Name() string
+	// Select returns at most n trxs from the trxs in buffer, which are in the order they are received
+	Select(trxs []*quorumpb.Trx, n int) []*quorumpb.Trx
+	// Sort returns the trxs in the order they are packaged
+	Sort(trxs map[string]*quorumpb.Trx, ownerPubkey string) []*quorumpb.Trx
+}
+
+// PackingConfig is the packing policy and limits of a group, set by group owner with chain config
+type PackingConfig struct {
+	Policy         PackingPolicy
+	BatchSize      int // maximum number of trxs will be proposed in one epoch
+	MaxBundleBytes int // maximum size of the trxs packaged in one block
+}
+
+func GetPackingPolicy(policyType quorumpb.PackingPolicyType) PackingPolicy {
+	switch policyType {
+	case quorumpb.PackingPolicyType_SENDER_ROUND_ROBIN:
+		return &RoundRobinPolicy{}
+	case quorumpb.PackingPolicyType_TRX_TYPE_PRIORITY:
+		return &TypePriorityPolicy{}
+	default:
+		return &FifoPolicy{}
+	}
+}
+
+// NewPackingConfig applies the default values to the limits not specified, the bundle size is
+// never larger than MAXIMUM_TRX_BUNDLE_LENGTH
+func NewPackingConfig(item *quorumpb.SetPackingPolicyItem) *PackingConfig {
+	config := &PackingConfig{
+		Policy:         &FifoPolicy{},
+		BatchSize:      DEFAULT_BATCH_SIZE,
+		MaxBundleBytes: MAXIMUM_TRX_BUNDLE_LENGTH,
+	}
+	if item == nil {
+		return config
+	}
+
+	config.Policy = GetPackingPolicy(item.Policy)
+	if item.BatchSize > 0 {
+		config.BatchSize = int(item.BatchSize)
+	}
+	if item.MaxBundleBytes > 0 && int(item.MaxBundleBytes) < MAXIMUM_TRX_BUNDLE_LENGTH {
+		config.MaxBundleBytes = int(item.MaxBundleBytes)
+	}
+	return config
+}
+
+// LoadPackingConfig loads the packing config of the group from chain config
+func LoadPackingConfig(groupId string, nodename string) *PackingConfig {
+	item, err := nodectx.GetNodeCtx().GetChainStorage().GetPackingPolicyByGroupId(groupId, nodename)
+	if err != nil {
+		molaproducer_log.Warningf("<%s> get packing policy failed <%s>, use default", groupId, err.Error())
+	}
+	return NewPackingConfig(item)
+}
+
+// Package sorts the trxs by policy and packages them in order until the bundle size is reached
+func (config *PackingConfig) Package(trxs map[string]*quorumpb.Trx, ownerPubkey string) []*quorumpb.Trx {
+	var result []*quorumpb.Trx
+	totalTrxSizeInBytes := 0
+	for _, trx := range config.Policy.Sort(trxs, ownerPubkey) {
+		datab, _ := proto.Marshal(trx)
+		if totalTrxSizeInBytes+len(datab) > config.MaxBundleBytes {
+			break
+		}
+		result = append(result, trx)
+		totalTrxSizeInBytes += len(datab)
+	}
+	return result
+}
+
+// FifoPolicy proposes the trxs randomly sampled from the earliest received ones, and packages trxs
+// of each sender by timestamp
+type FifoPolicy struct{}
+
+func (p *FifoPolicy) Name() string {
+	return quorumpb.PackingPolicyType_TIMESTAMP_FIFO.String()
+}
+
+func (p *FifoPolicy) Select(trxs []*quorumpb.Trx, n int) []*quorumpb.Trx {
+	return sampleN(selectN(trxs, n*PACKING_CANDIDATE_FACTOR), n)
+}
+
+func (p *FifoPolicy) Sort(trxs map[string]*quorumpb.Trx, ownerPubkey string) []*quorumpb.Trx {
+	return sortTrxs(trxs, ownerPubkey)
+}
+
+// RoundRobinPolicy takes one trx from each sender in turn, so a sender with lots of trxs
+// can not crowd out the others
+type RoundRobinPolicy struct{}
+
+func (p *RoundRobinPolicy) Name() string {
+	return quorumpb.PackingPolicyType_SENDER_ROUND_ROBIN.String()
+}
+
+func (p *RoundRobinPolicy) Select(trxs []*quorumpb.Trx, n int) []*quorumpb.Trx {
+	return selectN(p.Sort(trxMap(trxs), ""), n)
+}
+
+func (p *RoundRobinPolicy) Sort(trxs map[string]*quorumpb.Trx, ownerPubkey string) []*quorumpb.Trx {
+	container, senderKeys := groupBySender(trxs)
+
+	result := []*quorumpb.Trx{}
+	for round := 0; len(result) < len(trxs); round++ {
+		for _, key := range senderKeys {
+			if round < len(container[key]) {
+				result = append(result, container[key][round])
+			}
+		}
+	}
+	return result
+}
+
+// TypePriorityPolicy packages the trxs with PRIORITY_TRX_TYPES first, the trxs with same
+// priority are sorted as FifoPolicy
+type TypePriorityPolicy struct{}
+
+func (p *TypePriorityPolicy) Name() string {
+	return quorumpb.PackingPolicyType_TRX_TYPE_PRIORITY.String()
+}
+
+func (p *TypePriorityPolicy) Select(trxs []*quorumpb.Trx, n int) []*quorumpb.Trx {
+	return selectN(p.Sort(trxMap(trxs), ""), n)
+}
+
+func (p *TypePriorityPolicy) Sort(trxs map[string]*quorumpb.Trx, ownerPubkey string) []*quorumpb.Trx {
+	levels := make([]map[string]*quorumpb.Trx, len(PRIORITY_TRX_TYPES)+1)
+	for i := range levels {
+		levels[i] = make(map[string]*quorumpb.Trx)
+	}
+
+	for id, trx := range trxs {
+		level := len(PRIORITY_TRX_TYPES)
+		for i, typ := range PRIORITY_TRX_TYPES {
+			if trx.Type == typ {
+				level = i
+				break
+			}
+		}
+		levels[level][id] = trx
+	}
+
+	result := []*quorumpb.Trx{}
+	for _, level := range levels {
+		result = append(result, sortTrxs(level, ownerPubkey)...)
+	}
+	return result
+}
+
+func trxMap(trxs []*quorumpb.Trx) map[string]*quorumpb.Trx {
+	result := make(map[string]*quorumpb.Trx)
+	for _, trx := range trxs {
+		result[trx.TrxId] = trx
+	}
+	return result
+}
+
+func selectN(trxs []*quorumpb.Trx, n int) []*quorumpb.Trx {
+	if n >= len(trxs) {
+		return trxs
+	}
+	return trxs[:n]
+}
+
+// sampleN randomly samples n trxs, the sampled trxs are in the same order as they are in trxs
+func sampleN(trxs []*quorumpb.Trx, n int) []*quorumpb.Trx {
+	if n >= len(trxs) {
+		return trxs
+	}
+	picked := rand.Perm(len(trxs))[:n]
+	sort.Ints(picked)
+	result := make([]*quorumpb.Trx, 0, n)
+	for _, i := range picked {
+		result = append(result, trxs[i])
+	}
+	return result
+}
+
+// groupBySender groups trxs by sender and sorts them by timestamp, the sender keys are sorted
+func groupBySender(trxs map[string]*quorumpb.Trx) (map[string][]*quorumpb.Trx, []string) {
+	container := make(map[string][]*quorumpb.Trx)
+	for _, trx := range trxs {
+		container[trx.SenderPubkey] = append(container[trx.SenderPubkey], trx)
+	}
+
+	var senderKeys []string
+	for key, trxs := range container {
+		sort.Sort(sort.Reverse(TrxSlice(trxs)))
+		senderKeys = append(senderKeys, key)
+	}
+	sort.Strings(senderKeys)
+	return container, senderKeys
+}
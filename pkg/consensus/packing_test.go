@@ -0,0 +1,120 @@
+package consensus
+
+import (
+	"fmt"
+	"testing"
+
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+func newPackingTestTrxs() map[string]*quorumpb.Trx {
+	trxs := make(map[string]*quorumpb.Trx)
+	//a spammer sends lots of posts before the others
+	for i := 0; i < 10; i++ {
+		id := fmt.Sprintf("spam-%d", i)
+		trxs[id] = &quorumpb.Trx{TrxId: id, SenderPubkey: "spammer", Type: quorumpb.TrxType_POST, TimeStamp: int64(i)}
+	}
+	trxs["user-post"] = &quorumpb.Trx{TrxId: "user-post", SenderPubkey: "user", Type: quorumpb.TrxType_POST, TimeStamp: 20}
+	trxs["owner-config"] = &quorumpb.Trx{TrxId: "owner-config", SenderPubkey: "owner", Type: quorumpb.TrxType_CHAIN_CONFIG, TimeStamp: 30}
+	trxs["owner-user"] = &quorumpb.Trx{TrxId: "owner-user", SenderPubkey: "owner", Type: quorumpb.TrxType_USER, TimeStamp: 25}
+	return trxs
+}
+
+func trxIds(trxs []*quorumpb.Trx) []string {
+	var ids []string
+	for _, trx := range trxs {
+		ids = append(ids, trx.TrxId)
+	}
+	return ids
+}
+
+func TestFifoPolicy(t *testing.T) {
+	var buffered []*quorumpb.Trx
+	for i := 0; i < 100; i++ {
+		buffered = append(buffered, &quorumpb.Trx{TrxId: fmt.Sprintf("%03d", i)})
+	}
+
+	batches := make(map[string]bool)
+	for i := 0; i < 20; i++ {
+		selected := (&FifoPolicy{}).Select(buffered, 5)
+		ids := trxIds(selected)
+		if len(ids) != 5 {
+			t.Fatalf("expect 5 trxs, got %v", ids)
+		}
+		for j, id := range ids {
+			//sampled from the earliest received trxs, in the received order
+			if id >= fmt.Sprintf("%03d", 5*PACKING_CANDIDATE_FACTOR) || (j > 0 && id <= ids[j-1]) {
+				t.Fatalf("unexpected trxs selected %v", ids)
+			}
+		}
+		batches[fmt.Sprint(ids)] = true
+	}
+	if len(batches) == 1 {
+		t.Errorf("producers should not always propose the same batch")
+	}
+
+	if selected := (&FifoPolicy{}).Select(buffered[:3], 5); len(selected) != 3 {
+		t.Errorf("expect all trxs selected, got %v", trxIds(selected))
+	}
+}
+
+func TestRoundRobinPolicy(t *testing.T) {
+	trxs := newPackingTestTrxs()
+	sorted := (&RoundRobinPolicy{}).Sort(trxs, "owner")
+	if len(sorted) != len(trxs) {
+		t.Fatalf("expect %d trxs, got %d", len(trxs), len(sorted))
+	}
+
+	//senders are sorted, one trx from each sender in a round
+	expect := []string{"owner-user", "spam-0", "user-post", "owner-config", "spam-1", "spam-2"}
+	for i, id := range expect {
+		if sorted[i].TrxId != id {
+			t.Fatalf("expect %v at the beginning, got %v", expect, trxIds(sorted))
+		}
+	}
+
+	selected := (&RoundRobinPolicy{}).Select(sorted, 3)
+	if ids := trxIds(selected); len(ids) != 3 || ids[2] != "user-post" {
+		t.Errorf("the user should not be crowded out, got %v", ids)
+	}
+}
+
+func TestTypePriorityPolicy(t *testing.T) {
+	var buffered []*quorumpb.Trx
+	for _, trx := range newPackingTestTrxs() {
+		buffered = append(buffered, trx)
+	}
+
+	selected := (&TypePriorityPolicy{}).Select(buffered, 2)
+	if ids := trxIds(selected); len(ids) != 2 || ids[0] != "owner-config" || ids[1] != "owner-user" {
+		t.Errorf("expect admin trxs proposed first, got %v", ids)
+	}
+
+	sorted := (&TypePriorityPolicy{}).Sort(trxMap(buffered), "owner")
+	if ids := trxIds(sorted); len(ids) != len(buffered) || ids[0] != "owner-config" || ids[1] != "owner-user" || ids[2] != "spam-0" {
+		t.Errorf("unexpected order %v", ids)
+	}
+}
+
+func TestPackingConfig(t *testing.T) {
+	config := NewPackingConfig(nil)
+	if config.Policy.Name() != quorumpb.PackingPolicyType_TIMESTAMP_FIFO.String() || config.BatchSize != DEFAULT_BATCH_SIZE || config.MaxBundleBytes != MAXIMUM_TRX_BUNDLE_LENGTH {
+		t.Fatalf("unexpected default config %+v", config)
+	}
+
+	config = NewPackingConfig(&quorumpb.SetPackingPolicyItem{Policy: quorumpb.PackingPolicyType_TRX_TYPE_PRIORITY, MaxBundleBytes: uint32(MAXIMUM_TRX_BUNDLE_LENGTH + 1)})
+	if config.BatchSize != DEFAULT_BATCH_SIZE || config.MaxBundleBytes != MAXIMUM_TRX_BUNDLE_LENGTH {
+		t.Errorf("bundle size should not be larger than %d, got %+v", MAXIMUM_TRX_BUNDLE_LENGTH, config)
+	}
+
+	//only the trxs within the bundle size are packaged, in policy order
+	trxs := newPackingTestTrxs()
+	config.MaxBundleBytes = 1
+	if packaged := config.Package(trxs, "owner"); len(packaged) != 0 {
+		t.Errorf("expect nothing packaged, got %v", trxIds(packaged))
+	}
+	config.MaxBundleBytes = MAXIMUM_TRX_BUNDLE_LENGTH
+	if packaged := config.Package(trxs, "owner"); len(packaged) != len(trxs) || packaged[0].TrxId != "owner-config" {
+		t.Errorf("unexpected packaged trxs %v", trxIds(packaged))
+	}
+}
@@ -0,0 +1,206 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rumsystem/quorum/internal/pkg/logging"
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	"github.com/rumsystem/quorum/pkg/consensus/def"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+var pos_log = logging.Logger("pos")
+
+// blocks of a group are added or built one by one
+var posGroupLocks sync.Map
+
+func posGroupLock(groupId string) *sync.Mutex {
+	lock, _ := posGroupLocks.LoadOrStore(groupId, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// Pos is the consensus of POS groups, the producer of each slot is selected by stake,
+// see rumchaindata.SelectProposer
+type Pos struct {
+	name     string
+	producer def.Producer
+	user     def.User
+}
+
+func NewPos(p def.Producer, u def.User) *Pos {
+	return &Pos{name: "Pos", producer: p, user: u}
+}
+
+func (m *Pos) Name() string {
+	return m.name
+}
+
+func (m *Pos) Producer() def.Producer {
+	return m.producer
+}
+
+func (m *Pos) User() def.User {
+	return m.user
+}
+
+func (m *Pos) SetProducer(p def.Producer) {
+	m.producer = p
+}
+
+func (m *Pos) SetUser(u def.User) {
+	m.user = u
+}
+
+func (m *Pos) StartPropose() {
+	if m.producer != nil {
+		m.producer.StartPropose()
+	}
+}
+
+// posAddBlock adds the block to chain as MolassesUser.AddBlock, the block linked with the chain
+// should be proposed by the producer selected for its epoch
+func posAddBlock(block *quorumpb.Block, groupItem *quorumpb.GroupItem, nodename string, cIface def.ChainMolassesIface, producerNode bool) error {
+	groupId := groupItem.GroupId
+	chainStorage := nodectx.GetNodeCtx().GetChainStorage()
+
+	lock := posGroupLock(groupId)
+	lock.Lock()
+	defer lock.Unlock()
+
+	//report the producer signed another block with the same BlockId
+	checkBlockEquivocation(block, nodename)
+
+	//check if block exist
+	blockExist, _ := chainStorage.IsBlockExist(block.GroupId, block.BlockId, false, nodename)
+	if blockExist {
+		//the chain checks if the block conflicts with the saved one
+		if err := cIface.ResolveFork(block); err != nil {
+			pos_log.Warningf("<%s> resolve fork at block <%d> failed <%s>", groupId, block.BlockId, err.Error())
+		}
+		return nil
+	}
+
+	//check if block cached
+	isBlockCatched, _ := chainStorage.IsBlockExist(block.GroupId, block.BlockId, true, nodename)
+
+	//check if block parent exist
+	parentBlockId := block.BlockId - 1
+	parentExist, _ := chainStorage.IsBlockExist(block.GroupId, parentBlockId, false, nodename)
+	if !parentExist {
+		if isBlockCatched {
+			pos_log.Debugf("Block already catched but parent not exist, wait more block to fill the gap")
+			return nil
+		}
+		pos_log.Debugf("parent of block <%d> is not exist and block not catched, catch it.", block.BlockId)
+		return chainStorage.AddBlock(block, true, nodename)
+	}
+
+	//get parent block
+	parentBlock, err := chainStorage.GetBlock(block.GroupId, parentBlockId, false, nodename)
+	if err != nil {
+		return err
+	}
+
+	//valid block with parent block and the proposer selected by stake
+	valid, err := validPosBlockWithParent(block, parentBlock, groupItem, nodename)
+	if err != nil && valid {
+		return err
+	}
+	if !valid {
+		pos_log.Warningf("<%s> invalid block <%s>", groupId, err.Error())
+		pos_log.Debugf("<%s> remove invalid block <%d> from cache", groupId, block.BlockId)
+		return chainStorage.RmBlock(block.GroupId, block.BlockId, true, nodename)
+	}
+	pos_log.Debugf("block is validated")
+
+	//add this block to cache
+	if !isBlockCatched {
+		err = chainStorage.AddBlock(block, true, nodename)
+		if err != nil {
+			return err
+		}
+	}
+
+	//search cache, gather all blocks can be connected with this block (this block is the first one in the returned block list)
+	blockfromcache, err := chainStorage.GatherBlocksFromCache(block, nodename)
+	if err != nil {
+		return err
+	}
+
+	//move collected blocks from cache to chain one by one, the blocks after the first one are validated
+	//with the stakes updated by the trxs of the blocks before
+	for i, bc := range blockfromcache {
+		if i > 0 {
+			valid, err := validPosBlockWithParent(bc, blockfromcache[i-1], groupItem, nodename)
+			if err != nil && valid {
+				return err
+			}
+			if !valid {
+				pos_log.Warningf("<%s> invalid cached block <%s>", groupId, err.Error())
+				pos_log.Debugf("<%s> remove invalid block <%d> from cache", groupId, bc.BlockId)
+				if err := chainStorage.RmBlock(bc.GroupId, bc.BlockId, true, nodename); err != nil {
+					return err
+				}
+				break
+			}
+		}
+
+		pos_log.Debugf("<%s> move block <%d> from cache to chain", groupId, bc.BlockId)
+		err := chainStorage.AddBlock(bc, false, nodename)
+		if err != nil {
+			return err
+		}
+
+		err = chainStorage.RmBlock(bc.GroupId, bc.BlockId, true, nodename)
+		if err != nil {
+			return err
+		}
+
+		if bc.BlockId > cIface.GetCurrBlockId() {
+			pos_log.Debugf("<%s> UpdChainInfo, upd highest blockId from <%d> to <%d>", groupId, cIface.GetCurrBlockId(), bc.BlockId)
+			cIface.SetCurrBlockId(bc.BlockId)
+			cIface.SetCurrEpoch(bc.Epoch)
+			cIface.SetLastUpdate(bc.TimeStamp)
+			cIface.SaveChainInfoToDb()
+		}
+
+		//apply trxs
+		trxs, err := rumchaindata.GetAllTrxs([]*quorumpb.Block{bc})
+		if err != nil {
+			return err
+		}
+		if producerNode {
+			err = cIface.ApplyTrxsProducerNode(trxs, bc.BlockId, nodename)
+		} else {
+			err = cIface.ApplyTrxsFullNode(trxs, bc.BlockId, nodename)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	//write state snapshot and prune old blocks
+	cIface.UpdSnapshot()
+	return nil
+}
+
+// validPosBlockWithParent checks the block links with the parent, and is a sudo block of the owner
+// or proposed by the producer selected by the current stakes. The error is returned with valid
+// if the stakes can't be loaded.
+func validPosBlockWithParent(block, parent *quorumpb.Block, groupItem *quorumpb.GroupItem, nodename string) (bool, error) {
+	valid, err := rumchaindata.ValidBlockWithParent(block, parent)
+	if !valid {
+		return false, err
+	}
+	if block.Sudo {
+		//sudo blocks from the owner are not selected by stake
+		return rumchaindata.ValidSudoBlock(block, groupItem.OwnerPubKey)
+	}
+	stakes, err := nodectx.GetNodeCtx().GetChainStorage().GetStakes(groupItem.GroupId, nodename)
+	if err != nil {
+		return true, err
+	}
+	return rumchaindata.ValidPosBlock(block, parent, stakes, groupItem.OwnerPubKey, time.Now().UnixNano())
+}
@@ -0,0 +1,240 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	"github.com/rumsystem/quorum/pkg/consensus/def"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	rumchaindata "github.com/rumsystem/quorum/pkg/data"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+// PosProducer buffers the trxs received, and builds a block with them in the slots it is selected as the proposer.
+// Blocks are built only when there are trxs to package, the slots without a block are skipped.
+type PosProducer struct {
+	grpItem   *quorumpb.GroupItem
+	nodename  string
+	cIface    def.ChainMolassesIface
+	groupId   string
+	txBuffer  *TrxBuffer
+	transport Transport
+	lastEpoch uint64 //epoch of the last block built by me
+
+	mu         sync.Mutex
+	status     ProposeStatus
+	stopnotify chan struct{}
+}
+
+func (producer *PosProducer) NewProducer(item *quorumpb.GroupItem, nodename string, iface def.ChainMolassesIface) {
+	pos_log.Debug("NewProducer called")
+	producer.grpItem = item
+	producer.cIface = iface
+	producer.nodename = nodename
+	producer.groupId = item.GroupId
+	producer.txBuffer = NewTrxBuffer(producer.groupId, producer.nodename)
+	producer.transport = &ConnTransport{}
+	producer.status = IDLE
+}
+
+// SetTransport replaces the transport to broadcast blocks, should be called before StartPropose
+func (producer *PosProducer) SetTransport(transport Transport) {
+	producer.transport = transport
+}
+
+func (producer *PosProducer) StartPropose() {
+	pos_log.Debugf("<%s> StartPropose called", producer.groupId)
+	producer.mu.Lock()
+	defer producer.mu.Unlock()
+	if producer.status != IDLE {
+		return
+	}
+	producer.status = RUNNING
+	producer.stopnotify = make(chan struct{})
+
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(time.Duration(DEFAULT_PROPOSE_PULSE) * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := producer.propose(); err != nil {
+					pos_log.Warnf("<%s> propose failed <%s>", producer.groupId, err.Error())
+				}
+			}
+		}
+	}(producer.stopnotify)
+}
+
+func (producer *PosProducer) StopPropose() {
+	pos_log.Debugf("<%s> StopPropose called", producer.groupId)
+	producer.mu.Lock()
+	defer producer.mu.Unlock()
+	if producer.status == RUNNING {
+		close(producer.stopnotify)
+	}
+	producer.status = CLOSED
+}
+
+// the proposer is selected from the stakes saved in chain state, nothing to recreate
+func (producer *PosProducer) RecreateBft() {
+	pos_log.Debugf("<%s> RecreateBft called, ignore", producer.groupId)
+}
+
+func (producer *PosProducer) AddBlock(block *quorumpb.Block) error {
+	pos_log.Debugf("<%s> producer AddBlock called, BlockId <%d>", producer.groupId, block.BlockId)
+	return posAddBlock(block, producer.grpItem, producer.nodename, producer.cIface, true)
+}
+
+func (producer *PosProducer) AddTrx(trx *quorumpb.Trx) {
+	pos_log.Debugf("<%s> AddTrx called", producer.groupId)
+
+	//check if trx sender is in group block list
+	isAllow, err := nodectx.GetNodeCtx().GetChainStorage().CheckTrxTypeAuth(trx.GroupId, trx.SenderPubkey, trx.Type, producer.nodename)
+	if err != nil {
+		return
+	}
+
+	if !isAllow {
+		pos_log.Debugf("<%s> pubkey <%s> don't has permission to send trx with type <%s>", producer.groupId, trx.SenderPubkey, trx.Type.String())
+		return
+	}
+
+	//check if trx with same trxid exist (already packaged)
+	isExist, _ := nodectx.GetNodeCtx().GetChainStorage().IsTrxExist(trx.GroupId, trx.TrxId, producer.nodename)
+	if isExist {
+		pos_log.Debugf("<%s> trx <%s> already packaged, ignore", producer.groupId, trx.TrxId)
+		return
+	}
+
+	if len(trx.Data) > TRX_DATA_LENGTH {
+		pos_log.Errorf("<%s> Trx data length <%d> is too long", producer.groupId, len(trx.Data))
+		return
+	}
+
+	if _, err := producer.txBuffer.GetTrxById(trx.TrxId); err == nil {
+		pos_log.Debugf("<%s> trx <%s> already in buffer, ignore", producer.groupId, trx.TrxId)
+		return
+	}
+
+	if err := producer.txBuffer.Push(trx); err != nil {
+		pos_log.Warnf("<%s> push trx <%s> to buffer failed <%s>", producer.groupId, trx.TrxId, err.Error())
+	}
+}
+
+// no HB message for POS
+func (producer *PosProducer) HandleHBMsg(hbmsg *quorumpb.HBMsgv1) error {
+	return nil
+}
+
+// propose builds a block if I am the proposer of the current slot and there are trxs in buffer
+func (producer *PosProducer) propose() error {
+	lock := posGroupLock(producer.groupId)
+	lock.Lock()
+	defer lock.Unlock()
+
+	chainStorage := nodectx.GetNodeCtx().GetChainStorage()
+	parent, err := chainStorage.GetBlock(producer.groupId, producer.cIface.GetCurrBlockId(), false, producer.nodename)
+	if err != nil {
+		return err
+	}
+
+	epoch := rumchaindata.GetPosSlotEpoch(parent, time.Now().UnixNano())
+	if epoch <= producer.lastEpoch {
+		return nil
+	}
+
+	stakes, err := chainStorage.GetStakes(producer.groupId, producer.nodename)
+	if err != nil {
+		return err
+	}
+	proposer := rumchaindata.GetPosProposer(stakes, parent, epoch, producer.grpItem.OwnerPubKey)
+	if ethPubkey(proposer) != ethPubkey(producer.grpItem.UserSignPubkey) {
+		return nil
+	}
+
+	trxs, err := producer.getTrxsToPackage()
+	if err != nil || len(trxs) == 0 {
+		return err
+	}
+
+	pos_log.Debugf("<%s> build block <%d> at epoch <%d> with <%d> trxs", producer.groupId, parent.BlockId+1, epoch, len(trxs))
+	version, err := chainStorage.GetBlockVersionByGroupId(producer.groupId, epoch, producer.nodename)
+	if err != nil {
+		return err
+	}
+	ks := localcrypto.GetKeystore()
+	newBlock, err := rumchaindata.CreateBlockByEthKey(parent, epoch, version, trxs, false, producer.grpItem.UserSignPubkey, ks, "", producer.nodename)
+	if err != nil {
+		return err
+	}
+
+	err = chainStorage.AddBlock(newBlock, false, producer.nodename)
+	if err != nil {
+		return err
+	}
+	producer.lastEpoch = epoch
+
+	producer.cIface.SetCurrBlockId(newBlock.BlockId)
+	producer.cIface.SetCurrEpoch(newBlock.Epoch)
+	producer.cIface.SetLastUpdate(newBlock.TimeStamp)
+	producer.cIface.SaveChainInfoToDb()
+
+	//apply trxs
+	if nodectx.GetNodeCtx().NodeType == nodectx.PRODUCER_NODE {
+		producer.cIface.ApplyTrxsProducerNode(trxs, newBlock.BlockId, producer.nodename)
+	} else {
+		producer.cIface.ApplyTrxsFullNode(trxs, newBlock.BlockId, producer.nodename)
+	}
+	producer.cIface.UpdSnapshot()
+
+	for _, trx := range trxs {
+		if err := producer.txBuffer.Delete(trx.TrxId); err != nil {
+			pos_log.Warnf(err.Error())
+		}
+	}
+
+	//broadcast it
+	if err := producer.transport.BroadcastBlock(producer.groupId, newBlock); err != nil {
+		pos_log.Debugf("<%s> Broadcast failed <%s>", producer.groupId, err.Error())
+	}
+	return nil
+}
+
+// getTrxsToPackage returns the trxs in buffer not expired or packaged by other producers,
+// selected and sorted by the packing policy of the group
+func (producer *PosProducer) getTrxsToPackage() ([]*quorumpb.Trx, error) {
+	if _, err := producer.txBuffer.RemoveExpired(time.Now().UnixNano()); err != nil {
+		pos_log.Warnf("<%s> remove expired trxs failed <%s>", producer.groupId, err.Error())
+	}
+
+	buffered, err := producer.txBuffer.GetAllTrxByReceived()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*quorumpb.Trx
+	for _, trx := range buffered {
+		isExist, _ := nodectx.GetNodeCtx().GetChainStorage().IsTrxExist(trx.GroupId, trx.TrxId, producer.nodename)
+		if isExist {
+			producer.txBuffer.Delete(trx.TrxId)
+			continue
+		}
+		pending = append(pending, trx)
+	}
+
+	packing := LoadPackingConfig(producer.groupId, producer.nodename)
+	trxs := trxMap(packing.Policy.Select(pending, packing.BatchSize))
+	return packing.Package(trxs, producer.grpItem.OwnerPubKey), nil
+}
+
+func ethPubkey(pubkey string) string {
+	pk, _ := localcrypto.Libp2pPubkeyToEthBase64(pubkey)
+	if pk == "" {
+		pk = pubkey
+	}
+	return pk
+}
@@ -0,0 +1,26 @@
+package consensus
+
+import (
+	"github.com/rumsystem/quorum/pkg/consensus/def"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+type PosUser struct {
+	grpItem  *quorumpb.GroupItem
+	nodename string
+	cIface   def.ChainMolassesIface
+	groupId  string
+}
+
+func (user *PosUser) NewUser(item *quorumpb.GroupItem, nodename string, iface def.ChainMolassesIface) {
+	pos_log.Debugf("NewUser called")
+	user.grpItem = item
+	user.nodename = nodename
+	user.cIface = iface
+	user.groupId = item.GroupId
+}
+
+func (user *PosUser) AddBlock(block *quorumpb.Block) error {
+	pos_log.Debugf("<%s> user AddBlock called, BlockId <%d>", user.groupId, block.BlockId)
+	return posAddBlock(block, user.grpItem, user.nodename, user.cIface, false)
+}
@@ -17,7 +17,7 @@ func (p Echos) Len() int           { return len(p) }
 func (p Echos) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
 func (p Echos) Less(i, j int) bool { return p[i].Index < p[j].Index }
 
-func MakeRBCInitProposeMessage(groupId, nodename, proposerPubkey string, shards [][]byte, producerList []string, originalDataSize int) ([]*quorumpb.RBCMsg, error) {
+func MakeRBCInitProposeMessage(groupId, nodename, proposerPubkey string, shards [][]byte, producerList []string, originalDataSize int, epoch uint64) ([]*quorumpb.RBCMsg, error) {
 	msgs := make([]*quorumpb.RBCMsg, len(shards))
 
 	for i := 0; i < len(msgs); i++ {
@@ -28,98 +28,65 @@ func MakeRBCInitProposeMessage(groupId, nodename, proposerPubkey string, shards
 		}
 		root, proof, proofIndex, n := tree.Prove()
 
-		//create ECHO for myself
-		if producerList[i] == proposerPubkey {
-			payload := &quorumpb.Echo{
-				RootHash:               root,
-				Proof:                  proof,
-				Index:                  int64(proofIndex),
-				Leaves:                 int64(n),
-				OriginalDataSize:       int64(originalDataSize),
-				OriginalProposerPubkey: proposerPubkey, //myself
-				EchoProviderPubkey:     proposerPubkey, //myself
-				EchoProviderSign:       nil,
-			}
-			//get hash
-			bbytes, err := proto.Marshal(payload)
-			if err != nil {
-				return nil, err
-			}
-			payloadhash := localcrypto.Hash(bbytes)
+		payload := &quorumpb.InitPropose{
+			RootHash:         root,
+			Proof:            proof,
+			Index:            int64(proofIndex),
+			Leaves:           int64(n),
+			OriginalDataSize: int64(originalDataSize),
+			RecvNodePubkey:   producerList[i], //caller should make sure len(producerList) == len(shards)
+			ProposerPubkey:   proposerPubkey,
+			ProposerSign:     nil,
+			Epoch:            epoch,
+		}
 
-			//sign it
-			var signature []byte
-			ks := localcrypto.GetKeystore()
-			signature, err = ks.EthSignByKeyName(groupId, payloadhash, nodename)
-			if err != nil {
-				return nil, err
-			}
+		//get hash
+		bbytes, err := proto.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		payloadhash := localcrypto.Hash(bbytes)
+
+		//sign it
+		var signature []byte
+		ks := localcrypto.GetKeystore()
+		signature, err = ks.EthSignByKeyName(groupId, payloadhash, nodename)
+		if err != nil {
+			return nil, err
+		}
 
-			payload.EchoProviderSign = signature
+		payload.ProposerSign = signature
 
-			//put msg to container
-			payloadb, err := proto.Marshal(payload)
+		//create ECHO for myself
+		if producerList[i] == proposerPubkey {
+			msgs[i], err = MakeRBCEchoMessage(groupId, nodename, proposerPubkey, payload, originalDataSize)
 			if err != nil {
 				return nil, err
 			}
-
-			msgs[i] = &quorumpb.RBCMsg{
-				Type:    quorumpb.RBCMsgType_ECHO,
-				Payload: payloadb,
-			}
-
 			trx_bft_log.Debugf("proposer <%s> create ECHO for myself", proposerPubkey)
+			continue
+		}
 
-		} else {
-			payload := &quorumpb.InitPropose{
-				RootHash:         root,
-				Proof:            proof,
-				Index:            int64(proofIndex),
-				Leaves:           int64(n),
-				OriginalDataSize: int64(originalDataSize),
-				RecvNodePubkey:   producerList[i], //caller should make sure len(producerList) == len(shards)
-				ProposerPubkey:   proposerPubkey,
-				ProposerSign:     nil,
-			}
-
-			//get hash
-			bbytes, err := proto.Marshal(payload)
-			if err != nil {
-				return nil, err
-			}
-			payloadhash := localcrypto.Hash(bbytes)
-
-			//sign it
-			var signature []byte
-			ks := localcrypto.GetKeystore()
-			signature, err = ks.EthSignByKeyName(groupId, payloadhash, nodename)
-			if err != nil {
-				return nil, err
-			}
-
-			payload.ProposerSign = signature
-
-			//put msg to container
-			payloadb, err := proto.Marshal(payload)
-			if err != nil {
-				return nil, err
-			}
-
-			msgs[i] = &quorumpb.RBCMsg{
-				Type:    quorumpb.RBCMsgType_INIT_PROPOSE,
-				Payload: payloadb,
-			}
+		//put msg to container
+		payloadb, err := proto.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
 
-			trx_bft_log.Debugf("proposer <%s> create InitP for ", proposerPubkey, producerList[i])
+		msgs[i] = &quorumpb.RBCMsg{
+			Type:    quorumpb.RBCMsgType_INIT_PROPOSE,
+			Payload: payloadb,
 		}
 
+		trx_bft_log.Debugf("proposer <%s> create InitP for <%s>", proposerPubkey, producerList[i])
 	}
 
 	return msgs, nil
 }
 
 func MakeRBCEchoMessage(groupId, nodename, echoProviderPubkey string, initP *quorumpb.InitPropose, originalDataSize int) (*quorumpb.RBCMsg, error) {
-	//just dump my part of InitPropose to ProofMsg and sign it
+	//just dump my part of InitPropose to ProofMsg and sign it, the signature of InitPropose is kept for
+	//other producers to check the root hash is from the proposer
 	payload := &quorumpb.Echo{
 		RootHash:               initP.RootHash,
 		Proof:                  initP.Proof,
@@ -129,6 +96,8 @@ func MakeRBCEchoMessage(groupId, nodename, echoProviderPubkey string, initP *quo
 		OriginalProposerPubkey: initP.ProposerPubkey,
 		EchoProviderPubkey:     echoProviderPubkey,
 		EchoProviderSign:       nil,
+		ProposerSign:           initP.ProposerSign,
+		Epoch:                  initP.Epoch,
 	}
 
 	//get hash
@@ -159,12 +128,28 @@ func MakeRBCEchoMessage(groupId, nodename, echoProviderPubkey string, initP *quo
 	}, nil
 }
 
-func MakeRBCReadyMessage(groupId, nodename, providerPubkey, originalProposerPubkey string, roothash []byte) (*quorumpb.RBCMsg, error) {
+// InitProposeFromEcho recovers the InitPropose received by the echo provider
+func InitProposeFromEcho(echo *quorumpb.Echo) *quorumpb.InitPropose {
+	return &quorumpb.InitPropose{
+		RootHash:         echo.RootHash,
+		Proof:            echo.Proof,
+		Index:            echo.Index,
+		Leaves:           echo.Leaves,
+		OriginalDataSize: echo.OriginalDataSize,
+		RecvNodePubkey:   echo.EchoProviderPubkey,
+		ProposerPubkey:   echo.OriginalProposerPubkey,
+		ProposerSign:     echo.ProposerSign,
+		Epoch:            echo.Epoch,
+	}
+}
+
+func MakeRBCReadyMessage(groupId, nodename, providerPubkey, originalProposerPubkey string, roothash []byte, epoch uint64) (*quorumpb.RBCMsg, error) {
 	ready := &quorumpb.Ready{
 		RootHash:               roothash,
 		OriginalProposerPubkey: originalProposerPubkey,
 		ReadyProviderPubkey:    providerPubkey,
 		ReadyProviderSign:      nil,
+		Epoch:                  epoch,
 	}
 
 	//sign root_hash with my pubkey
@@ -0,0 +1,271 @@
+// Package simnet is an in-memory network for the consensus tests, it connects the producers of a group
+// without libp2p and simulates the latency, message drop, reordering and byzantine nodes.
+package simnet
+
+import (
+	"container/heap"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// Config of the network, faults of a link (sender to receiver) are decided by a RNG seeded with Seed
+// and the link, so the messages on the link are delayed, dropped and reordered the same way in each run
+type Config struct {
+	Seed        int64
+	MinLatency  time.Duration
+	MaxLatency  time.Duration
+	DropRate    float64 //probability of a message to other nodes being dropped
+	ReorderRate float64 //probability of a message being delayed another MaxLatency, arrives after the later ones
+}
+
+// Node is the receiver of the messages, implemented by the producers
+type Node interface {
+	HandleHBMsg(hbmsg *quorumpb.HBMsgv1) error
+	AddBlock(block *quorumpb.Block) error
+}
+
+// Fault is the behavior of a byzantine node, it is called with each consensus message sent by the node
+// to each receiver, and returns the messages really sent to the receiver. It is called with the network
+// locked, so it should not send messages itself
+type Fault func(to string, hbmsg *quorumpb.HBMsgv1) []*quorumpb.HBMsgv1
+
+type Stats struct {
+	Sent      int
+	Delivered int
+	Dropped   int
+}
+
+type Network struct {
+	cfg Config
+
+	mu        sync.Mutex
+	endpoints map[string]*Endpoint
+	ids       []string //in join order
+	links     map[string]*rand.Rand
+	queue     msgQueue
+	seq       uint64
+	stats     Stats
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Endpoint is a node in the network, it implements the consensus Transport
+type Endpoint struct {
+	net    *Network
+	id     string
+	node   Node
+	fault  Fault
+	silent bool
+}
+
+type message struct {
+	at    time.Time
+	seq   uint64
+	to    *Endpoint
+	hbmsg *quorumpb.HBMsgv1
+	block *quorumpb.Block
+}
+
+func New(cfg Config) *Network {
+	n := &Network{
+		cfg:       cfg,
+		endpoints: make(map[string]*Endpoint),
+		links:     make(map[string]*rand.Rand),
+		wake:      make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go n.dispatch()
+	return n
+}
+
+// Join adds the node with the id to the network
+func (n *Network) Join(id string, node Node) *Endpoint {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ep := &Endpoint{net: n, id: id, node: node}
+	n.endpoints[id] = ep
+	n.ids = append(n.ids, id)
+	return ep
+}
+
+// Stop stops delivering messages, the messages not delivered are dropped
+func (n *Network) Stop() {
+	close(n.stop)
+	<-n.done
+}
+
+func (n *Network) Stats() Stats {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.stats
+}
+
+func (ep *Endpoint) Id() string {
+	return ep.id
+}
+
+// SetFault makes the node byzantine, nil for an honest node
+func (ep *Endpoint) SetFault(fault Fault) {
+	ep.net.mu.Lock()
+	defer ep.net.mu.Unlock()
+	ep.fault = fault
+}
+
+// SetSilent makes the node send nothing to others as it is crashed, but it still receives messages
+func (ep *Endpoint) SetSilent(silent bool) {
+	ep.net.mu.Lock()
+	defer ep.net.mu.Unlock()
+	ep.silent = silent
+}
+
+func (ep *Endpoint) BroadcastHBMsg(groupId string, hbmsg *quorumpb.HBMsgv1) error {
+	n := ep.net
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, id := range n.ids {
+		if ep.silent && id != ep.id {
+			continue
+		}
+		msgs := []*quorumpb.HBMsgv1{hbmsg}
+		if ep.fault != nil {
+			msgs = ep.fault(id, hbmsg)
+		}
+		for _, msg := range msgs {
+			n.send(ep, n.endpoints[id], &message{hbmsg: proto.Clone(msg).(*quorumpb.HBMsgv1)})
+		}
+	}
+	return nil
+}
+
+func (ep *Endpoint) BroadcastBlock(groupId string, block *quorumpb.Block) error {
+	n := ep.net
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, id := range n.ids {
+		if ep.silent && id != ep.id {
+			continue
+		}
+		n.send(ep, n.endpoints[id], &message{block: proto.Clone(block).(*quorumpb.Block)})
+	}
+	return nil
+}
+
+// send queues the message with the faults of the link, messages to the sender itself are never delayed
+// or dropped, called with lock held
+func (n *Network) send(from, to *Endpoint, msg *message) {
+	n.stats.Sent++
+	msg.to = to
+	msg.at = time.Now()
+	if from != to {
+		rng := n.link(from.id, to.id)
+		if rng.Float64() < n.cfg.DropRate {
+			n.stats.Dropped++
+			return
+		}
+		latency := n.cfg.MinLatency
+		if n.cfg.MaxLatency > n.cfg.MinLatency {
+			latency += time.Duration(rng.Int63n(int64(n.cfg.MaxLatency - n.cfg.MinLatency)))
+		}
+		if rng.Float64() < n.cfg.ReorderRate {
+			latency += n.cfg.MaxLatency
+		}
+		msg.at = msg.at.Add(latency)
+	}
+
+	n.seq++
+	msg.seq = n.seq
+	heap.Push(&n.queue, msg)
+	select {
+	case n.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (n *Network) link(from, to string) *rand.Rand {
+	key := from + "->" + to
+	rng, ok := n.links[key]
+	if !ok {
+		h := fnv.New64a()
+		h.Write([]byte(key))
+		rng = rand.New(rand.NewSource(n.cfg.Seed ^ int64(h.Sum64())))
+		n.links[key] = rng
+	}
+	return rng
+}
+
+// dispatch delivers the messages one by one in the order of arrival time, so the handlers of nodes
+// are never called concurrently by the network
+func (n *Network) dispatch() {
+	defer close(n.done)
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		select {
+		case <-n.stop:
+			return
+		default:
+		}
+
+		n.mu.Lock()
+		var next *message
+		wait := time.Hour
+		if n.queue.Len() > 0 {
+			if d := time.Until(n.queue[0].at); d <= 0 {
+				next = heap.Pop(&n.queue).(*message)
+				n.stats.Delivered++
+			} else {
+				wait = d
+			}
+		}
+		n.mu.Unlock()
+
+		if next != nil {
+			if next.hbmsg != nil {
+				next.to.node.HandleHBMsg(next.hbmsg)
+			} else {
+				next.to.node.AddBlock(next.block)
+			}
+			continue
+		}
+
+		timer.Reset(wait)
+		select {
+		case <-n.stop:
+			return
+		case <-n.wake:
+		case <-timer.C:
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+	}
+}
+
+type msgQueue []*message
+
+func (q msgQueue) Len() int { return len(q) }
+func (q msgQueue) Less(i, j int) bool {
+	if q[i].at.Equal(q[j].at) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at.Before(q[j].at)
+}
+func (q msgQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *msgQueue) Push(x interface{}) { *q = append(*q, x.(*message)) }
+func (q *msgQueue) Pop() interface{} {
+	old := *q
+	msg := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return msg
+}
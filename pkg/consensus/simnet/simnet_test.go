@@ -0,0 +1,128 @@
+package simnet
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	msgs   []string
+	blocks int
+}
+
+func (r *recorder) HandleHBMsg(hbmsg *quorumpb.HBMsgv1) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.msgs = append(r.msgs, hbmsg.MsgId)
+	return nil
+}
+
+func (r *recorder) AddBlock(block *quorumpb.Block) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.blocks++
+	return nil
+}
+
+func (r *recorder) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.msgs...)
+}
+
+// run sends n messages from a to b, and returns the messages received by b
+func run(t *testing.T, cfg Config, n int) []string {
+	net := New(cfg)
+	defer net.Stop()
+	a, b := &recorder{}, &recorder{}
+	epA := net.Join("a", a)
+	net.Join("b", b)
+
+	for i := 0; i < n; i++ {
+		epA.BroadcastHBMsg("group", &quorumpb.HBMsgv1{MsgId: fmt.Sprintf("%d", i)})
+	}
+	time.Sleep(3*cfg.MaxLatency + 50*time.Millisecond)
+
+	if len(a.received()) != n {
+		t.Errorf("messages to the sender itself should never be dropped, got %d of %d", len(a.received()), n)
+	}
+	return b.received()
+}
+
+func TestDeterministicFaults(t *testing.T) {
+	cfg := Config{Seed: 42, MinLatency: time.Millisecond, MaxLatency: 5 * time.Millisecond, DropRate: 0.3, ReorderRate: 0.3}
+	first := run(t, cfg, 100)
+	if len(first) == 0 || len(first) == 100 {
+		t.Fatalf("expect some messages dropped, got %d of 100", len(first))
+	}
+
+	//messages are dropped the same way with the same seed
+	received := make(map[string]bool)
+	for _, id := range run(t, cfg, 100) {
+		received[id] = true
+	}
+	if len(received) != len(first) {
+		t.Fatalf("expect %d messages with the same seed, got %d", len(first), len(received))
+	}
+	for _, id := range first {
+		if !received[id] {
+			t.Errorf("message %s should be received with the same seed", id)
+		}
+	}
+
+	//and reordered
+	reordered := false
+	for i := 1; i < len(first); i++ {
+		var prev, curr int
+		fmt.Sscanf(first[i-1], "%d", &prev)
+		fmt.Sscanf(first[i], "%d", &curr)
+		if curr < prev {
+			reordered = true
+		}
+	}
+	if !reordered {
+		t.Errorf("expect messages reordered, got %v", first)
+	}
+}
+
+func TestSilentAndFault(t *testing.T) {
+	net := New(Config{Seed: 1})
+	defer net.Stop()
+	a, b, c := &recorder{}, &recorder{}, &recorder{}
+	epA := net.Join("a", a)
+	epB := net.Join("b", b)
+	net.Join("c", c)
+
+	//a sends nothing to others
+	epA.SetSilent(true)
+	epA.BroadcastHBMsg("group", &quorumpb.HBMsgv1{MsgId: "silent"})
+	epA.BroadcastBlock("group", &quorumpb.Block{})
+
+	//b sends a duplicated message to c only
+	epB.SetFault(func(to string, hbmsg *quorumpb.HBMsgv1) []*quorumpb.HBMsgv1 {
+		if to == "c" {
+			return []*quorumpb.HBMsgv1{hbmsg, {MsgId: hbmsg.MsgId + "-dup"}}
+		}
+		return nil
+	})
+	epB.BroadcastHBMsg("group", &quorumpb.HBMsgv1{MsgId: "byzantine"})
+	time.Sleep(50 * time.Millisecond)
+
+	if got := a.received(); len(got) != 1 || got[0] != "silent" || a.blocks != 1 {
+		t.Errorf("silent node should still receive its own messages, got %v and %d blocks", got, a.blocks)
+	}
+	if got := b.received(); len(got) != 0 {
+		t.Errorf("b should receive nothing, got %v", got)
+	}
+	if got := c.received(); len(got) != 2 || got[0] != "byzantine" || got[1] != "byzantine-dup" || c.blocks != 0 {
+		t.Errorf("unexpected messages received by c %v, %d blocks", got, c.blocks)
+	}
+	if stats := net.Stats(); stats.Sent != 4 || stats.Delivered != 4 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
@@ -0,0 +1,213 @@
+package consensus
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rumsystem/quorum/internal/pkg/metric"
+)
+
+// number of recent epochs traced for each group
+var TRACE_EPOCH_LIMIT = 20
+
+// ProposerTrace is the RBC and BBA state of a proposer in an epoch seen by this node
+type ProposerTrace struct {
+	ProposerPubkey string `json:"proposer_pubkey"`
+	InitPropose    int    `json:"init_propose"` // INIT_PROPOSE received, 1 for a healthy proposer
+	Echos          int    `json:"echos"`
+	Readys         int    `json:"readys"`
+	ReadySent      bool   `json:"ready_sent"`
+	RbcDone        bool   `json:"rbc_done"`
+	RbcDoneAt      int64  `json:"rbc_done_at"`
+	BbaValue       *bool  `json:"bba_value"` // nil before the agreement of this proposer is decided
+}
+
+// EpochTrace is the state of an epoch seen by this node, the epoch is decided once
+// RBC of N-f proposers are done, the proposers not in the decided set are agreed as false
+type EpochTrace struct {
+	Epoch         uint64                    `json:"epoch"`
+	N             int                       `json:"n"`
+	F             int                       `json:"f"`
+	StartAt       int64                     `json:"start_at"`
+	ProposedBytes int                       `json:"proposed_bytes"` // size of the trx bundle proposed by me
+	Proposers     map[string]*ProposerTrace `json:"proposers"`
+	BbaMsgs       int                       `json:"bba_msgs"`
+	Decided       []string                  `json:"decided"`
+	DecidedAt     int64                     `json:"decided_at"`
+	LatencyMs     int64                     `json:"latency_ms"`
+	Trxs          int                       `json:"trxs"` // trxs packaged into block
+}
+
+// EpochTracer keeps the traces of recent epochs of a group
+type EpochTracer struct {
+	groupId string
+	mu      sync.RWMutex
+	epochs  map[uint64]*EpochTrace
+}
+
+var epochTracers sync.Map
+
+// GetEpochTracer returns the tracer of the group, created if not exist
+func GetEpochTracer(groupId string) *EpochTracer {
+	tracer, _ := epochTracers.LoadOrStore(groupId, &EpochTracer{groupId: groupId, epochs: make(map[uint64]*EpochTrace)})
+	return tracer.(*EpochTracer)
+}
+
+// RemoveEpochTracer is called when the group is left
+func RemoveEpochTracer(groupId string) {
+	epochTracers.Delete(groupId)
+}
+
+// GetEpochTraces returns the traces of the group, the latest epoch first
+func GetEpochTraces(groupId string) []*EpochTrace {
+	tracer, ok := epochTracers.Load(groupId)
+	if !ok {
+		return []*EpochTrace{}
+	}
+	return tracer.(*EpochTracer).Traces()
+}
+
+func (t *EpochTracer) Traces() []*EpochTrace {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	result := []*EpochTrace{}
+	for _, trace := range t.epochs {
+		cp := *trace
+		cp.Proposers = make(map[string]*ProposerTrace)
+		for key, p := range trace.Proposers {
+			pcp := *p
+			cp.Proposers[key] = &pcp
+		}
+		cp.Decided = append([]string{}, trace.Decided...)
+		result = append(result, &cp)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Epoch > result[j].Epoch
+	})
+	return result
+}
+
+// StartEpoch starts tracing the epoch, the oldest epoch is dropped if there are more than TRACE_EPOCH_LIMIT
+func (t *EpochTracer) StartEpoch(epoch uint64, cfg Config) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	trace := &EpochTrace{
+		Epoch:     epoch,
+		N:         cfg.N,
+		F:         cfg.f,
+		StartAt:   time.Now().UnixNano(),
+		Proposers: make(map[string]*ProposerTrace),
+	}
+	for _, pubkey := range cfg.Nodes {
+		trace.Proposers[pubkey] = &ProposerTrace{ProposerPubkey: pubkey}
+	}
+	t.epochs[epoch] = trace
+
+	for len(t.epochs) > TRACE_EPOCH_LIMIT {
+		oldest := epoch
+		for e := range t.epochs {
+			if e < oldest {
+				oldest = e
+			}
+		}
+		delete(t.epochs, oldest)
+	}
+
+	metric.ConsensusEpoch.WithLabelValues(t.groupId).Set(float64(epoch))
+}
+
+// update calls fn with the trace of the proposer, does nothing if the epoch or proposer is not traced
+func (t *EpochTracer) update(epoch uint64, proposer string, fn func(trace *EpochTrace, p *ProposerTrace)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	trace, ok := t.epochs[epoch]
+	if !ok {
+		return
+	}
+	p, ok := trace.Proposers[proposer]
+	if !ok && proposer != "" {
+		return
+	}
+	fn(trace, p)
+}
+
+func (t *EpochTracer) Proposed(epoch uint64, size int) {
+	t.update(epoch, "", func(trace *EpochTrace, _ *ProposerTrace) {
+		trace.ProposedBytes = size
+	})
+}
+
+func (t *EpochTracer) InitProposeReceived(epoch uint64, proposer string) {
+	metric.ConsensusRbcMsgCount.WithLabelValues(t.groupId, "init_propose").Inc()
+	t.update(epoch, proposer, func(_ *EpochTrace, p *ProposerTrace) {
+		p.InitPropose++
+	})
+}
+
+func (t *EpochTracer) EchoReceived(epoch uint64, proposer string) {
+	metric.ConsensusRbcMsgCount.WithLabelValues(t.groupId, "echo").Inc()
+	t.update(epoch, proposer, func(_ *EpochTrace, p *ProposerTrace) {
+		p.Echos++
+	})
+}
+
+func (t *EpochTracer) ReadyReceived(epoch uint64, proposer string) {
+	metric.ConsensusRbcMsgCount.WithLabelValues(t.groupId, "ready").Inc()
+	t.update(epoch, proposer, func(_ *EpochTrace, p *ProposerTrace) {
+		p.Readys++
+	})
+}
+
+func (t *EpochTracer) ReadySent(epoch uint64, proposer string) {
+	t.update(epoch, proposer, func(_ *EpochTrace, p *ProposerTrace) {
+		p.ReadySent = true
+	})
+}
+
+func (t *EpochTracer) RbcDone(epoch uint64, proposer string) {
+	metric.ConsensusRbcDoneCount.WithLabelValues(t.groupId, proposer).Inc()
+	t.update(epoch, proposer, func(_ *EpochTrace, p *ProposerTrace) {
+		p.RbcDone = true
+		p.RbcDoneAt = time.Now().UnixNano()
+	})
+}
+
+func (t *EpochTracer) BbaReceived(epoch uint64) {
+	t.update(epoch, "", func(trace *EpochTrace, _ *ProposerTrace) {
+		trace.BbaMsgs++
+	})
+}
+
+// Decided records the decided set of the epoch, agreement of the proposers not in the set is false
+func (t *EpochTracer) Decided(epoch uint64, decided []string) {
+	t.update(epoch, "", func(trace *EpochTrace, _ *ProposerTrace) {
+		trace.Decided = append([]string{}, decided...)
+		sort.Strings(trace.Decided)
+		trace.DecidedAt = time.Now().UnixNano()
+		trace.LatencyMs = (trace.DecidedAt - trace.StartAt) / int64(time.Millisecond)
+
+		isDecided := make(map[string]bool)
+		for _, pubkey := range decided {
+			isDecided[pubkey] = true
+		}
+		for pubkey, p := range trace.Proposers {
+			value := isDecided[pubkey]
+			p.BbaValue = &value
+			if !value {
+				metric.ConsensusMissedProposalCount.WithLabelValues(t.groupId, pubkey).Inc()
+			}
+		}
+
+		metric.ConsensusEpochLatency.WithLabelValues(t.groupId).Observe(float64(trace.DecidedAt-trace.StartAt) / float64(time.Second))
+	})
+}
+
+func (t *EpochTracer) Packaged(epoch uint64, trxs int) {
+	t.update(epoch, "", func(trace *EpochTrace, _ *ProposerTrace) {
+		trace.Trxs = trxs
+	})
+}
@@ -0,0 +1,54 @@
+package consensus
+
+import (
+	"testing"
+)
+
+func TestEpochTracer(t *testing.T) {
+	groupId := "5d2a0a8e-1c4b-4f3e-9a6d-7b8c9d0e1f2a"
+	defer RemoveEpochTracer(groupId)
+
+	cfg := Config{N: 4, f: 1, Nodes: []string{"p1", "p2", "p3", "p4"}}
+	tracer := GetEpochTracer(groupId)
+	tracer.StartEpoch(1, cfg)
+
+	for _, proposer := range []string{"p1", "p2", "p3"} {
+		tracer.InitProposeReceived(1, proposer)
+		for i := 0; i < 3; i++ {
+			tracer.EchoReceived(1, proposer)
+			tracer.ReadyReceived(1, proposer)
+		}
+		tracer.ReadySent(1, proposer)
+		tracer.RbcDone(1, proposer)
+	}
+	//messages from unknown proposers or epochs are ignored
+	tracer.EchoReceived(1, "unknown")
+	tracer.EchoReceived(2, "p1")
+	tracer.Decided(1, []string{"p3", "p1", "p2"})
+	tracer.Packaged(1, 5)
+
+	traces := GetEpochTraces(groupId)
+	if len(traces) != 1 {
+		t.Fatalf("expect 1 epoch traced, got %d", len(traces))
+	}
+	trace := traces[0]
+	if trace.Epoch != 1 || trace.N != 4 || trace.F != 1 || trace.Trxs != 5 || len(trace.Decided) != 3 || trace.Decided[0] != "p1" {
+		t.Errorf("unexpected trace %+v", trace)
+	}
+	if p := trace.Proposers["p1"]; p.InitPropose != 1 || p.Echos != 3 || p.Readys != 3 || !p.ReadySent || !p.RbcDone || p.BbaValue == nil || !*p.BbaValue {
+		t.Errorf("unexpected trace of p1 %+v", p)
+	}
+	//the stuck proposer
+	if p := trace.Proposers["p4"]; p.InitPropose != 0 || p.RbcDone || p.BbaValue == nil || *p.BbaValue {
+		t.Errorf("unexpected trace of p4 %+v", p)
+	}
+
+	//only recent epochs are kept
+	for epoch := uint64(2); epoch <= uint64(TRACE_EPOCH_LIMIT)+5; epoch++ {
+		tracer.StartEpoch(epoch, cfg)
+	}
+	traces = GetEpochTraces(groupId)
+	if len(traces) != TRACE_EPOCH_LIMIT || traces[0].Epoch != uint64(TRACE_EPOCH_LIMIT)+5 || traces[len(traces)-1].Epoch != 6 {
+		t.Errorf("expect the latest %d epochs, got %d from epoch %d", TRACE_EPOCH_LIMIT, len(traces), traces[0].Epoch)
+	}
+}
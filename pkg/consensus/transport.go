@@ -0,0 +1,32 @@
+package consensus
+
+import (
+	"github.com/rumsystem/quorum/internal/pkg/conn"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+// Transport delivers consensus messages to the producers of a group and blocks to the group,
+// the sender should also receive its own messages as the pubsub does
+type Transport interface {
+	BroadcastHBMsg(groupId string, msg *quorumpb.HBMsgv1) error
+	BroadcastBlock(groupId string, block *quorumpb.Block) error
+}
+
+// ConnTransport sends messages with the group connection of the node, used by default
+type ConnTransport struct{}
+
+func (t *ConnTransport) BroadcastHBMsg(groupId string, msg *quorumpb.HBMsgv1) error {
+	connMgr, err := conn.GetConn().GetConnMgr(groupId)
+	if err != nil {
+		return err
+	}
+	return connMgr.BroadcastHBMsg(msg)
+}
+
+func (t *ConnTransport) BroadcastBlock(groupId string, block *quorumpb.Block) error {
+	connMgr, err := conn.GetConn().GetConnMgr(groupId)
+	if err != nil {
+		return err
+	}
+	return connMgr.BroadcastBlock(block)
+}
@@ -17,6 +17,7 @@ type TrxACS struct {
 	rbcInstances map[string]*TrxRBC
 	rbcOutput    map[string]bool
 	rbcResults   map[string][]byte
+	tracer       *EpochTracer
 }
 
 func NewTrxACS(cfg Config, bft *TrxBft, epoch uint64) *TrxACS {
@@ -29,7 +30,9 @@ func NewTrxACS(cfg Config, bft *TrxBft, epoch uint64) *TrxACS {
 		rbcInstances: make(map[string]*TrxRBC),
 		rbcOutput:    make(map[string]bool),
 		rbcResults:   make(map[string][]byte),
+		tracer:       GetEpochTracer(bft.groupId),
 	}
+	acs.tracer.StartEpoch(epoch, cfg)
 
 	for _, rbcInstPubkey := range cfg.Nodes {
 		acs.rbcInstances[rbcInstPubkey], _ = NewTrxRBC(cfg, acs, bft.producer.groupId, cfg.MyPubkey, rbcInstPubkey)
@@ -46,6 +49,7 @@ func (a *TrxACS) InputValue(val []byte) error {
 	if !ok {
 		return fmt.Errorf("could not find rbc instance (%s)", a.MyPubkey)
 	}
+	a.tracer.Proposed(a.Epoch, len(val))
 
 	return rbc.InputValue(val)
 }
@@ -53,17 +57,25 @@ func (a *TrxACS) InputValue(val []byte) error {
 // rbc for proposerIs finished
 func (a *TrxACS) RbcDone(proposerPubkey string) {
 	trx_acs_log.Infof("RbcDone called, Epoch <%d>", a.Epoch)
+	if a.rbcOutput[proposerPubkey] {
+		//the acs should be done only once
+		return
+	}
 	a.rbcOutput[proposerPubkey] = true
+	a.tracer.RbcDone(a.Epoch, proposerPubkey)
 	if len(a.rbcOutput) == a.N-a.f {
 		trx_acs_log.Debugf("enough RBC done for consensus <%d>", a.N-a.f)
 		//this only works when producer nodes equals to 3!!
 		//TBD:should add BBA here
 		//1. set all NOT finished RBC to false
 		//2. start BBA process till finished
+		var decided []string
 		for rbcInst, _ := range a.rbcOutput {
 			//load all valid rbc results
 			a.rbcResults[rbcInst] = a.rbcInstances[rbcInst].Output()
+			decided = append(decided, rbcInst)
 		}
+		a.tracer.Decided(a.Epoch, decided)
 
 		//call hbb to get result
 		a.bft.AcsDone(a.Epoch, a.rbcResults)
@@ -118,6 +130,7 @@ func (a *TrxACS) handleRbc(payload []byte) error {
 		if !ok {
 			return fmt.Errorf("could not find rbc instance to handle InitPropose form <%s>", initp.ProposerPubkey)
 		}
+		a.tracer.InitProposeReceived(a.Epoch, initp.ProposerPubkey)
 
 		return rbc.handleInitProposeMsg(initp)
 	case quorumpb.RBCMsgType_ECHO:
@@ -131,6 +144,7 @@ func (a *TrxACS) handleRbc(payload []byte) error {
 		if !ok {
 			return fmt.Errorf("could not find rbc instance to handle proof from <%s>, original propose <%s>", echo.EchoProviderPubkey, echo.OriginalProposerPubkey)
 		}
+		a.tracer.EchoReceived(a.Epoch, echo.OriginalProposerPubkey)
 		return rbc.handleEchoMsg(echo)
 	case quorumpb.RBCMsgType_READY:
 		ready := &quorumpb.Ready{}
@@ -142,6 +156,7 @@ func (a *TrxACS) handleRbc(payload []byte) error {
 		if !ok {
 			return fmt.Errorf("could not find rbc instance to handle ready from <%s>", ready.ReadyProviderPubkey)
 		}
+		a.tracer.ReadyReceived(a.Epoch, ready.OriginalProposerPubkey)
 		return rbc.handleReadyMsg(ready)
 
 	default:
@@ -152,5 +167,6 @@ func (a *TrxACS) handleRbc(payload []byte) error {
 func (a *TrxACS) handleBba(payload []byte) error {
 	//TBD
 	//Implement BBA
+	a.tracer.BbaReceived(a.Epoch)
 	return nil
 }
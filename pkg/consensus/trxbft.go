@@ -2,10 +2,9 @@ package consensus
 
 import (
 	"errors"
-	"sort"
+	"sync"
 	"time"
 
-	"github.com/rumsystem/quorum/internal/pkg/conn"
 	"github.com/rumsystem/quorum/internal/pkg/logging"
 	"github.com/rumsystem/quorum/internal/pkg/nodectx"
 	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
@@ -20,10 +19,14 @@ var DEFAULT_PROPOSE_PULSE = 1 * 1000       // 1s
 var MAXIMUM_TRX_BUNDLE_LENGTH = 900 * 1024 //900Kib
 var TRX_DATA_LENGTH = 300 * 1024           //300Kib
 
+// messages of at most PENDING_EPOCH_WINDOW epochs ahead are kept till the epoch starts
+var PENDING_EPOCH_WINDOW uint64 = 2
+
 type ProposeTask struct {
 	Epoch          uint64
 	ProposedData   []byte
 	DelayStartTime int
+	Config         Config //producers of the epoch
 }
 
 type ProposeStatus uint
@@ -47,24 +50,43 @@ type TrxBft struct {
 	stopnotify chan struct{}
 
 	status ProposeStatus
+
+	packing   *PackingConfig
+	packingMu sync.Mutex
+
+	//messages received before the acs of its epoch created
+	acsMu   sync.Mutex
+	pending map[uint64][]*quorumpb.HBMsgv1
 }
 
 func NewTrxBft(cfg Config, producer *MolassesProducer) *TrxBft {
 	trx_bft_log.Debugf("<%s> NewTrxBft called", producer.groupId)
+
+	//trxs buffered by older version are not kept by nodename
+	moved, err := nodectx.GetNodeCtx().GetChainStorage().MoveTrxHBB(producer.groupId, producer.nodename)
+	if err != nil {
+		trx_bft_log.Warnf("<%s> move buffered trxs failed <%s>", producer.groupId, err.Error())
+	} else if moved > 0 {
+		trx_bft_log.Infof("<%s> moved <%d> buffered trxs", producer.groupId, moved)
+	}
+
 	return &TrxBft{
 		Config:     cfg,
 		groupId:    producer.groupId,
 		producer:   producer,
-		txBuffer:   NewTrxBuffer(producer.groupId),
+		txBuffer:   NewTrxBuffer(producer.groupId, producer.nodename),
 		taskq:      make(chan *ProposeTask),
 		taskdone:   make(chan struct{}),
 		stopnotify: make(chan struct{}),
 		status:     IDLE,
+		packing:    cfg.Packing,
+		pending:    make(map[uint64][]*quorumpb.HBMsgv1),
 	}
 }
 
 func (bft *TrxBft) StartPropose() {
 	trx_bft_log.Debugf("<%s> StartPropose called", bft.groupId)
+	bft.status = RUNNING
 
 	//start taskq
 	go func() {
@@ -108,9 +130,26 @@ func (bft *TrxBft) runTask(task *ProposeTask) error {
 		trx_bft_log.Debugf("<%s> wait <%d> ms", bft.groupId, task.DelayStartTime)
 		time.Sleep(time.Duration(task.DelayStartTime) * time.Millisecond)
 
+		bft.acsMu.Lock()
+		defer bft.acsMu.Unlock()
+		if bft.status == CLOSED {
+			return
+		}
 		bft.CurrTask = task
-		bft.acsInsts = NewTrxACS(bft.Config, bft, task.Epoch)
+		if !task.Config.hasProducer(task.Config.MyPubkey) {
+			//not a producer of the epoch, wait for the block from others
+			trx_bft_log.Debugf("<%s> not in producer list of epoch <%d>, skip propose", bft.groupId, task.Epoch)
+			bft.acsInsts = nil
+			for e := range bft.pending {
+				if e <= task.Epoch {
+					delete(bft.pending, e)
+				}
+			}
+			return
+		}
+		bft.acsInsts = NewTrxACS(task.Config, bft, task.Epoch)
 		bft.acsInsts.InputValue(task.ProposedData)
+		bft.handlePending()
 	}()
 
 	//wait here
@@ -121,11 +160,22 @@ func (bft *TrxBft) runTask(task *ProposeTask) error {
 func (bft *TrxBft) NewProposeTask() (*ProposeTask, error) {
 	trx_bft_log.Debugf("<%s> NewProposeTask called", bft.groupId)
 
+	//drop expired trxs, they should not be proposed anymore
+	if removed, err := bft.txBuffer.RemoveExpired(time.Now().UnixNano()); err != nil {
+		trx_bft_log.Warnf("<%s> remove expired trxs failed <%s>", bft.groupId, err.Error())
+	} else if removed > 0 {
+		trx_bft_log.Debugf("<%s> <%d> expired trxs removed from buffer", bft.groupId, removed)
+	}
+
+	//packing config may be changed by chain config since last epoch
+	packing := bft.updPacking()
+
 	//select some trxs from buffer
-	trxs, err := bft.txBuffer.GetNRandTrx(bft.BatchSize)
+	buffered, err := bft.txBuffer.GetAllTrxByReceived()
 	if err != nil {
 		return nil, err
 	}
+	trxs := packing.Policy.Select(buffered, packing.BatchSize)
 
 	//list all trxs
 	trx_bft_log.Debugf("<%s> trxs to propose", bft.groupId)
@@ -148,7 +198,7 @@ func (bft *TrxBft) NewProposeTask() (*ProposeTask, error) {
 			datab = []byte("EMPTY")
 			trx_bft_log.Debugf("<%s> SOMETHING WRONG ~~~, datab is empty, set to EMPTY", bft.groupId)
 			break
-		} else if len(datab) <= MAXIMUM_TRX_BUNDLE_LENGTH {
+		} else if len(datab) <= packing.MaxBundleBytes {
 			trx_bft_log.Debugf("<%s> datab length <%d> is ok", bft.groupId, len(datab))
 			break
 		}
@@ -167,10 +217,18 @@ func (bft *TrxBft) NewProposeTask() (*ProposeTask, error) {
 	currEpoch := bft.producer.cIface.GetCurrEpoch()
 	proposedEpoch := currEpoch + 1
 
+	//producer list may be changed since last epoch, the epochs in progress are not affected
+	if cfg, err := bft.producer.createBftConfig(); err != nil {
+		trx_bft_log.Warnf("<%s> reload producers failed <%s>, use the last ones", bft.groupId, err.Error())
+	} else {
+		bft.Config = *cfg
+	}
+
 	task := &ProposeTask{
 		Epoch:          proposedEpoch,
 		ProposedData:   datab,
 		DelayStartTime: DEFAULT_PROPOSE_PULSE,
+		Config:         bft.Config,
 	}
 
 	return task, nil
@@ -178,11 +236,13 @@ func (bft *TrxBft) NewProposeTask() (*ProposeTask, error) {
 
 func (bft *TrxBft) StopPropose() {
 	trx_bft_log.Debugf("<%s> StopPropose called", bft.groupId)
+	started := bft.status == RUNNING
 	bft.status = CLOSED
 	safeCloseTaskQ(bft.taskq)
 	safeClose(bft.taskdone)
-	if bft.stopnotify != nil {
-		signcount := 1
+	//taskq is not started if the node never became a producer
+	if started && bft.stopnotify != nil {
+		signcount := 0
 		for range bft.stopnotify {
 			signcount++
 			//wait stop sign and set idle
@@ -228,7 +288,15 @@ func (bft *TrxBft) AddTrx(tx *quorumpb.Trx) error {
 		return errors.New("trx.data too large, should less than 300Kb")
 	}
 
-	bft.txBuffer.Push(tx)
+	if _, err := bft.txBuffer.GetTrxById(tx.TrxId); err == nil {
+		trx_bft_log.Debugf("<%s> trx <%s> already in buffer, ignore", bft.groupId, tx.TrxId)
+		return nil
+	}
+
+	if err := bft.txBuffer.Push(tx); err != nil {
+		trx_bft_log.Warnf("<%s> push trx <%s> to buffer failed <%s>", bft.groupId, tx.TrxId, err.Error())
+		return err
+	}
 	//for debug only added by cuicat
 	//list all trxs in buffer
 	trxs, err := bft.txBuffer.GetAllTrxInBuffer()
@@ -247,17 +315,61 @@ func (bft *TrxBft) AddTrx(tx *quorumpb.Trx) error {
 func (bft *TrxBft) HandleMessage(hbmsg *quorumpb.HBMsgv1) error {
 	trx_bft_log.Debugf("<%s> HandleMessage called, Epoch <%d>", bft.groupId, hbmsg.Epoch)
 
+	bft.acsMu.Lock()
+	defer bft.acsMu.Unlock()
+
+	//not a producer yet
+	if bft.status != RUNNING {
+		return nil
+	}
+
 	if bft.acsInsts != nil && hbmsg.Epoch < bft.acsInsts.Epoch {
 		trx_bft_log.Warnf("message from old epoch, ignore")
 		return nil
 	}
 
+	//other producers may start the epoch earlier, keep the message till the epoch starts
+	if bft.acsInsts == nil || hbmsg.Epoch > bft.acsInsts.Epoch {
+		if hbmsg.Epoch > bft.producer.cIface.GetCurrEpoch()+PENDING_EPOCH_WINDOW {
+			trx_bft_log.Debugf("<%s> message from epoch <%d> is too far ahead, ignore", bft.groupId, hbmsg.Epoch)
+			return nil
+		}
+		bft.pending[hbmsg.Epoch] = append(bft.pending[hbmsg.Epoch], hbmsg)
+		return nil
+	}
+
 	//handle msg
 	return bft.acsInsts.HandleMessage(hbmsg)
 }
 
+// handlePending handles the messages kept for the epoch of current acs, and drops the ones of older epochs
+func (bft *TrxBft) handlePending() {
+	epoch := bft.acsInsts.Epoch
+	for e, msgs := range bft.pending {
+		if e > epoch {
+			continue
+		}
+		delete(bft.pending, e)
+		if e < epoch {
+			continue
+		}
+		for _, hbmsg := range msgs {
+			if err := bft.acsInsts.HandleMessage(hbmsg); err != nil {
+				trx_bft_log.Debugf("<%s> handle pending message of epoch <%d> failed <%s>", bft.groupId, e, err.Error())
+			}
+		}
+	}
+}
+
 func (bft *TrxBft) AcsDone(epoch uint64, result map[string][]byte) {
 	trx_bft_log.Debugf("<%s> AcsDone called, Epoch <%d>", bft.producer.groupId, epoch)
+
+	//the epoch is finished by the block from other producer, the task is killed already
+	if epoch <= bft.producer.cIface.GetCurrEpoch() {
+		trx_bft_log.Debugf("<%s> epoch <%d> is done by block from other producer, ignore", bft.producer.groupId, epoch)
+		return
+	}
+
 	trxs := make(map[string]*quorumpb.Trx) //trx_id
 
 	//decode trxs
@@ -284,19 +396,15 @@ func (bft *TrxBft) AcsDone(epoch uint64, result map[string][]byte) {
 	//try package trxs with a new block
 	if len(trxs) != 0 {
 		//Try build block and broadcast it
-		err := bft.buildBlock(epoch, trxs)
+		packaged, err := bft.buildBlock(epoch, trxs)
 		if err != nil {
 			trx_bft_log.Warnf("<%s> Build block failed at epoch <%d>, error <%s>", bft.producer.groupId, epoch, err.Error())
 			return
 		}
-		//remove packaged trxs from buffer
-		for trxId := range trxs {
-			err := bft.txBuffer.Delete(trxId)
-			trx_bft_log.Debugf("<%s> remove packaged trx <%s>", bft.producer.groupId, trxId)
-			if err != nil {
-				trx_bft_log.Warnf(err.Error())
-			}
-		}
+		GetEpochTracer(bft.groupId).Packaged(epoch, len(packaged))
+
+		//remove packaged trxs from buffer, trxs out of the bundle size are left for next epochs
+		bft.removePackaged(packaged)
 
 		//get all trxs in buffer after delete
 		trxs, err := bft.txBuffer.GetAllTrxInBuffer()
@@ -320,6 +428,9 @@ func (bft *TrxBft) AcsDone(epoch uint64, result map[string][]byte) {
 	bft.producer.cIface.SaveChainInfoToDb()
 	trx_bft_log.Debugf("<%s> ChainInfo updated", bft.producer.groupId)
 
+	//write state snapshot and prune old blocks
+	bft.producer.cIface.UpdSnapshot()
+
 	//finish current task
 	bft.taskdone <- struct{}{}
 
@@ -327,29 +438,25 @@ func (bft *TrxBft) AcsDone(epoch uint64, result map[string][]byte) {
 	bft.addTask(task)
 }
 
-func (bft *TrxBft) buildBlock(epoch uint64, trxs map[string]*quorumpb.Trx) error {
-	trx_bft_log.Debugf("<%s> buildBlock called, epoch <%d>", bft.producer.groupId, epoch)
-	//try build block by using trxs
-	sortedTrxs := bft.sortTrx(trxs)
-	trx_bft_log.Debugf("<%s> sorted trxs", bft.producer.groupId)
-	//list all sorted trx
-	for _, trx := range sortedTrxs {
-		trx_bft_log.Debugf("<%s> TrxId <%s>", bft.producer.groupId, trx.TrxId)
-	}
-
-	var trxToPackage []*quorumpb.Trx
-
-	//check total trxs size
-	totalTrxSizeInBytes := 0
-	for _, trx := range sortedTrxs {
-		datab, _ := proto.Marshal(trx)
-		if totalTrxSizeInBytes+len(datab) <= MAXIMUM_TRX_BUNDLE_LENGTH {
-			trxToPackage = append(trxToPackage, trx)
-			totalTrxSizeInBytes = totalTrxSizeInBytes + len(datab)
-		} else {
-			break
+// removePackaged removes the trxs packaged into block from buffer
+func (bft *TrxBft) removePackaged(trxs []*quorumpb.Trx) {
+	for _, trx := range trxs {
+		if _, err := bft.txBuffer.GetTrxById(trx.TrxId); err != nil {
+			continue
+		}
+		trx_bft_log.Debugf("<%s> remove packaged trx <%s>", bft.producer.groupId, trx.TrxId)
+		if err := bft.txBuffer.Delete(trx.TrxId); err != nil {
+			trx_bft_log.Warnf(err.Error())
 		}
 	}
+}
+
+func (bft *TrxBft) buildBlock(epoch uint64, trxs map[string]*quorumpb.Trx) ([]*quorumpb.Trx, error) {
+	trx_bft_log.Debugf("<%s> buildBlock called, epoch <%d>", bft.producer.groupId, epoch)
+	//try build block by using trxs, sorted by packing policy and within the bundle size
+	packing := bft.getPacking()
+	trx_bft_log.Debugf("<%s> package trxs with policy <%s>", bft.producer.groupId, packing.Policy.Name())
+	trxToPackage := packing.Package(trxs, bft.producer.grpItem.OwnerPubKey)
 	//list all trx to package
 	trx_bft_log.Debugf("<%s> trx to package", bft.producer.groupId)
 	for _, trx := range trxToPackage {
@@ -361,45 +468,46 @@ func (bft *TrxBft) buildBlock(epoch uint64, trxs map[string]*quorumpb.Trx) error
 
 	if err != nil {
 		trx_bft_log.Debugf("<%s> get block parent failed, <%s>", bft.producer.groupId, err.Error())
-		return err
+		return nil, err
 	} else {
 		trx_bft_log.Debugf("<%s> start build block with parent <%d> ", bft.producer.groupId, parent.BlockId)
+		version, err := nodectx.GetNodeCtx().GetChainStorage().GetBlockVersionByGroupId(bft.producer.groupId, epoch, bft.producer.nodename)
+		if err != nil {
+			trx_bft_log.Debugf("<%s> get block version failed <%s>", bft.producer.groupId, err.Error())
+			return nil, err
+		}
 		ks := localcrypto.GetKeystore()
 
-		newBlock, err := rumchaindata.CreateBlockByEthKey(parent, epoch, trxToPackage, false, bft.producer.grpItem.UserSignPubkey, ks, "", bft.producer.nodename)
+		newBlock, err := rumchaindata.CreateBlockByEthKey(parent, epoch, version, trxToPackage, false, bft.producer.grpItem.UserSignPubkey, ks, "", bft.producer.nodename)
 
 		if err != nil {
 			trx_bft_log.Debugf("<%s> build block failed <%s>", bft.producer.groupId, err.Error())
-			return err
+			return nil, err
 		}
 
 		//save it
 		trx_bft_log.Debugf("<%s> save block just built to local db", bft.producer.groupId)
 		err = nodectx.GetNodeCtx().GetChainStorage().AddBlock(newBlock, false, bft.producer.nodename)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		//apply trxs
 		if nodectx.GetNodeCtx().NodeType == nodectx.PRODUCER_NODE {
-			bft.producer.cIface.ApplyTrxsProducerNode(trxToPackage, bft.producer.nodename)
+			bft.producer.cIface.ApplyTrxsProducerNode(trxToPackage, newBlock.BlockId, bft.producer.nodename)
 		} else if nodectx.GetNodeCtx().NodeType == nodectx.FULL_NODE {
-			bft.producer.cIface.ApplyTrxsFullNode(trxToPackage, bft.producer.nodename)
+			bft.producer.cIface.ApplyTrxsFullNode(trxToPackage, newBlock.BlockId, bft.producer.nodename)
 		}
 
 		//broadcast it
 		trx_bft_log.Debugf("<%s> broadcast block just built to user channel", bft.producer.groupId)
-		connMgr, err := conn.GetConn().GetConnMgr(bft.producer.groupId)
-		if err != nil {
-			return err
-		}
-		err = connMgr.BroadcastBlock(newBlock)
+		err = bft.producer.transport.BroadcastBlock(bft.producer.groupId, newBlock)
 		if err != nil {
 			trx_acs_log.Debugf("<%s> Broadcast failed <%s>", bft.producer.groupId, err.Error())
 		}
 	}
 
-	return nil
+	return trxToPackage, nil
 }
 
 // sort trxs by using timestamp
@@ -415,32 +523,31 @@ func (a TrxSlice) Less(i, j int) bool {
 	return a[j].TimeStamp < a[i].TimeStamp
 }
 
-func (bft *TrxBft) sortTrx(trxs map[string]*quorumpb.Trx) []*quorumpb.Trx {
-	result := []*quorumpb.Trx{}
-	container := make(map[string][]*quorumpb.Trx)
-
-	//group trxs by using sender Pubkey (group trxs from same sender)
-	for _, trx := range trxs {
-		container[trx.SenderPubkey] = append(container[trx.SenderPubkey], trx)
-	}
+func (bft *TrxBft) getPacking() *PackingConfig {
+	bft.packingMu.Lock()
+	defer bft.packingMu.Unlock()
+	return bft.packing
+}
 
-	//sort each grouped trxs by using timestamp (from small to large)
-	for _, trxs := range container {
-		sort.Sort(sort.Reverse(TrxSlice(trxs)))
-	}
+// updPacking reloads the packing config from chain config
+func (bft *TrxBft) updPacking() *PackingConfig {
+	packing := LoadPackingConfig(bft.groupId, bft.producer.nodename)
+	bft.packingMu.Lock()
+	defer bft.packingMu.Unlock()
+	bft.packing = packing
+	return packing
+}
 
-	var senderKeys []string
-	//get all key (sender pubkey) from container
-	for key, _ := range container {
-		senderKeys = append(senderKeys, key)
-	}
+// sortTrxs groups trxs by sender, and sorts each group by timestamp, trxs from owner are at the end
+func sortTrxs(trxs map[string]*quorumpb.Trx, ownerPubkey string) []*quorumpb.Trx {
+	result := []*quorumpb.Trx{}
 
-	//sort sender key
-	sort.Strings(senderKeys)
+	//group trxs by using sender Pubkey, each grouped trxs is sorted by timestamp (from small to large)
+	container, senderKeys := groupBySender(trxs)
 
 	for _, key := range senderKeys {
 		//skip owner trxs
-		if key == bft.producer.grpItem.OwnerPubKey {
+		if key == ownerPubkey {
 			continue
 		}
 		//append
@@ -448,7 +555,7 @@ func (bft *TrxBft) sortTrx(trxs map[string]*quorumpb.Trx) []*quorumpb.Trx {
 	}
 
 	//append any trxs from owner at the end of trxs slice
-	if ownertrxs, ok := container[bft.producer.grpItem.OwnerPubKey]; ok {
+	if ownertrxs, ok := container[ownerPubkey]; ok {
 		result = append(result, ownertrxs...)
 	}
 
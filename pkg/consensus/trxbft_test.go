@@ -0,0 +1,132 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rumsystem/quorum/pkg/consensus/simnet"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+const bftTestGroupId = "2f6e8a0c-4b1d-4d3a-9e7f-5c0b8a6d2e14"
+
+func newTestBft(currEpoch uint64) *TrxBft {
+	producer := &MolassesProducer{groupId: bftTestGroupId, cIface: &simChain{epoch: currEpoch}}
+	return &TrxBft{
+		Config:   Config{N: 4, f: 1, Nodes: []string{"p1", "p2", "p3", "p4"}},
+		groupId:  bftTestGroupId,
+		producer: producer,
+		status:   RUNNING,
+		pending:  make(map[uint64][]*quorumpb.HBMsgv1),
+	}
+}
+
+func TestHandleMessageOfLaterEpoch(t *testing.T) {
+	bft := newTestBft(5)
+
+	//the other producers started epoch 6 before me, keep the messages till my acs of epoch 6 is created
+	for _, epoch := range []uint64{6, 6, 7, 8} {
+		if err := bft.HandleMessage(&quorumpb.HBMsgv1{Epoch: epoch, PayloadType: -1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(bft.pending[6]) != 2 || len(bft.pending[7]) != 1 {
+		t.Fatalf("expect messages of epoch 6 and 7 kept, got %v", bft.pending)
+	}
+	if _, ok := bft.pending[8]; ok {
+		t.Errorf("messages more than %d epochs ahead should be dropped", PENDING_EPOCH_WINDOW)
+	}
+
+	bft.acsMu.Lock()
+	bft.acsInsts = &TrxACS{Config: bft.Config, bft: bft, Epoch: 6}
+	bft.handlePending()
+	bft.acsMu.Unlock()
+	if _, ok := bft.pending[6]; ok {
+		t.Errorf("messages of epoch 6 should be handled by the acs of epoch 6")
+	}
+	if len(bft.pending[7]) != 1 {
+		t.Errorf("messages of epoch 7 should be kept, got %v", bft.pending)
+	}
+
+	//old epoch is ignored
+	if err := bft.HandleMessage(&quorumpb.HBMsgv1{Epoch: 5, PayloadType: -1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := bft.pending[5]; ok {
+		t.Errorf("messages of old epoch should not be kept")
+	}
+}
+
+func TestRbcDoneOnce(t *testing.T) {
+	defer RemoveEpochTracer(bftTestGroupId)
+	//epoch 1 is finished already, so AcsDone does nothing
+	bft := newTestBft(1)
+
+	acs := &TrxACS{
+		Config:       bft.Config,
+		bft:          bft,
+		Epoch:        1,
+		rbcInstances: make(map[string]*TrxRBC),
+		rbcOutput:    make(map[string]bool),
+		rbcResults:   make(map[string][]byte),
+		tracer:       GetEpochTracer(bft.groupId),
+	}
+	for _, pubkey := range bft.Nodes {
+		acs.rbcInstances[pubkey] = &TrxRBC{}
+	}
+	acs.tracer.StartEpoch(1, bft.Config)
+
+	for _, pubkey := range []string{"p1", "p2", "p3"} {
+		acs.RbcDone(pubkey)
+	}
+	decidedAt := GetEpochTraces(bft.groupId)[0].DecidedAt
+	if decidedAt == 0 {
+		t.Fatalf("epoch should be decided after N-f rbc done")
+	}
+
+	//rbc of a proposer done again, by a late ECHO, should not decide the epoch again
+	time.Sleep(time.Millisecond)
+	acs.RbcDone("p3")
+	if trace := GetEpochTraces(bft.groupId)[0]; trace.DecidedAt != decidedAt {
+		t.Errorf("epoch should be decided only once")
+	}
+}
+
+func TestAcsDoneOfFinishedEpoch(t *testing.T) {
+	//the epoch is finished by the block from other producer, txBuffer is nil and must not be used
+	bft := newTestBft(3)
+
+	result := make(chan interface{}, 1)
+	go func() {
+		defer func() {
+			result <- recover()
+		}()
+		bft.AcsDone(3, map[string][]byte{"p1": nil})
+	}()
+	select {
+	case r := <-result:
+		if r != nil {
+			t.Errorf("AcsDone of finished epoch should do nothing, got %v", r)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("AcsDone of finished epoch should return")
+	}
+}
+
+func TestStopPropose(t *testing.T) {
+	cluster := newSimCluster(t, 4, simnet.Config{Seed: 5, MinLatency: time.Millisecond, MaxLatency: 2 * time.Millisecond})
+	cluster.start()
+
+	done := make(chan struct{})
+	go func() {
+		for _, node := range cluster.nodes {
+			node.producer.bft.StopPropose()
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("StopPropose should return after the propose task stopped")
+	}
+}
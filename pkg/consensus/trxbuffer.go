@@ -1,67 +1,237 @@
 package consensus
 
 import (
+	"fmt"
 	"math/rand"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
 	quorumpb "github.com/rumsystem/quorum/pkg/pb"
 )
 
+// limits of buffered trxs, 0 means no limit
+var TRX_BUFFER_SENDER_LIMIT = 100
+var TRX_BUFFER_GROUP_LIMIT = 10000
+
 // just a simple wrap of HBB Trx Buffer DB
 type TrxBuffer struct {
-	queueId string
+	queueId  string
+	nodename string
+}
+
+// bufferState is the in memory index of the trxs in a buffer, shared by the TrxBuffer of the same queue,
+// so the limits and the expired trxs are checked without loading the whole buffer from db
+type bufferState struct {
+	mu         sync.Mutex
+	trxs       map[string]*bufferedTrx // trxId => buffered trx
+	senders    map[string]int          // sender pubkey => count of buffered trxs
+	nextExpire int64                   // the earliest expire time of the buffered trxs, 0 for none
+}
+
+type bufferedTrx struct {
+	sender   string
+	expired  int64
+	received int64 // the time the trx is pushed, the trx timestamp for the trxs loaded from db
+}
+
+type bufferStateKey struct {
+	storage  *chainstorage.Storage
+	queueId  string
+	nodename string
 }
 
-func NewTrxBuffer(queueId string) *TrxBuffer {
+var (
+	bufferStatesMu sync.Mutex
+	bufferStates   = map[bufferStateKey]*bufferState{}
+)
+
+func NewTrxBuffer(queueId, nodename string) *TrxBuffer {
 	b := &TrxBuffer{
-		queueId: queueId,
+		queueId:  queueId,
+		nodename: nodename,
 	}
 	rand.Seed(time.Now().UnixNano())
 	return b
 }
 
+// state returns the index of the buffer, it is loaded from db at the first call
+func (b *TrxBuffer) state() (*bufferState, error) {
+	bufferStatesMu.Lock()
+	defer bufferStatesMu.Unlock()
+	key := bufferStateKey{storage: nodectx.GetNodeCtx().GetChainStorage(), queueId: b.queueId, nodename: b.nodename}
+	if st, ok := bufferStates[key]; ok {
+		return st, nil
+	}
+
+	trxs, err := b.GetAllTrxInBuffer()
+	if err != nil {
+		return nil, err
+	}
+	st := &bufferState{trxs: make(map[string]*bufferedTrx), senders: make(map[string]int)}
+	for _, trx := range trxs {
+		st.add(trx, trx.TimeStamp)
+	}
+	bufferStates[key] = st
+	return st, nil
+}
+
+func (st *bufferState) add(trx *quorumpb.Trx, received int64) {
+	st.trxs[trx.TrxId] = &bufferedTrx{sender: trx.SenderPubkey, expired: trx.Expired, received: received}
+	st.senders[trx.SenderPubkey]++
+	if trx.Expired > 0 && (st.nextExpire == 0 || trx.Expired < st.nextExpire) {
+		st.nextExpire = trx.Expired
+	}
+}
+
+func (st *bufferState) remove(trxId string) {
+	item, ok := st.trxs[trxId]
+	if !ok {
+		return
+	}
+	delete(st.trxs, trxId)
+	if st.senders[item.sender] <= 1 {
+		delete(st.senders, item.sender)
+	} else {
+		st.senders[item.sender]--
+	}
+}
+
 func (b *TrxBuffer) GetBufferLen() (int, error) {
-	return nodectx.GetNodeCtx().GetChainStorage().GeBufferedTrxLenHBB(b.queueId)
+	st, err := b.state()
+	if err != nil {
+		return -1, err
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return len(st.trxs), nil
 }
 
+// Push adds trx to the buffer, trx is rejected if the sender or the group is over limit
 func (b *TrxBuffer) Push(trx *quorumpb.Trx) error {
-	return nodectx.GetNodeCtx().GetChainStorage().AddTrxHBB(trx, b.queueId)
+	st, err := b.state()
+	if err != nil {
+		return err
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if TRX_BUFFER_GROUP_LIMIT > 0 && len(st.trxs) >= TRX_BUFFER_GROUP_LIMIT {
+		return fmt.Errorf("trx buffer of group is full, limit <%d>", TRX_BUFFER_GROUP_LIMIT)
+	}
+	if TRX_BUFFER_SENDER_LIMIT > 0 && st.senders[trx.SenderPubkey] >= TRX_BUFFER_SENDER_LIMIT {
+		return fmt.Errorf("trx buffer of sender <%s> is full, limit <%d>", trx.SenderPubkey, TRX_BUFFER_SENDER_LIMIT)
+	}
+	if err := nodectx.GetNodeCtx().GetChainStorage().AddTrxHBB(trx, b.queueId, b.nodename); err != nil {
+		return err
+	}
+	st.add(trx, time.Now().UnixNano())
+	return nil
 }
 
 func (b *TrxBuffer) Delete(trxId string) error {
-	return nodectx.GetNodeCtx().GetChainStorage().RemoveTrxHBB(trxId, b.queueId)
+	st, err := b.state()
+	if err != nil {
+		return err
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if err := nodectx.GetNodeCtx().GetChainStorage().RemoveTrxHBB(trxId, b.queueId, b.nodename); err != nil {
+		return err
+	}
+	st.remove(trxId)
+	return nil
+}
+
+// RemoveExpired drops trxs expired at now, returns the number of dropped trxs
+func (b *TrxBuffer) RemoveExpired(now int64) (int, error) {
+	st, err := b.state()
+	if err != nil {
+		return 0, err
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.nextExpire == 0 || now <= st.nextExpire {
+		return 0, nil
+	}
+
+	removed := 0
+	st.nextExpire = 0
+	for trxId, item := range st.trxs {
+		if item.expired == 0 {
+			continue
+		}
+		if now <= item.expired {
+			if st.nextExpire == 0 || item.expired < st.nextExpire {
+				st.nextExpire = item.expired
+			}
+			continue
+		}
+		if err := nodectx.GetNodeCtx().GetChainStorage().RemoveTrxHBB(trxId, b.queueId, b.nodename); err != nil {
+			//check it again at the next call
+			st.nextExpire = item.expired
+			return removed, err
+		}
+		st.remove(trxId)
+		removed++
+	}
+	return removed, nil
 }
 
 func (b *TrxBuffer) Clear() error {
-	return nodectx.GetNodeCtx().GetChainStorage().RemoveAllTrxHBB(b.queueId)
+	st, err := b.state()
+	if err != nil {
+		return err
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if err := nodectx.GetNodeCtx().GetChainStorage().RemoveAllTrxHBB(b.queueId, b.nodename); err != nil {
+		return err
+	}
+	st.trxs = make(map[string]*bufferedTrx)
+	st.senders = make(map[string]int)
+	st.nextExpire = 0
+	return nil
 }
 
 func (b *TrxBuffer) GetTrxById(trxId string) (*quorumpb.Trx, error) {
-	return nodectx.GetNodeCtx().GetChainStorage().GetTrxByIdHBB(trxId, b.queueId)
+	return nodectx.GetNodeCtx().GetChainStorage().GetTrxByIdHBB(trxId, b.queueId, b.nodename)
 }
 
 func (b *TrxBuffer) GetAllTrxInBuffer() ([]*quorumpb.Trx, error) {
-	return nodectx.GetNodeCtx().GetChainStorage().GetAllTrxHBB(b.queueId)
+	return nodectx.GetNodeCtx().GetChainStorage().GetAllTrxHBB(b.queueId, b.nodename)
 }
 
-// since trx is buffered in *random" way, no sequence is created
-// just return the first n items in the slice is enough
-// caller should check the length of return trx slice
-func (b *TrxBuffer) GetNRandTrx(n int) ([]*quorumpb.Trx, error) {
-	//get len
-	len, err := nodectx.GetNodeCtx().GetChainStorage().GeBufferedTrxLenHBB(b.queueId)
+// GetAllTrxByReceived returns the trxs in buffer in the order they are received, the earliest first
+func (b *TrxBuffer) GetAllTrxByReceived() ([]*quorumpb.Trx, error) {
+	st, err := b.state()
+	if err != nil {
+		return nil, err
+	}
+	trxs, err := b.GetAllTrxInBuffer()
 	if err != nil {
 		return nil, err
 	}
 
-	trxs, err := nodectx.GetNodeCtx().GetChainStorage().GetAllTrxHBB(b.queueId)
-
-	if n >= len {
-		//return all trxs in buffer
-		return trxs, err
-	} else {
-		//return first n trxs in buffer
-		return trxs[:n], err
+	st.mu.Lock()
+	received := make(map[string]int64, len(trxs))
+	for _, trx := range trxs {
+		if item, ok := st.trxs[trx.TrxId]; ok {
+			received[trx.TrxId] = item.received
+		} else {
+			received[trx.TrxId] = trx.TimeStamp
+		}
 	}
+	st.mu.Unlock()
+
+	sort.SliceStable(trxs, func(i, j int) bool {
+		ri, rj := received[trxs[i].TrxId], received[trxs[j].TrxId]
+		if ri != rj {
+			return ri < rj
+		}
+		return trxs[i].TrxId < trxs[j].TrxId
+	})
+	return trxs, nil
 }
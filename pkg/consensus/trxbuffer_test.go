@@ -0,0 +1,117 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rumsystem/quorum/internal/pkg/nodectx"
+	"github.com/rumsystem/quorum/internal/pkg/storage"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+func initBufferTestCtx(t *testing.T) {
+	dir := t.TempDir()
+	groupDb, err := storage.OpenStore(context.Background(), storage.BoltBackend, dir, "groups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataDb, err := storage.OpenStore(context.Background(), storage.BoltBackend, dir, "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbMgr := &storage.DbMgr{GroupInfoDb: groupDb, Db: dataDb, DataPath: dir}
+	nodectx.InitCtx(context.Background(), "buffer", nil, dbMgr, chainstorage.NewChainStorage(dbMgr), "pubsub", "", nodectx.PRODUCER_NODE)
+}
+
+func TestTrxBufferLimits(t *testing.T) {
+	initBufferTestCtx(t)
+	oldSender, oldGroup := TRX_BUFFER_SENDER_LIMIT, TRX_BUFFER_GROUP_LIMIT
+	TRX_BUFFER_SENDER_LIMIT, TRX_BUFFER_GROUP_LIMIT = 2, 3
+	t.Cleanup(func() { TRX_BUFFER_SENDER_LIMIT, TRX_BUFFER_GROUP_LIMIT = oldSender, oldGroup })
+
+	buffer := NewTrxBuffer(simTestGroupId, "buffer")
+	push := func(id, sender string) error {
+		return buffer.Push(&quorumpb.Trx{TrxId: id, GroupId: simTestGroupId, SenderPubkey: sender})
+	}
+	if err := push("1", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := push("2", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := push("3", "a"); err == nil {
+		t.Errorf("expect sender limit error")
+	}
+	if err := push("3", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := push("4", "c"); err == nil {
+		t.Errorf("expect group limit error")
+	}
+
+	// the counters are shared by the buffers of the same queue
+	if err := NewTrxBuffer(simTestGroupId, "buffer").Delete("1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := push("4", "a"); err != nil {
+		t.Errorf("sender counter should be decreased after delete: %s", err)
+	}
+	if n, err := buffer.GetBufferLen(); err != nil || n != 3 {
+		t.Errorf("expect 3 trxs in buffer, got %d, %v", n, err)
+	}
+
+	if err := buffer.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := buffer.GetBufferLen(); n != 0 {
+		t.Errorf("expect empty buffer after clear, got %d", n)
+	}
+}
+
+func TestTrxBufferRemoveExpired(t *testing.T) {
+	initBufferTestCtx(t)
+	buffer := NewTrxBuffer(simTestGroupId, "buffer")
+	now := time.Now().UnixNano()
+	for i, expired := range []int64{0, now - 1, now + int64(time.Hour)} {
+		trx := &quorumpb.Trx{TrxId: fmt.Sprint(i), GroupId: simTestGroupId, SenderPubkey: "a", Expired: expired}
+		if err := buffer.Push(trx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if removed, err := buffer.RemoveExpired(now); err != nil || removed != 1 {
+		t.Errorf("expect 1 expired trx removed, got %d, %v", removed, err)
+	}
+	if removed, err := buffer.RemoveExpired(now); err != nil || removed != 0 {
+		t.Errorf("expect no expired trx, got %d, %v", removed, err)
+	}
+	if removed, err := buffer.RemoveExpired(now + int64(2*time.Hour)); err != nil || removed != 1 {
+		t.Errorf("expect 1 expired trx removed, got %d, %v", removed, err)
+	}
+	trxs, err := buffer.GetAllTrxInBuffer()
+	if err != nil || len(trxs) != 1 || trxs[0].TrxId != "0" {
+		t.Errorf("expect the trx without expire time left, got %v, %v", trxs, err)
+	}
+}
+
+func TestTrxBufferReceivedOrder(t *testing.T) {
+	initBufferTestCtx(t)
+	buffer := NewTrxBuffer(simTestGroupId, "buffer")
+	for _, id := range []string{"c", "a", "b"} {
+		trx := &quorumpb.Trx{TrxId: id, GroupId: simTestGroupId, SenderPubkey: "a"}
+		if err := buffer.Push(trx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	trxs, err := buffer.GetAllTrxByReceived()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := trxIds(trxs); fmt.Sprint(ids) != "[c a b]" {
+		t.Errorf("expect trxs in received order, got %v", ids)
+	}
+}
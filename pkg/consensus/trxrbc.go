@@ -1,12 +1,14 @@
 package consensus
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/klauspost/reedsolomon"
 	"github.com/rumsystem/quorum/internal/pkg/logging"
 
 	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
 )
 
 var trx_rbc_log = logging.Logger("trbc")
@@ -32,6 +34,11 @@ type TrxRBC struct {
 	readySent    map[string]bool
 	consenusDone bool
 
+	//first signed messages seen in this epoch, to find the conflicting ones
+	seenPropose *quorumpb.InitPropose
+	seenEchos   map[string]*quorumpb.Echo  //key is echo provider
+	seenReadys  map[string]*quorumpb.Ready //key is ready provider
+
 	acs *TrxACS //for callback when finished
 }
 
@@ -74,6 +81,8 @@ func NewTrxRBC(cfg Config, acs *TrxACS, groupId, myPubkey, rbcInstPubkey string)
 		numDataShards:   dataShards,
 		readySent:       make(map[string]bool),
 		consenusDone:    false,
+		seenEchos:       make(map[string]*quorumpb.Echo),
+		seenReadys:      make(map[string]*quorumpb.Ready),
 	}
 
 	return rbc, nil
@@ -94,7 +103,7 @@ func (r *TrxRBC) InputValue(data []byte) error {
 
 	//create InitPropoeMsgs
 	originalDataSize := len(data)
-	initProposeMsgs, err := MakeRBCInitProposeMessage(r.groupId, r.acs.bft.producer.nodename, r.MyPubkey, shards, r.Config.Nodes, originalDataSize)
+	initProposeMsgs, err := MakeRBCInitProposeMessage(r.groupId, r.acs.bft.producer.nodename, r.MyPubkey, shards, r.Config.Nodes, originalDataSize, r.acs.Epoch)
 
 	if err != nil {
 		trx_rbc_log.Debugf(err.Error())
@@ -103,7 +112,7 @@ func (r *TrxRBC) InputValue(data []byte) error {
 
 	// broadcast RBC msg out via pubsub
 	for _, initMsg := range initProposeMsgs {
-		err := SendHBRBCMsg(r.groupId, initMsg, r.acs.Epoch)
+		err := SendHBRBCMsg(r.acs.bft.producer.transport, r.groupId, initMsg, r.acs.Epoch)
 		if err != nil {
 			return err
 		}
@@ -118,7 +127,7 @@ func (r *TrxRBC) handleInitProposeMsg(initp *quorumpb.InitPropose) error {
 		return fmt.Errorf("<%s> receive proof from non producer <%s>", r.rbcInstPubkey, initp.ProposerPubkey)
 	}
 
-	if !r.VerifySign() {
+	if !r.VerifySign(initp) {
 		return fmt.Errorf("<%s> verify signature failed from producer <%s>", r.rbcInstPubkey, initp.ProposerPubkey)
 	}
 
@@ -126,6 +135,7 @@ func (r *TrxRBC) handleInitProposeMsg(initp *quorumpb.InitPropose) error {
 	if isValid := ValidateInitPropose(initp); !isValid {
 		return fmt.Errorf("<%s> receive invalid InitPropose msg from producer<%s>", r.rbcInstPubkey, initp.ProposerPubkey)
 	}
+	r.checkPropose(initp)
 
 	//make proof
 	proofMsg, err := MakeRBCEchoMessage(r.groupId, r.acs.bft.producer.nodename, r.MyPubkey, initp, int(initp.OriginalDataSize))
@@ -134,7 +144,7 @@ func (r *TrxRBC) handleInitProposeMsg(initp *quorumpb.InitPropose) error {
 	}
 
 	trx_rbc_log.Infof("<%s> create and send Echo msg for proposer <%s>", r.rbcInstPubkey, initp.ProposerPubkey)
-	return SendHBRBCMsg(r.groupId, proofMsg, r.acs.Epoch)
+	return SendHBRBCMsg(r.acs.bft.producer.transport, r.groupId, proofMsg, r.acs.Epoch)
 }
 
 func (r *TrxRBC) handleEchoMsg(echo *quorumpb.Echo) error {
@@ -144,13 +154,14 @@ func (r *TrxRBC) handleEchoMsg(echo *quorumpb.Echo) error {
 		return fmt.Errorf("<%s> receive ECHO from non producer node <%s>", r.rbcInstPubkey, echo.EchoProviderPubkey)
 	}
 
-	if !r.VerifySign() {
+	if !r.VerifySign(echo) {
 		return fmt.Errorf("<%s> verify ECHO signature failed from producer node <%s>", r.rbcInstPubkey, echo.EchoProviderPubkey)
 	}
 
 	if !ValidateEcho(echo) {
 		return fmt.Errorf("<%s> received invalid ECHO from producer node <%s>", r.rbcInstPubkey, echo.EchoProviderPubkey)
 	}
+	r.checkEcho(echo)
 
 	roothashS := string(echo.RootHash)
 	//save echo by using roothash
@@ -160,7 +171,7 @@ func (r *TrxRBC) handleEchoMsg(echo *quorumpb.Echo) error {
 
 	trx_rbc_log.Debugf("<%s> RootHash <%v>, Recvived <%d> ECHO", r.rbcInstPubkey, echo.RootHash[:8], r.recvEchos[roothashS].Len())
 
-	if len(r.recvReadys[roothashS]) == 2*r.f+1 && r.recvEchos[roothashS].Len() >= r.N-2*r.f {
+	if !r.consenusDone && len(r.recvReadys[roothashS]) == 2*r.f+1 && r.recvEchos[roothashS].Len() >= r.N-2*r.f {
 		trx_rbc_log.Debugf("<%s> RootHash <%s>, Recvived <%d> READY, which is 2F + 1", r.rbcInstPubkey, roothashS, len(r.recvReadys))
 		trx_rbc_log.Debugf("<%s> RootHash <%s>, Received <%d> ECHO, which is morn than N - 2F", r.rbcInstPubkey, roothashS, r.recvEchos[roothashS].Len())
 		trx_rbc_log.Debugf("<%s> RootHash <%s>, try decode", r.rbcInstPubkey)
@@ -200,18 +211,19 @@ func (r *TrxRBC) handleEchoMsg(echo *quorumpb.Echo) error {
 
 		//multicast READY msg
 		trx_rbc_log.Debugf("<%s> broadcast READY msg", r.rbcInstPubkey)
-		readyMsg, err := MakeRBCReadyMessage(r.groupId, r.acs.bft.producer.nodename, r.MyPubkey, echo.OriginalProposerPubkey, echo.RootHash)
+		readyMsg, err := MakeRBCReadyMessage(r.groupId, r.acs.bft.producer.nodename, r.MyPubkey, echo.OriginalProposerPubkey, echo.RootHash, r.acs.Epoch)
 		if err != nil {
 			return err
 		}
 
-		err = SendHBRBCMsg(r.groupId, readyMsg, r.acs.Epoch)
+		err = SendHBRBCMsg(r.acs.bft.producer.transport, r.groupId, readyMsg, r.acs.Epoch)
 		if err != nil {
 			return err
 		}
 
 		//set ready sent
 		r.readySent[roothashS] = true
+		r.acs.tracer.ReadySent(r.acs.Epoch, r.rbcInstPubkey)
 
 		//set output
 		r.output = output
@@ -227,10 +239,12 @@ func (r *TrxRBC) handleReadyMsg(ready *quorumpb.Ready) error {
 		return fmt.Errorf("<%s> receive READY from non producer node <%s>", r.rbcInstPubkey, ready.ReadyProviderPubkey)
 	}
 
-	if !r.VerifySign() {
+	if !r.VerifySign(ready) {
 		return fmt.Errorf("<%s> verify READY signature failed from producer node <%s>", r.rbcInstPubkey, ready.ReadyProviderPubkey)
 	}
 
+	r.checkReady(ready)
+
 	roothashS := string(ready.RootHash)
 
 	//save it
@@ -252,18 +266,19 @@ func (r *TrxRBC) handleReadyMsg(ready *quorumpb.Ready) error {
 		trx_rbc_log.Debugf("<%s> RootHash <%v>, get f + 1 <%d> READY", r.rbcInstPubkey, ready.RootHash[:8], r.f+1)
 		if !r.readySent[roothashS] {
 			trx_rbc_log.Debugf("<%s> READY not send, boradcast now", r.rbcInstPubkey)
-			readyMsg, err := MakeRBCReadyMessage(r.groupId, r.acs.bft.producer.nodename, r.myPubkey, ready.OriginalProposerPubkey, ready.RootHash)
+			readyMsg, err := MakeRBCReadyMessage(r.groupId, r.acs.bft.producer.nodename, r.myPubkey, ready.OriginalProposerPubkey, ready.RootHash, r.acs.Epoch)
 			if err != nil {
 				return err
 			}
 
-			err = SendHBRBCMsg(r.groupId, readyMsg, r.acs.Epoch)
+			err = SendHBRBCMsg(r.acs.bft.producer.transport, r.groupId, readyMsg, r.acs.Epoch)
 			if err != nil {
 				return err
 			}
 
 			//set ready sent
 			r.readySent[roothashS] = true
+			r.acs.tracer.ReadySent(r.acs.Epoch, r.rbcInstPubkey)
 		}
 	}
 
@@ -319,6 +334,88 @@ func (r *TrxRBC) IsProducer(pubkey string) bool {
 	return false
 }
 
-func (r *TrxRBC) VerifySign() bool {
-	return true
+// checkPropose reports the evidence if the proposer signed InitPropose with another root hash in this epoch
+func (r *TrxRBC) checkPropose(initp *quorumpb.InitPropose) {
+	if initp.Epoch != r.acs.Epoch || initp.ProposerPubkey != r.rbcInstPubkey {
+		return
+	}
+	if r.seenPropose != nil && bytes.Equal(r.seenPropose.RootHash, initp.RootHash) {
+		return
+	}
+	if !verifyInitPropose(initp) {
+		return
+	}
+	if r.seenPropose == nil {
+		r.seenPropose = initp
+		return
+	}
+	r.reportEvidence(quorumpb.EvidenceType_RBC_PROPOSE_EQUIVOCATION, initp.ProposerPubkey, r.seenPropose, initp)
+}
+
+// checkEcho checks the InitPropose carried by the echo, and reports the evidence if the echo provider
+// signed ECHO with another root hash for the proposer in this epoch
+func (r *TrxRBC) checkEcho(echo *quorumpb.Echo) {
+	if echo.Epoch != r.acs.Epoch {
+		return
+	}
+	if echo.ProposerSign != nil {
+		r.checkPropose(InitProposeFromEcho(echo))
+	}
+
+	seen, ok := r.seenEchos[echo.EchoProviderPubkey]
+	if ok && bytes.Equal(seen.RootHash, echo.RootHash) {
+		return
+	}
+	if !verifyEcho(echo) {
+		return
+	}
+	if !ok {
+		r.seenEchos[echo.EchoProviderPubkey] = echo
+		return
+	}
+	r.reportEvidence(quorumpb.EvidenceType_RBC_ECHO_EQUIVOCATION, echo.EchoProviderPubkey, seen, echo)
+}
+
+// checkReady reports the evidence if the ready provider signed READY with another root hash for the
+// proposer in this epoch
+func (r *TrxRBC) checkReady(ready *quorumpb.Ready) {
+	if ready.Epoch != r.acs.Epoch {
+		return
+	}
+
+	seen, ok := r.seenReadys[ready.ReadyProviderPubkey]
+	if ok && bytes.Equal(seen.RootHash, ready.RootHash) {
+		return
+	}
+	if !verifyReady(ready) {
+		return
+	}
+	if !ok {
+		r.seenReadys[ready.ReadyProviderPubkey] = ready
+		return
+	}
+	r.reportEvidence(quorumpb.EvidenceType_RBC_READY_EQUIVOCATION, ready.ReadyProviderPubkey, seen, ready)
+}
+
+func (r *TrxRBC) reportEvidence(typ quorumpb.EvidenceType, offender string, msgA, msgB proto.Message) {
+	evidence, err := NewEvidence(r.groupId, typ, offender, r.acs.Epoch, msgA, msgB)
+	if err == nil {
+		err = ReportEvidence(evidence, r.acs.bft.producer.nodename)
+	}
+	if err != nil {
+		trx_rbc_log.Warnf("<%s> report <%s> of <%s> failed <%s>", r.rbcInstPubkey, typ.String(), offender, err.Error())
+	}
+}
+
+// VerifySign checks the rbc msg is signed by its provider, an ECHO must also carry the signature of the original proposer
+func (r *TrxRBC) VerifySign(msg proto.Message) bool {
+	switch m := msg.(type) {
+	case *quorumpb.InitPropose:
+		return verifyInitPropose(m)
+	case *quorumpb.Echo:
+		return verifyEcho(m) && verifyInitPropose(InitProposeFromEcho(m))
+	case *quorumpb.Ready:
+		return verifyReady(m)
+	}
+	return false
 }
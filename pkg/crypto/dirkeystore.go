@@ -36,6 +36,7 @@ type DirKeyStore struct {
 	unlocked     map[string]interface{} //eth *Key or *X25519Identity, will be upgrade to generics
 	signkeymap   map[string]string
 	keyaliasmap  map[string]string
+	seed         []byte //seed of the mnemonic, the keys are derived from
 	unlockTime   time.Time
 	v            *viper.Viper
 	mu           sync.RWMutex
@@ -135,6 +136,10 @@ func (ks *DirKeyStore) Lock() error {
 		}
 	}
 	ks.unlocked = make(map[string]interface{})
+	for i := range ks.seed {
+		ks.seed[i] = 0
+	}
+	ks.seed = nil
 
 	return nil
 }
@@ -354,6 +359,7 @@ func (ks *DirKeyStore) NewKeyWithDefaultPassword(keyname string, keytype KeyType
 func (ks *DirKeyStore) NewKey(keyname string, keytype KeyType, password string) (string, error) {
 	//interface{} eth *PublicKey address or *X25519Recipient string, will be upgrade to generics
 
+	name := keyname
 	keyname = keytype.NameString(keyname)
 	exist, err := ks.IfKeyExist(keyname)
 	if err != nil {
@@ -364,7 +370,7 @@ func (ks *DirKeyStore) NewKey(keyname string, keytype KeyType, password string)
 	}
 	switch keytype {
 	case Encrypt:
-		key, err := age.GenerateX25519Identity()
+		key, err := ks.newEncryptKey(name, password)
 		if err != nil {
 			return "", err
 		}
@@ -378,7 +384,7 @@ func (ks *DirKeyStore) NewKey(keyname string, keytype KeyType, password string)
 		ks.unlocked[keyname] = key
 		return key.Recipient().String(), nil
 	case Sign:
-		privkey, err := ethcrypto.GenerateKey()
+		privkey, err := ks.newSignKey(name, password)
 		if err != nil {
 			return "", err
 		}
@@ -574,8 +580,7 @@ func (ks *DirKeyStore) EthVerifyByKeyName(keyname string, digestHash, signature
 }
 
 func (ks *DirKeyStore) EthVerifySign(digestHash, signature []byte, pubKey *ecdsa.PublicKey) bool {
-	sig := signature[:len(signature)-1] // remove recovery id
-	return ethcrypto.VerifySignature(ethcrypto.FromECDSAPub(pubKey), digestHash, sig)
+	return EthVerifySign(digestHash, signature, pubKey)
 }
 
 func (ks *DirKeyStore) GetEncodedPubkey(keyname string, keytype KeyType) (string, error) {
@@ -0,0 +1,252 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"filippo.io/age"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+/* mnemonic (BIP39) and hierarchical key derivation (BIP32, hardened only) of the keys in keystore */
+
+const hardenedKeyStart uint32 = 0x80000000
+
+// keys of keystore are derived at m/44'/60'/0'/<keytype>'/<index of keyname>'
+var hdKeyPathPrefix = []uint32{44 + hardenedKeyStart, 60 + hardenedKeyStart, 0 + hardenedKeyStart}
+
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+// NewMnemonic creates a mnemonic with 128 (12 words) or 256 (24 words) bits entropy
+func NewMnemonic(bits int) (string, error) {
+	if bits != 128 && bits != 256 {
+		return "", fmt.Errorf("entropy bits should be 128 or 256")
+	}
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return entropyToMnemonic(entropy), nil
+}
+
+func entropyToMnemonic(entropy []byte) string {
+	bits := len(entropy) * 8
+	//entropy with checksum of bits/32, split into 11 bits for each word
+	hash := sha256.Sum256(entropy)
+	checksumBits := uint(bits / 32)
+	n := new(big.Int).SetBytes(entropy)
+	n.Lsh(n, checksumBits)
+	n.Or(n, big.NewInt(int64(hash[0]>>(8-checksumBits))))
+
+	count := (bits + bits/32) / 11
+	words := make([]string, count)
+	mask := big.NewInt(2047)
+	for i := count - 1; i >= 0; i-- {
+		words[i] = wordlist[new(big.Int).And(n, mask).Int64()]
+		n.Rsh(n, 11)
+	}
+	return strings.Join(words, " ")
+}
+
+// ValidateMnemonic checks the words and the checksum of the mnemonic
+func ValidateMnemonic(mnemonic string) error {
+	words := strings.Fields(mnemonic)
+	if len(words) != 12 && len(words) != 24 {
+		return ErrInvalidMnemonic
+	}
+	index := make(map[string]int64, len(wordlist))
+	for i, w := range wordlist {
+		index[w] = int64(i)
+	}
+	n := new(big.Int)
+	for _, w := range words {
+		i, ok := index[w]
+		if !ok {
+			return fmt.Errorf("%w: unknown word %s", ErrInvalidMnemonic, w)
+		}
+		n.Lsh(n, 11)
+		n.Or(n, big.NewInt(i))
+	}
+
+	bits := len(words) * 11 * 32 / 33
+	checksumBits := uint(bits / 32)
+	checksum := new(big.Int).And(n, big.NewInt(int64(1<<checksumBits-1)))
+	entropy := n.Rsh(n, checksumBits).FillBytes(make([]byte, bits/8))
+	hash := sha256.Sum256(entropy)
+	if checksum.Int64() != int64(hash[0]>>(8-checksumBits)) {
+		return fmt.Errorf("%w: checksum mismatch", ErrInvalidMnemonic)
+	}
+	return nil
+}
+
+// MnemonicToSeed returns the 64 bytes seed of the mnemonic, the passphrase is optional
+func MnemonicToSeed(mnemonic string, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+passphrase), 2048, 64, sha512.New), nil
+}
+
+// HDKey is an extended private key of secp256k1
+type HDKey struct {
+	Key       []byte
+	ChainCode []byte
+}
+
+func NewMasterKey(seed []byte) (*HDKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key := &HDKey{Key: sum[:32], ChainCode: sum[32:]}
+	if err := checkHDKey(key.Key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Derive returns the hardened child key at index
+func (k *HDKey) Derive(index uint32) (*HDKey, error) {
+	if index < hardenedKeyStart {
+		return nil, fmt.Errorf("only hardened derivation is supported")
+	}
+	data := make([]byte, 37)
+	copy(data[1:33], k.Key)
+	binary.BigEndian.PutUint32(data[33:], index)
+	mac := hmac.New(sha512.New, k.ChainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	if err := checkHDKey(sum[:32]); err != nil {
+		return nil, err
+	}
+	n := ethcrypto.S256().Params().N
+	child := new(big.Int).SetBytes(sum[:32])
+	child.Add(child, new(big.Int).SetBytes(k.Key))
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, fmt.Errorf("invalid child key at index %d", index)
+	}
+	return &HDKey{Key: child.FillBytes(make([]byte, 32)), ChainCode: sum[32:]}, nil
+}
+
+func (k *HDKey) DerivePath(path []uint32) (*HDKey, error) {
+	key := k
+	var err error
+	for _, index := range path {
+		if key, err = key.Derive(index); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+func checkHDKey(key []byte) error {
+	n := new(big.Int).SetBytes(key)
+	if n.Sign() == 0 || n.Cmp(ethcrypto.S256().Params().N) >= 0 {
+		return fmt.Errorf("invalid hd key")
+	}
+	return nil
+}
+
+// HDKeyPath returns the derivation path of the key, the index is the first 31 bits of the sha256 of the keyname
+func HDKeyPath(keyname string, keytype KeyType) []uint32 {
+	hash := sha256.Sum256([]byte(keyname))
+	index := binary.BigEndian.Uint32(hash[:4]) | hardenedKeyStart
+	return append(append([]uint32{}, hdKeyPathPrefix...), uint32(keytype)+hardenedKeyStart, index)
+}
+
+// DeriveSignKey derives the sign key of keyname from the seed of mnemonic
+func DeriveSignKey(seed []byte, keyname string) (*ecdsa.PrivateKey, error) {
+	key, err := deriveKey(seed, keyname, Sign)
+	if err != nil {
+		return nil, err
+	}
+	return ethcrypto.ToECDSA(key)
+}
+
+// DeriveEncryptKey derives the age X25519 identity of keyname from the seed of mnemonic
+func DeriveEncryptKey(seed []byte, keyname string) (*age.X25519Identity, error) {
+	key, err := deriveKey(seed, keyname, Encrypt)
+	if err != nil {
+		return nil, err
+	}
+	return age.ParseX25519Identity(strings.ToUpper(bech32Encode("AGE-SECRET-KEY-", key)))
+}
+
+func deriveKey(seed []byte, keyname string, keytype KeyType) ([]byte, error) {
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	key, err := master.DerivePath(HDKeyPath(keyname, keytype))
+	if err != nil {
+		return nil, err
+	}
+	return key.Key, nil
+}
+
+// bech32Encode encodes data with the bech32 (BIP173) checksum, as the age identities
+func bech32Encode(hrp string, data []byte) string {
+	const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	hrp = strings.ToLower(hrp)
+
+	//convert 8 bits groups to 5 bits groups
+	var values []byte
+	acc, bits := 0, 0
+	for _, b := range data {
+		acc = acc<<8 | int(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			values = append(values, byte(acc>>bits&31))
+		}
+	}
+	if bits > 0 {
+		values = append(values, byte(acc<<(5-bits)&31))
+	}
+
+	polymod := func(values []byte) int {
+		gen := []int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+		chk := 1
+		for _, v := range values {
+			top := chk >> 25
+			chk = (chk&0x1ffffff)<<5 ^ int(v)
+			for i := 0; i < 5; i++ {
+				if (top>>i)&1 == 1 {
+					chk ^= gen[i]
+				}
+			}
+		}
+		return chk
+	}
+	var expanded []byte
+	for _, c := range []byte(hrp) {
+		expanded = append(expanded, c>>5)
+	}
+	expanded = append(expanded, 0)
+	for _, c := range []byte(hrp) {
+		expanded = append(expanded, c&31)
+	}
+	mod := polymod(append(append(expanded, values...), 0, 0, 0, 0, 0, 0)) ^ 1
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteString("1")
+	for _, v := range values {
+		sb.WriteByte(charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(charset[(mod>>uint(5*(5-i)))&31])
+	}
+	return sb.String()
+}
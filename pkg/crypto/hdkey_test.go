@@ -0,0 +1,178 @@
+//go:build !js
+// +build !js
+
+package crypto
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMnemonic(t *testing.T) {
+	mnemonic := entropyToMnemonic(make([]byte, 16))
+	if mnemonic != strings.TrimSpace(strings.Repeat("abandon ", 11)+"about") {
+		t.Fatalf("unexpected mnemonic of zero entropy: %s", mnemonic)
+	}
+	seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"
+	if hex.EncodeToString(seed) != expected {
+		t.Errorf("unexpected seed %x", seed)
+	}
+
+	if err := ValidateMnemonic(strings.Repeat("abandon ", 12)); err == nil {
+		t.Errorf("expect checksum error")
+	}
+	if err := ValidateMnemonic("abandon notaword"); err == nil {
+		t.Errorf("expect invalid mnemonic error")
+	}
+	for _, bits := range []int{128, 256} {
+		mnemonic, err := NewMnemonic(bits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ValidateMnemonic(mnemonic); err != nil {
+			t.Errorf("new mnemonic is invalid: %s", err)
+		}
+	}
+}
+
+func TestHDKeyDerive(t *testing.T) {
+	// test vector 1 of BIP32
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(master.Key) != "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35" {
+		t.Errorf("unexpected master key %x", master.Key)
+	}
+	child, err := master.Derive(hardenedKeyStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(child.Key) != "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea" {
+		t.Errorf("unexpected child key %x", child.Key)
+	}
+	if _, err := master.Derive(0); err == nil {
+		t.Errorf("expect error for non-hardened derivation")
+	}
+}
+
+func TestMnemonicKeyStore(t *testing.T) {
+	password := "my.Passw0rd"
+	dir := t.TempDir()
+	mnemonic, err := NewMnemonic(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubkeys := [2][2]string{}
+	for i, name := range []string{"ks1", "ks2"} {
+		ks, _, err := InitDirKeyStore(name, filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ks.InitMnemonic(mnemonic, "", password); err != nil {
+			t.Fatal(err)
+		}
+		if err := ks.InitMnemonic(mnemonic, "", password); err == nil {
+			t.Errorf("expect error for the mnemonic exists")
+		}
+		ks.Lock()
+		//the seed is reloaded with the password after lock
+		if pubkeys[i][0], err = ks.NewKey("group", Sign, password); err != nil {
+			t.Fatal(err)
+		}
+		if pubkeys[i][1], err = ks.NewKey("group", Encrypt, password); err != nil {
+			t.Fatal(err)
+		}
+		if keys, err := ks.ListAll(); err != nil || len(keys) != 2 {
+			t.Errorf("expect 2 keys, got %d, %v", len(keys), err)
+		}
+	}
+	if pubkeys[0] != pubkeys[1] {
+		t.Errorf("keys derived from the same mnemonic mismatch: %v", pubkeys)
+	}
+
+	ks, _, err := InitDirKeyStore("ks3", filepath.Join(dir, "ks3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.InitMnemonic(mnemonic, "passphrase", password); err != nil {
+		t.Fatal(err)
+	}
+	addr, err := ks.NewKey("group", Sign, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr == pubkeys[0][0] {
+		t.Errorf("keys derived with a different passphrase should be different")
+	}
+}
+
+func TestKeyExportImport(t *testing.T) {
+	password := "my.Passw0rd"
+	exportPassword := "export.Passw0rd"
+	dir := t.TempDir()
+
+	src, _, err := InitDirKeyStore("src", filepath.Join(dir, "src"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := src.NewKey("group", Sign, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptPubkey, err := src.NewKey("group", Encrypt, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.NewAlias("mygroup", "group", password); err != nil {
+		t.Fatal(err)
+	}
+	src.Lock()
+	src.Unlock(map[string]string{"group": addr}, password)
+
+	data, err := src.ExportKeys([]string{"mygroup"}, exportPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.ExportKeys([]string{"unknown"}, exportPassword); err == nil {
+		t.Errorf("expect error for the key not exist")
+	}
+
+	dst, _, err := InitDirKeyStore("dst", filepath.Join(dir, "dst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := dst.ImportKeys(data, "wrong password", password); err == nil {
+		t.Errorf("expect error for the wrong export password")
+	}
+	keys, signkeymap, err := dst.ImportKeys(data, exportPassword, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || signkeymap["group"] != addr {
+		t.Errorf("unexpected imported keys %v, signkeymap %v", keys, signkeymap)
+	}
+	if dst.AliasToKeyname("mygroup") != "group" {
+		t.Errorf("alias is not imported")
+	}
+
+	dst.Lock()
+	dst.Unlock(signkeymap, password)
+	if pubkey, err := dst.GetEncodedPubkey("group", Encrypt); err != nil || pubkey != encryptPubkey {
+		t.Errorf("unexpected encrypt pubkey %s, %v", pubkey, err)
+	}
+	if _, err := dst.EthSignByKeyName("group", Hash([]byte("data"))); err != nil {
+		t.Errorf("sign with the imported key failed: %s", err)
+	}
+	if _, _, err := dst.ImportKeys(data, exportPassword, password); err == nil {
+		t.Errorf("expect error for the keys exist")
+	}
+}
@@ -0,0 +1,112 @@
+//go:build !js
+// +build !js
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// the seed of the mnemonic is saved in the keystore dir encrypted by the keystore password,
+// the filename has no key prefix, so it is not listed as a key
+const hdSeedFilename = "hdseed"
+
+// InitMnemonic saves the seed of the mnemonic in the keystore, the new keys of the keystore
+// are derived from the seed after that, so they can be re-derived from the mnemonic on another node
+func (ks *DirKeyStore) InitMnemonic(mnemonic string, passphrase string, password string) error {
+	if ks.HasMnemonic() {
+		return fmt.Errorf("mnemonic of keystore %s exists", ks.Name)
+	}
+	seed, err := MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return err
+	}
+
+	r, err := age.NewScryptRecipient(password)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(ks.KeystorePath, hdSeedFilename), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := AgeEncrypt([]age.Recipient{r}, strings.NewReader(hex.EncodeToString(seed)), f); err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.seed = seed
+	return nil
+}
+
+// HasMnemonic returns true if the keys of the keystore are derived from a mnemonic
+func (ks *DirKeyStore) HasMnemonic() bool {
+	_, err := os.Stat(filepath.Join(ks.KeystorePath, hdSeedFilename))
+	return err == nil
+}
+
+// loadSeed returns the seed of the mnemonic, or nil if the keystore has no mnemonic
+func (ks *DirKeyStore) loadSeed(password string) ([]byte, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if ks.seed != nil {
+		return ks.seed, nil
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(ks.KeystorePath, hdSeedFilename))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	r, err := AgeDecrypt(password, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	seedhex, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := hex.DecodeString(string(seedhex))
+	if err != nil {
+		return nil, err
+	}
+	ks.seed = seed
+	return seed, nil
+}
+
+// newSignKey derives the sign key of keyname from the mnemonic, or generates a random one without mnemonic
+func (ks *DirKeyStore) newSignKey(keyname string, password string) (*ecdsa.PrivateKey, error) {
+	seed, err := ks.loadSeed(password)
+	if err != nil {
+		return nil, err
+	}
+	if seed == nil {
+		return ethcrypto.GenerateKey()
+	}
+	return DeriveSignKey(seed, keyname)
+}
+
+// newEncryptKey derives the encrypt key of keyname from the mnemonic, or generates a random one without mnemonic
+func (ks *DirKeyStore) newEncryptKey(keyname string, password string) (*age.X25519Identity, error) {
+	seed, err := ks.loadSeed(password)
+	if err != nil {
+		return nil, err
+	}
+	if seed == nil {
+		return age.GenerateX25519Identity()
+	}
+	return DeriveEncryptKey(seed, keyname)
+}
@@ -0,0 +1,152 @@
+//go:build !js
+// +build !js
+
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"filippo.io/age"
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const keyExportVersion = 1
+
+// ExportedKey is a key in the export file, Key is the hex of the sign key or the age identity of the encrypt key
+type ExportedKey struct {
+	Keyname string   `json:"keyname"`
+	Type    KeyType  `json:"type"`
+	Key     string   `json:"key"`
+	Alias   []string `json:"alias"`
+}
+
+type keyExportFile struct {
+	Version int            `json:"version"`
+	Keys    []*ExportedKey `json:"keys"`
+}
+
+// ExportKeys exports the sign and encrypt keys of the keynames (or aliases) encrypted by the password,
+// the keystore should be unlocked with the SignKeyMap of the sign keys
+func (ks *DirKeyStore) ExportKeys(keynames []string, password string) ([]byte, error) {
+	file := &keyExportFile{Version: keyExportVersion}
+	for _, keyname := range keynames {
+		if name := ks.AliasToKeyname(keyname); name != "" {
+			keyname = name
+		}
+		found := false
+		for _, keytype := range []KeyType{Sign, Encrypt} {
+			exist, err := ks.IfKeyExist(keytype.NameString(keyname))
+			if err != nil {
+				return nil, err
+			}
+			if !exist {
+				continue
+			}
+			found = true
+			key, err := ks.GetKeyFromUnlocked(keytype.NameString(keyname))
+			if err != nil {
+				return nil, err
+			}
+			item := &ExportedKey{Keyname: keyname, Type: keytype, Alias: ks.GetAlias(keyname)}
+			switch k := key.(type) {
+			case *ethkeystore.Key:
+				item.Key = hex.EncodeToString(ethcrypto.FromECDSA(k.PrivateKey))
+			case *age.X25519Identity:
+				item.Key = k.String()
+			default:
+				return nil, fmt.Errorf("unsupported key %s", keytype.NameString(keyname))
+			}
+			file.Keys = append(file.Keys, item)
+		}
+		if !found {
+			return nil, fmt.Errorf("key %s not exist", keyname)
+		}
+	}
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return nil, err
+	}
+	r, err := age.NewScryptRecipient(password)
+	if err != nil {
+		return nil, err
+	}
+	out := &bytes.Buffer{}
+	if err := AgeEncrypt([]age.Recipient{r}, bytes.NewReader(data), out); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// ImportKeys imports the keys exported by ExportKeys and their aliases, returns the imported keys
+// and the addresses of the imported sign keys, which should be saved in the SignKeyMap of node options
+func (ks *DirKeyStore) ImportKeys(data []byte, password string, keystorePassword string) ([]*KeyItem, map[string]string, error) {
+	r, err := AgeDecrypt(password, bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	file := &keyExportFile{}
+	if err := json.Unmarshal(content, file); err != nil {
+		return nil, nil, err
+	}
+	if file.Version != keyExportVersion {
+		return nil, nil, fmt.Errorf("unsupported key export version %d", file.Version)
+	}
+
+	//check all keys before importing any of them
+	for _, item := range file.Keys {
+		exist, err := ks.IfKeyExist(item.Type.NameString(item.Keyname))
+		if err != nil {
+			return nil, nil, err
+		}
+		if exist {
+			return nil, nil, fmt.Errorf("Key '%s' exists", item.Type.NameString(item.Keyname))
+		}
+		switch item.Type {
+		case Sign:
+			if _, err := ethcrypto.HexToECDSA(item.Key); err != nil {
+				return nil, nil, fmt.Errorf("invalid sign key %s: %s", item.Keyname, err)
+			}
+		case Encrypt:
+			if _, err := age.ParseX25519Identity(item.Key); err != nil {
+				return nil, nil, fmt.Errorf("invalid encrypt key %s: %s", item.Keyname, err)
+			}
+		default:
+			return nil, nil, fmt.Errorf("unsupported key type of key %s", item.Keyname)
+		}
+	}
+
+	items := []*KeyItem{}
+	signkeymap := make(map[string]string)
+	for _, item := range file.Keys {
+		pubkey, err := ks.Import(item.Keyname, item.Key, item.Type, keystorePassword)
+		if err != nil {
+			return items, signkeymap, err
+		}
+		if item.Type == Sign {
+			signkeymap[item.Keyname] = pubkey
+		}
+		for _, alias := range item.Alias {
+			if keyname := ks.AliasToKeyname(alias); keyname == item.Keyname {
+				continue
+			} else if keyname != "" {
+				cryptolog.Warningf("alias %s of key %s exists, skip", alias, item.Keyname)
+				continue
+			}
+			if err := ks.NewAlias(alias, item.Keyname, keystorePassword); err != nil {
+				return items, signkeymap, err
+			}
+		}
+		items = append(items, &KeyItem{Keyname: item.Keyname, Alias: item.Alias, Type: item.Type})
+	}
+	return items, signkeymap, nil
+}
@@ -24,6 +24,11 @@ func GetKeystore() Keystore {
 	return ks
 }
 
+// SetKeystore replaces the keystore of the node, for the keystores not initialized by InitKeystore
+func SetKeystore(keystore Keystore) {
+	ks = keystore
+}
+
 func zeroSignKey(k *ecdsa.PrivateKey) {
 	b := k.D.Bits()
 	for i := range b {
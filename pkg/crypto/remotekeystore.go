@@ -0,0 +1,168 @@
+//go:build !js
+// +build !js
+
+package crypto
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// the keys held by the signer are reloaded at most once in the interval when a key is not found
+var remoteKeysReloadInterval = 10 * time.Second
+
+// RemoteKeyStore forwards the signing and decryption with the keys held by a signer, e.g. a signer process on
+// an isolated machine keeping the group owner keys, to the signer. The other keys (e.g. the node key) and
+// the other methods are served by the local keystore. Key aliases are resolved by the local keystore, then by the signer.
+type RemoteKeyStore struct {
+	Keystore
+	signer Signer
+
+	mu       sync.Mutex
+	remote   map[string]bool   //KeyType.NameString of the keys held by the signer
+	aliases  map[string]string //alias to keyname of the keys held by the signer
+	reloadAt time.Time
+}
+
+func NewRemoteKeyStore(local Keystore, signer Signer) (*RemoteKeyStore, error) {
+	ks := &RemoteKeyStore{Keystore: local, signer: signer}
+	if err := ks.reload(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+func (ks *RemoteKeyStore) reload() error {
+	keys, err := ks.signer.ListAll()
+	if err != nil {
+		return err
+	}
+	remote := make(map[string]bool)
+	aliases := make(map[string]string)
+	for _, key := range keys {
+		remote[key.Type.NameString(key.Keyname)] = true
+		for _, alias := range key.Alias {
+			aliases[alias] = key.Keyname
+		}
+	}
+	ks.remote = remote
+	ks.aliases = aliases
+	ks.reloadAt = time.Now()
+	return nil
+}
+
+func (ks *RemoteKeyStore) isRemote(keyname string, keytype KeyType) bool {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	name := keytype.NameString(keyname)
+	if !ks.remote[name] && time.Since(ks.reloadAt) > remoteKeysReloadInterval {
+		if err := ks.reload(); err != nil {
+			cryptolog.Warningf("reload keys of the signer failed: %s", err)
+		}
+	}
+	return ks.remote[name]
+}
+
+// aliasToKeyname resolves the alias by the local keystore, then by the signer
+func (ks *RemoteKeyStore) aliasToKeyname(keyalias string) string {
+	if dirks, ok := ks.Keystore.(*DirKeyStore); ok {
+		if keyname := dirks.AliasToKeyname(keyalias); keyname != "" {
+			return keyname
+		}
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if ks.aliases[keyalias] == "" && time.Since(ks.reloadAt) > remoteKeysReloadInterval {
+		if err := ks.reload(); err != nil {
+			cryptolog.Warningf("reload keys of the signer failed: %s", err)
+		}
+	}
+	return ks.aliases[keyalias]
+}
+
+func (ks *RemoteKeyStore) EthSignByKeyName(keyname string, digestHash []byte, opts ...string) ([]byte, error) {
+	if ks.isRemote(keyname, Sign) {
+		return ks.signer.EthSignByKeyName(keyname, digestHash, opts...)
+	}
+	return ks.Keystore.EthSignByKeyName(keyname, digestHash, opts...)
+}
+
+func (ks *RemoteKeyStore) EthSignByKeyAlias(keyalias string, digestHash []byte, opts ...string) ([]byte, error) {
+	keyname := ks.aliasToKeyname(keyalias)
+	if keyname == "" {
+		return nil, fmt.Errorf("The key alias %s is not exist", keyalias)
+	}
+	return ks.EthSignByKeyName(keyname, digestHash, opts...)
+}
+
+func (ks *RemoteKeyStore) SignTxByKeyName(keyname string, nonce uint64, to common.Address, value *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, chainID *big.Int) (string, error) {
+	if ks.isRemote(keyname, Sign) {
+		return ks.signer.SignTxByKeyName(keyname, nonce, to, value, gasLimit, gasPrice, data, chainID)
+	}
+	return ks.Keystore.SignTxByKeyName(keyname, nonce, to, value, gasLimit, gasPrice, data, chainID)
+}
+
+func (ks *RemoteKeyStore) SignTxByKeyAlias(keyalias string, nonce uint64, to common.Address, value *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, chainID *big.Int) (string, error) {
+	keyname := ks.aliasToKeyname(keyalias)
+	if keyname == "" {
+		return "", fmt.Errorf("The key alias %s is not exist", keyalias)
+	}
+	return ks.SignTxByKeyName(keyname, nonce, to, value, gasLimit, gasPrice, data, chainID)
+}
+
+func (ks *RemoteKeyStore) Decrypt(keyname string, data []byte) ([]byte, error) {
+	if ks.isRemote(keyname, Encrypt) {
+		return ks.signer.Decrypt(keyname, data)
+	}
+	return ks.Keystore.Decrypt(keyname, data)
+}
+
+func (ks *RemoteKeyStore) DecryptByAlias(keyalias string, data []byte) ([]byte, error) {
+	keyname := ks.aliasToKeyname(keyalias)
+	if keyname == "" {
+		return nil, fmt.Errorf("The key alias %s is not exist", keyalias)
+	}
+	return ks.Decrypt(keyname, data)
+}
+
+func (ks *RemoteKeyStore) GetEncodedPubkey(keyname string, keytype KeyType) (string, error) {
+	if ks.isRemote(keyname, keytype) {
+		return ks.signer.GetEncodedPubkey(keyname, keytype)
+	}
+	return ks.Keystore.GetEncodedPubkey(keyname, keytype)
+}
+
+func (ks *RemoteKeyStore) GetEncodedPubkeyByAlias(keyalias string, keytype KeyType) (string, error) {
+	keyname := ks.aliasToKeyname(keyalias)
+	if keyname == "" {
+		return "", fmt.Errorf("The key alias %s is not exist", keyalias)
+	}
+	return ks.GetEncodedPubkey(keyname, keytype)
+}
+
+// ListAll returns the keys of the local keystore and the keys held by the signer
+func (ks *RemoteKeyStore) ListAll() ([]*KeyItem, error) {
+	keys, err := ks.Keystore.ListAll()
+	if err != nil {
+		return nil, err
+	}
+	remoteKeys, err := ks.signer.ListAll()
+	if err != nil {
+		return nil, err
+	}
+	return append(keys, remoteKeys...), nil
+}
+
+// AsDirKeyStore returns the DirKeyStore of ks, or the local keystore of a RemoteKeyStore, where the keys are created
+func AsDirKeyStore(ks Keystore) (*DirKeyStore, bool) {
+	if remoteks, ok := ks.(*RemoteKeyStore); ok {
+		ks = remoteks.Keystore
+	}
+	dirks, ok := ks.(*DirKeyStore)
+	return dirks, ok
+}
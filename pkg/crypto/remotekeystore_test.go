@@ -0,0 +1,203 @@
+//go:build !js
+// +build !js
+
+package crypto
+
+import (
+	"encoding/base64"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestRemoteKeyStore(t *testing.T) {
+	password := "my.Passw0rd"
+	dir := t.TempDir()
+
+	signerKs, _, err := InitDirKeyStore("signer", filepath.Join(dir, "signer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signerKs.NewKey("owner", Sign, password); err != nil {
+		t.Fatal(err)
+	}
+	encryptPubkey, err := signerKs.NewKey("owner", Encrypt, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := signerKs.NewAlias("owner-alias", "owner", password); err != nil {
+		t.Fatal(err)
+	}
+
+	sock := filepath.Join(dir, "signer.sock")
+	ln, err := ListenSigner("unix://" + sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	if info, err := os.Stat(sock); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("signer socket should only be accessible by owner, got %v, %v", info.Mode(), err)
+	}
+	if tmps, _ := filepath.Glob(filepath.Join(dir, ".signer-*")); len(tmps) != 0 {
+		t.Errorf("the directory to create the socket should be removed, got %v", tmps)
+	}
+	go ServeSigner(ln, signerKs)
+
+	tcpLn, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tcpLn.Close()
+	if err := ServeSigner(tcpLn, signerKs); err == nil {
+		t.Errorf("signer should not be served on tcp")
+	}
+	if _, _, err := ParseSignerAddr("tcp://127.0.0.1:8000"); err == nil {
+		t.Errorf("tcp signer addr should be rejected")
+	}
+
+	client, err := DialSigner("unix://" + sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	localKs, _, err := InitDirKeyStore("local", filepath.Join(dir, "local"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := localKs.NewKey("default", Sign, password); err != nil {
+		t.Fatal(err)
+	}
+
+	ks, err := NewRemoteKeyStore(localKs, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := Hash([]byte("data to sign"))
+	for _, keyname := range []string{"owner", "default"} {
+		pubkey, err := ks.GetEncodedPubkey(keyname, Sign)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := ks.EthSignByKeyName(keyname, hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := base64.RawURLEncoding.DecodeString(pubkey)
+		ethPubkey, err := ethcrypto.DecompressPubkey(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ks.EthVerifySign(hash, sig, ethPubkey) {
+			t.Errorf("signature of key %s can't be verified", keyname)
+		}
+	}
+	if _, err := localKs.GetEncodedPubkey("owner", Sign); err == nil {
+		t.Errorf("owner key should be held by the signer only")
+	}
+
+	encrypted, err := ks.EncryptTo([]string{encryptPubkey}, []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ks.Decrypt("owner", encrypted); err != nil || string(data) != "secret" {
+		t.Errorf("decrypt by signer failed: %v", err)
+	}
+
+	//the alias of the key held by the signer is resolved by the signer
+	ownerPubkey, _ := ks.GetEncodedPubkey("owner", Sign)
+	if pubkey, err := ks.GetEncodedPubkeyByAlias("owner-alias", Sign); err != nil || pubkey != ownerPubkey {
+		t.Errorf("expect pubkey %s by alias, got %s, %v", ownerPubkey, pubkey, err)
+	}
+	if _, err := ks.EthSignByKeyAlias("owner-alias", hash); err != nil {
+		t.Errorf("sign by alias failed: %v", err)
+	}
+	if _, err := ks.SignTxByKeyAlias("owner-alias", 0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil, big.NewInt(1)); err != nil {
+		t.Errorf("sign tx by alias failed: %v", err)
+	}
+	if _, err := ks.EthSignByKeyAlias("unknown-alias", hash); err == nil {
+		t.Errorf("expect error for the alias not exist")
+	}
+
+	if _, err := ks.EthSignByKeyName("unknown", hash); err == nil {
+		t.Errorf("expect error for the key not exist")
+	}
+	if keys, err := ks.ListAll(); err != nil || len(keys) != 3 {
+		t.Errorf("expect 3 keys, got %d, %v", len(keys), err)
+	}
+}
+
+// connsListener keeps the accepted connections to close them as the signer is restarted
+type connsListener struct {
+	net.Listener
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func (l *connsListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.mu.Lock()
+		l.conns = append(l.conns, conn)
+		l.mu.Unlock()
+	}
+	return conn, err
+}
+
+func (l *connsListener) closeConns() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, conn := range l.conns {
+		conn.Close()
+	}
+	l.conns = nil
+}
+
+func TestSignerRedial(t *testing.T) {
+	dir := t.TempDir()
+	signerKs, _, err := InitDirKeyStore("signer", filepath.Join(dir, "signer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sock := filepath.Join(dir, "signer.sock")
+	ln, err := ListenSigner(sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cln := &connsListener{Listener: ln}
+	go ServeSigner(cln, signerKs)
+
+	client, err := DialSigner(sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	if _, err := client.ListAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	//the connection is closed by the signer
+	cln.closeConns()
+	if _, err := client.ListAll(); err != nil {
+		t.Errorf("expect the client redialed, got %v", err)
+	}
+
+	//the signer is stopped
+	cln.closeConns()
+	ln.Close()
+	if _, err := os.Stat(sock); !os.IsNotExist(err) {
+		t.Errorf("socket should be removed when the signer is stopped, got %v", err)
+	}
+	if _, err := client.ListAll(); err == nil {
+		t.Errorf("expect error when the signer is stopped")
+	}
+}
@@ -0,0 +1,276 @@
+//go:build !js
+// +build !js
+
+package crypto
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"net/rpc"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const signerServiceName = "Signer"
+
+// Signer signs and decrypts with the keys it holds, the methods are the same as the Keystore,
+// so any Keystore (e.g. DirKeyStore) is a Signer, and a fake signer is easy to swap in for testing
+type Signer interface {
+	EthSignByKeyName(keyname string, digestHash []byte, opts ...string) ([]byte, error)
+	SignTxByKeyName(keyname string, nonce uint64, to common.Address, value *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, chainID *big.Int) (string, error)
+	Decrypt(keyname string, data []byte) ([]byte, error)
+	GetEncodedPubkey(keyname string, keytype KeyType) (string, error)
+	ListAll() (keys []*KeyItem, err error)
+}
+
+type SignerArgs struct {
+	Keyname  string
+	KeyType  KeyType
+	Data     []byte
+	Nonce    uint64
+	To       common.Address
+	Value    *big.Int
+	GasLimit uint64
+	GasPrice *big.Int
+	ChainID  *big.Int
+}
+
+type SignerReply struct {
+	Data []byte
+	Text string
+	Keys []*KeyItem
+}
+
+// SignerService exports a Signer by net/rpc
+type SignerService struct {
+	signer Signer
+}
+
+func (s *SignerService) EthSignByKeyName(args *SignerArgs, reply *SignerReply) (err error) {
+	reply.Data, err = s.signer.EthSignByKeyName(args.Keyname, args.Data)
+	return err
+}
+
+func (s *SignerService) SignTxByKeyName(args *SignerArgs, reply *SignerReply) (err error) {
+	reply.Text, err = s.signer.SignTxByKeyName(args.Keyname, args.Nonce, args.To, args.Value, args.GasLimit, args.GasPrice, args.Data, args.ChainID)
+	return err
+}
+
+func (s *SignerService) Decrypt(args *SignerArgs, reply *SignerReply) (err error) {
+	reply.Data, err = s.signer.Decrypt(args.Keyname, args.Data)
+	return err
+}
+
+func (s *SignerService) GetEncodedPubkey(args *SignerArgs, reply *SignerReply) (err error) {
+	reply.Text, err = s.signer.GetEncodedPubkey(args.Keyname, args.KeyType)
+	return err
+}
+
+func (s *SignerService) ListAll(args *SignerArgs, reply *SignerReply) (err error) {
+	reply.Keys, err = s.signer.ListAll()
+	return err
+}
+
+// ServeSigner serves the signer on the unix socket listener until it is closed. The rpc is not authenticated,
+// so only the unix socket is served, and access is granted by the permission of the socket file (see ListenSigner).
+// Use a ssh tunnel of the socket (ssh -L) to serve a fullnode on another machine.
+func ServeSigner(ln net.Listener, signer Signer) error {
+	if ln.Addr().Network() != "unix" {
+		return fmt.Errorf("signer can only be served on unix socket, got %s", ln.Addr().Network())
+	}
+	server := rpc.NewServer()
+	if err := server.RegisterName(signerServiceName, &SignerService{signer: signer}); err != nil {
+		return err
+	}
+	server.Accept(ln)
+	return nil
+}
+
+// ListenSigner listens on the unix socket at addr, which is only accessible by the owner of the signer process.
+// The socket is created in a new directory only accessible by the owner and moved to addr after its permission is set,
+// so it is never accessible by others with the permission given by umask.
+func ListenSigner(addr string) (net.Listener, error) {
+	network, address, err := ParseSignerAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	//remove the socket left by the last run
+	os.Remove(address)
+
+	dir, err := os.MkdirTemp(filepath.Dir(address), ".signer-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	tmp := filepath.Join(dir, "sock")
+	ln, err := net.Listen(network, tmp)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(tmp, 0600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	if err := os.Rename(tmp, address); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return &signerListener{Listener: ln, path: address}, nil
+}
+
+// signerListener removes the socket moved to path when closed
+type signerListener struct {
+	net.Listener
+	path string
+}
+
+func (l *signerListener) Close() error {
+	err := l.Listener.Close()
+	os.Remove(l.path)
+	return err
+}
+
+// SignerClient is the Signer served by another process
+type SignerClient struct {
+	network string
+	address string
+
+	mu     sync.Mutex
+	client *rpc.Client
+}
+
+// DialSigner connects to the signer at addr, unix:///path/to/signer.sock
+func DialSigner(addr string) (*SignerClient, error) {
+	network, address, err := ParseSignerAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	client, err := rpc.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+	return &SignerClient{network: network, address: address, client: client}, nil
+}
+
+// ParseSignerAddr returns the network and address of the signer addr, a path without scheme is a unix socket
+func ParseSignerAddr(addr string) (string, string, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return "", "", err
+	}
+	switch u.Scheme {
+	case "":
+		return "unix", addr, nil
+	case "unix":
+		return "unix", u.Host + u.Path, nil
+	}
+	return "", "", fmt.Errorf("unsupported signer addr %s, should be unix:///path/to/signer.sock", addr)
+}
+
+func (c *SignerClient) call(method string, args *SignerArgs) (*SignerReply, error) {
+	c.mu.Lock()
+	client := c.client
+	c.mu.Unlock()
+
+	reply := &SignerReply{}
+	err := client.Call(signerServiceName+"."+method, args, reply)
+	if isSignerConnErr(err) {
+		//the connection is closed, as the signer is restarted, redial once and try again
+		if client, err = c.redial(client); err != nil {
+			return nil, err
+		}
+		reply = &SignerReply{}
+		err = client.Call(signerServiceName+"."+method, args, reply)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// isSignerConnErr checks if the call failed by the connection (rpc.ErrShutdown, io.EOF, or the write to a closed socket),
+// rather than by the signer, which returns rpc.ServerError
+func isSignerConnErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(rpc.ServerError)
+	return !ok
+}
+
+// redial replaces the broken client with a new connection, unless it is replaced by another call already
+func (c *SignerClient) redial(broken *rpc.Client) (*rpc.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client != broken {
+		return c.client, nil
+	}
+	broken.Close()
+	client, err := rpc.Dial(c.network, c.address)
+	if err != nil {
+		return nil, err
+	}
+	c.client = client
+	return client, nil
+}
+
+func (c *SignerClient) EthSignByKeyName(keyname string, digestHash []byte, opts ...string) ([]byte, error) {
+	reply, err := c.call("EthSignByKeyName", &SignerArgs{Keyname: keyname, Data: digestHash})
+	if err != nil {
+		return nil, err
+	}
+	return reply.Data, nil
+}
+
+func (c *SignerClient) SignTxByKeyName(keyname string, nonce uint64, to common.Address, value *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, chainID *big.Int) (string, error) {
+	reply, err := c.call("SignTxByKeyName", &SignerArgs{
+		Keyname:  keyname,
+		Nonce:    nonce,
+		To:       to,
+		Value:    value,
+		GasLimit: gasLimit,
+		GasPrice: gasPrice,
+		Data:     data,
+		ChainID:  chainID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return reply.Text, nil
+}
+
+func (c *SignerClient) Decrypt(keyname string, data []byte) ([]byte, error) {
+	reply, err := c.call("Decrypt", &SignerArgs{Keyname: keyname, Data: data})
+	if err != nil {
+		return nil, err
+	}
+	return reply.Data, nil
+}
+
+func (c *SignerClient) GetEncodedPubkey(keyname string, keytype KeyType) (string, error) {
+	reply, err := c.call("GetEncodedPubkey", &SignerArgs{Keyname: keyname, KeyType: keytype})
+	if err != nil {
+		return "", err
+	}
+	return reply.Text, nil
+}
+
+func (c *SignerClient) ListAll() ([]*KeyItem, error) {
+	reply, err := c.call("ListAll", &SignerArgs{})
+	if err != nil {
+		return nil, err
+	}
+	return reply.Keys, nil
+}
+
+func (c *SignerClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.Close()
+}
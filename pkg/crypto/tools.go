@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"crypto/ecdsa"
 	"crypto/sha256"
 	"encoding/base64"
 	"errors"
@@ -18,6 +19,15 @@ func Hash(data []byte) []byte {
 	return hashed
 }
 
+// EthVerifySign verifies the signature with recovery id of the digest hash, no key is needed from the keystore
+func EthVerifySign(digestHash, signature []byte, pubKey *ecdsa.PublicKey) bool {
+	if len(signature) != 65 || pubKey == nil {
+		return false
+	}
+	sig := signature[:len(signature)-1] // remove recovery id
+	return ethcrypto.VerifySignature(ethcrypto.FromECDSAPub(pubKey), digestHash, sig)
+}
+
 func Libp2pPubkeyToEthBase64(libp2ppubkey string) (string, error) {
 	p2pkeyBytes, err := p2pcrypto.ConfigDecodeKey(libp2ppubkey)
 	if err != nil {
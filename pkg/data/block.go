@@ -15,7 +15,16 @@ import (
 	"time"
 )
 
-func CreateBlockByEthKey(parentBlk *quorumpb.Block, epoch uint64, trxs []*quorumpb.Trx, sudo bool, groupPublicKey string, keystore localcrypto.Keystore, keyalias string, opts ...string) (*quorumpb.Block, error) {
+const (
+	BLOCK_VERSION_TRX_LIST uint32 = 0 //the block hash covers all trxs
+	BLOCK_VERSION_TRX_ROOT uint32 = 1 //the block hash covers the merkle root of the trxs, a trx can be proved without the whole block
+)
+
+// default version of the blocks, nodes of old releases can't validate the blocks with trx root,
+// so the group owner activates BLOCK_VERSION_TRX_ROOT from an epoch by chain config SET_BLOCK_VERSION
+var BLOCK_VERSION = BLOCK_VERSION_TRX_LIST
+
+func CreateBlockByEthKey(parentBlk *quorumpb.Block, epoch uint64, version uint32, trxs []*quorumpb.Trx, sudo bool, groupPublicKey string, keystore localcrypto.Keystore, keyalias string, opts ...string) (*quorumpb.Block, error) {
 	newBlock := &quorumpb.Block{
 		GroupId:        parentBlk.GroupId,
 		BlockId:        parentBlk.BlockId + 1,
@@ -25,14 +34,21 @@ func CreateBlockByEthKey(parentBlk *quorumpb.Block, epoch uint64, trxs []*quorum
 		Trxs:           trxs,
 		Sudo:           sudo,
 		TimeStamp:      time.Now().UnixNano(),
+		Version:        version,
 	}
 
-	tbytes, err := proto.Marshal(newBlock)
+	if newBlock.Version >= BLOCK_VERSION_TRX_ROOT {
+		root, err := GetTrxRoot(trxs)
+		if err != nil {
+			return nil, err
+		}
+		newBlock.TrxRoot = root
+	}
+
+	hash, err := GetBlockHash(newBlock)
 	if err != nil {
 		return nil, err
 	}
-
-	hash := localcrypto.Hash(tbytes)
 	newBlock.BlockHash = hash
 
 	var signature []byte
@@ -59,12 +75,10 @@ func RegenrateBlockWithParent(parentBlock *quorumpb.Block, orphanBlock *quorumpb
 	orphanBlock.PrevHash = parentBlock.BlockHash
 	orphanBlock.BlockId = parentBlock.BlockId + 1
 
-	tbytes, err := proto.Marshal(orphanBlock)
+	hash, err := GetBlockHash(orphanBlock)
 	if err != nil {
 		return nil, err
 	}
-
-	hash := localcrypto.Hash(tbytes)
 	orphanBlock.BlockHash = hash
 
 	var signature []byte
@@ -98,12 +112,10 @@ func CreateGenesisBlockByEthKey(groupId string, groupPublicKey string, keystore
 		TimeStamp:      time.Now().UnixNano(),
 	}
 
-	bbytes, err := proto.Marshal(genesisBlock)
+	blockHash, err := GetBlockHash(genesisBlock)
 	if err != nil {
 		return nil, err
 	}
-
-	blockHash := localcrypto.Hash(bbytes)
 	genesisBlock.BlockHash = blockHash
 
 	var signature []byte
@@ -126,25 +138,10 @@ func CreateGenesisBlockByEthKey(groupId string, groupPublicKey string, keystore
 func ValidBlockWithParent(newBlock, parentBlock *quorumpb.Block) (bool, error) {
 
 	//step 1, check hash for newBlock
-	blkWithOutHashAndSign := &quorumpb.Block{
-		GroupId:        newBlock.GroupId,
-		BlockId:        newBlock.BlockId,
-		Epoch:          newBlock.Epoch,
-		PrevHash:       newBlock.PrevHash,
-		ProducerPubkey: newBlock.ProducerPubkey,
-		Trxs:           newBlock.Trxs,
-		Sudo:           newBlock.Sudo,
-		TimeStamp:      newBlock.TimeStamp,
-		BlockHash:      nil,
-		ProducerSign:   nil,
-	}
-
-	tbytes, err := proto.Marshal(blkWithOutHashAndSign)
+	hash, err := GetBlockHash(newBlock)
 	if err != nil {
 		return false, err
 	}
-
-	hash := localcrypto.Hash(tbytes)
 	if !bytes.Equal(hash, newBlock.BlockHash) {
 		return false, fmt.Errorf("hash for new block is invalid")
 	}
@@ -163,8 +160,30 @@ func ValidBlockWithParent(newBlock, parentBlock *quorumpb.Block) (bool, error) {
 	if err == nil { //try eth key
 		ethpubkey, err := ethcrypto.DecompressPubkey(bytespubkey)
 		if err == nil {
-			ks := localcrypto.GetKeystore()
-			r := ks.EthVerifySign(hash, newBlock.ProducerSign, ethpubkey)
+			r := localcrypto.EthVerifySign(hash, newBlock.ProducerSign, ethpubkey)
+			return r, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// valid block hash and producer sign, without parent
+func ValidBlock(block *quorumpb.Block) (bool, error) {
+	hash, err := GetBlockHash(block)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(hash, block.BlockHash) {
+		return false, fmt.Errorf("hash for block is invalid")
+	}
+
+	bytespubkey, err := base64.RawURLEncoding.DecodeString(block.ProducerPubkey)
+	if err == nil { //try eth key
+		ethpubkey, err := ethcrypto.DecompressPubkey(bytespubkey)
+		if err == nil {
+			r := localcrypto.EthVerifySign(hash, block.ProducerSign, ethpubkey)
 			return r, nil
 		}
 		return false, err
@@ -186,25 +205,10 @@ func ValidGenesisBlock(genesisBlock *quorumpb.Block) (bool, error) {
 		return false, fmt.Errorf("prevhash for genesis block must be nil")
 	}
 
-	genesisBlockWithoutHashAndSign := &quorumpb.Block{
-		GroupId:        genesisBlock.GroupId,
-		BlockId:        genesisBlock.BlockId,
-		Epoch:          genesisBlock.Epoch,
-		PrevHash:       genesisBlock.PrevHash,
-		ProducerPubkey: genesisBlock.ProducerPubkey,
-		Trxs:           genesisBlock.Trxs,
-		Sudo:           genesisBlock.Sudo,
-		TimeStamp:      genesisBlock.TimeStamp,
-		BlockHash:      nil,
-		ProducerSign:   nil,
-	}
-
-	bts, err := proto.Marshal(genesisBlockWithoutHashAndSign)
+	hash, err := GetBlockHash(genesisBlock)
 	if err != nil {
 		return false, err
 	}
-
-	hash := localcrypto.Hash(bts)
 	if !bytes.Equal(hash, genesisBlock.BlockHash) {
 		return false, fmt.Errorf("hash for new block is invalid")
 	}
@@ -213,8 +217,7 @@ func ValidGenesisBlock(genesisBlock *quorumpb.Block) (bool, error) {
 	if err == nil { //try eth key
 		ethpubkey, err := ethcrypto.DecompressPubkey(bytespubkey)
 		if err == nil {
-			ks := localcrypto.GetKeystore()
-			r := ks.EthVerifySign(hash, genesisBlock.ProducerSign, ethpubkey)
+			r := localcrypto.EthVerifySign(hash, genesisBlock.ProducerSign, ethpubkey)
 			return r, nil
 		}
 		return false, err
@@ -223,6 +226,93 @@ func ValidGenesisBlock(genesisBlock *quorumpb.Block) (bool, error) {
 	return true, nil
 }
 
+// GetBlockHash calculates the hash of the block, without BlockHash and ProducerSign.
+// For the blocks with trx root, the root is checked with the trxs and the hash covers the root instead of the trxs.
+func GetBlockHash(block *quorumpb.Block) ([]byte, error) {
+	switch block.Version {
+	case BLOCK_VERSION_TRX_LIST:
+		return hashBlock(block, block.Trxs)
+	case BLOCK_VERSION_TRX_ROOT:
+		root, err := GetTrxRoot(block.Trxs)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(root, block.TrxRoot) {
+			return nil, fmt.Errorf("trx root of block <%d> mismatch with the trxs", block.BlockId)
+		}
+		return hashBlock(block, nil)
+	default:
+		return nil, fmt.Errorf("block version <%d> is not supported", block.Version)
+	}
+}
+
+// GetBlockHeaderHash calculates the hash of a block with trx root from its header, the trxs are not needed
+func GetBlockHeaderHash(header *quorumpb.Block) ([]byte, error) {
+	if header.Version != BLOCK_VERSION_TRX_ROOT {
+		return nil, fmt.Errorf("block version <%d> has no trx root", header.Version)
+	}
+	return hashBlock(header, nil)
+}
+
+func hashBlock(block *quorumpb.Block, trxs []*quorumpb.Trx) ([]byte, error) {
+	blkWithOutHashAndSign := &quorumpb.Block{
+		GroupId:        block.GroupId,
+		BlockId:        block.BlockId,
+		Epoch:          block.Epoch,
+		PrevHash:       block.PrevHash,
+		ProducerPubkey: block.ProducerPubkey,
+		Trxs:           trxs,
+		Sudo:           block.Sudo,
+		TimeStamp:      block.TimeStamp,
+		Version:        block.Version,
+		TrxRoot:        block.TrxRoot,
+	}
+
+	tbytes, err := proto.Marshal(blkWithOutHashAndSign)
+	if err != nil {
+		return nil, err
+	}
+	return localcrypto.Hash(tbytes), nil
+}
+
+// VerifyBlockSign verifies the producer sign of the block with its ProducerPubkey, the block hash is not recalculated
+func VerifyBlockSign(block *quorumpb.Block) (bool, error) {
+	bytespubkey, err := base64.RawURLEncoding.DecodeString(block.ProducerPubkey)
+	if err != nil {
+		return false, err
+	}
+	ethpubkey, err := ethcrypto.DecompressPubkey(bytespubkey)
+	if err != nil {
+		return false, err
+	}
+	return localcrypto.EthVerifySign(block.BlockHash, block.ProducerSign, ethpubkey), nil
+}
+
+// ValidSudoBlock checks the sudo block is produced and signed by the group owner. Sudo blocks are created by the owner
+// out of the consensus to recover a group whose producers are gone, they must not be accepted from anyone else.
+func ValidSudoBlock(block *quorumpb.Block, ownerPubkey string) (bool, error) {
+	if !block.Sudo {
+		return false, fmt.Errorf("block <%d> is not a sudo block", block.BlockId)
+	}
+	if posPubkey(block.ProducerPubkey) != posPubkey(ownerPubkey) {
+		return false, fmt.Errorf("sudo block <%d> is produced by <%s>, not the group owner", block.BlockId, block.ProducerPubkey)
+	}
+	hash, err := GetBlockHash(block)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(hash, block.BlockHash) {
+		return false, fmt.Errorf("hash of sudo block <%d> mismatch", block.BlockId)
+	}
+	if valid, err := VerifyBlockSign(block); !valid {
+		if err == nil {
+			err = fmt.Errorf("owner sign of sudo block <%d> is invalid", block.BlockId)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // get all trxs from the blocks list
 func GetAllTrxs(blocks []*quorumpb.Block) ([]*quorumpb.Trx, error) {
 	var trxs []*quorumpb.Trx
@@ -0,0 +1,51 @@
+package data
+
+import (
+	"encoding/base64"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+func TestValidSudoBlock(t *testing.T) {
+	ownerKey, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := base64.RawURLEncoding.EncodeToString(ethcrypto.CompressPubkey(&ownerKey.PublicKey))
+
+	sign := func(block *quorumpb.Block) {
+		var err error
+		if block.BlockHash, err = GetBlockHash(block); err != nil {
+			t.Fatal(err)
+		}
+		if block.ProducerSign, err = ethcrypto.Sign(block.BlockHash, ownerKey); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	block := &quorumpb.Block{GroupId: "group", BlockId: 3, Epoch: 5, PrevHash: []byte("parent"), ProducerPubkey: owner, Sudo: true, TimeStamp: 100}
+	sign(block)
+	if valid, err := ValidSudoBlock(block, owner); !valid {
+		t.Errorf("expect sudo block from owner valid, got %v", err)
+	}
+
+	//not produced by the owner
+	producer := newMerkleTestBlock(t, BLOCK_VERSION_TRX_ROOT, 1)
+	producer.Sudo = true
+	if valid, _ := ValidSudoBlock(producer, owner); valid {
+		t.Errorf("expect sudo block from producer invalid")
+	}
+
+	//the sudo flag is covered by the hash
+	normal := &quorumpb.Block{GroupId: "group", BlockId: 3, Epoch: 5, PrevHash: []byte("parent"), ProducerPubkey: owner, TimeStamp: 100}
+	sign(normal)
+	if valid, _ := ValidSudoBlock(normal, owner); valid {
+		t.Errorf("expect normal block invalid")
+	}
+	normal.Sudo = true
+	if valid, _ := ValidSudoBlock(normal, owner); valid {
+		t.Errorf("expect block with sudo flag set after signed invalid")
+	}
+}
@@ -0,0 +1,190 @@
+package data
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// the leaves and the inner nodes are hashed with different prefixes, so a leaf can't be taken as an inner node
+const (
+	merkleLeafPrefix byte = 0x00
+	merkleNodePrefix byte = 0x01
+)
+
+var ErrTrxNotInBlock = errors.New("trx is not found in the block")
+
+// TrxInclusionProof proves the trx is the Index-th of the Total trxs packaged in the block with TrxRoot.
+// Siblings are the hashes from the leaf level to the root, a node without sibling at a level is carried up as is.
+type TrxInclusionProof struct {
+	GroupId  string   `json:"group_id" example:"c0c8dc4d-4a0e-4a1b-8a0b-6b4a0b0c8dc4"`
+	BlockId  uint64   `json:"block_id" example:"12"`
+	TrxId    string   `json:"trx_id" example:"5b5ba0e4-0b4a-4e3e-8e3b-4a8b0c0d1e2f"`
+	Index    int      `json:"index" example:"3"`
+	Total    int      `json:"total" example:"8"`
+	Siblings [][]byte `json:"siblings"`
+	TrxRoot  []byte   `json:"trx_root"`
+}
+
+// GetTrxLeafHash is the merkle leaf of the trx. StorageType is set by the node which reads the trx,
+// so it is reset to the default CHAIN.
+func GetTrxLeafHash(trx *quorumpb.Trx) ([]byte, error) {
+	leaf := proto.Clone(trx).(*quorumpb.Trx)
+	leaf.StorageType = quorumpb.TrxStroageType_CHAIN
+	tbytes, err := proto.Marshal(leaf)
+	if err != nil {
+		return nil, err
+	}
+	return localcrypto.Hash(append([]byte{merkleLeafPrefix}, tbytes...)), nil
+}
+
+func merkleParent(left, right []byte) []byte {
+	data := make([]byte, 0, 1+len(left)+len(right))
+	data = append(data, merkleNodePrefix)
+	data = append(data, left...)
+	data = append(data, right...)
+	return localcrypto.Hash(data)
+}
+
+// merkleLevels returns all levels of the tree, from the leaves to the root
+func merkleLevels(trxs []*quorumpb.Trx) ([][][]byte, error) {
+	level := make([][]byte, 0, len(trxs))
+	for _, trx := range trxs {
+		leaf, err := GetTrxLeafHash(trx)
+		if err != nil {
+			return nil, err
+		}
+		level = append(level, leaf)
+	}
+
+	levels := [][][]byte{level}
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				next = append(next, merkleParent(level[i], level[i+1]))
+			} else {
+				next = append(next, level[i])
+			}
+		}
+		levels = append(levels, next)
+		level = next
+	}
+	return levels, nil
+}
+
+// GetTrxRoot calculates the merkle root of the trxs in the order of the block, nil for no trx
+func GetTrxRoot(trxs []*quorumpb.Trx) ([]byte, error) {
+	if len(trxs) == 0 {
+		return nil, nil
+	}
+	levels, err := merkleLevels(trxs)
+	if err != nil {
+		return nil, err
+	}
+	return levels[len(levels)-1][0], nil
+}
+
+// GetTrxInclusionProof creates the proof of the trx with the trxs of a block in the trx root format
+func GetTrxInclusionProof(block *quorumpb.Block, trxId string) (*TrxInclusionProof, error) {
+	if block.Version < BLOCK_VERSION_TRX_ROOT {
+		return nil, fmt.Errorf("block <%d> is in version <%d> without trx root, the trx can only be proved by the whole block", block.BlockId, block.Version)
+	}
+	index := -1
+	for i, trx := range block.Trxs {
+		if trx.TrxId == trxId {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, ErrTrxNotInBlock
+	}
+
+	levels, err := merkleLevels(block.Trxs)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(levels[len(levels)-1][0], block.TrxRoot) {
+		return nil, fmt.Errorf("trx root of block <%d> mismatch with the trxs", block.BlockId)
+	}
+
+	proof := &TrxInclusionProof{
+		GroupId:  block.GroupId,
+		BlockId:  block.BlockId,
+		TrxId:    trxId,
+		Index:    index,
+		Total:    len(block.Trxs),
+		Siblings: [][]byte{},
+		TrxRoot:  block.TrxRoot,
+	}
+	pos := index
+	for _, level := range levels[:len(levels)-1] {
+		if sibling := pos ^ 1; sibling < len(level) {
+			proof.Siblings = append(proof.Siblings, level[sibling])
+		}
+		pos /= 2
+	}
+	return proof, nil
+}
+
+// VerifyTrxInclusionProof checks the trx is in the block by the proof. The header is the block without trxs,
+// its hash is recalculated with the trx root, and the producer sign is checked. The caller still has to check
+// the producer of the header is trusted, e.g. by the verified headers of a light node.
+func VerifyTrxInclusionProof(trx *quorumpb.Trx, proof *TrxInclusionProof, header *quorumpb.Block) (bool, error) {
+	if trx.TrxId != proof.TrxId || trx.GroupId != header.GroupId || proof.GroupId != header.GroupId || proof.BlockId != header.BlockId {
+		return false, errors.New("trx, proof and block header mismatch")
+	}
+	if !bytes.Equal(proof.TrxRoot, header.TrxRoot) {
+		return false, errors.New("trx root mismatch with the block header")
+	}
+	hash, err := GetBlockHeaderHash(header)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(hash, header.BlockHash) {
+		return false, errors.New("block hash mismatch with the header")
+	}
+	if valid, err := VerifyBlockSign(header); !valid {
+		if err == nil {
+			err = errors.New("producer sign is invalid")
+		}
+		return false, err
+	}
+
+	if proof.Index < 0 || proof.Index >= proof.Total {
+		return false, fmt.Errorf("index <%d> out of range <%d>", proof.Index, proof.Total)
+	}
+	node, err := GetTrxLeafHash(trx)
+	if err != nil {
+		return false, err
+	}
+	siblings := proof.Siblings
+	pos, count := proof.Index, proof.Total
+	for count > 1 {
+		if pos%2 == 1 || pos+1 < count {
+			if len(siblings) == 0 {
+				return false, errors.New("not enough siblings in the proof")
+			}
+			if pos%2 == 1 {
+				node = merkleParent(siblings[0], node)
+			} else {
+				node = merkleParent(node, siblings[0])
+			}
+			siblings = siblings[1:]
+		}
+		pos /= 2
+		count = (count + 1) / 2
+	}
+	if len(siblings) > 0 {
+		return false, errors.New("too many siblings in the proof")
+	}
+	if !bytes.Equal(node, proof.TrxRoot) {
+		return false, errors.New("trx root mismatch with the proof")
+	}
+	return true, nil
+}
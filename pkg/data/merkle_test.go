@@ -0,0 +1,117 @@
+package data
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func newMerkleTestBlock(t *testing.T, version uint32, trxCount int) *quorumpb.Block {
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &quorumpb.Block{
+		GroupId:        "3e1c5a7b-9d2f-4b6e-8a0c-2f4e6a8c0b1d",
+		BlockId:        9,
+		Epoch:          9,
+		PrevHash:       []byte("parent"),
+		ProducerPubkey: base64.RawURLEncoding.EncodeToString(ethcrypto.CompressPubkey(&key.PublicKey)),
+		TimeStamp:      100,
+		Version:        version,
+	}
+	for i := 0; i < trxCount; i++ {
+		block.Trxs = append(block.Trxs, &quorumpb.Trx{
+			TrxId:      fmt.Sprintf("trx-%d", i),
+			GroupId:    block.GroupId,
+			Data:       []byte(fmt.Sprintf("data-%d", i)),
+			SenderSign: []byte("sign"),
+		})
+	}
+	if version >= BLOCK_VERSION_TRX_ROOT {
+		if block.TrxRoot, err = GetTrxRoot(block.Trxs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if block.BlockHash, err = GetBlockHash(block); err != nil {
+		t.Fatal(err)
+	}
+	if block.ProducerSign, err = ethcrypto.Sign(block.BlockHash, key); err != nil {
+		t.Fatal(err)
+	}
+	return block
+}
+
+func TestTrxInclusionProof(t *testing.T) {
+	for count := 1; count <= 9; count++ {
+		block := newMerkleTestBlock(t, BLOCK_VERSION_TRX_ROOT, count)
+		if valid, err := ValidBlock(block); !valid {
+			t.Fatalf("%d trxs: expect valid block, got %v", count, err)
+		}
+		header := proto.Clone(block).(*quorumpb.Block)
+		header.Trxs = nil
+
+		for i, trx := range block.Trxs {
+			proof, err := GetTrxInclusionProof(block, trx.TrxId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if valid, err := VerifyTrxInclusionProof(trx, proof, header); !valid {
+				t.Errorf("%d trxs: expect trx %d proved, got %v", count, i, err)
+			}
+
+			//the proof of a trx can't prove another one
+			other := block.Trxs[(i+1)%count]
+			if count > 1 {
+				if valid, _ := VerifyTrxInclusionProof(other, &TrxInclusionProof{GroupId: proof.GroupId, BlockId: proof.BlockId, TrxId: other.TrxId, Index: proof.Index, Total: proof.Total, Siblings: proof.Siblings, TrxRoot: proof.TrxRoot}, header); valid {
+					t.Errorf("%d trxs: proof of trx %d should not prove %s", count, i, other.TrxId)
+				}
+			}
+			tampered := proto.Clone(trx).(*quorumpb.Trx)
+			tampered.Data = []byte("tampered")
+			if valid, _ := VerifyTrxInclusionProof(tampered, proof, header); valid {
+				t.Errorf("%d trxs: tampered trx %d should not be proved", count, i)
+			}
+		}
+	}
+
+	if _, err := GetTrxInclusionProof(newMerkleTestBlock(t, BLOCK_VERSION_TRX_ROOT, 3), "trx-unknown"); err != ErrTrxNotInBlock {
+		t.Errorf("expect trx not found, got %v", err)
+	}
+}
+
+func TestBlockVersions(t *testing.T) {
+	//old format blocks are still valid, but can't prove a trx without the whole block
+	old := newMerkleTestBlock(t, BLOCK_VERSION_TRX_LIST, 3)
+	if valid, err := ValidBlock(old); !valid {
+		t.Errorf("expect old format block valid, got %v", err)
+	}
+	if _, err := GetTrxInclusionProof(old, "trx-0"); err == nil {
+		t.Errorf("expect no proof for old format block")
+	}
+
+	//the trxs of a new format block can't be changed without changing the root
+	block := newMerkleTestBlock(t, BLOCK_VERSION_TRX_ROOT, 3)
+	block.Trxs = block.Trxs[:2]
+	if valid, _ := ValidBlock(block); valid {
+		t.Errorf("expect block with trx removed invalid")
+	}
+
+	//the header is covered by the hash
+	header := newMerkleTestBlock(t, BLOCK_VERSION_TRX_ROOT, 3)
+	proof, _ := GetTrxInclusionProof(header, "trx-0")
+	trx := header.Trxs[0]
+	header.Trxs = nil
+	header.Epoch++
+	if valid, _ := VerifyTrxInclusionProof(trx, proof, header); valid {
+		t.Errorf("expect proof with tampered header invalid")
+	}
+
+	if _, err := GetBlockHash(&quorumpb.Block{Version: BLOCK_VERSION_TRX_ROOT + 1}); err == nil {
+		t.Errorf("expect unknown block version unsupported")
+	}
+}
@@ -0,0 +1,128 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+// minimal stake to be selected as a producer of a POS group
+var POS_MIN_STAKE uint64 = 1
+
+// stake is declared by the staker for free, so the stake of a staker is capped, the stakers can be limited
+// by the owner with the auth mode of STAKE trx
+var POS_MAX_STAKE uint64 = 1000
+
+// length of a POS slot in ns, the epoch of a block is the slot it is proposed in,
+// the next proposer is selected for the next slot if the block of a slot is not proposed
+var POS_SLOT_DURATION int64 = 3 * 1000 * 1000 * 1000 //3s
+
+// max clock drift of the producers, a block with the timestamp later than now + drift is rejected
+var POS_MAX_CLOCK_DRIFT int64 = 2 * 1000 * 1000 * 1000 //2s
+
+func posPubkey(pubkey string) string {
+	pk, _ := localcrypto.Libp2pPubkeyToEthBase64(pubkey)
+	if pk == "" {
+		pk = pubkey
+	}
+	return pk
+}
+
+// GetActiveStakes returns the stakes not less than POS_MIN_STAKE, sorted by staker pubkey
+func GetActiveStakes(stakes []*quorumpb.StakeItem) []*quorumpb.StakeItem {
+	var active []*quorumpb.StakeItem
+	for _, item := range stakes {
+		if item.Amount >= POS_MIN_STAKE {
+			active = append(active, item)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		return posPubkey(active[i].StakerPubkey) < posPubkey(active[j].StakerPubkey)
+	})
+	return active
+}
+
+// posWeight returns the stake capped by POS_MAX_STAKE
+func posWeight(item *quorumpb.StakeItem) *big.Int {
+	if item.Amount > POS_MAX_STAKE {
+		return new(big.Int).SetUint64(POS_MAX_STAKE)
+	}
+	return new(big.Int).SetUint64(item.Amount)
+}
+
+// SelectProposer selects the producer of the epoch after the parent block weighted by stake,
+// every node gets the same result with the same stakes, "" is returned if no active stake
+func SelectProposer(stakes []*quorumpb.StakeItem, parentHash []byte, epoch uint64) string {
+	active := GetActiveStakes(stakes)
+	if len(active) == 0 {
+		return ""
+	}
+
+	total := new(big.Int)
+	for _, item := range active {
+		total.Add(total, posWeight(item))
+	}
+
+	epochb := make([]byte, 8)
+	binary.BigEndian.PutUint64(epochb, epoch)
+	seed := sha256.Sum256(append(append([]byte{}, parentHash...), epochb...))
+	point := new(big.Int).Mod(new(big.Int).SetBytes(seed[:]), total)
+
+	sum := new(big.Int)
+	for _, item := range active {
+		sum.Add(sum, posWeight(item))
+		if point.Cmp(sum) < 0 {
+			return item.StakerPubkey
+		}
+	}
+	return active[len(active)-1].StakerPubkey
+}
+
+// GetPosSlotEpoch returns the epoch of the slot at the time after the parent block
+func GetPosSlotEpoch(parent *quorumpb.Block, now int64) uint64 {
+	slots := uint64(1)
+	if now > parent.TimeStamp {
+		slots += uint64((now - parent.TimeStamp) / POS_SLOT_DURATION)
+	}
+	return parent.Epoch + slots
+}
+
+// GetPosProposer returns the producer should propose the block of the epoch after the parent block,
+// the owner proposes all blocks if no active stake
+func GetPosProposer(stakes []*quorumpb.StakeItem, parent *quorumpb.Block, epoch uint64, ownerPubkey string) string {
+	proposer := SelectProposer(stakes, parent.BlockHash, epoch)
+	if proposer == "" {
+		return ownerPubkey
+	}
+	return proposer
+}
+
+// ValidPosBlock checks the block of a POS group is proposed by the producer selected for its epoch,
+// and not before the slot of the epoch starts, and not later than now. The hash, link and sign are
+// checked by ValidBlockWithParent.
+func ValidPosBlock(block, parent *quorumpb.Block, stakes []*quorumpb.StakeItem, ownerPubkey string, now int64) (bool, error) {
+	//the producer can't pick a later epoch selecting itself by a future timestamp
+	if block.TimeStamp > now+POS_MAX_CLOCK_DRIFT {
+		return false, fmt.Errorf("block timestamp <%d> is later than now <%d>", block.TimeStamp, now)
+	}
+	if block.Epoch <= parent.Epoch {
+		return false, fmt.Errorf("epoch <%d> should be after the parent epoch <%d>", block.Epoch, parent.Epoch)
+	}
+
+	//the slots skipped can't be more than the time passed
+	skipped := block.Epoch - parent.Epoch - 1
+	if block.TimeStamp < parent.TimeStamp || skipped > uint64((block.TimeStamp-parent.TimeStamp)/POS_SLOT_DURATION) {
+		return false, fmt.Errorf("block is proposed before the slot of epoch <%d>", block.Epoch)
+	}
+
+	proposer := GetPosProposer(stakes, parent, block.Epoch, ownerPubkey)
+	if posPubkey(proposer) != posPubkey(block.ProducerPubkey) {
+		return false, fmt.Errorf("producer <%s> is not the proposer <%s> of epoch <%d>", block.ProducerPubkey, proposer, block.Epoch)
+	}
+	return true, nil
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+func TestSelectProposer(t *testing.T) {
+	if proposer := SelectProposer(nil, []byte("parent"), 1); proposer != "" {
+		t.Errorf("expect no proposer without stakes, got %s", proposer)
+	}
+
+	stakes := []*quorumpb.StakeItem{
+		{StakerPubkey: "c", Amount: 100},
+		{StakerPubkey: "a", Amount: 300},
+		{StakerPubkey: "b", Amount: 0},
+	}
+	//the order of stakes does not matter
+	reversed := []*quorumpb.StakeItem{stakes[2], stakes[1], stakes[0]}
+
+	counts := make(map[string]int)
+	for epoch := uint64(1); epoch <= 4000; epoch++ {
+		parentHash := []byte(fmt.Sprintf("parent-%d", epoch/10))
+		proposer := SelectProposer(stakes, parentHash, epoch)
+		if again := SelectProposer(reversed, parentHash, epoch); again != proposer {
+			t.Fatalf("epoch %d: proposer %s != %s", epoch, proposer, again)
+		}
+		counts[proposer]++
+	}
+	if counts["b"] != 0 {
+		t.Errorf("inactive stake should not be selected, got %v", counts)
+	}
+	//weighted by stake, about 3000 vs 1000
+	if counts["a"] < 2700 || counts["c"] < 700 {
+		t.Errorf("unexpected distribution %v", counts)
+	}
+}
+
+func TestValidPosBlock(t *testing.T) {
+	stakes := []*quorumpb.StakeItem{{StakerPubkey: "a", Amount: 1}, {StakerPubkey: "c", Amount: 1}}
+	parent := &quorumpb.Block{BlockId: 5, Epoch: 7, BlockHash: []byte("parent"), TimeStamp: 1000}
+
+	epoch := GetPosSlotEpoch(parent, parent.TimeStamp+POS_SLOT_DURATION/2)
+	if epoch != 8 {
+		t.Fatalf("expect epoch 8 in the first slot, got %d", epoch)
+	}
+	proposer := GetPosProposer(stakes, parent, epoch, "owner")
+	other := "a"
+	if proposer == "a" {
+		other = "c"
+	}
+
+	now := parent.TimeStamp + 10*POS_SLOT_DURATION
+	block := &quorumpb.Block{BlockId: 6, Epoch: epoch, ProducerPubkey: proposer, TimeStamp: parent.TimeStamp + 1}
+	if valid, err := ValidPosBlock(block, parent, stakes, "owner", now); !valid {
+		t.Errorf("expect valid block, got %s", err)
+	}
+
+	block.ProducerPubkey = other
+	if valid, _ := ValidPosBlock(block, parent, stakes, "owner", now); valid {
+		t.Errorf("block from the producer not selected should be invalid")
+	}
+
+	//skip slots before they start
+	block.Epoch = epoch + 2
+	block.ProducerPubkey = GetPosProposer(stakes, parent, block.Epoch, "owner")
+	if valid, _ := ValidPosBlock(block, parent, stakes, "owner", now); valid {
+		t.Errorf("block proposed before its slot should be invalid")
+	}
+	block.TimeStamp = parent.TimeStamp + 2*POS_SLOT_DURATION
+	if valid, err := ValidPosBlock(block, parent, stakes, "owner", now); !valid {
+		t.Errorf("expect valid block after 2 slots skipped, got %s", err)
+	}
+
+	//the owner proposes all blocks without stakes
+	block.ProducerPubkey = "owner"
+	if valid, err := ValidPosBlock(block, parent, nil, "owner", now); !valid {
+		t.Errorf("expect valid block from owner, got %s", err)
+	}
+
+	//a future block can skip any slots
+	block.TimeStamp = now + 100*POS_SLOT_DURATION
+	block.Epoch = GetPosSlotEpoch(parent, block.TimeStamp)
+	if valid, _ := ValidPosBlock(block, parent, nil, "owner", now); valid {
+		t.Errorf("block with future timestamp should be invalid")
+	}
+	if valid, err := ValidPosBlock(block, parent, nil, "owner", block.TimeStamp); !valid {
+		t.Errorf("expect valid block at its time, got %s", err)
+	}
+
+	block.Epoch = parent.Epoch
+	if valid, _ := ValidPosBlock(block, parent, nil, "owner", now); valid {
+		t.Errorf("epoch should be after the parent")
+	}
+}
+
+func TestSelectProposerCapped(t *testing.T) {
+	stakes := []*quorumpb.StakeItem{
+		{StakerPubkey: "a", Amount: ^uint64(0)},
+		{StakerPubkey: "c", Amount: POS_MAX_STAKE},
+	}
+	counts := make(map[string]int)
+	for epoch := uint64(1); epoch <= 2000; epoch++ {
+		counts[SelectProposer(stakes, []byte("parent"), epoch)]++
+	}
+	//the stake over the cap doesn't win every slot
+	if counts["c"] < 800 {
+		t.Errorf("unexpected distribution %v", counts)
+	}
+}
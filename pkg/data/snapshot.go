@@ -0,0 +1,155 @@
+package data
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// create a state snapshot of the group after the block applied, sign it with the group sign key
+func CreateSnapshotByEthKey(block *quorumpb.Block, items []*quorumpb.SnapshotItem, senderPubkey string, keystore localcrypto.Keystore, keyalias string, opts ...string) (*quorumpb.Snapshot, error) {
+	stateHash, err := GetSnapshotStateHash(items)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &quorumpb.Snapshot{
+		GroupId:      block.GroupId,
+		BlockId:      block.BlockId,
+		Epoch:        block.Epoch,
+		BlockHash:    block.BlockHash,
+		ItemsCount:   int32(len(items)),
+		StateHash:    stateHash,
+		SenderPubkey: senderPubkey,
+		TimeStamp:    time.Now().UnixNano(),
+	}
+
+	hash, err := getSnapshotHash(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var signature []byte
+	if keyalias == "" {
+		signature, err = keystore.EthSignByKeyName(snapshot.GroupId, hash, opts...)
+	} else {
+		signature, err = keystore.EthSignByKeyAlias(keyalias, hash, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(signature) == 0 {
+		return nil, errors.New("create signature failed")
+	}
+
+	snapshot.SnapshotHash = hash
+	snapshot.SenderSign = signature
+	snapshot.Items = items
+	return snapshot, nil
+}
+
+// hash of all snapshot items, in order
+func GetSnapshotStateHash(items []*quorumpb.SnapshotItem) ([]byte, error) {
+	h := sha256.New()
+	for _, item := range items {
+		b, err := proto.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		h.Write(b)
+	}
+	return h.Sum(nil), nil
+}
+
+// hash of the snapshot without items, hash and sign
+func getSnapshotHash(snapshot *quorumpb.Snapshot) ([]byte, error) {
+	snapshotWithoutItemsHashAndSign := &quorumpb.Snapshot{
+		GroupId:      snapshot.GroupId,
+		BlockId:      snapshot.BlockId,
+		Epoch:        snapshot.Epoch,
+		BlockHash:    snapshot.BlockHash,
+		ItemsCount:   snapshot.ItemsCount,
+		StateHash:    snapshot.StateHash,
+		Items:        nil,
+		SenderPubkey: snapshot.SenderPubkey,
+		TimeStamp:    snapshot.TimeStamp,
+		SnapshotHash: nil,
+		SenderSign:   nil,
+	}
+
+	sbytes, err := proto.Marshal(snapshotWithoutItemsHashAndSign)
+	if err != nil {
+		return nil, err
+	}
+	return localcrypto.Hash(sbytes), nil
+}
+
+// verify the snapshot header (hash and sender sign) and the block at the snapshot height, items are not checked
+func ValidSnapshotHeader(snapshot *quorumpb.Snapshot, block *quorumpb.Block) (bool, error) {
+	//step 1, check hash for snapshot
+	hash, err := getSnapshotHash(snapshot)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(hash, snapshot.SnapshotHash) {
+		return false, fmt.Errorf("hash for snapshot is invalid")
+	}
+
+	//step 2, check block at the snapshot height
+	if block.GroupId != snapshot.GroupId || block.BlockId != snapshot.BlockId || block.Epoch != snapshot.Epoch {
+		return false, fmt.Errorf("block mismatch with snapshot")
+	}
+	if !bytes.Equal(block.BlockHash, snapshot.BlockHash) {
+		return false, fmt.Errorf("blockhash mismatch with snapshot")
+	}
+	if valid, err := ValidBlock(block); !valid {
+		if err == nil {
+			err = fmt.Errorf("producer sign for block is invalid")
+		}
+		return false, err
+	}
+
+	//step 3, check sender sign
+	bytespubkey, err := base64.RawURLEncoding.DecodeString(snapshot.SenderPubkey)
+	if err != nil {
+		return false, err
+	}
+	ethpubkey, err := ethcrypto.DecompressPubkey(bytespubkey)
+	if err != nil {
+		return false, err
+	}
+	return localcrypto.EthVerifySign(hash, snapshot.SenderSign, ethpubkey), nil
+}
+
+// verify the header of the snapshot in the resp, the snapshot can be served by any node, but must be signed by a producer
+func ValidSnapshotResp(resp *quorumpb.ReqSnapshotResp, isProducer func(pubkey string) bool) (bool, error) {
+	if !isProducer(resp.Snapshot.SenderPubkey) {
+		return false, fmt.Errorf("snapshot signed by non-producer <%s>", resp.Snapshot.SenderPubkey)
+	}
+	return ValidSnapshotHeader(resp.Snapshot, resp.Block)
+}
+
+// verify the snapshot items with the state hash in the snapshot header
+func ValidSnapshotItems(snapshot *quorumpb.Snapshot, items []*quorumpb.SnapshotItem) (bool, error) {
+	if len(items) != int(snapshot.ItemsCount) {
+		return false, fmt.Errorf("snapshot items count mismatch, expect <%d>, got <%d>", snapshot.ItemsCount, len(items))
+	}
+
+	stateHash, err := GetSnapshotStateHash(items)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(stateHash, snapshot.StateHash) {
+		return false, fmt.Errorf("state hash for snapshot is invalid")
+	}
+	return true, nil
+}
@@ -0,0 +1,124 @@
+package data
+
+import (
+	"testing"
+
+	localcrypto "github.com/rumsystem/quorum/pkg/crypto"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+func TestSnapshotSignAndVerify(t *testing.T) {
+	groupId := "7c352591-f237-4b80-81fb-d6347d0380b5"
+	if _, err := localcrypto.InitKeystore("defaultkeystore", t.TempDir()); err != nil {
+		t.Fatalf("init keystore failed: %s", err)
+	}
+	ks := localcrypto.GetKeystore()
+	if err := ks.Unlock(map[string]string{}, "password"); err != nil {
+		t.Fatalf("unlock keystore failed: %s", err)
+	}
+	if _, err := ks.NewKeyWithDefaultPassword(groupId, localcrypto.Sign); err != nil {
+		t.Fatalf("new sign key failed: %s", err)
+	}
+	pubkey, err := ks.GetEncodedPubkey(groupId, localcrypto.Sign)
+	if err != nil {
+		t.Fatalf("get pubkey failed: %s", err)
+	}
+
+	block, err := CreateGenesisBlockByEthKey(groupId, pubkey, ks, "")
+	if err != nil {
+		t.Fatalf("create block failed: %s", err)
+	}
+
+	items := []*quorumpb.SnapshotItem{
+		{Key: "prd_" + groupId + "_a", Value: []byte("producer")},
+		{Key: "usr_" + groupId + "_b", Value: []byte("user")},
+	}
+	snapshot, err := CreateSnapshotByEthKey(block, items, pubkey, ks, "")
+	if err != nil {
+		t.Fatalf("create snapshot failed: %s", err)
+	}
+
+	if valid, err := ValidSnapshotHeader(snapshot, block); !valid {
+		t.Fatalf("snapshot header should be valid, err: %v", err)
+	}
+	if valid, err := ValidSnapshotItems(snapshot, items); !valid {
+		t.Fatalf("snapshot items should be valid, err: %v", err)
+	}
+
+	tampered := []*quorumpb.SnapshotItem{items[0], {Key: items[1].Key, Value: []byte("owner")}}
+	if valid, _ := ValidSnapshotItems(snapshot, tampered); valid {
+		t.Fatalf("tampered snapshot items should be invalid")
+	}
+	if valid, _ := ValidSnapshotItems(snapshot, items[:1]); valid {
+		t.Fatalf("partial snapshot items should be invalid")
+	}
+
+	snapshot.BlockId = 1
+	if valid, _ := ValidSnapshotHeader(snapshot, block); valid {
+		t.Fatalf("tampered snapshot header should be invalid")
+	}
+}
+
+func TestSnapshotServedByFullnode(t *testing.T) {
+	groupId := "0b0b7c1e-6a0e-4bb3-9d62-2b6e7f6f3a51"
+	if _, err := localcrypto.InitKeystore("defaultkeystore", t.TempDir()); err != nil {
+		t.Fatalf("init keystore failed: %s", err)
+	}
+	ks := localcrypto.GetKeystore()
+	if err := ks.Unlock(map[string]string{}, "password"); err != nil {
+		t.Fatalf("unlock keystore failed: %s", err)
+	}
+	if _, err := ks.NewKeyWithDefaultPassword(groupId, localcrypto.Sign); err != nil {
+		t.Fatalf("new sign key failed: %s", err)
+	}
+	producerPubkey, err := ks.GetEncodedPubkey(groupId, localcrypto.Sign)
+	if err != nil {
+		t.Fatalf("get pubkey failed: %s", err)
+	}
+	fullnodeKeyname := groupId + "_fullnode"
+	if _, err := ks.NewKeyWithDefaultPassword(fullnodeKeyname, localcrypto.Sign); err != nil {
+		t.Fatalf("new sign key failed: %s", err)
+	}
+	fullnodePubkey, err := ks.GetEncodedPubkey(fullnodeKeyname, localcrypto.Sign)
+	if err != nil {
+		t.Fatalf("get pubkey failed: %s", err)
+	}
+	isProducer := func(pubkey string) bool { return pubkey == producerPubkey }
+
+	block, err := CreateGenesisBlockByEthKey(groupId, producerPubkey, ks, "")
+	if err != nil {
+		t.Fatalf("create block failed: %s", err)
+	}
+	items := []*quorumpb.SnapshotItem{{Key: "usr_" + groupId + "_b", Value: []byte("user")}}
+
+	//the snapshot of the producer, served by a fullnode bootstrapped from it
+	snapshot, err := CreateSnapshotByEthKey(block, items, producerPubkey, ks, "")
+	if err != nil {
+		t.Fatalf("create snapshot failed: %s", err)
+	}
+	resp := &quorumpb.ReqSnapshotResp{GroupId: groupId, ProviderPubkey: fullnodePubkey, Snapshot: snapshot, Block: block}
+	if valid, err := ValidSnapshotResp(resp, isProducer); !valid {
+		t.Fatalf("producer signed snapshot served by fullnode should be valid, err: %v", err)
+	}
+
+	//the snapshot signed by the fullnode itself
+	signer := &fullnodeKeystore{Keystore: ks, keyname: fullnodeKeyname}
+	snapshot, err = CreateSnapshotByEthKey(block, items, fullnodePubkey, signer, "")
+	if err != nil {
+		t.Fatalf("create snapshot failed: %s", err)
+	}
+	resp.Snapshot = snapshot
+	if valid, _ := ValidSnapshotResp(resp, isProducer); valid {
+		t.Fatalf("snapshot signed by fullnode should be invalid")
+	}
+}
+
+// fullnodeKeystore signs by the key of the fullnode instead of the group key
+type fullnodeKeystore struct {
+	localcrypto.Keystore
+	keyname string
+}
+
+func (ks *fullnodeKeystore) EthSignByKeyName(keyname string, data []byte, opts ...string) ([]byte, error) {
+	return ks.Keystore.EthSignByKeyName(ks.keyname, data, opts...)
+}
@@ -21,6 +21,10 @@ const (
 	Sec   = 30
 )
 
+// trx should be packaged before TimeStamp + TRX_EXPIRE_DURATION, or it is dropped by producers,
+// it is long enough for the trxs waiting in the buffer and the clock drift of the nodes, set by --trxexpire
+var TRX_EXPIRE_DURATION = 12 * time.Hour
+
 func CreateTrxWithoutSign(nodename string, version string, groupItem *quorumpb.GroupItem, msgType quorumpb.TrxType, data []byte, encryptto ...[]string) (*quorumpb.Trx, []byte, error) {
 	var trx quorumpb.Trx
 
@@ -60,6 +64,7 @@ func CreateTrxWithoutSign(nodename string, version string, groupItem *quorumpb.G
 	trx.Data = encryptdData
 	trx.Version = version
 	trx.TimeStamp = time.Now().UnixNano()
+	trx.Expired = trx.TimeStamp + TRX_EXPIRE_DURATION.Nanoseconds()
 
 	bytes, err := proto.Marshal(&trx)
 	if err != nil {
@@ -91,6 +96,24 @@ func CreateTrxByEthKey(nodename string, version string, groupItem *quorumpb.Grou
 	return trx, nil
 }
 
+// IsTrxExpired returns true if trx is expired at now, trx without Expired never expires
+func IsTrxExpired(trx *quorumpb.Trx, now int64) bool {
+	return trx.Expired > 0 && now > trx.Expired
+}
+
+// IsSeedKeyTrx returns true if the trx data is always encrypted by the cipher key in the seed,
+// so users who only have the seed can join and sync the group. The other trxs are encrypted by
+// the latest cipher key rotated by the GROUP_KEY trxs
+func IsSeedKeyTrx(trxType quorumpb.TrxType) bool {
+	switch trxType {
+	case quorumpb.TrxType_ANNOUNCE, quorumpb.TrxType_GROUP_KEY,
+		quorumpb.TrxType_REQ_BLOCK, quorumpb.TrxType_REQ_BLOCK_RESP,
+		quorumpb.TrxType_REQ_SNAPSHOT, quorumpb.TrxType_REQ_SNAPSHOT_RESP:
+		return true
+	}
+	return false
+}
+
 func VerifyTrx(trx *quorumpb.Trx) (bool, error) {
 	//clone trxMsg to verify
 	clonetrxmsg := &quorumpb.Trx{
@@ -109,17 +132,16 @@ func VerifyTrx(trx *quorumpb.Trx) (bool, error) {
 		return false, err
 	}
 	hash := localcrypto.Hash(bytes)
-	ks := localcrypto.GetKeystore()
 
 	if len(trx.SenderPubkey) == 42 && trx.SenderPubkey[:2] == "0x" { //try 0x address
 		//try verify 0x address
 		sig := trx.SenderSign
-		if sig[crypto.RecoveryIDOffset] == 27 || sig[crypto.RecoveryIDOffset] == 28 {
+		if len(sig) == 65 && (sig[crypto.RecoveryIDOffset] == 27 || sig[crypto.RecoveryIDOffset] == 28) {
 			sig[crypto.RecoveryIDOffset] -= 27
 		}
 		sigpubkey, err := ethcrypto.SigToPub(hash, sig)
 		if err == nil {
-			ok := ks.EthVerifySign(hash, trx.SenderSign, sigpubkey)
+			ok := localcrypto.EthVerifySign(hash, trx.SenderSign, sigpubkey)
 			if ok {
 				addressfrompubkey := ethcrypto.PubkeyToAddress(*sigpubkey).Hex()
 				if strings.EqualFold(addressfrompubkey, trx.SenderPubkey) {
@@ -136,7 +158,7 @@ func VerifyTrx(trx *quorumpb.Trx) (bool, error) {
 	if err == nil { //try eth key
 		ethpubkey, err := ethcrypto.DecompressPubkey(bytespubkey)
 		if err == nil {
-			r := ks.EthVerifySign(hash, trx.SenderSign, ethpubkey)
+			r := localcrypto.EthVerifySign(hash, trx.SenderSign, ethpubkey)
 			return r, nil
 		}
 		return false, err
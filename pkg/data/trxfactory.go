@@ -10,6 +10,7 @@ type TrxFactory struct {
 	groupId   string
 	groupItem *quorumpb.GroupItem
 	version   string
+	cipherKey string //the latest cipher key rotated by owner, empty if never rotated
 }
 
 func (factory *TrxFactory) Init(version string, groupItem *quorumpb.GroupItem, nodename string) {
@@ -19,8 +20,18 @@ func (factory *TrxFactory) Init(version string, groupItem *quorumpb.GroupItem, n
 	factory.version = version
 }
 
+// SetCipherKey sets the latest cipher key of the group, used by the trxs not encrypted by the seed key
+func (factory *TrxFactory) SetCipherKey(cipherKey string) {
+	factory.cipherKey = cipherKey
+}
+
 func (factory *TrxFactory) CreateTrxByEthKey(msgType quorumpb.TrxType, data []byte, keyalias string, encryptto ...[]string) (*quorumpb.Trx, error) {
-	return CreateTrxByEthKey(factory.nodename, factory.version, factory.groupItem, msgType, data, keyalias, encryptto...)
+	groupItem := factory.groupItem
+	if factory.cipherKey != "" && !IsSeedKeyTrx(msgType) {
+		groupItem = proto.Clone(factory.groupItem).(*quorumpb.GroupItem)
+		groupItem.CipherKey = factory.cipherKey
+	}
+	return CreateTrxByEthKey(factory.nodename, factory.version, groupItem, msgType, data, keyalias, encryptto...)
 }
 
 func (factory *TrxFactory) GetUpdAppConfigTrx(keyalias string, item *quorumpb.AppConfigItem) (*quorumpb.Trx, error) {
@@ -49,6 +60,22 @@ func (factory *TrxFactory) GetRegProducerBundleTrx(keyalias string, item *quorum
 	return factory.CreateTrxByEthKey(quorumpb.TrxType_PRODUCER, encodedcontent, keyalias)
 }
 
+func (factory *TrxFactory) GetStakeTrx(keyalias string, item *quorumpb.StakeItem) (*quorumpb.Trx, error) {
+	encodedcontent, err := proto.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	return factory.CreateTrxByEthKey(quorumpb.TrxType_STAKE, encodedcontent, keyalias)
+}
+
+func (factory *TrxFactory) GetGroupKeyTrx(keyalias string, item *quorumpb.GroupKeyItem) (*quorumpb.Trx, error) {
+	encodedcontent, err := proto.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	return factory.CreateTrxByEthKey(quorumpb.TrxType_GROUP_KEY, encodedcontent, keyalias)
+}
+
 func (factory *TrxFactory) GetRegUserTrx(keyalias string, item *quorumpb.UserItem) (*quorumpb.Trx, error) {
 	encodedcontent, err := proto.Marshal(item)
 	if err != nil {
@@ -103,6 +130,39 @@ func (factory *TrxFactory) GetReqBlocksRespTrx(keyalias string, groupId string,
 	return factory.CreateTrxByEthKey(quorumpb.TrxType_REQ_BLOCK_RESP, bItemBytes, keyalias)
 }
 
+func (factory *TrxFactory) GetReqSnapshotTrx(keyalias string, groupId string, fromItem int32, stateHash []byte) (*quorumpb.Trx, error) {
+	var reqSnapshotItem quorumpb.ReqSnapshot
+	reqSnapshotItem.GroupId = groupId
+	reqSnapshotItem.FromItem = fromItem
+	reqSnapshotItem.StateHash = stateHash
+	reqSnapshotItem.ReqPubkey = factory.groupItem.UserSignPubkey
+
+	bItemBytes, err := proto.Marshal(&reqSnapshotItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return factory.CreateTrxByEthKey(quorumpb.TrxType_REQ_SNAPSHOT, bItemBytes, keyalias)
+}
+
+func (factory *TrxFactory) GetReqSnapshotRespTrx(keyalias string, groupId string, requester string, fromItem int32, snapshot *quorumpb.Snapshot, block *quorumpb.Block, result quorumpb.ReqSnapshotResult) (*quorumpb.Trx, error) {
+	var reqSnapshotRespItem quorumpb.ReqSnapshotResp
+	reqSnapshotRespItem.GroupId = groupId
+	reqSnapshotRespItem.RequesterPubkey = requester
+	reqSnapshotRespItem.ProviderPubkey = factory.groupItem.UserSignPubkey
+	reqSnapshotRespItem.Result = result
+	reqSnapshotRespItem.FromItem = fromItem
+	reqSnapshotRespItem.Snapshot = snapshot
+	reqSnapshotRespItem.Block = block
+
+	bItemBytes, err := proto.Marshal(&reqSnapshotRespItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return factory.CreateTrxByEthKey(quorumpb.TrxType_REQ_SNAPSHOT_RESP, bItemBytes, keyalias)
+}
+
 func (factory *TrxFactory) GetPostAnyTrx(keyalias string, content []byte, encryptto ...[]string) (*quorumpb.Trx, error) {
 	if _, err := IsTrxDataWithinSizeLimit(content); err != nil {
 		return nil, err
@@ -59,6 +59,10 @@ const GROUP_PRODUCER string = "group_producer"
 const POST_TRX_URI string = "/api/v1/node/trx"
 const GET_CTN_URI string = "/api/v1/node/groupctn"
 const GET_CHAIN_DATA_URI string = "/api/v1/node/getchaindata"
+const GET_NODE_BLOCK_URI string = "/api/v1/node/%s/block/%d"
+const GET_NODE_HEADERS_URI string = "/api/v1/node/%s/headers/%d?num=%d"
+const GET_NODE_TRUSTED_HEADER_URI string = "/api/v1/node/%s/headers/trusted"
+const GET_NODE_TRX_PROOF_URI string = "/api/v1/node/%s/trx/%s/proof"
 
 func GetPostTrxURI(groupId string) string {
 	return fmt.Sprintf("%s/%s", POST_TRX_URI, groupId)
@@ -72,6 +76,22 @@ func GetChainDataURI(groupId string) string {
 	return fmt.Sprintf("%s/%s", GET_CHAIN_DATA_URI, groupId)
 }
 
+func GetNodeBlockURI(groupId string, blockId uint64) string {
+	return fmt.Sprintf(GET_NODE_BLOCK_URI, groupId, blockId)
+}
+
+func GetNodeHeadersURI(groupId string, blockId uint64, num int) string {
+	return fmt.Sprintf(GET_NODE_HEADERS_URI, groupId, blockId, num)
+}
+
+func GetNodeTrustedHeaderURI(groupId string) string {
+	return fmt.Sprintf(GET_NODE_TRUSTED_HEADER_URI, groupId)
+}
+
+func GetNodeTrxProofURI(groupId string, trxId string) string {
+	return fmt.Sprintf(GET_NODE_TRX_PROOF_URI, groupId, trxId)
+}
+
 type NodeSDKSendTrxItem struct {
 	TrxItem []byte
 }
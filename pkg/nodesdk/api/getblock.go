@@ -2,6 +2,7 @@ package nodesdkapi
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
@@ -9,7 +10,11 @@ import (
 	quorumpb "github.com/rumsystem/quorum/pkg/pb"
 )
 
-const GET_BLOCK_URI string = "/api/v1/block"
+// VerifiedBlock is the block from the chain api, Verified is false if the block is after the verified headers
+type VerifiedBlock struct {
+	*quorumpb.Block
+	Verified bool
+}
 
 func (h *NodeSDKHandler) GetBlock() echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -18,8 +23,8 @@ func (h *NodeSDKHandler) GetBlock() echo.HandlerFunc {
 			return rumerrors.NewBadRequestError(rumerrors.ErrInvalidGroupID)
 		}
 
-		blockid := c.Param("block_id")
-		if blockid == "" {
+		blockid, err := strconv.ParseUint(c.Param("block_id"), 10, 64)
+		if err != nil {
 			return rumerrors.NewBadRequestError(rumerrors.ErrInvalidBlockID)
 		}
 
@@ -38,14 +43,21 @@ func (h *NodeSDKHandler) GetBlock() echo.HandlerFunc {
 			return rumerrors.NewBadRequestError(err)
 		}
 
-		path := GET_BLOCK_URI + "/" + groupid + "/" + blockid
+		block := new(quorumpb.Block)
+		err = httpClient.RequestChainAPI(GetNodeBlockURI(groupid, blockid), http.MethodGet, nil, nil, block)
+		if err != nil {
+			return rumerrors.NewBadRequestError(err)
+		}
 
-		result := new(quorumpb.Block)
-		err = httpClient.RequestChainAPI(path, http.MethodGet, nil, nil, result)
+		//refuse the block conflicts with the verified header, sync the headers in background if it is not verified yet
+		verified, err := nodesdkctx.GetCtx().GetChainStorage().VerifyLightBlock(block)
+		if err == nil && !verified {
+			startHeaderSync(nodesdkGroupItem)
+		}
 		if err != nil {
 			return rumerrors.NewBadRequestError(err)
 		}
 
-		return c.JSON(http.StatusOK, result)
+		return c.JSON(http.StatusOK, &VerifiedBlock{Block: block, Verified: verified})
 	}
 }
@@ -37,6 +37,8 @@ type GroupContentObjectItem struct {
 	Content   proto.Message
 	TypeUrl   string
 	TimeStamp int64
+	BlockId   uint64
+	Verified  bool //false if the trx is not found in the verified blocks yet
 }
 
 func (h *NodeSDKHandler) GetGroupCtn() echo.HandlerFunc {
@@ -85,8 +87,17 @@ func (h *NodeSDKHandler) GetGroupCtn() echo.HandlerFunc {
 			return rumerrors.NewBadRequestError(err)
 		}
 
+		//the trxs after the verified headers are verified after the headers synced in background
+		startHeaderSync(nodesdkGroupItem)
+
 		ctnobjList := []*GroupContentObjectItem{}
 		for _, trx := range *trxs {
+			//refuse the trx with invalid sign or mismatch with the verified block
+			proof, err := nodesdkctx.GetCtx().GetChainStorage().VerifyLightTrx(trx)
+			if err != nil || trx.GroupId != groupId {
+				c.Logger().Errorf("Verify trx %s Err: %v", trx.TrxId, err)
+				continue
+			}
 
 			//TODO: support private group
 			//if item.TrxType == quorumpb.TrxType_POST && nodesdkGroupItem.EncryptType == quorumpb.GroupEncryptType_PRIVATE {
@@ -109,6 +120,10 @@ func (h *NodeSDKHandler) GetGroupCtn() echo.HandlerFunc {
 
 				pk, _ := localcrypto.Libp2pPubkeyToEthBase64(trx.SenderPubkey)
 				ctnobjitem := &GroupContentObjectItem{TrxId: trx.TrxId, Publisher: pk, Content: ctnobj, TimeStamp: trx.TimeStamp, TypeUrl: typeurl}
+				if proof != nil {
+					ctnobjitem.BlockId = proof.BlockId
+					ctnobjitem.Verified = true
+				}
 				ctnobjList = append(ctnobjList, ctnobjitem)
 			}
 		}
@@ -5,21 +5,17 @@ import (
 
 	"github.com/labstack/echo/v4"
 	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
 	nodesdkctx "github.com/rumsystem/quorum/pkg/nodesdk/nodesdkctx"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
 )
 
-type Trx struct {
-	TrxId        string
-	Type         string
-	GroupId      string
-	Data         string
-	TimeStamp    string
-	Version      string
-	Expired      string
-	ResendCount  string
-	SenderPubkey string
-	SenderSign   string
-	StorageType  string
+// VerifiedTrx is the trx from the chain api, with the verified block packaged it.
+// Verified is false if the trx is not found in the verified blocks yet.
+type VerifiedTrx struct {
+	*quorumpb.Trx
+	BlockId  uint64
+	Verified bool
 }
 
 const GET_TRX_URI string = "/api/v1/trx"
@@ -54,12 +50,36 @@ func (h *NodeSDKHandler) GetTrx() echo.HandlerFunc {
 
 		path := GET_TRX_URI + "/" + groupid + "/" + trxid
 
-		result := new(Trx)
-		err = httpClient.RequestChainAPI(path, http.MethodGet, nil, nil, result)
+		trx := new(quorumpb.Trx)
+		err = httpClient.RequestChainAPI(path, http.MethodGet, nil, nil, trx)
 		if err != nil {
 			return rumerrors.NewBadRequestError(err)
 		}
+		if trx.GroupId != groupid || trx.TrxId != trxid {
+			return rumerrors.NewBadRequestError(rumerrors.ErrInvalidTrxData)
+		}
 
+		//refuse the trx with invalid sign or mismatch with the verified block, the trx in a block synced by header
+		//is proved by the merkle proof, the headers are synced in background if the block is not verified yet
+		proof, err := nodesdkctx.GetCtx().GetChainStorage().VerifyLightTrx(trx)
+		if err == nil && proof == nil {
+			trxProof := new(handlers.TrxProofResult)
+			if perr := httpClient.RequestChainAPI(GetNodeTrxProofURI(groupid, trxid), http.MethodGet, nil, nil, trxProof); perr == nil && trxProof.Proof != nil && trxProof.Header != nil {
+				proof, err = nodesdkctx.GetCtx().GetChainStorage().VerifyLightTrxInclusion(trx, trxProof.Proof, trxProof.Header)
+			}
+			if err == nil && proof == nil {
+				startHeaderSync(nodesdkGroupItem)
+			}
+		}
+		if err != nil {
+			return rumerrors.NewBadRequestError(err)
+		}
+
+		result := &VerifiedTrx{Trx: trx}
+		if proof != nil {
+			result.BlockId = proof.BlockId
+			result.Verified = true
+		}
 		return c.JSON(http.StatusOK, result)
 	}
 }
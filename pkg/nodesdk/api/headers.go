@@ -0,0 +1,185 @@
+package nodesdkapi
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	rumerrors "github.com/rumsystem/quorum/internal/pkg/errors"
+	"github.com/rumsystem/quorum/internal/pkg/logging"
+	chainstorage "github.com/rumsystem/quorum/internal/pkg/storage/chain"
+	"github.com/rumsystem/quorum/internal/pkg/utils"
+	"github.com/rumsystem/quorum/pkg/chainapi/handlers"
+	nodesdkctx "github.com/rumsystem/quorum/pkg/nodesdk/nodesdkctx"
+	quorumpb "github.com/rumsystem/quorum/pkg/pb"
+)
+
+var headers_log = logging.Logger("headers")
+
+// max headers fetched and verified by one header sync, the rest are synced by the next call
+var LIGHT_SYNC_MAX_BLOCKS = 1000
+
+// headers fetched by one request of the header sync
+var LIGHT_SYNC_BATCH = 100
+
+var headerSyncLocker sync.Mutex
+
+// groups with a header sync running in background
+var headerSyncing sync.Map
+
+type LightHeadersResult struct {
+	*chainstorage.LightHeaderState
+	Synced int `json:"synced"`
+}
+
+type TrustHeaderParam struct {
+	BlockHash []byte `json:"block_hash" validate:"required"` // hash of the head block of a node the user trusts, in base64
+}
+
+// syncHeaders fetches the headers after the verified head from the chain api of the group and verifies them one by one,
+// starting from the genesis block in the seed or the trusted header. The blocks with trx root are synced without trxs,
+// except the trxs changing the producers. The sync stops at the first header the api can't provide.
+// A header failing the verification is refused, the error is returned and kept in the state.
+func syncHeaders(nodesdkGroupItem *quorumpb.NodeSDKGroupItem) (*chainstorage.LightHeaderState, int, error) {
+	headerSyncLocker.Lock()
+	defer headerSyncLocker.Unlock()
+
+	groupItem := nodesdkGroupItem.Group
+	chainStorage := nodesdkctx.GetCtx().GetChainStorage()
+	state, err := chainStorage.GetLightHeaderState(groupItem)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	httpClient, err := nodesdkctx.GetCtx().GetHttpClient(groupItem.GroupId)
+	if err != nil {
+		return state, 0, err
+	}
+	if err := httpClient.UpdApiServer(nodesdkGroupItem.ApiUrl); err != nil {
+		return state, 0, err
+	}
+
+	synced := 0
+	for synced < LIGHT_SYNC_MAX_BLOCKS {
+		items := []*handlers.BlockHeaderItem{}
+		if err := httpClient.RequestChainAPI(GetNodeHeadersURI(groupItem.GroupId, state.Head.BlockId+1, LIGHT_SYNC_BATCH), http.MethodGet, nil, nil, &items); err != nil {
+			//the api is not available now
+			headers_log.Debugf("<%s> get headers from <%d> failed: %s", groupItem.GroupId, state.Head.BlockId+1, err)
+			break
+		}
+		if len(items) == 0 {
+			//no more blocks produced, or the blocks are pruned
+			break
+		}
+		for _, item := range items {
+			if err := chainStorage.AddLightHeader(groupItem, state, item.Header, item.Trxs, item.Proofs); err != nil {
+				headers_log.Warnf("<%s> header <%d> from chain api is refused: %s", groupItem.GroupId, item.Header.BlockId, err)
+				state.LastError = err.Error()
+				if serr := chainStorage.SaveLightHeaderState(state); serr != nil {
+					headers_log.Errorf("<%s> save header state failed: %s", groupItem.GroupId, serr)
+				}
+				return state, synced, err
+			}
+			synced++
+		}
+	}
+	return state, synced, nil
+}
+
+// startHeaderSync syncs the headers in background, it returns at once if the group is syncing
+func startHeaderSync(nodesdkGroupItem *quorumpb.NodeSDKGroupItem) {
+	groupId := nodesdkGroupItem.Group.GroupId
+	if _, running := headerSyncing.LoadOrStore(groupId, true); running {
+		return
+	}
+	go func() {
+		defer headerSyncing.Delete(groupId)
+		if _, synced, err := syncHeaders(nodesdkGroupItem); err != nil {
+			headers_log.Warnf("<%s> sync headers failed: %s", groupId, err)
+		} else if synced > 0 {
+			headers_log.Debugf("<%s> <%d> headers synced", groupId, synced)
+		}
+	}()
+}
+
+func (h *NodeSDKHandler) GetHeaders(c echo.Context) (err error) {
+	groupid := c.Param("group_id")
+	if groupid == "" {
+		return rumerrors.NewBadRequestError(rumerrors.ErrInvalidGroupID)
+	}
+
+	nodesdkGroupItem, err := nodesdkctx.GetCtx().GetChainStorage().GetGroupInfoV2(groupid)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	state, err := nodesdkctx.GetCtx().GetChainStorage().GetLightHeaderState(nodesdkGroupItem.Group)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+	return c.JSON(http.StatusOK, &LightHeadersResult{LightHeaderState: state})
+}
+
+func (h *NodeSDKHandler) SyncHeaders(c echo.Context) (err error) {
+	groupid := c.Param("group_id")
+	if groupid == "" {
+		return rumerrors.NewBadRequestError(rumerrors.ErrInvalidGroupID)
+	}
+
+	nodesdkGroupItem, err := nodesdkctx.GetCtx().GetChainStorage().GetGroupInfoV2(groupid)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	state, synced, err := syncHeaders(nodesdkGroupItem)
+	refused := errors.Is(err, chainstorage.ErrLightBlockRejected) || errors.Is(err, chainstorage.ErrLightBlockNotNext)
+	if err != nil && (state == nil || !refused) {
+		return rumerrors.NewBadRequestError(err)
+	}
+	//a refused block is reported in the last error of the state
+	return c.JSON(http.StatusOK, &LightHeadersResult{LightHeaderState: state, Synced: synced})
+}
+
+// TrustHeader starts the header sync from the head of a trusted node instead of the genesis block. The user gets the
+// hash from a node the user trusts, the header and the producers are fetched from the chain api and checked with it.
+func (h *NodeSDKHandler) TrustHeader(c echo.Context) (err error) {
+	cc := c.(*utils.CustomContext)
+	groupid := c.Param("group_id")
+	if groupid == "" {
+		return rumerrors.NewBadRequestError(rumerrors.ErrInvalidGroupID)
+	}
+	params := new(TrustHeaderParam)
+	if err := cc.BindAndValidate(params); err != nil {
+		return err
+	}
+
+	nodesdkGroupItem, err := nodesdkctx.GetCtx().GetChainStorage().GetGroupInfoV2(groupid)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+	httpClient, err := nodesdkctx.GetCtx().GetHttpClient(groupid)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+	if err := httpClient.UpdApiServer(nodesdkGroupItem.ApiUrl); err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+
+	trusted := new(handlers.TrustedHeaderResult)
+	if err := httpClient.RequestChainAPI(GetNodeTrustedHeaderURI(groupid), http.MethodGet, nil, nil, trusted); err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+	if trusted.Header == nil || !bytes.Equal(trusted.Header.BlockHash, params.BlockHash) {
+		return rumerrors.NewBadRequestError(errors.New("the head of the chain api mismatch with the trusted block hash"))
+	}
+
+	headerSyncLocker.Lock()
+	defer headerSyncLocker.Unlock()
+	state, err := nodesdkctx.GetCtx().GetChainStorage().TrustLightHeader(nodesdkGroupItem.Group, trusted.Header, trusted.Producers, trusted.Stakes, trusted.Scheduled)
+	if err != nil {
+		return rumerrors.NewBadRequestError(err)
+	}
+	return c.JSON(http.StatusOK, &LightHeadersResult{LightHeaderState: state})
+}
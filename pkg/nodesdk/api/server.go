@@ -41,6 +41,9 @@ func StartNodeSDKServer(config StartAPIParam, signalch chan os.Signal, h *NodeSD
 	r.GET("/v1/keystore/listall", h.GetAllAlias())
 	r.GET("/v1/trx/:group_id/:trx_id", h.GetTrx())
 	r.GET("/v1/block/:group_id/:block_id", h.GetBlock())
+	r.GET("/v1/group/:group_id/headers", h.GetHeaders)
+	r.POST("/v1/group/:group_id/headers/sync", h.SyncHeaders)
+	r.POST("/v1/group/:group_id/headers/trust", h.TrustHeader)
 	r.GET("/v1/group/:group_id/info", h.GetGroupInfo)
 	r.GET("/v1/group/:group_id/producers", h.GetProducers)
 	r.GET("/v1/group/:group_id/announced/users", h.GetAnnouncedUsers)
@@ -99,7 +99,12 @@ func (hc *HttpClient) getFullUrl(path string) (fullurl string, jwt string, err e
 	if err != nil {
 		return "", "", errors.New("Can not get Full Url, url invalid")
 	}
-	u.Path = path
+	ref, err := url.Parse(path)
+	if err != nil {
+		return "", "", errors.New("Can not get Full Url, path invalid")
+	}
+	u.Path = ref.Path
+	u.RawQuery = ref.RawQuery
 	fullurl = u.String()
 
 	http_log.Debugf("fullurl: %s", fullurl)
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.25.0-devel
+// 	protoc-gen-go v1.36.11
 // 	protoc        v3.18.1
 // source: chain.proto
 
@@ -11,6 +11,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -259,37 +260,49 @@ func (TrxStroageType) EnumDescriptor() ([]byte, []int) {
 type TrxType int32
 
 const (
-	TrxType_POST           TrxType = 0 // post to group
-	TrxType_ANNOUNCE       TrxType = 1 // producer or user self announce
-	TrxType_PRODUCER       TrxType = 2 // owner update group producer
-	TrxType_USER           TrxType = 3 // owner update group user
-	TrxType_REQ_BLOCK      TrxType = 4 // request block
-	TrxType_REQ_BLOCK_RESP TrxType = 5 // response request block
-	TrxType_CHAIN_CONFIG   TrxType = 6 // chain configuration
-	TrxType_APP_CONFIG     TrxType = 7 // app configuration
+	TrxType_POST              TrxType = 0  // post to group
+	TrxType_ANNOUNCE          TrxType = 1  // producer or user self announce
+	TrxType_PRODUCER          TrxType = 2  // owner update group producer
+	TrxType_USER              TrxType = 3  // owner update group user
+	TrxType_REQ_BLOCK         TrxType = 4  // request block
+	TrxType_REQ_BLOCK_RESP    TrxType = 5  // response request block
+	TrxType_CHAIN_CONFIG      TrxType = 6  // chain configuration
+	TrxType_APP_CONFIG        TrxType = 7  // app configuration
+	TrxType_REQ_SNAPSHOT      TrxType = 8  // request state snapshot
+	TrxType_REQ_SNAPSHOT_RESP TrxType = 9  // response request state snapshot
+	TrxType_STAKE             TrxType = 10 // stake or unstake to be a producer of a POS group
+	TrxType_GROUP_KEY         TrxType = 11 // owner rotate the group cipher key
 )
 
 // Enum value maps for TrxType.
 var (
 	TrxType_name = map[int32]string{
-		0: "POST",
-		1: "ANNOUNCE",
-		2: "PRODUCER",
-		3: "USER",
-		4: "REQ_BLOCK",
-		5: "REQ_BLOCK_RESP",
-		6: "CHAIN_CONFIG",
-		7: "APP_CONFIG",
+		0:  "POST",
+		1:  "ANNOUNCE",
+		2:  "PRODUCER",
+		3:  "USER",
+		4:  "REQ_BLOCK",
+		5:  "REQ_BLOCK_RESP",
+		6:  "CHAIN_CONFIG",
+		7:  "APP_CONFIG",
+		8:  "REQ_SNAPSHOT",
+		9:  "REQ_SNAPSHOT_RESP",
+		10: "STAKE",
+		11: "GROUP_KEY",
 	}
 	TrxType_value = map[string]int32{
-		"POST":           0,
-		"ANNOUNCE":       1,
-		"PRODUCER":       2,
-		"USER":           3,
-		"REQ_BLOCK":      4,
-		"REQ_BLOCK_RESP": 5,
-		"CHAIN_CONFIG":   6,
-		"APP_CONFIG":     7,
+		"POST":              0,
+		"ANNOUNCE":          1,
+		"PRODUCER":          2,
+		"USER":              3,
+		"REQ_BLOCK":         4,
+		"REQ_BLOCK_RESP":    5,
+		"CHAIN_CONFIG":      6,
+		"APP_CONFIG":        7,
+		"REQ_SNAPSHOT":      8,
+		"REQ_SNAPSHOT_RESP": 9,
+		"STAKE":             10,
+		"GROUP_KEY":         11,
 	}
 )
 
@@ -369,6 +382,55 @@ func (ReqBlkResult) EnumDescriptor() ([]byte, []int) {
 	return file_chain_proto_rawDescGZIP(), []int{6}
 }
 
+type ReqSnapshotResult int32
+
+const (
+	ReqSnapshotResult_SNAPSHOT_IN_RESP   ReqSnapshotResult = 0 //"snapshot items in resp and I may have more"
+	ReqSnapshotResult_SNAPSHOT_ON_TOP    ReqSnapshotResult = 1 //"snapshot items in resp and no more items"
+	ReqSnapshotResult_SNAPSHOT_NOT_FOUND ReqSnapshotResult = 2 //"I don't have the requested snapshot"
+)
+
+// Enum value maps for ReqSnapshotResult.
+var (
+	ReqSnapshotResult_name = map[int32]string{
+		0: "SNAPSHOT_IN_RESP",
+		1: "SNAPSHOT_ON_TOP",
+		2: "SNAPSHOT_NOT_FOUND",
+	}
+	ReqSnapshotResult_value = map[string]int32{
+		"SNAPSHOT_IN_RESP":   0,
+		"SNAPSHOT_ON_TOP":    1,
+		"SNAPSHOT_NOT_FOUND": 2,
+	}
+)
+
+func (x ReqSnapshotResult) Enum() *ReqSnapshotResult {
+	p := new(ReqSnapshotResult)
+	*p = x
+	return p
+}
+
+func (x ReqSnapshotResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReqSnapshotResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_chain_proto_enumTypes[7].Descriptor()
+}
+
+func (ReqSnapshotResult) Type() protoreflect.EnumType {
+	return &file_chain_proto_enumTypes[7]
+}
+
+func (x ReqSnapshotResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReqSnapshotResult.Descriptor instead.
+func (ReqSnapshotResult) EnumDescriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{7}
+}
+
 type GroupEncryptType int32
 
 const (
@@ -399,11 +461,11 @@ func (x GroupEncryptType) String() string {
 }
 
 func (GroupEncryptType) Descriptor() protoreflect.EnumDescriptor {
-	return file_chain_proto_enumTypes[7].Descriptor()
+	return file_chain_proto_enumTypes[8].Descriptor()
 }
 
 func (GroupEncryptType) Type() protoreflect.EnumType {
-	return &file_chain_proto_enumTypes[7]
+	return &file_chain_proto_enumTypes[8]
 }
 
 func (x GroupEncryptType) Number() protoreflect.EnumNumber {
@@ -412,7 +474,7 @@ func (x GroupEncryptType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use GroupEncryptType.Descriptor instead.
 func (GroupEncryptType) EnumDescriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{7}
+	return file_chain_proto_rawDescGZIP(), []int{8}
 }
 
 type GroupConsenseType int32
@@ -445,11 +507,11 @@ func (x GroupConsenseType) String() string {
 }
 
 func (GroupConsenseType) Descriptor() protoreflect.EnumDescriptor {
-	return file_chain_proto_enumTypes[8].Descriptor()
+	return file_chain_proto_enumTypes[9].Descriptor()
 }
 
 func (GroupConsenseType) Type() protoreflect.EnumType {
-	return &file_chain_proto_enumTypes[8]
+	return &file_chain_proto_enumTypes[9]
 }
 
 func (x GroupConsenseType) Number() protoreflect.EnumNumber {
@@ -458,7 +520,7 @@ func (x GroupConsenseType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use GroupConsenseType.Descriptor instead.
 func (GroupConsenseType) EnumDescriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{8}
+	return file_chain_proto_rawDescGZIP(), []int{9}
 }
 
 type RoleV0 int32
@@ -491,11 +553,11 @@ func (x RoleV0) String() string {
 }
 
 func (RoleV0) Descriptor() protoreflect.EnumDescriptor {
-	return file_chain_proto_enumTypes[9].Descriptor()
+	return file_chain_proto_enumTypes[10].Descriptor()
 }
 
 func (RoleV0) Type() protoreflect.EnumType {
-	return &file_chain_proto_enumTypes[9]
+	return &file_chain_proto_enumTypes[10]
 }
 
 func (x RoleV0) Number() protoreflect.EnumNumber {
@@ -504,15 +566,17 @@ func (x RoleV0) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RoleV0.Descriptor instead.
 func (RoleV0) EnumDescriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{9}
+	return file_chain_proto_rawDescGZIP(), []int{10}
 }
 
 type ChainConfigType int32
 
 const (
-	ChainConfigType_SET_TRX_AUTH_MODE ChainConfigType = 0
-	ChainConfigType_UPD_DNY_LIST      ChainConfigType = 1
-	ChainConfigType_UPD_ALW_LIST      ChainConfigType = 2
+	ChainConfigType_SET_TRX_AUTH_MODE  ChainConfigType = 0
+	ChainConfigType_UPD_DNY_LIST       ChainConfigType = 1
+	ChainConfigType_UPD_ALW_LIST       ChainConfigType = 2
+	ChainConfigType_SET_PACKING_POLICY ChainConfigType = 3
+	ChainConfigType_SET_BLOCK_VERSION  ChainConfigType = 4
 )
 
 // Enum value maps for ChainConfigType.
@@ -521,11 +585,15 @@ var (
 		0: "SET_TRX_AUTH_MODE",
 		1: "UPD_DNY_LIST",
 		2: "UPD_ALW_LIST",
+		3: "SET_PACKING_POLICY",
+		4: "SET_BLOCK_VERSION",
 	}
 	ChainConfigType_value = map[string]int32{
-		"SET_TRX_AUTH_MODE": 0,
-		"UPD_DNY_LIST":      1,
-		"UPD_ALW_LIST":      2,
+		"SET_TRX_AUTH_MODE":  0,
+		"UPD_DNY_LIST":       1,
+		"UPD_ALW_LIST":       2,
+		"SET_PACKING_POLICY": 3,
+		"SET_BLOCK_VERSION":  4,
 	}
 )
 
@@ -540,11 +608,11 @@ func (x ChainConfigType) String() string {
 }
 
 func (ChainConfigType) Descriptor() protoreflect.EnumDescriptor {
-	return file_chain_proto_enumTypes[10].Descriptor()
+	return file_chain_proto_enumTypes[11].Descriptor()
 }
 
 func (ChainConfigType) Type() protoreflect.EnumType {
-	return &file_chain_proto_enumTypes[10]
+	return &file_chain_proto_enumTypes[11]
 }
 
 func (x ChainConfigType) Number() protoreflect.EnumNumber {
@@ -553,7 +621,7 @@ func (x ChainConfigType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ChainConfigType.Descriptor instead.
 func (ChainConfigType) EnumDescriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{10}
+	return file_chain_proto_rawDescGZIP(), []int{11}
 }
 
 type TrxAuthMode int32
@@ -586,11 +654,11 @@ func (x TrxAuthMode) String() string {
 }
 
 func (TrxAuthMode) Descriptor() protoreflect.EnumDescriptor {
-	return file_chain_proto_enumTypes[11].Descriptor()
+	return file_chain_proto_enumTypes[12].Descriptor()
 }
 
 func (TrxAuthMode) Type() protoreflect.EnumType {
-	return &file_chain_proto_enumTypes[11]
+	return &file_chain_proto_enumTypes[12]
 }
 
 func (x TrxAuthMode) Number() protoreflect.EnumNumber {
@@ -599,7 +667,7 @@ func (x TrxAuthMode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TrxAuthMode.Descriptor instead.
 func (TrxAuthMode) EnumDescriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{11}
+	return file_chain_proto_rawDescGZIP(), []int{12}
 }
 
 type AuthListType int32
@@ -632,11 +700,11 @@ func (x AuthListType) String() string {
 }
 
 func (AuthListType) Descriptor() protoreflect.EnumDescriptor {
-	return file_chain_proto_enumTypes[12].Descriptor()
+	return file_chain_proto_enumTypes[13].Descriptor()
 }
 
 func (AuthListType) Type() protoreflect.EnumType {
-	return &file_chain_proto_enumTypes[12]
+	return &file_chain_proto_enumTypes[13]
 }
 
 func (x AuthListType) Number() protoreflect.EnumNumber {
@@ -645,7 +713,56 @@ func (x AuthListType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AuthListType.Descriptor instead.
 func (AuthListType) EnumDescriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{12}
+	return file_chain_proto_rawDescGZIP(), []int{13}
+}
+
+type PackingPolicyType int32
+
+const (
+	PackingPolicyType_TIMESTAMP_FIFO     PackingPolicyType = 0
+	PackingPolicyType_SENDER_ROUND_ROBIN PackingPolicyType = 1
+	PackingPolicyType_TRX_TYPE_PRIORITY  PackingPolicyType = 2
+)
+
+// Enum value maps for PackingPolicyType.
+var (
+	PackingPolicyType_name = map[int32]string{
+		0: "TIMESTAMP_FIFO",
+		1: "SENDER_ROUND_ROBIN",
+		2: "TRX_TYPE_PRIORITY",
+	}
+	PackingPolicyType_value = map[string]int32{
+		"TIMESTAMP_FIFO":     0,
+		"SENDER_ROUND_ROBIN": 1,
+		"TRX_TYPE_PRIORITY":  2,
+	}
+)
+
+func (x PackingPolicyType) Enum() *PackingPolicyType {
+	p := new(PackingPolicyType)
+	*p = x
+	return p
+}
+
+func (x PackingPolicyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PackingPolicyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_chain_proto_enumTypes[14].Descriptor()
+}
+
+func (PackingPolicyType) Type() protoreflect.EnumType {
+	return &file_chain_proto_enumTypes[14]
+}
+
+func (x PackingPolicyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PackingPolicyType.Descriptor instead.
+func (PackingPolicyType) EnumDescriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{14}
 }
 
 type AppConfigType int32
@@ -681,11 +798,11 @@ func (x AppConfigType) String() string {
 }
 
 func (AppConfigType) Descriptor() protoreflect.EnumDescriptor {
-	return file_chain_proto_enumTypes[13].Descriptor()
+	return file_chain_proto_enumTypes[15].Descriptor()
 }
 
 func (AppConfigType) Type() protoreflect.EnumType {
-	return &file_chain_proto_enumTypes[13]
+	return &file_chain_proto_enumTypes[15]
 }
 
 func (x AppConfigType) Number() protoreflect.EnumNumber {
@@ -694,7 +811,7 @@ func (x AppConfigType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AppConfigType.Descriptor instead.
 func (AppConfigType) EnumDescriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{13}
+	return file_chain_proto_rawDescGZIP(), []int{15}
 }
 
 type HBMsgPayloadType int32
@@ -727,11 +844,11 @@ func (x HBMsgPayloadType) String() string {
 }
 
 func (HBMsgPayloadType) Descriptor() protoreflect.EnumDescriptor {
-	return file_chain_proto_enumTypes[14].Descriptor()
+	return file_chain_proto_enumTypes[16].Descriptor()
 }
 
 func (HBMsgPayloadType) Type() protoreflect.EnumType {
-	return &file_chain_proto_enumTypes[14]
+	return &file_chain_proto_enumTypes[16]
 }
 
 func (x HBMsgPayloadType) Number() protoreflect.EnumNumber {
@@ -740,7 +857,7 @@ func (x HBMsgPayloadType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use HBMsgPayloadType.Descriptor instead.
 func (HBMsgPayloadType) EnumDescriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{14}
+	return file_chain_proto_rawDescGZIP(), []int{16}
 }
 
 type RBCMsgType int32
@@ -776,11 +893,11 @@ func (x RBCMsgType) String() string {
 }
 
 func (RBCMsgType) Descriptor() protoreflect.EnumDescriptor {
-	return file_chain_proto_enumTypes[15].Descriptor()
+	return file_chain_proto_enumTypes[17].Descriptor()
 }
 
 func (RBCMsgType) Type() protoreflect.EnumType {
-	return &file_chain_proto_enumTypes[15]
+	return &file_chain_proto_enumTypes[17]
 }
 
 func (x RBCMsgType) Number() protoreflect.EnumNumber {
@@ -789,7 +906,59 @@ func (x RBCMsgType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RBCMsgType.Descriptor instead.
 func (RBCMsgType) EnumDescriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{15}
+	return file_chain_proto_rawDescGZIP(), []int{17}
+}
+
+type EvidenceType int32
+
+const (
+	EvidenceType_RBC_PROPOSE_EQUIVOCATION EvidenceType = 0 //proposer sends InitPropose with different root hashes in an epoch
+	EvidenceType_RBC_ECHO_EQUIVOCATION    EvidenceType = 1 //producer sends ECHO with different root hashes for a proposer in an epoch
+	EvidenceType_RBC_READY_EQUIVOCATION   EvidenceType = 2 //producer sends READY with different root hashes for a proposer in an epoch
+	EvidenceType_BLOCK_EQUIVOCATION       EvidenceType = 3 //producer signs different blocks with the same BlockId
+)
+
+// Enum value maps for EvidenceType.
+var (
+	EvidenceType_name = map[int32]string{
+		0: "RBC_PROPOSE_EQUIVOCATION",
+		1: "RBC_ECHO_EQUIVOCATION",
+		2: "RBC_READY_EQUIVOCATION",
+		3: "BLOCK_EQUIVOCATION",
+	}
+	EvidenceType_value = map[string]int32{
+		"RBC_PROPOSE_EQUIVOCATION": 0,
+		"RBC_ECHO_EQUIVOCATION":    1,
+		"RBC_READY_EQUIVOCATION":   2,
+		"BLOCK_EQUIVOCATION":       3,
+	}
+)
+
+func (x EvidenceType) Enum() *EvidenceType {
+	p := new(EvidenceType)
+	*p = x
+	return p
+}
+
+func (x EvidenceType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EvidenceType) Descriptor() protoreflect.EnumDescriptor {
+	return file_chain_proto_enumTypes[18].Descriptor()
+}
+
+func (EvidenceType) Type() protoreflect.EnumType {
+	return &file_chain_proto_enumTypes[18]
+}
+
+func (x EvidenceType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EvidenceType.Descriptor instead.
+func (EvidenceType) EnumDescriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{18}
 }
 
 type BBAMsgType int32
@@ -822,11 +991,11 @@ func (x BBAMsgType) String() string {
 }
 
 func (BBAMsgType) Descriptor() protoreflect.EnumDescriptor {
-	return file_chain_proto_enumTypes[16].Descriptor()
+	return file_chain_proto_enumTypes[19].Descriptor()
 }
 
 func (BBAMsgType) Type() protoreflect.EnumType {
-	return &file_chain_proto_enumTypes[16]
+	return &file_chain_proto_enumTypes[19]
 }
 
 func (x BBAMsgType) Number() protoreflect.EnumNumber {
@@ -835,25 +1004,22 @@ func (x BBAMsgType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use BBAMsgType.Descriptor instead.
 func (BBAMsgType) EnumDescriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{16}
+	return file_chain_proto_rawDescGZIP(), []int{19}
 }
 
 type Package struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          PackageType            `protobuf:"varint,1,opt,name=type,proto3,enum=quorum.pb.PackageType" json:"type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=Data,proto3" json:"Data,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Type PackageType `protobuf:"varint,1,opt,name=type,proto3,enum=quorum.pb.PackageType" json:"type,omitempty"`
-	Data []byte      `protobuf:"bytes,2,opt,name=Data,proto3" json:"Data,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Package) Reset() {
 	*x = Package{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Package) String() string {
@@ -864,7 +1030,7 @@ func (*Package) ProtoMessage() {}
 
 func (x *Package) ProtoReflect() protoreflect.Message {
 	mi := &file_chain_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -894,30 +1060,27 @@ func (x *Package) GetData() []byte {
 }
 
 type Trx struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrxId         string                 `protobuf:"bytes,1,opt,name=TrxId,proto3" json:"TrxId,omitempty"`
+	Type          TrxType                `protobuf:"varint,2,opt,name=Type,proto3,enum=quorum.pb.TrxType" json:"Type,omitempty"`
+	GroupId       string                 `protobuf:"bytes,3,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	Data          []byte                 `protobuf:"bytes,4,opt,name=Data,proto3" json:"Data,omitempty"`
+	TimeStamp     int64                  `protobuf:"varint,5,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
+	Version       string                 `protobuf:"bytes,6,opt,name=Version,proto3" json:"Version,omitempty"`
+	Expired       int64                  `protobuf:"varint,7,opt,name=Expired,proto3" json:"Expired,omitempty"`
+	ResendCount   int64                  `protobuf:"varint,8,opt,name=ResendCount,proto3" json:"ResendCount,omitempty"`
+	SenderPubkey  string                 `protobuf:"bytes,10,opt,name=SenderPubkey,proto3" json:"SenderPubkey,omitempty"`
+	SenderSign    []byte                 `protobuf:"bytes,11,opt,name=SenderSign,proto3" json:"SenderSign,omitempty"`
+	StorageType   TrxStroageType         `protobuf:"varint,12,opt,name=StorageType,proto3,enum=quorum.pb.TrxStroageType" json:"StorageType,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	TrxId        string         `protobuf:"bytes,1,opt,name=TrxId,proto3" json:"TrxId,omitempty"`
-	Type         TrxType        `protobuf:"varint,2,opt,name=Type,proto3,enum=quorum.pb.TrxType" json:"Type,omitempty"`
-	GroupId      string         `protobuf:"bytes,3,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
-	Data         []byte         `protobuf:"bytes,4,opt,name=Data,proto3" json:"Data,omitempty"`
-	TimeStamp    int64          `protobuf:"varint,5,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
-	Version      string         `protobuf:"bytes,6,opt,name=Version,proto3" json:"Version,omitempty"`
-	Expired      int64          `protobuf:"varint,7,opt,name=Expired,proto3" json:"Expired,omitempty"`
-	ResendCount  int64          `protobuf:"varint,8,opt,name=ResendCount,proto3" json:"ResendCount,omitempty"`
-	SenderPubkey string         `protobuf:"bytes,10,opt,name=SenderPubkey,proto3" json:"SenderPubkey,omitempty"`
-	SenderSign   []byte         `protobuf:"bytes,11,opt,name=SenderSign,proto3" json:"SenderSign,omitempty"`
-	StorageType  TrxStroageType `protobuf:"varint,12,opt,name=StorageType,proto3,enum=quorum.pb.TrxStroageType" json:"StorageType,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Trx) Reset() {
 	*x = Trx{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Trx) String() string {
@@ -928,7 +1091,7 @@ func (*Trx) ProtoMessage() {}
 
 func (x *Trx) ProtoReflect() protoreflect.Message {
 	mi := &file_chain_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -1021,29 +1184,28 @@ func (x *Trx) GetStorageType() TrxStroageType {
 }
 
 type Block struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	GroupId        string `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
-	BlockId        uint64 `protobuf:"varint,2,opt,name=BlockId,proto3" json:"BlockId,omitempty"`
-	Epoch          uint64 `protobuf:"varint,3,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
-	PrevHash       []byte `protobuf:"bytes,4,opt,name=PrevHash,proto3" json:"PrevHash,omitempty"`
-	ProducerPubkey string `protobuf:"bytes,5,opt,name=ProducerPubkey,proto3" json:"ProducerPubkey,omitempty"`
-	Trxs           []*Trx `protobuf:"bytes,6,rep,name=Trxs,proto3" json:"Trxs,omitempty"`
-	Sudo           bool   `protobuf:"varint,7,opt,name=Sudo,proto3" json:"Sudo,omitempty"`
-	TimeStamp      int64  `protobuf:"varint,8,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
-	BlockHash      []byte `protobuf:"bytes,9,opt,name=BlockHash,proto3" json:"BlockHash,omitempty"`
-	ProducerSign   []byte `protobuf:"bytes,10,opt,name=ProducerSign,proto3" json:"ProducerSign,omitempty"`
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	GroupId        string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	BlockId        uint64                 `protobuf:"varint,2,opt,name=BlockId,proto3" json:"BlockId,omitempty"`
+	Epoch          uint64                 `protobuf:"varint,3,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	PrevHash       []byte                 `protobuf:"bytes,4,opt,name=PrevHash,proto3" json:"PrevHash,omitempty"`
+	ProducerPubkey string                 `protobuf:"bytes,5,opt,name=ProducerPubkey,proto3" json:"ProducerPubkey,omitempty"`
+	Trxs           []*Trx                 `protobuf:"bytes,6,rep,name=Trxs,proto3" json:"Trxs,omitempty"`
+	Sudo           bool                   `protobuf:"varint,7,opt,name=Sudo,proto3" json:"Sudo,omitempty"`
+	TimeStamp      int64                  `protobuf:"varint,8,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
+	BlockHash      []byte                 `protobuf:"bytes,9,opt,name=BlockHash,proto3" json:"BlockHash,omitempty"`
+	ProducerSign   []byte                 `protobuf:"bytes,10,opt,name=ProducerSign,proto3" json:"ProducerSign,omitempty"`
+	Version        uint32                 `protobuf:"varint,11,opt,name=Version,proto3" json:"Version,omitempty"` //0: BlockHash covers all trxs, 1: BlockHash covers TrxRoot instead of the trxs
+	TrxRoot        []byte                 `protobuf:"bytes,12,opt,name=TrxRoot,proto3" json:"TrxRoot,omitempty"`  //merkle root of the trxs, for version 1
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Block) Reset() {
 	*x = Block{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Block) String() string {
@@ -1054,7 +1216,7 @@ func (*Block) ProtoMessage() {}
 
 func (x *Block) ProtoReflect() protoreflect.Message {
 	mi := &file_chain_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -1139,24 +1301,35 @@ func (x *Block) GetProducerSign() []byte {
 	return nil
 }
 
+func (x *Block) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Block) GetTrxRoot() []byte {
+	if x != nil {
+		return x.TrxRoot
+	}
+	return nil
+}
+
 type ReqBlock struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`              //group id
+	FromBlock     uint64                 `protobuf:"varint,2,opt,name=FromBlock,proto3" json:"FromBlock,omitempty"`         //from which block
+	BlksRequested int32                  `protobuf:"varint,3,opt,name=BlksRequested,proto3" json:"BlksRequested,omitempty"` //how many blocks requested, "-1" means many as possible
+	ReqPubkey     string                 `protobuf:"bytes,4,opt,name=ReqPubkey,proto3" json:"ReqPubkey,omitempty"`          //requester pubkey
 	unknownFields protoimpl.UnknownFields
-
-	GroupId       string `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`              //group id
-	FromBlock     uint64 `protobuf:"varint,2,opt,name=FromBlock,proto3" json:"FromBlock,omitempty"`         //from which block
-	BlksRequested int32  `protobuf:"varint,3,opt,name=BlksRequested,proto3" json:"BlksRequested,omitempty"` //how many blocks requested, "-1" means many as possible
-	ReqPubkey     string `protobuf:"bytes,4,opt,name=ReqPubkey,proto3" json:"ReqPubkey,omitempty"`          //requester pubkey
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ReqBlock) Reset() {
 	*x = ReqBlock{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ReqBlock) String() string {
@@ -1167,7 +1340,7 @@ func (*ReqBlock) ProtoMessage() {}
 
 func (x *ReqBlock) ProtoReflect() protoreflect.Message {
 	mi := &file_chain_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -1211,20 +1384,17 @@ func (x *ReqBlock) GetReqPubkey() string {
 }
 
 type BlocksBundle struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        []*Block               `protobuf:"bytes,1,rep,name=Blocks,proto3" json:"Blocks,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Blocks []*Block `protobuf:"bytes,1,rep,name=Blocks,proto3" json:"Blocks,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BlocksBundle) Reset() {
 	*x = BlocksBundle{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *BlocksBundle) String() string {
@@ -1235,7 +1405,7 @@ func (*BlocksBundle) ProtoMessage() {}
 
 func (x *BlocksBundle) ProtoReflect() protoreflect.Message {
 	mi := &file_chain_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -1258,27 +1428,24 @@ func (x *BlocksBundle) GetBlocks() []*Block {
 }
 
 type ReqBlockResp struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	GroupId         string        `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
-	RequesterPubkey string        `protobuf:"bytes,2,opt,name=RequesterPubkey,proto3" json:"RequesterPubkey,omitempty"`
-	ProviderPubkey  string        `protobuf:"bytes,3,opt,name=ProviderPubkey,proto3" json:"ProviderPubkey,omitempty"`
-	Result          ReqBlkResult  `protobuf:"varint,4,opt,name=Result,proto3,enum=quorum.pb.ReqBlkResult" json:"Result,omitempty"`
-	FromBlock       uint64        `protobuf:"varint,5,opt,name=FromBlock,proto3" json:"FromBlock,omitempty"`
-	BlksRequested   int32         `protobuf:"varint,6,opt,name=BlksRequested,proto3" json:"BlksRequested,omitempty"`
-	BlksProvided    int32         `protobuf:"varint,7,opt,name=BlksProvided,proto3" json:"BlksProvided,omitempty"`
-	Blocks          *BlocksBundle `protobuf:"bytes,8,opt,name=Blocks,proto3" json:"Blocks,omitempty"`
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	GroupId         string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	RequesterPubkey string                 `protobuf:"bytes,2,opt,name=RequesterPubkey,proto3" json:"RequesterPubkey,omitempty"`
+	ProviderPubkey  string                 `protobuf:"bytes,3,opt,name=ProviderPubkey,proto3" json:"ProviderPubkey,omitempty"`
+	Result          ReqBlkResult           `protobuf:"varint,4,opt,name=Result,proto3,enum=quorum.pb.ReqBlkResult" json:"Result,omitempty"`
+	FromBlock       uint64                 `protobuf:"varint,5,opt,name=FromBlock,proto3" json:"FromBlock,omitempty"`
+	BlksRequested   int32                  `protobuf:"varint,6,opt,name=BlksRequested,proto3" json:"BlksRequested,omitempty"`
+	BlksProvided    int32                  `protobuf:"varint,7,opt,name=BlksProvided,proto3" json:"BlksProvided,omitempty"`
+	Blocks          *BlocksBundle          `protobuf:"bytes,8,opt,name=Blocks,proto3" json:"Blocks,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ReqBlockResp) Reset() {
 	*x = ReqBlockResp{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ReqBlockResp) String() string {
@@ -1289,7 +1456,7 @@ func (*ReqBlockResp) ProtoMessage() {}
 
 func (x *ReqBlockResp) ProtoReflect() protoreflect.Message {
 	mi := &file_chain_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -1360,35 +1527,30 @@ func (x *ReqBlockResp) GetBlocks() *BlocksBundle {
 	return nil
 }
 
-type PostItem struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type SnapshotItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"` //storage key without the node prefix
+	Value         []byte                 `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	TrxId        string `protobuf:"bytes,1,opt,name=TrxId,proto3" json:"TrxId,omitempty"`
-	SenderPubkey string `protobuf:"bytes,2,opt,name=SenderPubkey,proto3" json:"SenderPubkey,omitempty"`
-	Content      []byte `protobuf:"bytes,3,opt,name=Content,proto3" json:"Content,omitempty"`
-	TimeStamp    int64  `protobuf:"varint,4,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PostItem) Reset() {
-	*x = PostItem{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *SnapshotItem) Reset() {
+	*x = SnapshotItem{}
+	mi := &file_chain_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *PostItem) String() string {
+func (x *SnapshotItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostItem) ProtoMessage() {}
+func (*SnapshotItem) ProtoMessage() {}
 
-func (x *PostItem) ProtoReflect() protoreflect.Message {
+func (x *SnapshotItem) ProtoReflect() protoreflect.Message {
 	mi := &file_chain_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -1398,72 +1560,58 @@ func (x *PostItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostItem.ProtoReflect.Descriptor instead.
-func (*PostItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use SnapshotItem.ProtoReflect.Descriptor instead.
+func (*SnapshotItem) Descriptor() ([]byte, []int) {
 	return file_chain_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *PostItem) GetTrxId() string {
-	if x != nil {
-		return x.TrxId
-	}
-	return ""
-}
-
-func (x *PostItem) GetSenderPubkey() string {
+func (x *SnapshotItem) GetKey() string {
 	if x != nil {
-		return x.SenderPubkey
+		return x.Key
 	}
 	return ""
 }
 
-func (x *PostItem) GetContent() []byte {
+func (x *SnapshotItem) GetValue() []byte {
 	if x != nil {
-		return x.Content
+		return x.Value
 	}
 	return nil
 }
 
-func (x *PostItem) GetTimeStamp() int64 {
-	if x != nil {
-		return x.TimeStamp
-	}
-	return 0
-}
-
-type ProducerItem struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type Snapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	BlockId       uint64                 `protobuf:"varint,2,opt,name=BlockId,proto3" json:"BlockId,omitempty"` //state of the group after this block applied
+	Epoch         uint64                 `protobuf:"varint,3,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	BlockHash     []byte                 `protobuf:"bytes,4,opt,name=BlockHash,proto3" json:"BlockHash,omitempty"`
+	ItemsCount    int32                  `protobuf:"varint,5,opt,name=ItemsCount,proto3" json:"ItemsCount,omitempty"`
+	StateHash     []byte                 `protobuf:"bytes,6,opt,name=StateHash,proto3" json:"StateHash,omitempty"` //hash of all items
+	Items         []*SnapshotItem        `protobuf:"bytes,7,rep,name=Items,proto3" json:"Items,omitempty"`         //not included when calculate the snapshot hash
+	SenderPubkey  string                 `protobuf:"bytes,8,opt,name=SenderPubkey,proto3" json:"SenderPubkey,omitempty"`
+	TimeStamp     int64                  `protobuf:"varint,9,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
+	SnapshotHash  []byte                 `protobuf:"bytes,10,opt,name=SnapshotHash,proto3" json:"SnapshotHash,omitempty"`
+	SenderSign    []byte                 `protobuf:"bytes,11,opt,name=SenderSign,proto3" json:"SenderSign,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	GroupId          string     `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
-	ProducerPubkey   string     `protobuf:"bytes,2,opt,name=ProducerPubkey,proto3" json:"ProducerPubkey,omitempty"`
-	GroupOwnerPubkey string     `protobuf:"bytes,3,opt,name=GroupOwnerPubkey,proto3" json:"GroupOwnerPubkey,omitempty"`
-	GroupOwnerSign   string     `protobuf:"bytes,4,opt,name=GroupOwnerSign,proto3" json:"GroupOwnerSign,omitempty"`
-	Action           ActionType `protobuf:"varint,5,opt,name=Action,proto3,enum=quorum.pb.ActionType" json:"Action,omitempty"`
-	WithnessBlocks   int64      `protobuf:"varint,6,opt,name=WithnessBlocks,proto3" json:"WithnessBlocks,omitempty"`
-	TimeStamp        int64      `protobuf:"varint,7,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
-	Memo             string     `protobuf:"bytes,8,opt,name=Memo,proto3" json:"Memo,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProducerItem) Reset() {
-	*x = ProducerItem{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_chain_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProducerItem) String() string {
+func (x *Snapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProducerItem) ProtoMessage() {}
+func (*Snapshot) ProtoMessage() {}
 
-func (x *ProducerItem) ProtoReflect() protoreflect.Message {
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
 	mi := &file_chain_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -1473,93 +1621,579 @@ func (x *ProducerItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProducerItem.ProtoReflect.Descriptor instead.
-func (*ProducerItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
 	return file_chain_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ProducerItem) GetGroupId() string {
+func (x *Snapshot) GetGroupId() string {
 	if x != nil {
 		return x.GroupId
 	}
 	return ""
 }
 
-func (x *ProducerItem) GetProducerPubkey() string {
+func (x *Snapshot) GetBlockId() uint64 {
 	if x != nil {
-		return x.ProducerPubkey
+		return x.BlockId
 	}
-	return ""
+	return 0
 }
 
-func (x *ProducerItem) GetGroupOwnerPubkey() string {
+func (x *Snapshot) GetEpoch() uint64 {
 	if x != nil {
-		return x.GroupOwnerPubkey
+		return x.Epoch
 	}
-	return ""
+	return 0
 }
 
-func (x *ProducerItem) GetGroupOwnerSign() string {
+func (x *Snapshot) GetBlockHash() []byte {
 	if x != nil {
-		return x.GroupOwnerSign
+		return x.BlockHash
 	}
-	return ""
+	return nil
 }
 
-func (x *ProducerItem) GetAction() ActionType {
+func (x *Snapshot) GetItemsCount() int32 {
+	if x != nil {
+		return x.ItemsCount
+	}
+	return 0
+}
+
+func (x *Snapshot) GetStateHash() []byte {
+	if x != nil {
+		return x.StateHash
+	}
+	return nil
+}
+
+func (x *Snapshot) GetItems() []*SnapshotItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Snapshot) GetSenderPubkey() string {
+	if x != nil {
+		return x.SenderPubkey
+	}
+	return ""
+}
+
+func (x *Snapshot) GetTimeStamp() int64 {
+	if x != nil {
+		return x.TimeStamp
+	}
+	return 0
+}
+
+func (x *Snapshot) GetSnapshotHash() []byte {
+	if x != nil {
+		return x.SnapshotHash
+	}
+	return nil
+}
+
+func (x *Snapshot) GetSenderSign() []byte {
+	if x != nil {
+		return x.SenderSign
+	}
+	return nil
+}
+
+type ReqSnapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	FromItem      int32                  `protobuf:"varint,2,opt,name=FromItem,proto3" json:"FromItem,omitempty"`  //from which snapshot item
+	StateHash     []byte                 `protobuf:"bytes,3,opt,name=StateHash,proto3" json:"StateHash,omitempty"` //state hash of the snapshot being synced, empty for the latest one
+	ReqPubkey     string                 `protobuf:"bytes,4,opt,name=ReqPubkey,proto3" json:"ReqPubkey,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReqSnapshot) Reset() {
+	*x = ReqSnapshot{}
+	mi := &file_chain_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqSnapshot) ProtoMessage() {}
+
+func (x *ReqSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_chain_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqSnapshot.ProtoReflect.Descriptor instead.
+func (*ReqSnapshot) Descriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReqSnapshot) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ReqSnapshot) GetFromItem() int32 {
+	if x != nil {
+		return x.FromItem
+	}
+	return 0
+}
+
+func (x *ReqSnapshot) GetStateHash() []byte {
+	if x != nil {
+		return x.StateHash
+	}
+	return nil
+}
+
+func (x *ReqSnapshot) GetReqPubkey() string {
+	if x != nil {
+		return x.ReqPubkey
+	}
+	return ""
+}
+
+type ReqSnapshotResp struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	GroupId         string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	RequesterPubkey string                 `protobuf:"bytes,2,opt,name=RequesterPubkey,proto3" json:"RequesterPubkey,omitempty"`
+	ProviderPubkey  string                 `protobuf:"bytes,3,opt,name=ProviderPubkey,proto3" json:"ProviderPubkey,omitempty"`
+	Result          ReqSnapshotResult      `protobuf:"varint,4,opt,name=Result,proto3,enum=quorum.pb.ReqSnapshotResult" json:"Result,omitempty"`
+	FromItem        int32                  `protobuf:"varint,5,opt,name=FromItem,proto3" json:"FromItem,omitempty"`
+	Snapshot        *Snapshot              `protobuf:"bytes,6,opt,name=Snapshot,proto3" json:"Snapshot,omitempty"` //snapshot with items from FromItem
+	Block           *Block                 `protobuf:"bytes,7,opt,name=Block,proto3" json:"Block,omitempty"`       //block at the snapshot height
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReqSnapshotResp) Reset() {
+	*x = ReqSnapshotResp{}
+	mi := &file_chain_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReqSnapshotResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqSnapshotResp) ProtoMessage() {}
+
+func (x *ReqSnapshotResp) ProtoReflect() protoreflect.Message {
+	mi := &file_chain_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqSnapshotResp.ProtoReflect.Descriptor instead.
+func (*ReqSnapshotResp) Descriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReqSnapshotResp) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ReqSnapshotResp) GetRequesterPubkey() string {
+	if x != nil {
+		return x.RequesterPubkey
+	}
+	return ""
+}
+
+func (x *ReqSnapshotResp) GetProviderPubkey() string {
+	if x != nil {
+		return x.ProviderPubkey
+	}
+	return ""
+}
+
+func (x *ReqSnapshotResp) GetResult() ReqSnapshotResult {
+	if x != nil {
+		return x.Result
+	}
+	return ReqSnapshotResult_SNAPSHOT_IN_RESP
+}
+
+func (x *ReqSnapshotResp) GetFromItem() int32 {
+	if x != nil {
+		return x.FromItem
+	}
+	return 0
+}
+
+func (x *ReqSnapshotResp) GetSnapshot() *Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *ReqSnapshotResp) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+type PostItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrxId         string                 `protobuf:"bytes,1,opt,name=TrxId,proto3" json:"TrxId,omitempty"`
+	SenderPubkey  string                 `protobuf:"bytes,2,opt,name=SenderPubkey,proto3" json:"SenderPubkey,omitempty"`
+	Content       []byte                 `protobuf:"bytes,3,opt,name=Content,proto3" json:"Content,omitempty"`
+	TimeStamp     int64                  `protobuf:"varint,4,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostItem) Reset() {
+	*x = PostItem{}
+	mi := &file_chain_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostItem) ProtoMessage() {}
+
+func (x *PostItem) ProtoReflect() protoreflect.Message {
+	mi := &file_chain_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostItem.ProtoReflect.Descriptor instead.
+func (*PostItem) Descriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PostItem) GetTrxId() string {
+	if x != nil {
+		return x.TrxId
+	}
+	return ""
+}
+
+func (x *PostItem) GetSenderPubkey() string {
+	if x != nil {
+		return x.SenderPubkey
+	}
+	return ""
+}
+
+func (x *PostItem) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *PostItem) GetTimeStamp() int64 {
+	if x != nil {
+		return x.TimeStamp
+	}
+	return 0
+}
+
+type ProducerItem struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	GroupId          string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	ProducerPubkey   string                 `protobuf:"bytes,2,opt,name=ProducerPubkey,proto3" json:"ProducerPubkey,omitempty"`
+	GroupOwnerPubkey string                 `protobuf:"bytes,3,opt,name=GroupOwnerPubkey,proto3" json:"GroupOwnerPubkey,omitempty"`
+	GroupOwnerSign   string                 `protobuf:"bytes,4,opt,name=GroupOwnerSign,proto3" json:"GroupOwnerSign,omitempty"`
+	Action           ActionType             `protobuf:"varint,5,opt,name=Action,proto3,enum=quorum.pb.ActionType" json:"Action,omitempty"`
+	WithnessBlocks   int64                  `protobuf:"varint,6,opt,name=WithnessBlocks,proto3" json:"WithnessBlocks,omitempty"`
+	TimeStamp        int64                  `protobuf:"varint,7,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
+	Memo             string                 `protobuf:"bytes,8,opt,name=Memo,proto3" json:"Memo,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ProducerItem) Reset() {
+	*x = ProducerItem{}
+	mi := &file_chain_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProducerItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProducerItem) ProtoMessage() {}
+
+func (x *ProducerItem) ProtoReflect() protoreflect.Message {
+	mi := &file_chain_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProducerItem.ProtoReflect.Descriptor instead.
+func (*ProducerItem) Descriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ProducerItem) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ProducerItem) GetProducerPubkey() string {
+	if x != nil {
+		return x.ProducerPubkey
+	}
+	return ""
+}
+
+func (x *ProducerItem) GetGroupOwnerPubkey() string {
+	if x != nil {
+		return x.GroupOwnerPubkey
+	}
+	return ""
+}
+
+func (x *ProducerItem) GetGroupOwnerSign() string {
+	if x != nil {
+		return x.GroupOwnerSign
+	}
+	return ""
+}
+
+func (x *ProducerItem) GetAction() ActionType {
 	if x != nil {
 		return x.Action
 	}
-	return ActionType_ADD
+	return ActionType_ADD
+}
+
+func (x *ProducerItem) GetWithnessBlocks() int64 {
+	if x != nil {
+		return x.WithnessBlocks
+	}
+	return 0
+}
+
+func (x *ProducerItem) GetTimeStamp() int64 {
+	if x != nil {
+		return x.TimeStamp
+	}
+	return 0
+}
+
+func (x *ProducerItem) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+type BFTProducerBundleItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Producers     []*ProducerItem        `protobuf:"bytes,1,rep,name=Producers,proto3" json:"Producers,omitempty"`
+	ActivateEpoch uint64                 `protobuf:"varint,2,opt,name=ActivateEpoch,proto3" json:"ActivateEpoch,omitempty"` //the producers take over from this epoch, 0 for the next epoch
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BFTProducerBundleItem) Reset() {
+	*x = BFTProducerBundleItem{}
+	mi := &file_chain_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BFTProducerBundleItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BFTProducerBundleItem) ProtoMessage() {}
+
+func (x *BFTProducerBundleItem) ProtoReflect() protoreflect.Message {
+	mi := &file_chain_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *ProducerItem) GetWithnessBlocks() int64 {
+// Deprecated: Use BFTProducerBundleItem.ProtoReflect.Descriptor instead.
+func (*BFTProducerBundleItem) Descriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BFTProducerBundleItem) GetProducers() []*ProducerItem {
 	if x != nil {
-		return x.WithnessBlocks
+		return x.Producers
+	}
+	return nil
+}
+
+func (x *BFTProducerBundleItem) GetActivateEpoch() uint64 {
+	if x != nil {
+		return x.ActivateEpoch
 	}
 	return 0
 }
 
-func (x *ProducerItem) GetTimeStamp() int64 {
+type StakeItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	StakerPubkey  string                 `protobuf:"bytes,2,opt,name=StakerPubkey,proto3" json:"StakerPubkey,omitempty"`
+	Amount        uint64                 `protobuf:"varint,3,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Action        ActionType             `protobuf:"varint,4,opt,name=Action,proto3,enum=quorum.pb.ActionType" json:"Action,omitempty"`
+	TimeStamp     int64                  `protobuf:"varint,5,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
+	Memo          string                 `protobuf:"bytes,6,opt,name=Memo,proto3" json:"Memo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StakeItem) Reset() {
+	*x = StakeItem{}
+	mi := &file_chain_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StakeItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StakeItem) ProtoMessage() {}
+
+func (x *StakeItem) ProtoReflect() protoreflect.Message {
+	mi := &file_chain_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StakeItem.ProtoReflect.Descriptor instead.
+func (*StakeItem) Descriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StakeItem) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *StakeItem) GetStakerPubkey() string {
+	if x != nil {
+		return x.StakerPubkey
+	}
+	return ""
+}
+
+func (x *StakeItem) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *StakeItem) GetAction() ActionType {
+	if x != nil {
+		return x.Action
+	}
+	return ActionType_ADD
+}
+
+func (x *StakeItem) GetTimeStamp() int64 {
 	if x != nil {
 		return x.TimeStamp
 	}
 	return 0
 }
 
-func (x *ProducerItem) GetMemo() string {
+func (x *StakeItem) GetMemo() string {
 	if x != nil {
 		return x.Memo
 	}
 	return ""
 }
 
-type BFTProducerBundleItem struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+type GroupKeyItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	EncryptedKey  []byte                 `protobuf:"bytes,2,opt,name=EncryptedKey,proto3" json:"EncryptedKey,omitempty"` // new cipher key encrypted by age to the recipients
+	Recipients    []string               `protobuf:"bytes,3,rep,name=Recipients,proto3" json:"Recipients,omitempty"`     // encrypt pubkeys of the remaining group users
+	TimeStamp     int64                  `protobuf:"varint,4,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
+	Memo          string                 `protobuf:"bytes,5,opt,name=Memo,proto3" json:"Memo,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Producers []*ProducerItem `protobuf:"bytes,1,rep,name=Producers,proto3" json:"Producers,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BFTProducerBundleItem) Reset() {
-	*x = BFTProducerBundleItem{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *GroupKeyItem) Reset() {
+	*x = GroupKeyItem{}
+	mi := &file_chain_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *BFTProducerBundleItem) String() string {
+func (x *GroupKeyItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BFTProducerBundleItem) ProtoMessage() {}
+func (*GroupKeyItem) ProtoMessage() {}
 
-func (x *BFTProducerBundleItem) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[8]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *GroupKeyItem) ProtoReflect() protoreflect.Message {
+	mi := &file_chain_proto_msgTypes[14]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -1569,40 +2203,65 @@ func (x *BFTProducerBundleItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BFTProducerBundleItem.ProtoReflect.Descriptor instead.
-func (*BFTProducerBundleItem) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GroupKeyItem.ProtoReflect.Descriptor instead.
+func (*GroupKeyItem) Descriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *BFTProducerBundleItem) GetProducers() []*ProducerItem {
+func (x *GroupKeyItem) GetGroupId() string {
 	if x != nil {
-		return x.Producers
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *GroupKeyItem) GetEncryptedKey() []byte {
+	if x != nil {
+		return x.EncryptedKey
 	}
 	return nil
 }
 
-type UserItem struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *GroupKeyItem) GetRecipients() []string {
+	if x != nil {
+		return x.Recipients
+	}
+	return nil
+}
+
+func (x *GroupKeyItem) GetTimeStamp() int64 {
+	if x != nil {
+		return x.TimeStamp
+	}
+	return 0
+}
+
+func (x *GroupKeyItem) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
 
-	GroupId          string     `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
-	UserPubkey       string     `protobuf:"bytes,2,opt,name=UserPubkey,proto3" json:"UserPubkey,omitempty"`
-	EncryptPubkey    string     `protobuf:"bytes,3,opt,name=EncryptPubkey,proto3" json:"EncryptPubkey,omitempty"`
-	GroupOwnerPubkey string     `protobuf:"bytes,4,opt,name=GroupOwnerPubkey,proto3" json:"GroupOwnerPubkey,omitempty"`
-	GroupOwnerSign   string     `protobuf:"bytes,5,opt,name=GroupOwnerSign,proto3" json:"GroupOwnerSign,omitempty"`
-	TimeStamp        int64      `protobuf:"varint,6,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
-	Action           ActionType `protobuf:"varint,7,opt,name=Action,proto3,enum=quorum.pb.ActionType" json:"Action,omitempty"`
-	Memo             string     `protobuf:"bytes,8,opt,name=Memo,proto3" json:"Memo,omitempty"`
+type UserItem struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	GroupId          string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	UserPubkey       string                 `protobuf:"bytes,2,opt,name=UserPubkey,proto3" json:"UserPubkey,omitempty"`
+	EncryptPubkey    string                 `protobuf:"bytes,3,opt,name=EncryptPubkey,proto3" json:"EncryptPubkey,omitempty"`
+	GroupOwnerPubkey string                 `protobuf:"bytes,4,opt,name=GroupOwnerPubkey,proto3" json:"GroupOwnerPubkey,omitempty"`
+	GroupOwnerSign   string                 `protobuf:"bytes,5,opt,name=GroupOwnerSign,proto3" json:"GroupOwnerSign,omitempty"`
+	TimeStamp        int64                  `protobuf:"varint,6,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
+	Action           ActionType             `protobuf:"varint,7,opt,name=Action,proto3,enum=quorum.pb.ActionType" json:"Action,omitempty"`
+	Memo             string                 `protobuf:"bytes,8,opt,name=Memo,proto3" json:"Memo,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *UserItem) Reset() {
 	*x = UserItem{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *UserItem) String() string {
@@ -1612,8 +2271,8 @@ func (x *UserItem) String() string {
 func (*UserItem) ProtoMessage() {}
 
 func (x *UserItem) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[15]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -1625,7 +2284,7 @@ func (x *UserItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserItem.ProtoReflect.Descriptor instead.
 func (*UserItem) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{9}
+	return file_chain_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *UserItem) GetGroupId() string {
@@ -1685,30 +2344,27 @@ func (x *UserItem) GetMemo() string {
 }
 
 type AnnounceItem struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	GroupId            string       `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
-	SignPubkey         string       `protobuf:"bytes,2,opt,name=SignPubkey,proto3" json:"SignPubkey,omitempty"`
-	EncryptPubkey      string       `protobuf:"bytes,3,opt,name=EncryptPubkey,proto3" json:"EncryptPubkey,omitempty"`
-	AnnouncerSignature string       `protobuf:"bytes,4,opt,name=AnnouncerSignature,proto3" json:"AnnouncerSignature,omitempty"`
-	Type               AnnounceType `protobuf:"varint,5,opt,name=Type,proto3,enum=quorum.pb.AnnounceType" json:"Type,omitempty"`
-	OwnerPubkey        string       `protobuf:"bytes,6,opt,name=OwnerPubkey,proto3" json:"OwnerPubkey,omitempty"`
-	OwnerSignature     string       `protobuf:"bytes,7,opt,name=OwnerSignature,proto3" json:"OwnerSignature,omitempty"`
-	Result             ApproveType  `protobuf:"varint,8,opt,name=Result,proto3,enum=quorum.pb.ApproveType" json:"Result,omitempty"`
-	TimeStamp          int64        `protobuf:"varint,9,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
-	Action             ActionType   `protobuf:"varint,10,opt,name=Action,proto3,enum=quorum.pb.ActionType" json:"Action,omitempty"`
-	Memo               string       `protobuf:"bytes,11,opt,name=Memo,proto3" json:"Memo,omitempty"`
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	GroupId            string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	SignPubkey         string                 `protobuf:"bytes,2,opt,name=SignPubkey,proto3" json:"SignPubkey,omitempty"`
+	EncryptPubkey      string                 `protobuf:"bytes,3,opt,name=EncryptPubkey,proto3" json:"EncryptPubkey,omitempty"`
+	AnnouncerSignature string                 `protobuf:"bytes,4,opt,name=AnnouncerSignature,proto3" json:"AnnouncerSignature,omitempty"`
+	Type               AnnounceType           `protobuf:"varint,5,opt,name=Type,proto3,enum=quorum.pb.AnnounceType" json:"Type,omitempty"`
+	OwnerPubkey        string                 `protobuf:"bytes,6,opt,name=OwnerPubkey,proto3" json:"OwnerPubkey,omitempty"`
+	OwnerSignature     string                 `protobuf:"bytes,7,opt,name=OwnerSignature,proto3" json:"OwnerSignature,omitempty"`
+	Result             ApproveType            `protobuf:"varint,8,opt,name=Result,proto3,enum=quorum.pb.ApproveType" json:"Result,omitempty"`
+	TimeStamp          int64                  `protobuf:"varint,9,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
+	Action             ActionType             `protobuf:"varint,10,opt,name=Action,proto3,enum=quorum.pb.ActionType" json:"Action,omitempty"`
+	Memo               string                 `protobuf:"bytes,11,opt,name=Memo,proto3" json:"Memo,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *AnnounceItem) Reset() {
 	*x = AnnounceItem{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[10]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *AnnounceItem) String() string {
@@ -1718,8 +2374,8 @@ func (x *AnnounceItem) String() string {
 func (*AnnounceItem) ProtoMessage() {}
 
 func (x *AnnounceItem) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[10]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[16]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -1731,7 +2387,7 @@ func (x *AnnounceItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnnounceItem.ProtoReflect.Descriptor instead.
 func (*AnnounceItem) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{10}
+	return file_chain_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *AnnounceItem) GetGroupId() string {
@@ -1812,30 +2468,27 @@ func (x *AnnounceItem) GetMemo() string {
 }
 
 type GroupItem struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	GroupId           string            `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
-	GroupName         string            `protobuf:"bytes,2,opt,name=GroupName,proto3" json:"GroupName,omitempty"`
-	OwnerPubKey       string            `protobuf:"bytes,3,opt,name=OwnerPubKey,proto3" json:"OwnerPubKey,omitempty"`
-	UserSignPubkey    string            `protobuf:"bytes,4,opt,name=UserSignPubkey,proto3" json:"UserSignPubkey,omitempty"`
-	UserEncryptPubkey string            `protobuf:"bytes,5,opt,name=UserEncryptPubkey,proto3" json:"UserEncryptPubkey,omitempty"`
-	LastUpdate        int64             `protobuf:"varint,6,opt,name=LastUpdate,proto3" json:"LastUpdate,omitempty"`
-	GenesisBlock      *Block            `protobuf:"bytes,7,opt,name=GenesisBlock,proto3" json:"GenesisBlock,omitempty"`
-	EncryptType       GroupEncryptType  `protobuf:"varint,8,opt,name=EncryptType,proto3,enum=quorum.pb.GroupEncryptType" json:"EncryptType,omitempty"`
-	ConsenseType      GroupConsenseType `protobuf:"varint,9,opt,name=ConsenseType,proto3,enum=quorum.pb.GroupConsenseType" json:"ConsenseType,omitempty"`
-	CipherKey         string            `protobuf:"bytes,10,opt,name=CipherKey,proto3" json:"CipherKey,omitempty"`
-	AppKey            string            `protobuf:"bytes,11,opt,name=AppKey,proto3" json:"AppKey,omitempty"`
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	GroupId           string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	GroupName         string                 `protobuf:"bytes,2,opt,name=GroupName,proto3" json:"GroupName,omitempty"`
+	OwnerPubKey       string                 `protobuf:"bytes,3,opt,name=OwnerPubKey,proto3" json:"OwnerPubKey,omitempty"`
+	UserSignPubkey    string                 `protobuf:"bytes,4,opt,name=UserSignPubkey,proto3" json:"UserSignPubkey,omitempty"`
+	UserEncryptPubkey string                 `protobuf:"bytes,5,opt,name=UserEncryptPubkey,proto3" json:"UserEncryptPubkey,omitempty"`
+	LastUpdate        int64                  `protobuf:"varint,6,opt,name=LastUpdate,proto3" json:"LastUpdate,omitempty"`
+	GenesisBlock      *Block                 `protobuf:"bytes,7,opt,name=GenesisBlock,proto3" json:"GenesisBlock,omitempty"`
+	EncryptType       GroupEncryptType       `protobuf:"varint,8,opt,name=EncryptType,proto3,enum=quorum.pb.GroupEncryptType" json:"EncryptType,omitempty"`
+	ConsenseType      GroupConsenseType      `protobuf:"varint,9,opt,name=ConsenseType,proto3,enum=quorum.pb.GroupConsenseType" json:"ConsenseType,omitempty"`
+	CipherKey         string                 `protobuf:"bytes,10,opt,name=CipherKey,proto3" json:"CipherKey,omitempty"`
+	AppKey            string                 `protobuf:"bytes,11,opt,name=AppKey,proto3" json:"AppKey,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GroupItem) Reset() {
 	*x = GroupItem{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[11]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GroupItem) String() string {
@@ -1845,8 +2498,8 @@ func (x *GroupItem) String() string {
 func (*GroupItem) ProtoMessage() {}
 
 func (x *GroupItem) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[11]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[17]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -1858,7 +2511,7 @@ func (x *GroupItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupItem.ProtoReflect.Descriptor instead.
 func (*GroupItem) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{11}
+	return file_chain_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GroupItem) GetGroupId() string {
@@ -1939,26 +2592,23 @@ func (x *GroupItem) GetAppKey() string {
 }
 
 type ChainConfigItem struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	GroupId        string          `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
-	Type           ChainConfigType `protobuf:"varint,2,opt,name=Type,proto3,enum=quorum.pb.ChainConfigType" json:"Type,omitempty"`
-	Data           []byte          `protobuf:"bytes,3,opt,name=Data,proto3" json:"Data,omitempty"`
-	OwnerPubkey    string          `protobuf:"bytes,4,opt,name=OwnerPubkey,proto3" json:"OwnerPubkey,omitempty"`
-	OwnerSignature string          `protobuf:"bytes,5,opt,name=OwnerSignature,proto3" json:"OwnerSignature,omitempty"`
-	TimeStamp      int64           `protobuf:"varint,6,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
-	Memo           string          `protobuf:"bytes,7,opt,name=Memo,proto3" json:"Memo,omitempty"`
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	GroupId        string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	Type           ChainConfigType        `protobuf:"varint,2,opt,name=Type,proto3,enum=quorum.pb.ChainConfigType" json:"Type,omitempty"`
+	Data           []byte                 `protobuf:"bytes,3,opt,name=Data,proto3" json:"Data,omitempty"`
+	OwnerPubkey    string                 `protobuf:"bytes,4,opt,name=OwnerPubkey,proto3" json:"OwnerPubkey,omitempty"`
+	OwnerSignature string                 `protobuf:"bytes,5,opt,name=OwnerSignature,proto3" json:"OwnerSignature,omitempty"`
+	TimeStamp      int64                  `protobuf:"varint,6,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
+	Memo           string                 `protobuf:"bytes,7,opt,name=Memo,proto3" json:"Memo,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ChainConfigItem) Reset() {
 	*x = ChainConfigItem{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[12]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ChainConfigItem) String() string {
@@ -1968,8 +2618,8 @@ func (x *ChainConfigItem) String() string {
 func (*ChainConfigItem) ProtoMessage() {}
 
 func (x *ChainConfigItem) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[12]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[18]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -1981,7 +2631,7 @@ func (x *ChainConfigItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChainConfigItem.ProtoReflect.Descriptor instead.
 func (*ChainConfigItem) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{12}
+	return file_chain_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ChainConfigItem) GetGroupId() string {
@@ -2034,22 +2684,19 @@ func (x *ChainConfigItem) GetMemo() string {
 }
 
 type ChainSendTrxRuleListItem struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        ActionType             `protobuf:"varint,1,opt,name=Action,proto3,enum=quorum.pb.ActionType" json:"Action,omitempty"`
+	Pubkey        string                 `protobuf:"bytes,3,opt,name=Pubkey,proto3" json:"Pubkey,omitempty"`
+	Type          []TrxType              `protobuf:"varint,4,rep,packed,name=Type,proto3,enum=quorum.pb.TrxType" json:"Type,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Action ActionType `protobuf:"varint,1,opt,name=Action,proto3,enum=quorum.pb.ActionType" json:"Action,omitempty"`
-	Pubkey string     `protobuf:"bytes,3,opt,name=Pubkey,proto3" json:"Pubkey,omitempty"`
-	Type   []TrxType  `protobuf:"varint,4,rep,packed,name=Type,proto3,enum=quorum.pb.TrxType" json:"Type,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ChainSendTrxRuleListItem) Reset() {
 	*x = ChainSendTrxRuleListItem{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[13]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ChainSendTrxRuleListItem) String() string {
@@ -2059,8 +2706,8 @@ func (x *ChainSendTrxRuleListItem) String() string {
 func (*ChainSendTrxRuleListItem) ProtoMessage() {}
 
 func (x *ChainSendTrxRuleListItem) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[13]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[19]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -2072,7 +2719,7 @@ func (x *ChainSendTrxRuleListItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChainSendTrxRuleListItem.ProtoReflect.Descriptor instead.
 func (*ChainSendTrxRuleListItem) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{13}
+	return file_chain_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ChainSendTrxRuleListItem) GetAction() ActionType {
@@ -2097,32 +2744,141 @@ func (x *ChainSendTrxRuleListItem) GetType() []TrxType {
 }
 
 type SetTrxAuthModeItem struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          TrxType                `protobuf:"varint,1,opt,name=Type,proto3,enum=quorum.pb.TrxType" json:"Type,omitempty"`
+	Mode          TrxAuthMode            `protobuf:"varint,2,opt,name=Mode,proto3,enum=quorum.pb.TrxAuthMode" json:"Mode,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Type TrxType     `protobuf:"varint,1,opt,name=Type,proto3,enum=quorum.pb.TrxType" json:"Type,omitempty"`
-	Mode TrxAuthMode `protobuf:"varint,2,opt,name=Mode,proto3,enum=quorum.pb.TrxAuthMode" json:"Mode,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SetTrxAuthModeItem) Reset() {
 	*x = SetTrxAuthModeItem{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[14]
+	mi := &file_chain_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTrxAuthModeItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTrxAuthModeItem) ProtoMessage() {}
+
+func (x *SetTrxAuthModeItem) ProtoReflect() protoreflect.Message {
+	mi := &file_chain_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTrxAuthModeItem.ProtoReflect.Descriptor instead.
+func (*SetTrxAuthModeItem) Descriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetTrxAuthModeItem) GetType() TrxType {
+	if x != nil {
+		return x.Type
+	}
+	return TrxType_POST
+}
+
+func (x *SetTrxAuthModeItem) GetMode() TrxAuthMode {
+	if x != nil {
+		return x.Mode
+	}
+	return TrxAuthMode_FOLLOW_ALW_LIST
+}
+
+type SetPackingPolicyItem struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Policy         PackingPolicyType      `protobuf:"varint,1,opt,name=Policy,proto3,enum=quorum.pb.PackingPolicyType" json:"Policy,omitempty"`
+	BatchSize      uint32                 `protobuf:"varint,2,opt,name=BatchSize,proto3" json:"BatchSize,omitempty"`           //0 for default
+	MaxBundleBytes uint32                 `protobuf:"varint,3,opt,name=MaxBundleBytes,proto3" json:"MaxBundleBytes,omitempty"` //0 for default
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetPackingPolicyItem) Reset() {
+	*x = SetPackingPolicyItem{}
+	mi := &file_chain_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPackingPolicyItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPackingPolicyItem) ProtoMessage() {}
+
+func (x *SetPackingPolicyItem) ProtoReflect() protoreflect.Message {
+	mi := &file_chain_proto_msgTypes[21]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPackingPolicyItem.ProtoReflect.Descriptor instead.
+func (*SetPackingPolicyItem) Descriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetPackingPolicyItem) GetPolicy() PackingPolicyType {
+	if x != nil {
+		return x.Policy
 	}
+	return PackingPolicyType_TIMESTAMP_FIFO
+}
+
+func (x *SetPackingPolicyItem) GetBatchSize() uint32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *SetPackingPolicyItem) GetMaxBundleBytes() uint32 {
+	if x != nil {
+		return x.MaxBundleBytes
+	}
+	return 0
+}
+
+type SetBlockVersionItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       uint32                 `protobuf:"varint,1,opt,name=Version,proto3" json:"Version,omitempty"`
+	FromEpoch     uint64                 `protobuf:"varint,2,opt,name=FromEpoch,proto3" json:"FromEpoch,omitempty"` //blocks produced from this epoch on use the version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBlockVersionItem) Reset() {
+	*x = SetBlockVersionItem{}
+	mi := &file_chain_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetTrxAuthModeItem) String() string {
+func (x *SetBlockVersionItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetTrxAuthModeItem) ProtoMessage() {}
+func (*SetBlockVersionItem) ProtoMessage() {}
 
-func (x *SetTrxAuthModeItem) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[14]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *SetBlockVersionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_chain_proto_msgTypes[22]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -2132,48 +2888,45 @@ func (x *SetTrxAuthModeItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetTrxAuthModeItem.ProtoReflect.Descriptor instead.
-func (*SetTrxAuthModeItem) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use SetBlockVersionItem.ProtoReflect.Descriptor instead.
+func (*SetBlockVersionItem) Descriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *SetTrxAuthModeItem) GetType() TrxType {
+func (x *SetBlockVersionItem) GetVersion() uint32 {
 	if x != nil {
-		return x.Type
+		return x.Version
 	}
-	return TrxType_POST
+	return 0
 }
 
-func (x *SetTrxAuthModeItem) GetMode() TrxAuthMode {
+func (x *SetBlockVersionItem) GetFromEpoch() uint64 {
 	if x != nil {
-		return x.Mode
+		return x.FromEpoch
 	}
-	return TrxAuthMode_FOLLOW_ALW_LIST
+	return 0
 }
 
 type AppConfigItem struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	Action        ActionType             `protobuf:"varint,2,opt,name=Action,proto3,enum=quorum.pb.ActionType" json:"Action,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`
+	Type          AppConfigType          `protobuf:"varint,4,opt,name=Type,proto3,enum=quorum.pb.AppConfigType" json:"Type,omitempty"`
+	Value         string                 `protobuf:"bytes,5,opt,name=Value,proto3" json:"Value,omitempty"`
+	OwnerPubkey   string                 `protobuf:"bytes,6,opt,name=OwnerPubkey,proto3" json:"OwnerPubkey,omitempty"`
+	OwnerSign     string                 `protobuf:"bytes,7,opt,name=OwnerSign,proto3" json:"OwnerSign,omitempty"`
+	Memo          string                 `protobuf:"bytes,8,opt,name=Memo,proto3" json:"Memo,omitempty"`
+	TimeStamp     int64                  `protobuf:"varint,9,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
 	unknownFields protoimpl.UnknownFields
-
-	GroupId     string        `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
-	Action      ActionType    `protobuf:"varint,2,opt,name=Action,proto3,enum=quorum.pb.ActionType" json:"Action,omitempty"`
-	Name        string        `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`
-	Type        AppConfigType `protobuf:"varint,4,opt,name=Type,proto3,enum=quorum.pb.AppConfigType" json:"Type,omitempty"`
-	Value       string        `protobuf:"bytes,5,opt,name=Value,proto3" json:"Value,omitempty"`
-	OwnerPubkey string        `protobuf:"bytes,6,opt,name=OwnerPubkey,proto3" json:"OwnerPubkey,omitempty"`
-	OwnerSign   string        `protobuf:"bytes,7,opt,name=OwnerSign,proto3" json:"OwnerSign,omitempty"`
-	Memo        string        `protobuf:"bytes,8,opt,name=Memo,proto3" json:"Memo,omitempty"`
-	TimeStamp   int64         `protobuf:"varint,9,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AppConfigItem) Reset() {
 	*x = AppConfigItem{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[15]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *AppConfigItem) String() string {
@@ -2183,8 +2936,8 @@ func (x *AppConfigItem) String() string {
 func (*AppConfigItem) ProtoMessage() {}
 
 func (x *AppConfigItem) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[15]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[23]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -2196,7 +2949,7 @@ func (x *AppConfigItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppConfigItem.ProtoReflect.Descriptor instead.
 func (*AppConfigItem) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{15}
+	return file_chain_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *AppConfigItem) GetGroupId() string {
@@ -2263,28 +3016,25 @@ func (x *AppConfigItem) GetTimeStamp() int64 {
 }
 
 type GroupSeed struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	GenesisBlock   *Block `protobuf:"bytes,1,opt,name=GenesisBlock,proto3" json:"GenesisBlock,omitempty"`
-	GroupId        string `protobuf:"bytes,2,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
-	GroupName      string `protobuf:"bytes,3,opt,name=GroupName,proto3" json:"GroupName,omitempty"`
-	OwnerPubkey    string `protobuf:"bytes,4,opt,name=OwnerPubkey,proto3" json:"OwnerPubkey,omitempty"`
-	ConsensusType  string `protobuf:"bytes,5,opt,name=ConsensusType,proto3" json:"ConsensusType,omitempty"`
-	EncryptionType string `protobuf:"bytes,6,opt,name=EncryptionType,proto3" json:"EncryptionType,omitempty"`
-	CipherKey      string `protobuf:"bytes,7,opt,name=CipherKey,proto3" json:"CipherKey,omitempty"`
-	AppKey         string `protobuf:"bytes,8,opt,name=AppKey,proto3" json:"AppKey,omitempty"`
-	Signature      string `protobuf:"bytes,9,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	GenesisBlock   *Block                 `protobuf:"bytes,1,opt,name=GenesisBlock,proto3" json:"GenesisBlock,omitempty"`
+	GroupId        string                 `protobuf:"bytes,2,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	GroupName      string                 `protobuf:"bytes,3,opt,name=GroupName,proto3" json:"GroupName,omitempty"`
+	OwnerPubkey    string                 `protobuf:"bytes,4,opt,name=OwnerPubkey,proto3" json:"OwnerPubkey,omitempty"`
+	ConsensusType  string                 `protobuf:"bytes,5,opt,name=ConsensusType,proto3" json:"ConsensusType,omitempty"`
+	EncryptionType string                 `protobuf:"bytes,6,opt,name=EncryptionType,proto3" json:"EncryptionType,omitempty"`
+	CipherKey      string                 `protobuf:"bytes,7,opt,name=CipherKey,proto3" json:"CipherKey,omitempty"`
+	AppKey         string                 `protobuf:"bytes,8,opt,name=AppKey,proto3" json:"AppKey,omitempty"`
+	Signature      string                 `protobuf:"bytes,9,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GroupSeed) Reset() {
 	*x = GroupSeed{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[16]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GroupSeed) String() string {
@@ -2294,8 +3044,8 @@ func (x *GroupSeed) String() string {
 func (*GroupSeed) ProtoMessage() {}
 
 func (x *GroupSeed) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[16]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[24]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -2307,7 +3057,7 @@ func (x *GroupSeed) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupSeed.ProtoReflect.Descriptor instead.
 func (*GroupSeed) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{16}
+	return file_chain_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GroupSeed) GetGenesisBlock() *Block {
@@ -2374,24 +3124,21 @@ func (x *GroupSeed) GetSignature() string {
 }
 
 type NodeSDKGroupItem struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *GroupItem             `protobuf:"bytes,1,opt,name=Group,proto3" json:"Group,omitempty"`
+	EncryptAlias  string                 `protobuf:"bytes,2,opt,name=EncryptAlias,proto3" json:"EncryptAlias,omitempty"`
+	SignAlias     string                 `protobuf:"bytes,3,opt,name=SignAlias,proto3" json:"SignAlias,omitempty"`
+	ApiUrl        []string               `protobuf:"bytes,4,rep,name=ApiUrl,proto3" json:"ApiUrl,omitempty"`
+	GroupSeed     string                 `protobuf:"bytes,5,opt,name=GroupSeed,proto3" json:"GroupSeed,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Group        *GroupItem `protobuf:"bytes,1,opt,name=Group,proto3" json:"Group,omitempty"`
-	EncryptAlias string     `protobuf:"bytes,2,opt,name=EncryptAlias,proto3" json:"EncryptAlias,omitempty"`
-	SignAlias    string     `protobuf:"bytes,3,opt,name=SignAlias,proto3" json:"SignAlias,omitempty"`
-	ApiUrl       []string   `protobuf:"bytes,4,rep,name=ApiUrl,proto3" json:"ApiUrl,omitempty"`
-	GroupSeed    string     `protobuf:"bytes,5,opt,name=GroupSeed,proto3" json:"GroupSeed,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *NodeSDKGroupItem) Reset() {
 	*x = NodeSDKGroupItem{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[17]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *NodeSDKGroupItem) String() string {
@@ -2401,8 +3148,8 @@ func (x *NodeSDKGroupItem) String() string {
 func (*NodeSDKGroupItem) ProtoMessage() {}
 
 func (x *NodeSDKGroupItem) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[17]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[25]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -2414,7 +3161,7 @@ func (x *NodeSDKGroupItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeSDKGroupItem.ProtoReflect.Descriptor instead.
 func (*NodeSDKGroupItem) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{17}
+	return file_chain_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *NodeSDKGroupItem) GetGroup() *GroupItem {
@@ -2453,20 +3200,17 @@ func (x *NodeSDKGroupItem) GetGroupSeed() string {
 }
 
 type HBTrxBundle struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trxs          []*Trx                 `protobuf:"bytes,1,rep,name=Trxs,proto3" json:"Trxs,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Trxs []*Trx `protobuf:"bytes,1,rep,name=Trxs,proto3" json:"Trxs,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *HBTrxBundle) Reset() {
 	*x = HBTrxBundle{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[18]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *HBTrxBundle) String() string {
@@ -2476,8 +3220,8 @@ func (x *HBTrxBundle) String() string {
 func (*HBTrxBundle) ProtoMessage() {}
 
 func (x *HBTrxBundle) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[18]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[26]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -2489,7 +3233,7 @@ func (x *HBTrxBundle) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HBTrxBundle.ProtoReflect.Descriptor instead.
 func (*HBTrxBundle) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{18}
+	return file_chain_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *HBTrxBundle) GetTrxs() []*Trx {
@@ -2500,23 +3244,20 @@ func (x *HBTrxBundle) GetTrxs() []*Trx {
 }
 
 type HBMsgv1 struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MsgId         string                 `protobuf:"bytes,1,opt,name=MsgId,proto3" json:"MsgId,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,2,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	PayloadType   HBMsgPayloadType       `protobuf:"varint,3,opt,name=PayloadType,proto3,enum=quorum.pb.HBMsgPayloadType" json:"PayloadType,omitempty"` // RBC or BBA
+	Payload       []byte                 `protobuf:"bytes,4,opt,name=Payload,proto3" json:"Payload,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	MsgId       string           `protobuf:"bytes,1,opt,name=MsgId,proto3" json:"MsgId,omitempty"`
-	Epoch       uint64           `protobuf:"varint,2,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
-	PayloadType HBMsgPayloadType `protobuf:"varint,3,opt,name=PayloadType,proto3,enum=quorum.pb.HBMsgPayloadType" json:"PayloadType,omitempty"` // RBC or BBA
-	Payload     []byte           `protobuf:"bytes,4,opt,name=Payload,proto3" json:"Payload,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *HBMsgv1) Reset() {
 	*x = HBMsgv1{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[19]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *HBMsgv1) String() string {
@@ -2526,8 +3267,8 @@ func (x *HBMsgv1) String() string {
 func (*HBMsgv1) ProtoMessage() {}
 
 func (x *HBMsgv1) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[19]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[27]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -2539,7 +3280,7 @@ func (x *HBMsgv1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HBMsgv1.ProtoReflect.Descriptor instead.
 func (*HBMsgv1) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{19}
+	return file_chain_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *HBMsgv1) GetMsgId() string {
@@ -2572,21 +3313,18 @@ func (x *HBMsgv1) GetPayload() []byte {
 
 // RBC
 type RBCMsg struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          RBCMsgType             `protobuf:"varint,1,opt,name=Type,proto3,enum=quorum.pb.RBCMsgType" json:"Type,omitempty"` //INIT_PROPOSE / PROOF / READY
+	Payload       []byte                 `protobuf:"bytes,2,opt,name=Payload,proto3" json:"Payload,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Type    RBCMsgType `protobuf:"varint,1,opt,name=Type,proto3,enum=quorum.pb.RBCMsgType" json:"Type,omitempty"` //INIT_PROPOSE / PROOF / READY
-	Payload []byte     `protobuf:"bytes,2,opt,name=Payload,proto3" json:"Payload,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RBCMsg) Reset() {
 	*x = RBCMsg{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[20]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *RBCMsg) String() string {
@@ -2596,8 +3334,8 @@ func (x *RBCMsg) String() string {
 func (*RBCMsg) ProtoMessage() {}
 
 func (x *RBCMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[20]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[28]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -2609,7 +3347,7 @@ func (x *RBCMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RBCMsg.ProtoReflect.Descriptor instead.
 func (*RBCMsg) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{20}
+	return file_chain_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *RBCMsg) GetType() RBCMsgType {
@@ -2627,27 +3365,25 @@ func (x *RBCMsg) GetPayload() []byte {
 }
 
 type InitPropose struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	RootHash         []byte   `protobuf:"bytes,1,opt,name=RootHash,proto3" json:"RootHash,omitempty"`
-	Proof            [][]byte `protobuf:"bytes,2,rep,name=Proof,proto3" json:"Proof,omitempty"`
-	Index            int64    `protobuf:"varint,3,opt,name=Index,proto3" json:"Index,omitempty"`
-	Leaves           int64    `protobuf:"varint,4,opt,name=Leaves,proto3" json:"Leaves,omitempty"`
-	OriginalDataSize int64    `protobuf:"varint,5,opt,name=OriginalDataSize,proto3" json:"OriginalDataSize,omitempty"`
-	RecvNodePubkey   string   `protobuf:"bytes,6,opt,name=RecvNodePubkey,proto3" json:"RecvNodePubkey,omitempty"` //producer which should handle this ecc data shard
-	ProposerPubkey   string   `protobuf:"bytes,7,opt,name=ProposerPubkey,proto3" json:"ProposerPubkey,omitempty"` //producer which make this propose (part of ecc shards)
-	ProposerSign     []byte   `protobuf:"bytes,8,opt,name=ProposerSign,proto3" json:"ProposerSign,omitempty"`     //signature of producer made this propose
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	RootHash         []byte                 `protobuf:"bytes,1,opt,name=RootHash,proto3" json:"RootHash,omitempty"`
+	Proof            [][]byte               `protobuf:"bytes,2,rep,name=Proof,proto3" json:"Proof,omitempty"`
+	Index            int64                  `protobuf:"varint,3,opt,name=Index,proto3" json:"Index,omitempty"`
+	Leaves           int64                  `protobuf:"varint,4,opt,name=Leaves,proto3" json:"Leaves,omitempty"`
+	OriginalDataSize int64                  `protobuf:"varint,5,opt,name=OriginalDataSize,proto3" json:"OriginalDataSize,omitempty"`
+	RecvNodePubkey   string                 `protobuf:"bytes,6,opt,name=RecvNodePubkey,proto3" json:"RecvNodePubkey,omitempty"` //producer which should handle this ecc data shard
+	ProposerPubkey   string                 `protobuf:"bytes,7,opt,name=ProposerPubkey,proto3" json:"ProposerPubkey,omitempty"` //producer which make this propose (part of ecc shards)
+	ProposerSign     []byte                 `protobuf:"bytes,8,opt,name=ProposerSign,proto3" json:"ProposerSign,omitempty"`     //signature of producer made this propose
+	Epoch            uint64                 `protobuf:"varint,9,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *InitPropose) Reset() {
 	*x = InitPropose{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[21]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *InitPropose) String() string {
@@ -2657,8 +3393,8 @@ func (x *InitPropose) String() string {
 func (*InitPropose) ProtoMessage() {}
 
 func (x *InitPropose) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[21]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[29]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -2670,7 +3406,7 @@ func (x *InitPropose) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InitPropose.ProtoReflect.Descriptor instead.
 func (*InitPropose) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{21}
+	return file_chain_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *InitPropose) GetRootHash() []byte {
@@ -2729,28 +3465,34 @@ func (x *InitPropose) GetProposerSign() []byte {
 	return nil
 }
 
-type Echo struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *InitPropose) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
 
-	RootHash               []byte   `protobuf:"bytes,1,opt,name=RootHash,proto3" json:"RootHash,omitempty"`
-	Proof                  [][]byte `protobuf:"bytes,2,rep,name=Proof,proto3" json:"Proof,omitempty"`
-	Index                  int64    `protobuf:"varint,3,opt,name=Index,proto3" json:"Index,omitempty"`
-	Leaves                 int64    `protobuf:"varint,4,opt,name=Leaves,proto3" json:"Leaves,omitempty"`
-	OriginalDataSize       int64    `protobuf:"varint,5,opt,name=OriginalDataSize,proto3" json:"OriginalDataSize,omitempty"`
-	OriginalProposerPubkey string   `protobuf:"bytes,6,opt,name=OriginalProposerPubkey,proto3" json:"OriginalProposerPubkey,omitempty"` //producer make this original input
-	EchoProviderPubkey     string   `protobuf:"bytes,7,opt,name=EchoProviderPubkey,proto3" json:"EchoProviderPubkey,omitempty"`         //producer which broadcast this Echo
-	EchoProviderSign       []byte   `protobuf:"bytes,8,opt,name=EchoProviderSign,proto3" json:"EchoProviderSign,omitempty"`             //signature of producer broadcast this Echo
+type Echo struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	RootHash               []byte                 `protobuf:"bytes,1,opt,name=RootHash,proto3" json:"RootHash,omitempty"`
+	Proof                  [][]byte               `protobuf:"bytes,2,rep,name=Proof,proto3" json:"Proof,omitempty"`
+	Index                  int64                  `protobuf:"varint,3,opt,name=Index,proto3" json:"Index,omitempty"`
+	Leaves                 int64                  `protobuf:"varint,4,opt,name=Leaves,proto3" json:"Leaves,omitempty"`
+	OriginalDataSize       int64                  `protobuf:"varint,5,opt,name=OriginalDataSize,proto3" json:"OriginalDataSize,omitempty"`
+	OriginalProposerPubkey string                 `protobuf:"bytes,6,opt,name=OriginalProposerPubkey,proto3" json:"OriginalProposerPubkey,omitempty"` //producer make this original input
+	EchoProviderPubkey     string                 `protobuf:"bytes,7,opt,name=EchoProviderPubkey,proto3" json:"EchoProviderPubkey,omitempty"`         //producer which broadcast this Echo
+	EchoProviderSign       []byte                 `protobuf:"bytes,8,opt,name=EchoProviderSign,proto3" json:"EchoProviderSign,omitempty"`             //signature of producer broadcast this Echo
+	ProposerSign           []byte                 `protobuf:"bytes,9,opt,name=ProposerSign,proto3" json:"ProposerSign,omitempty"`                     //signature of the InitPropose received by the echo provider
+	Epoch                  uint64                 `protobuf:"varint,10,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *Echo) Reset() {
 	*x = Echo{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[22]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Echo) String() string {
@@ -2760,8 +3502,8 @@ func (x *Echo) String() string {
 func (*Echo) ProtoMessage() {}
 
 func (x *Echo) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[22]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[30]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -2773,7 +3515,7 @@ func (x *Echo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Echo.ProtoReflect.Descriptor instead.
 func (*Echo) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{22}
+	return file_chain_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *Echo) GetRootHash() []byte {
@@ -2832,24 +3574,36 @@ func (x *Echo) GetEchoProviderSign() []byte {
 	return nil
 }
 
-type Ready struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Echo) GetProposerSign() []byte {
+	if x != nil {
+		return x.ProposerSign
+	}
+	return nil
+}
+
+func (x *Echo) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
 
-	RootHash               []byte `protobuf:"bytes,1,opt,name=RootHash,proto3" json:"RootHash,omitempty"`
-	OriginalProposerPubkey string `protobuf:"bytes,2,opt,name=OriginalProposerPubkey,proto3" json:"OriginalProposerPubkey,omitempty"`
-	ReadyProviderPubkey    string `protobuf:"bytes,3,opt,name=ReadyProviderPubkey,proto3" json:"ReadyProviderPubkey,omitempty"`
-	ReadyProviderSign      []byte `protobuf:"bytes,4,opt,name=ReadyProviderSign,proto3" json:"ReadyProviderSign,omitempty"`
+type Ready struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	RootHash               []byte                 `protobuf:"bytes,1,opt,name=RootHash,proto3" json:"RootHash,omitempty"`
+	OriginalProposerPubkey string                 `protobuf:"bytes,2,opt,name=OriginalProposerPubkey,proto3" json:"OriginalProposerPubkey,omitempty"`
+	ReadyProviderPubkey    string                 `protobuf:"bytes,3,opt,name=ReadyProviderPubkey,proto3" json:"ReadyProviderPubkey,omitempty"`
+	ReadyProviderSign      []byte                 `protobuf:"bytes,4,opt,name=ReadyProviderSign,proto3" json:"ReadyProviderSign,omitempty"`
+	Epoch                  uint64                 `protobuf:"varint,5,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *Ready) Reset() {
 	*x = Ready{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[23]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Ready) String() string {
@@ -2859,8 +3613,8 @@ func (x *Ready) String() string {
 func (*Ready) ProtoMessage() {}
 
 func (x *Ready) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[23]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[31]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -2872,7 +3626,7 @@ func (x *Ready) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Ready.ProtoReflect.Descriptor instead.
 func (*Ready) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{23}
+	return file_chain_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *Ready) GetRootHash() []byte {
@@ -2903,23 +3657,128 @@ func (x *Ready) GetReadyProviderSign() []byte {
 	return nil
 }
 
+func (x *Ready) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+// both conflicting messages are signed by the offender, so the evidence can be verified by any node
+type Evidence struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EvidenceId     string                 `protobuf:"bytes,1,opt,name=EvidenceId,proto3" json:"EvidenceId,omitempty"`
+	GroupId        string                 `protobuf:"bytes,2,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	Type           EvidenceType           `protobuf:"varint,3,opt,name=Type,proto3,enum=quorum.pb.EvidenceType" json:"Type,omitempty"`
+	OffenderPubkey string                 `protobuf:"bytes,4,opt,name=OffenderPubkey,proto3" json:"OffenderPubkey,omitempty"`
+	Epoch          uint64                 `protobuf:"varint,5,opt,name=Epoch,proto3" json:"Epoch,omitempty"` //epoch of RBC messages, or BlockId of blocks
+	MsgA           []byte                 `protobuf:"bytes,6,opt,name=MsgA,proto3" json:"MsgA,omitempty"`    //InitPropose, Echo, Ready or Block
+	MsgB           []byte                 `protobuf:"bytes,7,opt,name=MsgB,proto3" json:"MsgB,omitempty"`
+	TimeStamp      int64                  `protobuf:"varint,8,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty,string"` //when detected
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Evidence) Reset() {
+	*x = Evidence{}
+	mi := &file_chain_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Evidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Evidence) ProtoMessage() {}
+
+func (x *Evidence) ProtoReflect() protoreflect.Message {
+	mi := &file_chain_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Evidence.ProtoReflect.Descriptor instead.
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return file_chain_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Evidence) GetEvidenceId() string {
+	if x != nil {
+		return x.EvidenceId
+	}
+	return ""
+}
+
+func (x *Evidence) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *Evidence) GetType() EvidenceType {
+	if x != nil {
+		return x.Type
+	}
+	return EvidenceType_RBC_PROPOSE_EQUIVOCATION
+}
+
+func (x *Evidence) GetOffenderPubkey() string {
+	if x != nil {
+		return x.OffenderPubkey
+	}
+	return ""
+}
+
+func (x *Evidence) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *Evidence) GetMsgA() []byte {
+	if x != nil {
+		return x.MsgA
+	}
+	return nil
+}
+
+func (x *Evidence) GetMsgB() []byte {
+	if x != nil {
+		return x.MsgB
+	}
+	return nil
+}
+
+func (x *Evidence) GetTimeStamp() int64 {
+	if x != nil {
+		return x.TimeStamp
+	}
+	return 0
+}
+
 // BBA
 type BBAMsg struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          BBAMsgType             `protobuf:"varint,1,opt,name=Type,proto3,enum=quorum.pb.BBAMsgType" json:"Type,omitempty"` //BVAL or AUX
+	Payload       []byte                 `protobuf:"bytes,2,opt,name=Payload,proto3" json:"Payload,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Type    BBAMsgType `protobuf:"varint,1,opt,name=Type,proto3,enum=quorum.pb.BBAMsgType" json:"Type,omitempty"` //BVAL or AUX
-	Payload []byte     `protobuf:"bytes,2,opt,name=Payload,proto3" json:"Payload,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BBAMsg) Reset() {
 	*x = BBAMsg{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[24]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *BBAMsg) String() string {
@@ -2929,8 +3788,8 @@ func (x *BBAMsg) String() string {
 func (*BBAMsg) ProtoMessage() {}
 
 func (x *BBAMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[24]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[33]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -2942,7 +3801,7 @@ func (x *BBAMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BBAMsg.ProtoReflect.Descriptor instead.
 func (*BBAMsg) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{24}
+	return file_chain_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *BBAMsg) GetType() BBAMsgType {
@@ -2960,23 +3819,20 @@ func (x *BBAMsg) GetPayload() []byte {
 }
 
 type Bval struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProposerId    string                 `protobuf:"bytes,1,opt,name=ProposerId,proto3" json:"ProposerId,omitempty"`
+	SenderPubkey  string                 `protobuf:"bytes,2,opt,name=SenderPubkey,proto3" json:"SenderPubkey,omitempty"`
+	Epoch         int64                  `protobuf:"varint,3,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	Value         bool                   `protobuf:"varint,4,opt,name=Value,proto3" json:"Value,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	ProposerId   string `protobuf:"bytes,1,opt,name=ProposerId,proto3" json:"ProposerId,omitempty"`
-	SenderPubkey string `protobuf:"bytes,2,opt,name=SenderPubkey,proto3" json:"SenderPubkey,omitempty"`
-	Epoch        int64  `protobuf:"varint,3,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
-	Value        bool   `protobuf:"varint,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Bval) Reset() {
 	*x = Bval{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[25]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Bval) String() string {
@@ -2986,8 +3842,8 @@ func (x *Bval) String() string {
 func (*Bval) ProtoMessage() {}
 
 func (x *Bval) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[25]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[34]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -2999,7 +3855,7 @@ func (x *Bval) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Bval.ProtoReflect.Descriptor instead.
 func (*Bval) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{25}
+	return file_chain_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *Bval) GetProposerId() string {
@@ -3031,23 +3887,20 @@ func (x *Bval) GetValue() bool {
 }
 
 type Aux struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProposerId    string                 `protobuf:"bytes,1,opt,name=ProposerId,proto3" json:"ProposerId,omitempty"`
+	SenderPubkey  string                 `protobuf:"bytes,2,opt,name=SenderPubkey,proto3" json:"SenderPubkey,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,3,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	Value         bool                   `protobuf:"varint,4,opt,name=Value,proto3" json:"Value,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	ProposerId   string `protobuf:"bytes,1,opt,name=ProposerId,proto3" json:"ProposerId,omitempty"`
-	SenderPubkey string `protobuf:"bytes,2,opt,name=SenderPubkey,proto3" json:"SenderPubkey,omitempty"`
-	Epoch        uint64 `protobuf:"varint,3,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
-	Value        bool   `protobuf:"varint,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Aux) Reset() {
 	*x = Aux{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[26]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Aux) String() string {
@@ -3057,8 +3910,8 @@ func (x *Aux) String() string {
 func (*Aux) ProtoMessage() {}
 
 func (x *Aux) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[26]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[35]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -3070,7 +3923,7 @@ func (x *Aux) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Aux.ProtoReflect.Descriptor instead.
 func (*Aux) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{26}
+	return file_chain_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *Aux) GetProposerId() string {
@@ -3101,35 +3954,32 @@ func (x *Aux) GetValue() bool {
 	return false
 }
 
-//old proto msg
+// old proto msg
 type GroupItemV0 struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	GroupId           string            `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
-	GroupName         string            `protobuf:"bytes,2,opt,name=GroupName,proto3" json:"GroupName,omitempty"`
-	OwnerPubKey       string            `protobuf:"bytes,3,opt,name=OwnerPubKey,proto3" json:"OwnerPubKey,omitempty"`
-	UserSignPubkey    string            `protobuf:"bytes,4,opt,name=UserSignPubkey,proto3" json:"UserSignPubkey,omitempty"`
-	UserEncryptPubkey string            `protobuf:"bytes,5,opt,name=UserEncryptPubkey,proto3" json:"UserEncryptPubkey,omitempty"`
-	UserRole          RoleV0            `protobuf:"varint,6,opt,name=UserRole,proto3,enum=quorum.pb.RoleV0" json:"UserRole,omitempty"`
-	LastUpdate        int64             `protobuf:"varint,7,opt,name=LastUpdate,proto3" json:"LastUpdate,omitempty"`
-	HighestHeight     int64             `protobuf:"varint,8,opt,name=HighestHeight,proto3" json:"HighestHeight,omitempty"`
-	HighestBlockId    string            `protobuf:"bytes,9,opt,name=HighestBlockId,proto3" json:"HighestBlockId,omitempty"`
-	GenesisBlock      *Block            `protobuf:"bytes,10,opt,name=GenesisBlock,proto3" json:"GenesisBlock,omitempty"`
-	EncryptType       GroupEncryptType  `protobuf:"varint,11,opt,name=EncryptType,proto3,enum=quorum.pb.GroupEncryptType" json:"EncryptType,omitempty"`
-	ConsenseType      GroupConsenseType `protobuf:"varint,12,opt,name=ConsenseType,proto3,enum=quorum.pb.GroupConsenseType" json:"ConsenseType,omitempty"`
-	CipherKey         string            `protobuf:"bytes,13,opt,name=CipherKey,proto3" json:"CipherKey,omitempty"`
-	AppKey            string            `protobuf:"bytes,14,opt,name=AppKey,proto3" json:"AppKey,omitempty"`
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	GroupId           string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	GroupName         string                 `protobuf:"bytes,2,opt,name=GroupName,proto3" json:"GroupName,omitempty"`
+	OwnerPubKey       string                 `protobuf:"bytes,3,opt,name=OwnerPubKey,proto3" json:"OwnerPubKey,omitempty"`
+	UserSignPubkey    string                 `protobuf:"bytes,4,opt,name=UserSignPubkey,proto3" json:"UserSignPubkey,omitempty"`
+	UserEncryptPubkey string                 `protobuf:"bytes,5,opt,name=UserEncryptPubkey,proto3" json:"UserEncryptPubkey,omitempty"`
+	UserRole          RoleV0                 `protobuf:"varint,6,opt,name=UserRole,proto3,enum=quorum.pb.RoleV0" json:"UserRole,omitempty"`
+	LastUpdate        int64                  `protobuf:"varint,7,opt,name=LastUpdate,proto3" json:"LastUpdate,omitempty"`
+	HighestHeight     int64                  `protobuf:"varint,8,opt,name=HighestHeight,proto3" json:"HighestHeight,omitempty"`
+	HighestBlockId    string                 `protobuf:"bytes,9,opt,name=HighestBlockId,proto3" json:"HighestBlockId,omitempty"`
+	GenesisBlock      *Block                 `protobuf:"bytes,10,opt,name=GenesisBlock,proto3" json:"GenesisBlock,omitempty"`
+	EncryptType       GroupEncryptType       `protobuf:"varint,11,opt,name=EncryptType,proto3,enum=quorum.pb.GroupEncryptType" json:"EncryptType,omitempty"`
+	ConsenseType      GroupConsenseType      `protobuf:"varint,12,opt,name=ConsenseType,proto3,enum=quorum.pb.GroupConsenseType" json:"ConsenseType,omitempty"`
+	CipherKey         string                 `protobuf:"bytes,13,opt,name=CipherKey,proto3" json:"CipherKey,omitempty"`
+	AppKey            string                 `protobuf:"bytes,14,opt,name=AppKey,proto3" json:"AppKey,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GroupItemV0) Reset() {
 	*x = GroupItemV0{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_chain_proto_msgTypes[27]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_chain_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GroupItemV0) String() string {
@@ -3139,8 +3989,8 @@ func (x *GroupItemV0) String() string {
 func (*GroupItemV0) ProtoMessage() {}
 
 func (x *GroupItemV0) ProtoReflect() protoreflect.Message {
-	mi := &file_chain_proto_msgTypes[27]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_chain_proto_msgTypes[36]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -3152,7 +4002,7 @@ func (x *GroupItemV0) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupItemV0.ProtoReflect.Descriptor instead.
 func (*GroupItemV0) Descriptor() ([]byte, []int) {
-	return file_chain_proto_rawDescGZIP(), []int{27}
+	return file_chain_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *GroupItemV0) GetGroupId() string {
@@ -3255,472 +4105,404 @@ func (x *GroupItemV0) GetAppKey() string {
 
 var File_chain_proto protoreflect.FileDescriptor
 
-var file_chain_proto_rawDesc = []byte{
-	0x0a, 0x0b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x71,
-	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x22, 0x49, 0x0a, 0x07, 0x50, 0x61, 0x63, 0x6b,
-	0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x16, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x61,
-	0x63, 0x6b, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x44,
-	0x61, 0x74, 0x61, 0x22, 0xec, 0x02, 0x0a, 0x03, 0x54, 0x72, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x54,
-	0x72, 0x78, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x72, 0x78, 0x49,
-	0x64, 0x12, 0x26, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x12, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x78, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75,
-	0x70, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x53,
-	0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65,
-	0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
-	0x18, 0x0a, 0x07, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x07, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x52, 0x65, 0x73,
-	0x65, 0x6e, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
-	0x52, 0x65, 0x73, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x53,
-	0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12,
-	0x1e, 0x0a, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x0b, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x12,
-	0x3b, 0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x0c,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62,
-	0x2e, 0x54, 0x72, 0x78, 0x53, 0x74, 0x72, 0x6f, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x0b, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x4a, 0x04, 0x08, 0x09,
-	0x10, 0x0a, 0x22, 0xad, 0x02, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x0a, 0x07,
-	0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64,
-	0x12, 0x14, 0x0a, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x72, 0x65, 0x76, 0x48, 0x61,
-	0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x50, 0x72, 0x65, 0x76, 0x48, 0x61,
-	0x73, 0x68, 0x12, 0x26, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x50, 0x75,
-	0x62, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x50, 0x72, 0x6f, 0x64,
-	0x75, 0x63, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x04, 0x54, 0x72,
-	0x78, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75,
-	0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x78, 0x52, 0x04, 0x54, 0x72, 0x78, 0x73, 0x12, 0x12,
-	0x0a, 0x04, 0x53, 0x75, 0x64, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x53, 0x75,
-	0x64, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70,
-	0x12, 0x1c, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x18, 0x09, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x22,
-	0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x53, 0x69,
-	0x67, 0x6e, 0x22, 0x86, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x71, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
-	0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x72, 0x6f,
-	0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x46, 0x72,
-	0x6f, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x0d, 0x42, 0x6c, 0x6b, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d,
-	0x42, 0x6c, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a,
-	0x09, 0x52, 0x65, 0x71, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x52, 0x65, 0x71, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x22, 0x38, 0x0a, 0x0c, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x71, 0x75,
-	0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x06, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0xc4, 0x02, 0x0a, 0x0c, 0x52, 0x65, 0x71, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64,
-	0x12, 0x28, 0x0a, 0x0f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62,
-	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x0e, 0x50, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b,
-	0x65, 0x79, 0x12, 0x2f, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x52,
-	0x65, 0x71, 0x42, 0x6c, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x72, 0x6f, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x46, 0x72, 0x6f, 0x6d, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x12, 0x24, 0x0a, 0x0d, 0x42, 0x6c, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x42, 0x6c, 0x6b, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x42, 0x6c, 0x6b, 0x73, 0x50,
-	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x42,
-	0x6c, 0x6b, 0x73, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x06, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x71, 0x75,
-	0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x42, 0x75,
-	0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0x7c, 0x0a, 0x08,
-	0x50, 0x6f, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x72, 0x78, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x72, 0x78, 0x49, 0x64, 0x12, 0x22,
-	0x0a, 0x0c, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b,
-	0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09,
-	0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x09, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xad, 0x02, 0x0a, 0x0c, 0x50,
-	0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65,
-	0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x50,
-	0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a,
-	0x10, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65,
-	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x77,
-	0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x0e, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x53, 0x69, 0x67,
-	0x6e, 0x12, 0x2d, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x15, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x26, 0x0a, 0x0e, 0x57, 0x69, 0x74, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x57, 0x69, 0x74, 0x68, 0x6e, 0x65,
-	0x73, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65,
-	0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x54, 0x69, 0x6d,
-	0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x65, 0x6d, 0x6f, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4d, 0x65, 0x6d, 0x6f, 0x22, 0x4e, 0x0a, 0x15, 0x42, 0x46,
-	0x54, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49,
-	0x74, 0x65, 0x6d, 0x12, 0x35, 0x0a, 0x09, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e,
-	0x70, 0x62, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x52,
-	0x09, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x73, 0x22, 0x9f, 0x02, 0x0a, 0x08, 0x55,
-	0x73, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49,
-	0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x55, 0x73, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x55, 0x73, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65,
-	0x79, 0x12, 0x24, 0x0a, 0x0d, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x50, 0x75, 0x62, 0x6b,
-	0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70,
-	0x74, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x10, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x4f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x10, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62,
-	0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x0e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4f, 0x77, 0x6e, 0x65,
-	0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x54,
-	0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
-	0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2d, 0x0a, 0x06, 0x41, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x71, 0x75, 0x6f, 0x72,
-	0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x65, 0x6d, 0x6f,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4d, 0x65, 0x6d, 0x6f, 0x22, 0xa6, 0x03, 0x0a,
-	0x0c, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x18, 0x0a,
-	0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x69, 0x67, 0x6e, 0x50,
-	0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x69, 0x67,
-	0x6e, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x45, 0x6e, 0x63, 0x72, 0x79,
-	0x70, 0x74, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
-	0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a,
-	0x12, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
-	0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x41, 0x6e, 0x6e, 0x6f, 0x75,
-	0x6e, 0x63, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2b, 0x0a,
-	0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x71, 0x75,
-	0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4f, 0x77,
-	0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x0e,
-	0x4f, 0x77, 0x6e, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61,
-	0x74, 0x75, 0x72, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62,
-	0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d,
-	0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61,
-	0x6d, 0x70, 0x12, 0x2d, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x15, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x65, 0x6d, 0x6f, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x4d, 0x65, 0x6d, 0x6f, 0x22, 0xc8, 0x03, 0x0a, 0x09, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49,
-	0x74, 0x65, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1c, 0x0a,
-	0x09, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4f,
-	0x77, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x26, 0x0a,
-	0x0e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x50,
-	0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x11, 0x55, 0x73, 0x65, 0x72, 0x45, 0x6e, 0x63,
-	0x72, 0x79, 0x70, 0x74, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x11, 0x55, 0x73, 0x65, 0x72, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x50, 0x75, 0x62,
-	0x6b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x4c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x4c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x12, 0x34, 0x0a, 0x0c, 0x47, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x71, 0x75, 0x6f, 0x72,
-	0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x0c, 0x47, 0x65, 0x6e,
-	0x65, 0x73, 0x69, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x3d, 0x0a, 0x0b, 0x45, 0x6e, 0x63,
-	0x72, 0x79, 0x70, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b,
-	0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x45, 0x6e, 0x63,
-	0x72, 0x79, 0x70, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x40, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x73,
-	0x65, 0x6e, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c,
-	0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x43, 0x6f,
-	0x6e, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x69,
-	0x70, 0x68, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x43,
-	0x69, 0x70, 0x68, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x70, 0x70, 0x4b,
-	0x65, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x41, 0x70, 0x70, 0x4b, 0x65, 0x79,
-	0x22, 0xeb, 0x01, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x49, 0x74, 0x65, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x2e,
-	0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x71,
-	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x44, 0x61,
-	0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65,
-	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x75,
-	0x62, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x53, 0x69, 0x67,
-	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x4f, 0x77,
-	0x6e, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09,
-	0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x09, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x65,
-	0x6d, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4d, 0x65, 0x6d, 0x6f, 0x22, 0x89,
-	0x01, 0x0a, 0x18, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x72, 0x78, 0x52,
-	0x75, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x2d, 0x0a, 0x06, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x71, 0x75,
-	0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
-	0x70, 0x65, 0x52, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x75,
-	0x62, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x50, 0x75, 0x62, 0x6b,
-	0x65, 0x79, 0x12, 0x26, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e,
-	0x32, 0x12, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x78,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x22, 0x68, 0x0a, 0x12, 0x53, 0x65,
-	0x74, 0x54, 0x72, 0x78, 0x41, 0x75, 0x74, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x49, 0x74, 0x65, 0x6d,
-	0x12, 0x26, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12,
-	0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x78, 0x54, 0x79,
-	0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e,
-	0x70, 0x62, 0x2e, 0x54, 0x72, 0x78, 0x41, 0x75, 0x74, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04,
-	0x4d, 0x6f, 0x64, 0x65, 0x22, 0xa2, 0x02, 0x0a, 0x0d, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64,
-	0x12, 0x2d, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x15, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e,
-	0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x18, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70,
-	0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4f, 0x77, 0x6e, 0x65, 0x72,
-	0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4f, 0x77,
-	0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x4f, 0x77, 0x6e,
-	0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4f, 0x77,
-	0x6e, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x65, 0x6d, 0x6f, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4d, 0x65, 0x6d, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x54,
-	0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
-	0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xbd, 0x02, 0x0a, 0x09, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x53, 0x65, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x0c, 0x47, 0x65, 0x6e, 0x65, 0x73,
-	0x69, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
-	0x0c, 0x47, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x0a,
-	0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x47, 0x72, 0x6f, 0x75,
-	0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x75,
-	0x62, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4f, 0x77, 0x6e, 0x65,
-	0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x73, 0x65,
-	0x6e, 0x73, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
-	0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a,
-	0x0e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x69, 0x70, 0x68, 0x65, 0x72, 0x4b,
-	0x65, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x43, 0x69, 0x70, 0x68, 0x65, 0x72,
-	0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x70, 0x70, 0x4b, 0x65, 0x79, 0x18, 0x08, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x41, 0x70, 0x70, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x53,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xb6, 0x01, 0x0a, 0x10, 0x4e, 0x6f,
-	0x64, 0x65, 0x53, 0x44, 0x4b, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x2a,
-	0x0a, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
-	0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49,
-	0x74, 0x65, 0x6d, 0x52, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x22, 0x0a, 0x0c, 0x45, 0x6e,
-	0x63, 0x72, 0x79, 0x70, 0x74, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0c, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x1c,
-	0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x16, 0x0a, 0x06,
-	0x41, 0x70, 0x69, 0x55, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x41, 0x70,
-	0x69, 0x55, 0x72, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x65, 0x65,
-	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x65,
-	0x65, 0x64, 0x22, 0x31, 0x0a, 0x0b, 0x48, 0x42, 0x54, 0x72, 0x78, 0x42, 0x75, 0x6e, 0x64, 0x6c,
-	0x65, 0x12, 0x22, 0x0a, 0x04, 0x54, 0x72, 0x78, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x0e, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x78, 0x52,
-	0x04, 0x54, 0x72, 0x78, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x07, 0x48, 0x42, 0x4d, 0x73, 0x67, 0x76,
-	0x31, 0x12, 0x14, 0x0a, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x3d, 0x0a,
-	0x0b, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x48,
-	0x42, 0x4d, 0x73, 0x67, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x0b, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07,
-	0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x50,
-	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x4d, 0x0a, 0x06, 0x52, 0x42, 0x43, 0x4d, 0x73, 0x67,
-	0x12, 0x29, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15,
-	0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x42, 0x43, 0x4d, 0x73,
-	0x67, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x50,
-	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x50, 0x61,
-	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x8d, 0x02, 0x0a, 0x0b, 0x49, 0x6e, 0x69, 0x74, 0x50, 0x72,
-	0x6f, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73,
-	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73,
-	0x68, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c,
-	0x52, 0x05, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a,
-	0x06, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x4c,
-	0x65, 0x61, 0x76, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61,
-	0x6c, 0x44, 0x61, 0x74, 0x61, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x10, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x53, 0x69, 0x7a,
-	0x65, 0x12, 0x26, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x76, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x75, 0x62,
-	0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x52, 0x65, 0x63, 0x76, 0x4e,
-	0x6f, 0x64, 0x65, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x0e, 0x50, 0x72, 0x6f,
-	0x70, 0x6f, 0x73, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65,
-	0x79, 0x12, 0x22, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67,
-	0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65,
-	0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0xa6, 0x02, 0x0a, 0x04, 0x45, 0x63, 0x68, 0x6f, 0x12, 0x1a,
-	0x0a, 0x08, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x08, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72,
-	0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x50, 0x72, 0x6f, 0x6f, 0x66,
-	0x12, 0x14, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x73,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x73, 0x12, 0x2a,
-	0x0a, 0x10, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x53, 0x69,
-	0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e,
-	0x61, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x36, 0x0a, 0x16, 0x4f, 0x72,
-	0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x50, 0x75,
-	0x62, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x4f, 0x72, 0x69, 0x67,
-	0x69, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b,
-	0x65, 0x79, 0x12, 0x2e, 0x0a, 0x12, 0x45, 0x63, 0x68, 0x6f, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
-	0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12,
-	0x45, 0x63, 0x68, 0x6f, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b,
-	0x65, 0x79, 0x12, 0x2a, 0x0a, 0x10, 0x45, 0x63, 0x68, 0x6f, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
-	0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x45, 0x63,
-	0x68, 0x6f, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0xbb,
-	0x01, 0x0a, 0x05, 0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x6f, 0x6f, 0x74,
-	0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x52, 0x6f, 0x6f, 0x74,
-	0x48, 0x61, 0x73, 0x68, 0x12, 0x36, 0x0a, 0x16, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c,
-	0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x50, 0x72,
-	0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x13,
-	0x52, 0x65, 0x61, 0x64, 0x79, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62,
-	0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x52, 0x65, 0x61, 0x64, 0x79,
-	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x2c,
-	0x0a, 0x11, 0x52, 0x65, 0x61, 0x64, 0x79, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53,
-	0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x52, 0x65, 0x61, 0x64, 0x79,
-	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x4d, 0x0a, 0x06,
-	0x42, 0x42, 0x41, 0x4d, 0x73, 0x67, 0x12, 0x29, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62,
-	0x2e, 0x42, 0x42, 0x41, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x76, 0x0a, 0x04, 0x42,
-	0x76, 0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65,
-	0x72, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62,
-	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x53, 0x65, 0x6e, 0x64, 0x65,
-	0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a,
-	0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x22, 0x75, 0x0a, 0x03, 0x41, 0x75, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x72,
-	0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x65,
-	0x6e, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0c, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x45,
-	0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xc7, 0x04, 0x0a, 0x0b, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x49, 0x74, 0x65, 0x6d, 0x56, 0x30, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65,
-	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x75,
-	0x62, 0x4b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e,
-	0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x55, 0x73,
-	0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x11,
-	0x55, 0x73, 0x65, 0x72, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x50, 0x75, 0x62, 0x6b, 0x65,
-	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x55, 0x73, 0x65, 0x72, 0x45, 0x6e, 0x63,
-	0x72, 0x79, 0x70, 0x74, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x2d, 0x0a, 0x08, 0x55, 0x73,
-	0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x71,
-	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x56, 0x30, 0x52,
-	0x08, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x4c, 0x61, 0x73,
-	0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x4c,
-	0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x48, 0x69, 0x67,
-	0x68, 0x65, 0x73, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0d, 0x48, 0x69, 0x67, 0x68, 0x65, 0x73, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
-	0x26, 0x0a, 0x0e, 0x48, 0x69, 0x67, 0x68, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49,
-	0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x48, 0x69, 0x67, 0x68, 0x65, 0x73, 0x74,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x0c, 0x47, 0x65, 0x6e, 0x65, 0x73,
-	0x69, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
-	0x0c, 0x47, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x3d, 0x0a,
-	0x0b, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x0b, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x0b, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x40, 0x0a, 0x0c,
-	0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x0c, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2e, 0x70, 0x62, 0x2e, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x0c, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c,
-	0x0a, 0x09, 0x43, 0x69, 0x70, 0x68, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x43, 0x69, 0x70, 0x68, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06,
-	0x41, 0x70, 0x70, 0x4b, 0x65, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x41, 0x70,
-	0x70, 0x4b, 0x65, 0x79, 0x2a, 0x2a, 0x0a, 0x0b, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x52, 0x58, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05,
-	0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x48, 0x42, 0x42, 0x10, 0x02,
-	0x2a, 0x2c, 0x0a, 0x0c, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x0b, 0x0a, 0x07, 0x41, 0x53, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0f, 0x0a,
-	0x0b, 0x41, 0x53, 0x5f, 0x50, 0x52, 0x4f, 0x44, 0x55, 0x43, 0x45, 0x52, 0x10, 0x01, 0x2a, 0x38,
-	0x0a, 0x0b, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0d, 0x0a,
-	0x09, 0x41, 0x4e, 0x4e, 0x4f, 0x55, 0x4e, 0x43, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08,
-	0x41, 0x50, 0x50, 0x52, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45,
-	0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x02, 0x2a, 0x21, 0x0a, 0x0a, 0x41, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x44, 0x44, 0x10, 0x00, 0x12,
-	0x0a, 0x0a, 0x06, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x10, 0x01, 0x2a, 0x26, 0x0a, 0x0e, 0x54,
-	0x72, 0x78, 0x53, 0x74, 0x72, 0x6f, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a,
-	0x05, 0x43, 0x48, 0x41, 0x49, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x41, 0x43, 0x48,
-	0x45, 0x10, 0x01, 0x2a, 0x7e, 0x0a, 0x07, 0x54, 0x72, 0x78, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08,
-	0x0a, 0x04, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x4e, 0x4e, 0x4f,
-	0x55, 0x4e, 0x43, 0x45, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x52, 0x4f, 0x44, 0x55, 0x43,
-	0x45, 0x52, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x55, 0x53, 0x45, 0x52, 0x10, 0x03, 0x12, 0x0d,
-	0x0a, 0x09, 0x52, 0x45, 0x51, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x04, 0x12, 0x12, 0x0a,
-	0x0e, 0x52, 0x45, 0x51, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x10,
-	0x05, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x48, 0x41, 0x49, 0x4e, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49,
-	0x47, 0x10, 0x06, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x50, 0x50, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49,
-	0x47, 0x10, 0x07, 0x2a, 0x50, 0x0a, 0x0c, 0x52, 0x65, 0x71, 0x42, 0x6c, 0x6b, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x12, 0x11, 0x0a, 0x0d, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x49, 0x4e, 0x5f,
-	0x52, 0x45, 0x53, 0x50, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f,
-	0x49, 0x4e, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x5f, 0x4f, 0x4e, 0x5f, 0x54, 0x4f, 0x50, 0x10, 0x01,
-	0x12, 0x13, 0x0a, 0x0f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f,
-	0x55, 0x4e, 0x44, 0x10, 0x02, 0x2a, 0x2b, 0x0a, 0x10, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x6e,
-	0x63, 0x72, 0x79, 0x70, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x55, 0x42,
-	0x4c, 0x49, 0x43, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x52, 0x49, 0x56, 0x41, 0x54, 0x45,
-	0x10, 0x01, 0x2a, 0x25, 0x0a, 0x11, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x73, 0x65,
-	0x6e, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x4f, 0x41, 0x10, 0x00,
-	0x12, 0x07, 0x0a, 0x03, 0x50, 0x4f, 0x53, 0x10, 0x01, 0x2a, 0x2c, 0x0a, 0x06, 0x52, 0x6f, 0x6c,
-	0x65, 0x56, 0x30, 0x12, 0x12, 0x0a, 0x0e, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x5f, 0x50, 0x52, 0x4f,
-	0x44, 0x55, 0x43, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x47, 0x52, 0x4f, 0x55, 0x50,
-	0x5f, 0x55, 0x53, 0x45, 0x52, 0x10, 0x01, 0x2a, 0x4c, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x69, 0x6e,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x45,
-	0x54, 0x5f, 0x54, 0x52, 0x58, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x10,
-	0x00, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x50, 0x44, 0x5f, 0x44, 0x4e, 0x59, 0x5f, 0x4c, 0x49, 0x53,
-	0x54, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x50, 0x44, 0x5f, 0x41, 0x4c, 0x57, 0x5f, 0x4c,
-	0x49, 0x53, 0x54, 0x10, 0x02, 0x2a, 0x37, 0x0a, 0x0b, 0x54, 0x72, 0x78, 0x41, 0x75, 0x74, 0x68,
-	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x46, 0x4f, 0x4c, 0x4c, 0x4f, 0x57, 0x5f, 0x41,
-	0x4c, 0x57, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x46, 0x4f, 0x4c,
-	0x4c, 0x4f, 0x57, 0x5f, 0x44, 0x4e, 0x59, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x01, 0x2a, 0x2d,
-	0x0a, 0x0c, 0x41, 0x75, 0x74, 0x68, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e,
-	0x0a, 0x0a, 0x41, 0x4c, 0x4c, 0x4f, 0x57, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x00, 0x12, 0x0d,
-	0x0a, 0x09, 0x44, 0x45, 0x4e, 0x59, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x01, 0x2a, 0x2e, 0x0a,
-	0x0d, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07,
-	0x0a, 0x03, 0x49, 0x4e, 0x54, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4c, 0x10,
-	0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x2a, 0x24, 0x0a,
-	0x10, 0x48, 0x42, 0x4d, 0x73, 0x67, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x42, 0x43, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x42,
-	0x41, 0x10, 0x01, 0x2a, 0x33, 0x0a, 0x0a, 0x52, 0x42, 0x43, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4e, 0x49, 0x54, 0x5f, 0x50, 0x52, 0x4f, 0x50, 0x4f, 0x53,
-	0x45, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x43, 0x48, 0x4f, 0x10, 0x01, 0x12, 0x09, 0x0a,
-	0x05, 0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x02, 0x2a, 0x1f, 0x0a, 0x0a, 0x42, 0x42, 0x41, 0x4d,
-	0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x56, 0x41, 0x4c, 0x10, 0x00,
-	0x12, 0x07, 0x0a, 0x03, 0x41, 0x55, 0x58, 0x10, 0x01, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x75, 0x6d, 0x73, 0x79, 0x73, 0x74, 0x65,
-	0x6d, 0x2f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
+const file_chain_proto_rawDesc = "" +
+	"\n" +
+	"\vchain.proto\x12\tquorum.pb\"I\n" +
+	"\aPackage\x12*\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x16.quorum.pb.PackageTypeR\x04type\x12\x12\n" +
+	"\x04Data\x18\x02 \x01(\fR\x04Data\"\xec\x02\n" +
+	"\x03Trx\x12\x14\n" +
+	"\x05TrxId\x18\x01 \x01(\tR\x05TrxId\x12&\n" +
+	"\x04Type\x18\x02 \x01(\x0e2\x12.quorum.pb.TrxTypeR\x04Type\x12\x18\n" +
+	"\aGroupId\x18\x03 \x01(\tR\aGroupId\x12\x12\n" +
+	"\x04Data\x18\x04 \x01(\fR\x04Data\x12\x1c\n" +
+	"\tTimeStamp\x18\x05 \x01(\x03R\tTimeStamp\x12\x18\n" +
+	"\aVersion\x18\x06 \x01(\tR\aVersion\x12\x18\n" +
+	"\aExpired\x18\a \x01(\x03R\aExpired\x12 \n" +
+	"\vResendCount\x18\b \x01(\x03R\vResendCount\x12\"\n" +
+	"\fSenderPubkey\x18\n" +
+	" \x01(\tR\fSenderPubkey\x12\x1e\n" +
+	"\n" +
+	"SenderSign\x18\v \x01(\fR\n" +
+	"SenderSign\x12;\n" +
+	"\vStorageType\x18\f \x01(\x0e2\x19.quorum.pb.TrxStroageTypeR\vStorageTypeJ\x04\b\t\x10\n" +
+	"\"\xe1\x02\n" +
+	"\x05Block\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12\x18\n" +
+	"\aBlockId\x18\x02 \x01(\x04R\aBlockId\x12\x14\n" +
+	"\x05Epoch\x18\x03 \x01(\x04R\x05Epoch\x12\x1a\n" +
+	"\bPrevHash\x18\x04 \x01(\fR\bPrevHash\x12&\n" +
+	"\x0eProducerPubkey\x18\x05 \x01(\tR\x0eProducerPubkey\x12\"\n" +
+	"\x04Trxs\x18\x06 \x03(\v2\x0e.quorum.pb.TrxR\x04Trxs\x12\x12\n" +
+	"\x04Sudo\x18\a \x01(\bR\x04Sudo\x12\x1c\n" +
+	"\tTimeStamp\x18\b \x01(\x03R\tTimeStamp\x12\x1c\n" +
+	"\tBlockHash\x18\t \x01(\fR\tBlockHash\x12\"\n" +
+	"\fProducerSign\x18\n" +
+	" \x01(\fR\fProducerSign\x12\x18\n" +
+	"\aVersion\x18\v \x01(\rR\aVersion\x12\x18\n" +
+	"\aTrxRoot\x18\f \x01(\fR\aTrxRoot\"\x86\x01\n" +
+	"\bReqBlock\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12\x1c\n" +
+	"\tFromBlock\x18\x02 \x01(\x04R\tFromBlock\x12$\n" +
+	"\rBlksRequested\x18\x03 \x01(\x05R\rBlksRequested\x12\x1c\n" +
+	"\tReqPubkey\x18\x04 \x01(\tR\tReqPubkey\"8\n" +
+	"\fBlocksBundle\x12(\n" +
+	"\x06Blocks\x18\x01 \x03(\v2\x10.quorum.pb.BlockR\x06Blocks\"\xc4\x02\n" +
+	"\fReqBlockResp\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12(\n" +
+	"\x0fRequesterPubkey\x18\x02 \x01(\tR\x0fRequesterPubkey\x12&\n" +
+	"\x0eProviderPubkey\x18\x03 \x01(\tR\x0eProviderPubkey\x12/\n" +
+	"\x06Result\x18\x04 \x01(\x0e2\x17.quorum.pb.ReqBlkResultR\x06Result\x12\x1c\n" +
+	"\tFromBlock\x18\x05 \x01(\x04R\tFromBlock\x12$\n" +
+	"\rBlksRequested\x18\x06 \x01(\x05R\rBlksRequested\x12\"\n" +
+	"\fBlksProvided\x18\a \x01(\x05R\fBlksProvided\x12/\n" +
+	"\x06Blocks\x18\b \x01(\v2\x17.quorum.pb.BlocksBundleR\x06Blocks\"6\n" +
+	"\fSnapshotItem\x12\x10\n" +
+	"\x03Key\x18\x01 \x01(\tR\x03Key\x12\x14\n" +
+	"\x05Value\x18\x02 \x01(\fR\x05Value\"\xe5\x02\n" +
+	"\bSnapshot\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12\x18\n" +
+	"\aBlockId\x18\x02 \x01(\x04R\aBlockId\x12\x14\n" +
+	"\x05Epoch\x18\x03 \x01(\x04R\x05Epoch\x12\x1c\n" +
+	"\tBlockHash\x18\x04 \x01(\fR\tBlockHash\x12\x1e\n" +
+	"\n" +
+	"ItemsCount\x18\x05 \x01(\x05R\n" +
+	"ItemsCount\x12\x1c\n" +
+	"\tStateHash\x18\x06 \x01(\fR\tStateHash\x12-\n" +
+	"\x05Items\x18\a \x03(\v2\x17.quorum.pb.SnapshotItemR\x05Items\x12\"\n" +
+	"\fSenderPubkey\x18\b \x01(\tR\fSenderPubkey\x12\x1c\n" +
+	"\tTimeStamp\x18\t \x01(\x03R\tTimeStamp\x12\"\n" +
+	"\fSnapshotHash\x18\n" +
+	" \x01(\fR\fSnapshotHash\x12\x1e\n" +
+	"\n" +
+	"SenderSign\x18\v \x01(\fR\n" +
+	"SenderSign\"\x7f\n" +
+	"\vReqSnapshot\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12\x1a\n" +
+	"\bFromItem\x18\x02 \x01(\x05R\bFromItem\x12\x1c\n" +
+	"\tStateHash\x18\x03 \x01(\fR\tStateHash\x12\x1c\n" +
+	"\tReqPubkey\x18\x04 \x01(\tR\tReqPubkey\"\xa8\x02\n" +
+	"\x0fReqSnapshotResp\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12(\n" +
+	"\x0fRequesterPubkey\x18\x02 \x01(\tR\x0fRequesterPubkey\x12&\n" +
+	"\x0eProviderPubkey\x18\x03 \x01(\tR\x0eProviderPubkey\x124\n" +
+	"\x06Result\x18\x04 \x01(\x0e2\x1c.quorum.pb.ReqSnapshotResultR\x06Result\x12\x1a\n" +
+	"\bFromItem\x18\x05 \x01(\x05R\bFromItem\x12/\n" +
+	"\bSnapshot\x18\x06 \x01(\v2\x13.quorum.pb.SnapshotR\bSnapshot\x12&\n" +
+	"\x05Block\x18\a \x01(\v2\x10.quorum.pb.BlockR\x05Block\"|\n" +
+	"\bPostItem\x12\x14\n" +
+	"\x05TrxId\x18\x01 \x01(\tR\x05TrxId\x12\"\n" +
+	"\fSenderPubkey\x18\x02 \x01(\tR\fSenderPubkey\x12\x18\n" +
+	"\aContent\x18\x03 \x01(\fR\aContent\x12\x1c\n" +
+	"\tTimeStamp\x18\x04 \x01(\x03R\tTimeStamp\"\xad\x02\n" +
+	"\fProducerItem\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12&\n" +
+	"\x0eProducerPubkey\x18\x02 \x01(\tR\x0eProducerPubkey\x12*\n" +
+	"\x10GroupOwnerPubkey\x18\x03 \x01(\tR\x10GroupOwnerPubkey\x12&\n" +
+	"\x0eGroupOwnerSign\x18\x04 \x01(\tR\x0eGroupOwnerSign\x12-\n" +
+	"\x06Action\x18\x05 \x01(\x0e2\x15.quorum.pb.ActionTypeR\x06Action\x12&\n" +
+	"\x0eWithnessBlocks\x18\x06 \x01(\x03R\x0eWithnessBlocks\x12\x1c\n" +
+	"\tTimeStamp\x18\a \x01(\x03R\tTimeStamp\x12\x12\n" +
+	"\x04Memo\x18\b \x01(\tR\x04Memo\"t\n" +
+	"\x15BFTProducerBundleItem\x125\n" +
+	"\tProducers\x18\x01 \x03(\v2\x17.quorum.pb.ProducerItemR\tProducers\x12$\n" +
+	"\rActivateEpoch\x18\x02 \x01(\x04R\rActivateEpoch\"\xc2\x01\n" +
+	"\tStakeItem\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12\"\n" +
+	"\fStakerPubkey\x18\x02 \x01(\tR\fStakerPubkey\x12\x16\n" +
+	"\x06Amount\x18\x03 \x01(\x04R\x06Amount\x12-\n" +
+	"\x06Action\x18\x04 \x01(\x0e2\x15.quorum.pb.ActionTypeR\x06Action\x12\x1c\n" +
+	"\tTimeStamp\x18\x05 \x01(\x03R\tTimeStamp\x12\x12\n" +
+	"\x04Memo\x18\x06 \x01(\tR\x04Memo\"\x9e\x01\n" +
+	"\fGroupKeyItem\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12\"\n" +
+	"\fEncryptedKey\x18\x02 \x01(\fR\fEncryptedKey\x12\x1e\n" +
+	"\n" +
+	"Recipients\x18\x03 \x03(\tR\n" +
+	"Recipients\x12\x1c\n" +
+	"\tTimeStamp\x18\x04 \x01(\x03R\tTimeStamp\x12\x12\n" +
+	"\x04Memo\x18\x05 \x01(\tR\x04Memo\"\x9f\x02\n" +
+	"\bUserItem\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12\x1e\n" +
+	"\n" +
+	"UserPubkey\x18\x02 \x01(\tR\n" +
+	"UserPubkey\x12$\n" +
+	"\rEncryptPubkey\x18\x03 \x01(\tR\rEncryptPubkey\x12*\n" +
+	"\x10GroupOwnerPubkey\x18\x04 \x01(\tR\x10GroupOwnerPubkey\x12&\n" +
+	"\x0eGroupOwnerSign\x18\x05 \x01(\tR\x0eGroupOwnerSign\x12\x1c\n" +
+	"\tTimeStamp\x18\x06 \x01(\x03R\tTimeStamp\x12-\n" +
+	"\x06Action\x18\a \x01(\x0e2\x15.quorum.pb.ActionTypeR\x06Action\x12\x12\n" +
+	"\x04Memo\x18\b \x01(\tR\x04Memo\"\xa6\x03\n" +
+	"\fAnnounceItem\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12\x1e\n" +
+	"\n" +
+	"SignPubkey\x18\x02 \x01(\tR\n" +
+	"SignPubkey\x12$\n" +
+	"\rEncryptPubkey\x18\x03 \x01(\tR\rEncryptPubkey\x12.\n" +
+	"\x12AnnouncerSignature\x18\x04 \x01(\tR\x12AnnouncerSignature\x12+\n" +
+	"\x04Type\x18\x05 \x01(\x0e2\x17.quorum.pb.AnnounceTypeR\x04Type\x12 \n" +
+	"\vOwnerPubkey\x18\x06 \x01(\tR\vOwnerPubkey\x12&\n" +
+	"\x0eOwnerSignature\x18\a \x01(\tR\x0eOwnerSignature\x12.\n" +
+	"\x06Result\x18\b \x01(\x0e2\x16.quorum.pb.ApproveTypeR\x06Result\x12\x1c\n" +
+	"\tTimeStamp\x18\t \x01(\x03R\tTimeStamp\x12-\n" +
+	"\x06Action\x18\n" +
+	" \x01(\x0e2\x15.quorum.pb.ActionTypeR\x06Action\x12\x12\n" +
+	"\x04Memo\x18\v \x01(\tR\x04Memo\"\xc8\x03\n" +
+	"\tGroupItem\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12\x1c\n" +
+	"\tGroupName\x18\x02 \x01(\tR\tGroupName\x12 \n" +
+	"\vOwnerPubKey\x18\x03 \x01(\tR\vOwnerPubKey\x12&\n" +
+	"\x0eUserSignPubkey\x18\x04 \x01(\tR\x0eUserSignPubkey\x12,\n" +
+	"\x11UserEncryptPubkey\x18\x05 \x01(\tR\x11UserEncryptPubkey\x12\x1e\n" +
+	"\n" +
+	"LastUpdate\x18\x06 \x01(\x03R\n" +
+	"LastUpdate\x124\n" +
+	"\fGenesisBlock\x18\a \x01(\v2\x10.quorum.pb.BlockR\fGenesisBlock\x12=\n" +
+	"\vEncryptType\x18\b \x01(\x0e2\x1b.quorum.pb.GroupEncryptTypeR\vEncryptType\x12@\n" +
+	"\fConsenseType\x18\t \x01(\x0e2\x1c.quorum.pb.GroupConsenseTypeR\fConsenseType\x12\x1c\n" +
+	"\tCipherKey\x18\n" +
+	" \x01(\tR\tCipherKey\x12\x16\n" +
+	"\x06AppKey\x18\v \x01(\tR\x06AppKey\"\xeb\x01\n" +
+	"\x0fChainConfigItem\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12.\n" +
+	"\x04Type\x18\x02 \x01(\x0e2\x1a.quorum.pb.ChainConfigTypeR\x04Type\x12\x12\n" +
+	"\x04Data\x18\x03 \x01(\fR\x04Data\x12 \n" +
+	"\vOwnerPubkey\x18\x04 \x01(\tR\vOwnerPubkey\x12&\n" +
+	"\x0eOwnerSignature\x18\x05 \x01(\tR\x0eOwnerSignature\x12\x1c\n" +
+	"\tTimeStamp\x18\x06 \x01(\x03R\tTimeStamp\x12\x12\n" +
+	"\x04Memo\x18\a \x01(\tR\x04Memo\"\x89\x01\n" +
+	"\x18ChainSendTrxRuleListItem\x12-\n" +
+	"\x06Action\x18\x01 \x01(\x0e2\x15.quorum.pb.ActionTypeR\x06Action\x12\x16\n" +
+	"\x06Pubkey\x18\x03 \x01(\tR\x06Pubkey\x12&\n" +
+	"\x04Type\x18\x04 \x03(\x0e2\x12.quorum.pb.TrxTypeR\x04Type\"h\n" +
+	"\x12SetTrxAuthModeItem\x12&\n" +
+	"\x04Type\x18\x01 \x01(\x0e2\x12.quorum.pb.TrxTypeR\x04Type\x12*\n" +
+	"\x04Mode\x18\x02 \x01(\x0e2\x16.quorum.pb.TrxAuthModeR\x04Mode\"\x92\x01\n" +
+	"\x14SetPackingPolicyItem\x124\n" +
+	"\x06Policy\x18\x01 \x01(\x0e2\x1c.quorum.pb.PackingPolicyTypeR\x06Policy\x12\x1c\n" +
+	"\tBatchSize\x18\x02 \x01(\rR\tBatchSize\x12&\n" +
+	"\x0eMaxBundleBytes\x18\x03 \x01(\rR\x0eMaxBundleBytes\"M\n" +
+	"\x13SetBlockVersionItem\x12\x18\n" +
+	"\aVersion\x18\x01 \x01(\rR\aVersion\x12\x1c\n" +
+	"\tFromEpoch\x18\x02 \x01(\x04R\tFromEpoch\"\xa2\x02\n" +
+	"\rAppConfigItem\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12-\n" +
+	"\x06Action\x18\x02 \x01(\x0e2\x15.quorum.pb.ActionTypeR\x06Action\x12\x12\n" +
+	"\x04Name\x18\x03 \x01(\tR\x04Name\x12,\n" +
+	"\x04Type\x18\x04 \x01(\x0e2\x18.quorum.pb.AppConfigTypeR\x04Type\x12\x14\n" +
+	"\x05Value\x18\x05 \x01(\tR\x05Value\x12 \n" +
+	"\vOwnerPubkey\x18\x06 \x01(\tR\vOwnerPubkey\x12\x1c\n" +
+	"\tOwnerSign\x18\a \x01(\tR\tOwnerSign\x12\x12\n" +
+	"\x04Memo\x18\b \x01(\tR\x04Memo\x12\x1c\n" +
+	"\tTimeStamp\x18\t \x01(\x03R\tTimeStamp\"\xbd\x02\n" +
+	"\tGroupSeed\x124\n" +
+	"\fGenesisBlock\x18\x01 \x01(\v2\x10.quorum.pb.BlockR\fGenesisBlock\x12\x18\n" +
+	"\aGroupId\x18\x02 \x01(\tR\aGroupId\x12\x1c\n" +
+	"\tGroupName\x18\x03 \x01(\tR\tGroupName\x12 \n" +
+	"\vOwnerPubkey\x18\x04 \x01(\tR\vOwnerPubkey\x12$\n" +
+	"\rConsensusType\x18\x05 \x01(\tR\rConsensusType\x12&\n" +
+	"\x0eEncryptionType\x18\x06 \x01(\tR\x0eEncryptionType\x12\x1c\n" +
+	"\tCipherKey\x18\a \x01(\tR\tCipherKey\x12\x16\n" +
+	"\x06AppKey\x18\b \x01(\tR\x06AppKey\x12\x1c\n" +
+	"\tSignature\x18\t \x01(\tR\tSignature\"\xb6\x01\n" +
+	"\x10NodeSDKGroupItem\x12*\n" +
+	"\x05Group\x18\x01 \x01(\v2\x14.quorum.pb.GroupItemR\x05Group\x12\"\n" +
+	"\fEncryptAlias\x18\x02 \x01(\tR\fEncryptAlias\x12\x1c\n" +
+	"\tSignAlias\x18\x03 \x01(\tR\tSignAlias\x12\x16\n" +
+	"\x06ApiUrl\x18\x04 \x03(\tR\x06ApiUrl\x12\x1c\n" +
+	"\tGroupSeed\x18\x05 \x01(\tR\tGroupSeed\"1\n" +
+	"\vHBTrxBundle\x12\"\n" +
+	"\x04Trxs\x18\x01 \x03(\v2\x0e.quorum.pb.TrxR\x04Trxs\"\x8e\x01\n" +
+	"\aHBMsgv1\x12\x14\n" +
+	"\x05MsgId\x18\x01 \x01(\tR\x05MsgId\x12\x14\n" +
+	"\x05Epoch\x18\x02 \x01(\x04R\x05Epoch\x12=\n" +
+	"\vPayloadType\x18\x03 \x01(\x0e2\x1b.quorum.pb.HBMsgPayloadTypeR\vPayloadType\x12\x18\n" +
+	"\aPayload\x18\x04 \x01(\fR\aPayload\"M\n" +
+	"\x06RBCMsg\x12)\n" +
+	"\x04Type\x18\x01 \x01(\x0e2\x15.quorum.pb.RBCMsgTypeR\x04Type\x12\x18\n" +
+	"\aPayload\x18\x02 \x01(\fR\aPayload\"\xa3\x02\n" +
+	"\vInitPropose\x12\x1a\n" +
+	"\bRootHash\x18\x01 \x01(\fR\bRootHash\x12\x14\n" +
+	"\x05Proof\x18\x02 \x03(\fR\x05Proof\x12\x14\n" +
+	"\x05Index\x18\x03 \x01(\x03R\x05Index\x12\x16\n" +
+	"\x06Leaves\x18\x04 \x01(\x03R\x06Leaves\x12*\n" +
+	"\x10OriginalDataSize\x18\x05 \x01(\x03R\x10OriginalDataSize\x12&\n" +
+	"\x0eRecvNodePubkey\x18\x06 \x01(\tR\x0eRecvNodePubkey\x12&\n" +
+	"\x0eProposerPubkey\x18\a \x01(\tR\x0eProposerPubkey\x12\"\n" +
+	"\fProposerSign\x18\b \x01(\fR\fProposerSign\x12\x14\n" +
+	"\x05Epoch\x18\t \x01(\x04R\x05Epoch\"\xe0\x02\n" +
+	"\x04Echo\x12\x1a\n" +
+	"\bRootHash\x18\x01 \x01(\fR\bRootHash\x12\x14\n" +
+	"\x05Proof\x18\x02 \x03(\fR\x05Proof\x12\x14\n" +
+	"\x05Index\x18\x03 \x01(\x03R\x05Index\x12\x16\n" +
+	"\x06Leaves\x18\x04 \x01(\x03R\x06Leaves\x12*\n" +
+	"\x10OriginalDataSize\x18\x05 \x01(\x03R\x10OriginalDataSize\x126\n" +
+	"\x16OriginalProposerPubkey\x18\x06 \x01(\tR\x16OriginalProposerPubkey\x12.\n" +
+	"\x12EchoProviderPubkey\x18\a \x01(\tR\x12EchoProviderPubkey\x12*\n" +
+	"\x10EchoProviderSign\x18\b \x01(\fR\x10EchoProviderSign\x12\"\n" +
+	"\fProposerSign\x18\t \x01(\fR\fProposerSign\x12\x14\n" +
+	"\x05Epoch\x18\n" +
+	" \x01(\x04R\x05Epoch\"\xd1\x01\n" +
+	"\x05Ready\x12\x1a\n" +
+	"\bRootHash\x18\x01 \x01(\fR\bRootHash\x126\n" +
+	"\x16OriginalProposerPubkey\x18\x02 \x01(\tR\x16OriginalProposerPubkey\x120\n" +
+	"\x13ReadyProviderPubkey\x18\x03 \x01(\tR\x13ReadyProviderPubkey\x12,\n" +
+	"\x11ReadyProviderSign\x18\x04 \x01(\fR\x11ReadyProviderSign\x12\x14\n" +
+	"\x05Epoch\x18\x05 \x01(\x04R\x05Epoch\"\xf5\x01\n" +
+	"\bEvidence\x12\x1e\n" +
+	"\n" +
+	"EvidenceId\x18\x01 \x01(\tR\n" +
+	"EvidenceId\x12\x18\n" +
+	"\aGroupId\x18\x02 \x01(\tR\aGroupId\x12+\n" +
+	"\x04Type\x18\x03 \x01(\x0e2\x17.quorum.pb.EvidenceTypeR\x04Type\x12&\n" +
+	"\x0eOffenderPubkey\x18\x04 \x01(\tR\x0eOffenderPubkey\x12\x14\n" +
+	"\x05Epoch\x18\x05 \x01(\x04R\x05Epoch\x12\x12\n" +
+	"\x04MsgA\x18\x06 \x01(\fR\x04MsgA\x12\x12\n" +
+	"\x04MsgB\x18\a \x01(\fR\x04MsgB\x12\x1c\n" +
+	"\tTimeStamp\x18\b \x01(\x03R\tTimeStamp\"M\n" +
+	"\x06BBAMsg\x12)\n" +
+	"\x04Type\x18\x01 \x01(\x0e2\x15.quorum.pb.BBAMsgTypeR\x04Type\x12\x18\n" +
+	"\aPayload\x18\x02 \x01(\fR\aPayload\"v\n" +
+	"\x04Bval\x12\x1e\n" +
+	"\n" +
+	"ProposerId\x18\x01 \x01(\tR\n" +
+	"ProposerId\x12\"\n" +
+	"\fSenderPubkey\x18\x02 \x01(\tR\fSenderPubkey\x12\x14\n" +
+	"\x05Epoch\x18\x03 \x01(\x03R\x05Epoch\x12\x14\n" +
+	"\x05Value\x18\x04 \x01(\bR\x05Value\"u\n" +
+	"\x03Aux\x12\x1e\n" +
+	"\n" +
+	"ProposerId\x18\x01 \x01(\tR\n" +
+	"ProposerId\x12\"\n" +
+	"\fSenderPubkey\x18\x02 \x01(\tR\fSenderPubkey\x12\x14\n" +
+	"\x05Epoch\x18\x03 \x01(\x04R\x05Epoch\x12\x14\n" +
+	"\x05Value\x18\x04 \x01(\bR\x05Value\"\xc7\x04\n" +
+	"\vGroupItemV0\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12\x1c\n" +
+	"\tGroupName\x18\x02 \x01(\tR\tGroupName\x12 \n" +
+	"\vOwnerPubKey\x18\x03 \x01(\tR\vOwnerPubKey\x12&\n" +
+	"\x0eUserSignPubkey\x18\x04 \x01(\tR\x0eUserSignPubkey\x12,\n" +
+	"\x11UserEncryptPubkey\x18\x05 \x01(\tR\x11UserEncryptPubkey\x12-\n" +
+	"\bUserRole\x18\x06 \x01(\x0e2\x11.quorum.pb.RoleV0R\bUserRole\x12\x1e\n" +
+	"\n" +
+	"LastUpdate\x18\a \x01(\x03R\n" +
+	"LastUpdate\x12$\n" +
+	"\rHighestHeight\x18\b \x01(\x03R\rHighestHeight\x12&\n" +
+	"\x0eHighestBlockId\x18\t \x01(\tR\x0eHighestBlockId\x124\n" +
+	"\fGenesisBlock\x18\n" +
+	" \x01(\v2\x10.quorum.pb.BlockR\fGenesisBlock\x12=\n" +
+	"\vEncryptType\x18\v \x01(\x0e2\x1b.quorum.pb.GroupEncryptTypeR\vEncryptType\x12@\n" +
+	"\fConsenseType\x18\f \x01(\x0e2\x1c.quorum.pb.GroupConsenseTypeR\fConsenseType\x12\x1c\n" +
+	"\tCipherKey\x18\r \x01(\tR\tCipherKey\x12\x16\n" +
+	"\x06AppKey\x18\x0e \x01(\tR\x06AppKey**\n" +
+	"\vPackageType\x12\a\n" +
+	"\x03TRX\x10\x00\x12\t\n" +
+	"\x05BLOCK\x10\x01\x12\a\n" +
+	"\x03HBB\x10\x02*,\n" +
+	"\fAnnounceType\x12\v\n" +
+	"\aAS_USER\x10\x00\x12\x0f\n" +
+	"\vAS_PRODUCER\x10\x01*8\n" +
+	"\vApproveType\x12\r\n" +
+	"\tANNOUNCED\x10\x00\x12\f\n" +
+	"\bAPPROVED\x10\x01\x12\f\n" +
+	"\bREJECTED\x10\x02*!\n" +
+	"\n" +
+	"ActionType\x12\a\n" +
+	"\x03ADD\x10\x00\x12\n" +
+	"\n" +
+	"\x06REMOVE\x10\x01*&\n" +
+	"\x0eTrxStroageType\x12\t\n" +
+	"\x05CHAIN\x10\x00\x12\t\n" +
+	"\x05CACHE\x10\x01*\xc1\x01\n" +
+	"\aTrxType\x12\b\n" +
+	"\x04POST\x10\x00\x12\f\n" +
+	"\bANNOUNCE\x10\x01\x12\f\n" +
+	"\bPRODUCER\x10\x02\x12\b\n" +
+	"\x04USER\x10\x03\x12\r\n" +
+	"\tREQ_BLOCK\x10\x04\x12\x12\n" +
+	"\x0eREQ_BLOCK_RESP\x10\x05\x12\x10\n" +
+	"\fCHAIN_CONFIG\x10\x06\x12\x0e\n" +
+	"\n" +
+	"APP_CONFIG\x10\a\x12\x10\n" +
+	"\fREQ_SNAPSHOT\x10\b\x12\x15\n" +
+	"\x11REQ_SNAPSHOT_RESP\x10\t\x12\t\n" +
+	"\x05STAKE\x10\n" +
+	"\x12\r\n" +
+	"\tGROUP_KEY\x10\v*P\n" +
+	"\fReqBlkResult\x12\x11\n" +
+	"\rBLOCK_IN_RESP\x10\x00\x12\x18\n" +
+	"\x14BLOCK_IN_RESP_ON_TOP\x10\x01\x12\x13\n" +
+	"\x0fBLOCK_NOT_FOUND\x10\x02*V\n" +
+	"\x11ReqSnapshotResult\x12\x14\n" +
+	"\x10SNAPSHOT_IN_RESP\x10\x00\x12\x13\n" +
+	"\x0fSNAPSHOT_ON_TOP\x10\x01\x12\x16\n" +
+	"\x12SNAPSHOT_NOT_FOUND\x10\x02*+\n" +
+	"\x10GroupEncryptType\x12\n" +
+	"\n" +
+	"\x06PUBLIC\x10\x00\x12\v\n" +
+	"\aPRIVATE\x10\x01*%\n" +
+	"\x11GroupConsenseType\x12\a\n" +
+	"\x03POA\x10\x00\x12\a\n" +
+	"\x03POS\x10\x01*,\n" +
+	"\x06RoleV0\x12\x12\n" +
+	"\x0eGROUP_PRODUCER\x10\x00\x12\x0e\n" +
+	"\n" +
+	"GROUP_USER\x10\x01*{\n" +
+	"\x0fChainConfigType\x12\x15\n" +
+	"\x11SET_TRX_AUTH_MODE\x10\x00\x12\x10\n" +
+	"\fUPD_DNY_LIST\x10\x01\x12\x10\n" +
+	"\fUPD_ALW_LIST\x10\x02\x12\x16\n" +
+	"\x12SET_PACKING_POLICY\x10\x03\x12\x15\n" +
+	"\x11SET_BLOCK_VERSION\x10\x04*7\n" +
+	"\vTrxAuthMode\x12\x13\n" +
+	"\x0fFOLLOW_ALW_LIST\x10\x00\x12\x13\n" +
+	"\x0fFOLLOW_DNY_LIST\x10\x01*-\n" +
+	"\fAuthListType\x12\x0e\n" +
+	"\n" +
+	"ALLOW_LIST\x10\x00\x12\r\n" +
+	"\tDENY_LIST\x10\x01*V\n" +
+	"\x11PackingPolicyType\x12\x12\n" +
+	"\x0eTIMESTAMP_FIFO\x10\x00\x12\x16\n" +
+	"\x12SENDER_ROUND_ROBIN\x10\x01\x12\x15\n" +
+	"\x11TRX_TYPE_PRIORITY\x10\x02*.\n" +
+	"\rAppConfigType\x12\a\n" +
+	"\x03INT\x10\x00\x12\b\n" +
+	"\x04BOOL\x10\x01\x12\n" +
+	"\n" +
+	"\x06STRING\x10\x02*$\n" +
+	"\x10HBMsgPayloadType\x12\a\n" +
+	"\x03RBC\x10\x00\x12\a\n" +
+	"\x03BBA\x10\x01*3\n" +
+	"\n" +
+	"RBCMsgType\x12\x10\n" +
+	"\fINIT_PROPOSE\x10\x00\x12\b\n" +
+	"\x04ECHO\x10\x01\x12\t\n" +
+	"\x05READY\x10\x02*{\n" +
+	"\fEvidenceType\x12\x1c\n" +
+	"\x18RBC_PROPOSE_EQUIVOCATION\x10\x00\x12\x19\n" +
+	"\x15RBC_ECHO_EQUIVOCATION\x10\x01\x12\x1a\n" +
+	"\x16RBC_READY_EQUIVOCATION\x10\x02\x12\x16\n" +
+	"\x12BLOCK_EQUIVOCATION\x10\x03*\x1f\n" +
+	"\n" +
+	"BBAMsgType\x12\b\n" +
+	"\x04BVAL\x10\x00\x12\a\n" +
+	"\x03AUX\x10\x01B$Z\"github.com/rumsystem/quorum/pkg/pbb\x06proto3"
 
 var (
 	file_chain_proto_rawDescOnce sync.Once
-	file_chain_proto_rawDescData = file_chain_proto_rawDesc
+	file_chain_proto_rawDescData []byte
 )
 
 func file_chain_proto_rawDescGZIP() []byte {
 	file_chain_proto_rawDescOnce.Do(func() {
-		file_chain_proto_rawDescData = protoimpl.X.CompressGZIP(file_chain_proto_rawDescData)
+		file_chain_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_chain_proto_rawDesc), len(file_chain_proto_rawDesc)))
 	})
 	return file_chain_proto_rawDescData
 }
 
-var file_chain_proto_enumTypes = make([]protoimpl.EnumInfo, 17)
-var file_chain_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
-var file_chain_proto_goTypes = []interface{}{
+var file_chain_proto_enumTypes = make([]protoimpl.EnumInfo, 20)
+var file_chain_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_chain_proto_goTypes = []any{
 	(PackageType)(0),                 // 0: quorum.pb.PackageType
 	(AnnounceType)(0),                // 1: quorum.pb.AnnounceType
 	(ApproveType)(0),                 // 2: quorum.pb.ApproveType
@@ -3728,84 +4510,103 @@ var file_chain_proto_goTypes = []interface{}{
 	(TrxStroageType)(0),              // 4: quorum.pb.TrxStroageType
 	(TrxType)(0),                     // 5: quorum.pb.TrxType
 	(ReqBlkResult)(0),                // 6: quorum.pb.ReqBlkResult
-	(GroupEncryptType)(0),            // 7: quorum.pb.GroupEncryptType
-	(GroupConsenseType)(0),           // 8: quorum.pb.GroupConsenseType
-	(RoleV0)(0),                      // 9: quorum.pb.RoleV0
-	(ChainConfigType)(0),             // 10: quorum.pb.ChainConfigType
-	(TrxAuthMode)(0),                 // 11: quorum.pb.TrxAuthMode
-	(AuthListType)(0),                // 12: quorum.pb.AuthListType
-	(AppConfigType)(0),               // 13: quorum.pb.AppConfigType
-	(HBMsgPayloadType)(0),            // 14: quorum.pb.HBMsgPayloadType
-	(RBCMsgType)(0),                  // 15: quorum.pb.RBCMsgType
-	(BBAMsgType)(0),                  // 16: quorum.pb.BBAMsgType
-	(*Package)(nil),                  // 17: quorum.pb.Package
-	(*Trx)(nil),                      // 18: quorum.pb.Trx
-	(*Block)(nil),                    // 19: quorum.pb.Block
-	(*ReqBlock)(nil),                 // 20: quorum.pb.ReqBlock
-	(*BlocksBundle)(nil),             // 21: quorum.pb.BlocksBundle
-	(*ReqBlockResp)(nil),             // 22: quorum.pb.ReqBlockResp
-	(*PostItem)(nil),                 // 23: quorum.pb.PostItem
-	(*ProducerItem)(nil),             // 24: quorum.pb.ProducerItem
-	(*BFTProducerBundleItem)(nil),    // 25: quorum.pb.BFTProducerBundleItem
-	(*UserItem)(nil),                 // 26: quorum.pb.UserItem
-	(*AnnounceItem)(nil),             // 27: quorum.pb.AnnounceItem
-	(*GroupItem)(nil),                // 28: quorum.pb.GroupItem
-	(*ChainConfigItem)(nil),          // 29: quorum.pb.ChainConfigItem
-	(*ChainSendTrxRuleListItem)(nil), // 30: quorum.pb.ChainSendTrxRuleListItem
-	(*SetTrxAuthModeItem)(nil),       // 31: quorum.pb.SetTrxAuthModeItem
-	(*AppConfigItem)(nil),            // 32: quorum.pb.AppConfigItem
-	(*GroupSeed)(nil),                // 33: quorum.pb.GroupSeed
-	(*NodeSDKGroupItem)(nil),         // 34: quorum.pb.NodeSDKGroupItem
-	(*HBTrxBundle)(nil),              // 35: quorum.pb.HBTrxBundle
-	(*HBMsgv1)(nil),                  // 36: quorum.pb.HBMsgv1
-	(*RBCMsg)(nil),                   // 37: quorum.pb.RBCMsg
-	(*InitPropose)(nil),              // 38: quorum.pb.InitPropose
-	(*Echo)(nil),                     // 39: quorum.pb.Echo
-	(*Ready)(nil),                    // 40: quorum.pb.Ready
-	(*BBAMsg)(nil),                   // 41: quorum.pb.BBAMsg
-	(*Bval)(nil),                     // 42: quorum.pb.Bval
-	(*Aux)(nil),                      // 43: quorum.pb.Aux
-	(*GroupItemV0)(nil),              // 44: quorum.pb.GroupItemV0
+	(ReqSnapshotResult)(0),           // 7: quorum.pb.ReqSnapshotResult
+	(GroupEncryptType)(0),            // 8: quorum.pb.GroupEncryptType
+	(GroupConsenseType)(0),           // 9: quorum.pb.GroupConsenseType
+	(RoleV0)(0),                      // 10: quorum.pb.RoleV0
+	(ChainConfigType)(0),             // 11: quorum.pb.ChainConfigType
+	(TrxAuthMode)(0),                 // 12: quorum.pb.TrxAuthMode
+	(AuthListType)(0),                // 13: quorum.pb.AuthListType
+	(PackingPolicyType)(0),           // 14: quorum.pb.PackingPolicyType
+	(AppConfigType)(0),               // 15: quorum.pb.AppConfigType
+	(HBMsgPayloadType)(0),            // 16: quorum.pb.HBMsgPayloadType
+	(RBCMsgType)(0),                  // 17: quorum.pb.RBCMsgType
+	(EvidenceType)(0),                // 18: quorum.pb.EvidenceType
+	(BBAMsgType)(0),                  // 19: quorum.pb.BBAMsgType
+	(*Package)(nil),                  // 20: quorum.pb.Package
+	(*Trx)(nil),                      // 21: quorum.pb.Trx
+	(*Block)(nil),                    // 22: quorum.pb.Block
+	(*ReqBlock)(nil),                 // 23: quorum.pb.ReqBlock
+	(*BlocksBundle)(nil),             // 24: quorum.pb.BlocksBundle
+	(*ReqBlockResp)(nil),             // 25: quorum.pb.ReqBlockResp
+	(*SnapshotItem)(nil),             // 26: quorum.pb.SnapshotItem
+	(*Snapshot)(nil),                 // 27: quorum.pb.Snapshot
+	(*ReqSnapshot)(nil),              // 28: quorum.pb.ReqSnapshot
+	(*ReqSnapshotResp)(nil),          // 29: quorum.pb.ReqSnapshotResp
+	(*PostItem)(nil),                 // 30: quorum.pb.PostItem
+	(*ProducerItem)(nil),             // 31: quorum.pb.ProducerItem
+	(*BFTProducerBundleItem)(nil),    // 32: quorum.pb.BFTProducerBundleItem
+	(*StakeItem)(nil),                // 33: quorum.pb.StakeItem
+	(*GroupKeyItem)(nil),             // 34: quorum.pb.GroupKeyItem
+	(*UserItem)(nil),                 // 35: quorum.pb.UserItem
+	(*AnnounceItem)(nil),             // 36: quorum.pb.AnnounceItem
+	(*GroupItem)(nil),                // 37: quorum.pb.GroupItem
+	(*ChainConfigItem)(nil),          // 38: quorum.pb.ChainConfigItem
+	(*ChainSendTrxRuleListItem)(nil), // 39: quorum.pb.ChainSendTrxRuleListItem
+	(*SetTrxAuthModeItem)(nil),       // 40: quorum.pb.SetTrxAuthModeItem
+	(*SetPackingPolicyItem)(nil),     // 41: quorum.pb.SetPackingPolicyItem
+	(*SetBlockVersionItem)(nil),      // 42: quorum.pb.SetBlockVersionItem
+	(*AppConfigItem)(nil),            // 43: quorum.pb.AppConfigItem
+	(*GroupSeed)(nil),                // 44: quorum.pb.GroupSeed
+	(*NodeSDKGroupItem)(nil),         // 45: quorum.pb.NodeSDKGroupItem
+	(*HBTrxBundle)(nil),              // 46: quorum.pb.HBTrxBundle
+	(*HBMsgv1)(nil),                  // 47: quorum.pb.HBMsgv1
+	(*RBCMsg)(nil),                   // 48: quorum.pb.RBCMsg
+	(*InitPropose)(nil),              // 49: quorum.pb.InitPropose
+	(*Echo)(nil),                     // 50: quorum.pb.Echo
+	(*Ready)(nil),                    // 51: quorum.pb.Ready
+	(*Evidence)(nil),                 // 52: quorum.pb.Evidence
+	(*BBAMsg)(nil),                   // 53: quorum.pb.BBAMsg
+	(*Bval)(nil),                     // 54: quorum.pb.Bval
+	(*Aux)(nil),                      // 55: quorum.pb.Aux
+	(*GroupItemV0)(nil),              // 56: quorum.pb.GroupItemV0
 }
 var file_chain_proto_depIdxs = []int32{
 	0,  // 0: quorum.pb.Package.type:type_name -> quorum.pb.PackageType
 	5,  // 1: quorum.pb.Trx.Type:type_name -> quorum.pb.TrxType
 	4,  // 2: quorum.pb.Trx.StorageType:type_name -> quorum.pb.TrxStroageType
-	18, // 3: quorum.pb.Block.Trxs:type_name -> quorum.pb.Trx
-	19, // 4: quorum.pb.BlocksBundle.Blocks:type_name -> quorum.pb.Block
+	21, // 3: quorum.pb.Block.Trxs:type_name -> quorum.pb.Trx
+	22, // 4: quorum.pb.BlocksBundle.Blocks:type_name -> quorum.pb.Block
 	6,  // 5: quorum.pb.ReqBlockResp.Result:type_name -> quorum.pb.ReqBlkResult
-	21, // 6: quorum.pb.ReqBlockResp.Blocks:type_name -> quorum.pb.BlocksBundle
-	3,  // 7: quorum.pb.ProducerItem.Action:type_name -> quorum.pb.ActionType
-	24, // 8: quorum.pb.BFTProducerBundleItem.Producers:type_name -> quorum.pb.ProducerItem
-	3,  // 9: quorum.pb.UserItem.Action:type_name -> quorum.pb.ActionType
-	1,  // 10: quorum.pb.AnnounceItem.Type:type_name -> quorum.pb.AnnounceType
-	2,  // 11: quorum.pb.AnnounceItem.Result:type_name -> quorum.pb.ApproveType
-	3,  // 12: quorum.pb.AnnounceItem.Action:type_name -> quorum.pb.ActionType
-	19, // 13: quorum.pb.GroupItem.GenesisBlock:type_name -> quorum.pb.Block
-	7,  // 14: quorum.pb.GroupItem.EncryptType:type_name -> quorum.pb.GroupEncryptType
-	8,  // 15: quorum.pb.GroupItem.ConsenseType:type_name -> quorum.pb.GroupConsenseType
-	10, // 16: quorum.pb.ChainConfigItem.Type:type_name -> quorum.pb.ChainConfigType
-	3,  // 17: quorum.pb.ChainSendTrxRuleListItem.Action:type_name -> quorum.pb.ActionType
-	5,  // 18: quorum.pb.ChainSendTrxRuleListItem.Type:type_name -> quorum.pb.TrxType
-	5,  // 19: quorum.pb.SetTrxAuthModeItem.Type:type_name -> quorum.pb.TrxType
-	11, // 20: quorum.pb.SetTrxAuthModeItem.Mode:type_name -> quorum.pb.TrxAuthMode
-	3,  // 21: quorum.pb.AppConfigItem.Action:type_name -> quorum.pb.ActionType
-	13, // 22: quorum.pb.AppConfigItem.Type:type_name -> quorum.pb.AppConfigType
-	19, // 23: quorum.pb.GroupSeed.GenesisBlock:type_name -> quorum.pb.Block
-	28, // 24: quorum.pb.NodeSDKGroupItem.Group:type_name -> quorum.pb.GroupItem
-	18, // 25: quorum.pb.HBTrxBundle.Trxs:type_name -> quorum.pb.Trx
-	14, // 26: quorum.pb.HBMsgv1.PayloadType:type_name -> quorum.pb.HBMsgPayloadType
-	15, // 27: quorum.pb.RBCMsg.Type:type_name -> quorum.pb.RBCMsgType
-	16, // 28: quorum.pb.BBAMsg.Type:type_name -> quorum.pb.BBAMsgType
-	9,  // 29: quorum.pb.GroupItemV0.UserRole:type_name -> quorum.pb.RoleV0
-	19, // 30: quorum.pb.GroupItemV0.GenesisBlock:type_name -> quorum.pb.Block
-	7,  // 31: quorum.pb.GroupItemV0.EncryptType:type_name -> quorum.pb.GroupEncryptType
-	8,  // 32: quorum.pb.GroupItemV0.ConsenseType:type_name -> quorum.pb.GroupConsenseType
-	33, // [33:33] is the sub-list for method output_type
-	33, // [33:33] is the sub-list for method input_type
-	33, // [33:33] is the sub-list for extension type_name
-	33, // [33:33] is the sub-list for extension extendee
-	0,  // [0:33] is the sub-list for field type_name
+	24, // 6: quorum.pb.ReqBlockResp.Blocks:type_name -> quorum.pb.BlocksBundle
+	26, // 7: quorum.pb.Snapshot.Items:type_name -> quorum.pb.SnapshotItem
+	7,  // 8: quorum.pb.ReqSnapshotResp.Result:type_name -> quorum.pb.ReqSnapshotResult
+	27, // 9: quorum.pb.ReqSnapshotResp.Snapshot:type_name -> quorum.pb.Snapshot
+	22, // 10: quorum.pb.ReqSnapshotResp.Block:type_name -> quorum.pb.Block
+	3,  // 11: quorum.pb.ProducerItem.Action:type_name -> quorum.pb.ActionType
+	31, // 12: quorum.pb.BFTProducerBundleItem.Producers:type_name -> quorum.pb.ProducerItem
+	3,  // 13: quorum.pb.StakeItem.Action:type_name -> quorum.pb.ActionType
+	3,  // 14: quorum.pb.UserItem.Action:type_name -> quorum.pb.ActionType
+	1,  // 15: quorum.pb.AnnounceItem.Type:type_name -> quorum.pb.AnnounceType
+	2,  // 16: quorum.pb.AnnounceItem.Result:type_name -> quorum.pb.ApproveType
+	3,  // 17: quorum.pb.AnnounceItem.Action:type_name -> quorum.pb.ActionType
+	22, // 18: quorum.pb.GroupItem.GenesisBlock:type_name -> quorum.pb.Block
+	8,  // 19: quorum.pb.GroupItem.EncryptType:type_name -> quorum.pb.GroupEncryptType
+	9,  // 20: quorum.pb.GroupItem.ConsenseType:type_name -> quorum.pb.GroupConsenseType
+	11, // 21: quorum.pb.ChainConfigItem.Type:type_name -> quorum.pb.ChainConfigType
+	3,  // 22: quorum.pb.ChainSendTrxRuleListItem.Action:type_name -> quorum.pb.ActionType
+	5,  // 23: quorum.pb.ChainSendTrxRuleListItem.Type:type_name -> quorum.pb.TrxType
+	5,  // 24: quorum.pb.SetTrxAuthModeItem.Type:type_name -> quorum.pb.TrxType
+	12, // 25: quorum.pb.SetTrxAuthModeItem.Mode:type_name -> quorum.pb.TrxAuthMode
+	14, // 26: quorum.pb.SetPackingPolicyItem.Policy:type_name -> quorum.pb.PackingPolicyType
+	3,  // 27: quorum.pb.AppConfigItem.Action:type_name -> quorum.pb.ActionType
+	15, // 28: quorum.pb.AppConfigItem.Type:type_name -> quorum.pb.AppConfigType
+	22, // 29: quorum.pb.GroupSeed.GenesisBlock:type_name -> quorum.pb.Block
+	37, // 30: quorum.pb.NodeSDKGroupItem.Group:type_name -> quorum.pb.GroupItem
+	21, // 31: quorum.pb.HBTrxBundle.Trxs:type_name -> quorum.pb.Trx
+	16, // 32: quorum.pb.HBMsgv1.PayloadType:type_name -> quorum.pb.HBMsgPayloadType
+	17, // 33: quorum.pb.RBCMsg.Type:type_name -> quorum.pb.RBCMsgType
+	18, // 34: quorum.pb.Evidence.Type:type_name -> quorum.pb.EvidenceType
+	19, // 35: quorum.pb.BBAMsg.Type:type_name -> quorum.pb.BBAMsgType
+	10, // 36: quorum.pb.GroupItemV0.UserRole:type_name -> quorum.pb.RoleV0
+	22, // 37: quorum.pb.GroupItemV0.GenesisBlock:type_name -> quorum.pb.Block
+	8,  // 38: quorum.pb.GroupItemV0.EncryptType:type_name -> quorum.pb.GroupEncryptType
+	9,  // 39: quorum.pb.GroupItemV0.ConsenseType:type_name -> quorum.pb.GroupConsenseType
+	40, // [40:40] is the sub-list for method output_type
+	40, // [40:40] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_chain_proto_init() }
@@ -3813,351 +4614,13 @@ func file_chain_proto_init() {
 	if File_chain_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_chain_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Package); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Trx); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Block); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReqBlock); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlocksBundle); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReqBlockResp); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PostItem); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProducerItem); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BFTProducerBundleItem); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UserItem); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AnnounceItem); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GroupItem); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChainConfigItem); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChainSendTrxRuleListItem); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetTrxAuthModeItem); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AppConfigItem); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GroupSeed); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NodeSDKGroupItem); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HBTrxBundle); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HBMsgv1); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RBCMsg); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InitPropose); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Echo); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Ready); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BBAMsg); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Bval); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Aux); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_chain_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GroupItemV0); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_chain_proto_rawDesc,
-			NumEnums:      17,
-			NumMessages:   28,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chain_proto_rawDesc), len(file_chain_proto_rawDesc)),
+			NumEnums:      20,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -4167,7 +4630,6 @@ func file_chain_proto_init() {
 		MessageInfos:      file_chain_proto_msgTypes,
 	}.Build()
 	File_chain_proto = out.File
-	file_chain_proto_rawDesc = nil
 	file_chain_proto_goTypes = nil
 	file_chain_proto_depIdxs = nil
 }